@@ -26,14 +26,25 @@ func init() {
 	}
 	awsCmd.Flags().StringVarP(&flagFramework, "framework", "k", flagFramework, "framework to use (default, all, cis-aws-1.2, cis-aws-1.4)")
 	awsCmd.Flags().StringVarP(&flagAWSRegion, "region", "r", flagAWSRegion, "AWS region to scan")
+	awsCmd.Flags().StringSliceVar(&flagAWSRegions, "regions", flagAWSRegions, "AWS regions to scan, instead of only --region")
 	awsCmd.Flags().StringSliceVarP(&flagAWSServices, "services", "s", flagAWSServices, "AWS services to scan")
+	awsCmd.Flags().BoolVar(&flagAWSOrganization, "organization", flagAWSOrganization, "scan every account in the AWS organization")
+	awsCmd.Flags().StringVar(&flagAWSOrganizationRole, "organization-role", flagAWSOrganizationRole, "role to assume in each account of the organization")
+	awsCmd.Flags().StringSliceVar(&flagAWSAccounts, "accounts", flagAWSAccounts, "IDs of the organization accounts to scan (defaults to all)")
+	addSelectionFlags(awsCmd)
+	addStateCacheFlags(awsCmd)
+	addThrottlingFlags(awsCmd)
 	rootCmd.AddCommand(awsCmd)
 }
 
 var (
-	flagAWSRegion   = "us-east-1"
-	flagAWSServices []string
-	flagFramework   = string(framework.Default)
+	flagAWSRegion           = "us-east-1"
+	flagAWSRegions          []string
+	flagAWSServices         []string
+	flagAWSOrganization     bool
+	flagAWSOrganizationRole string
+	flagAWSAccounts         []string
+	flagFramework           = string(framework.Default)
 )
 
 func scanAWS(stdout, stderr io.Writer) error {
@@ -50,14 +61,42 @@ func scanAWS(stdout, stderr io.Writer) error {
 		opts = append(opts, aws.ScannerWithAWSRegion(flagAWSRegion))
 	}
 
+	if len(flagAWSRegions) > 0 {
+		opts = append(opts, aws.ScannerWithAWSRegions(flagAWSRegions...))
+	}
+
 	if len(flagAWSServices) > 0 {
 		opts = append(opts, aws.ScannerWithAWSServices(flagAWSServices...))
 	}
 
+	if flagAWSOrganization {
+		opts = append(opts, aws.ScannerWithAWSOrganization(true))
+		if flagAWSOrganizationRole != "" {
+			opts = append(opts, aws.ScannerWithAWSOrganizationRole(flagAWSOrganizationRole))
+		}
+		if len(flagAWSAccounts) > 0 {
+			opts = append(opts, aws.ScannerWithAWSAccounts(flagAWSAccounts...))
+		}
+	}
+
+	opts = append(opts, selectionOptions()...)
+	opts = append(opts, stateCacheOptions()...)
+	opts = append(opts, throttlingOptions()...)
 	opts = append(opts, options.ScannerWithFrameworks(framework.Framework(flagFramework)))
 
 	scanner := aws.New(opts...)
 
+	if flagAWSOrganization {
+		results, err := scanner.ScanWithStateRefresh(context.TODO())
+		if err != nil {
+			return err
+		}
+		if err := writeCoverage(stderr, scanner.Coverage()); err != nil {
+			return err
+		}
+		return outputResults(stdout, ".", results)
+	}
+
 	st, err := scanner.CreateState(context.TODO())
 	if err != nil {
 		return err
@@ -69,5 +108,9 @@ func scanAWS(stdout, stderr io.Writer) error {
 		return err
 	}
 
+	if err := writeCoverage(stderr, scanner.Coverage()); err != nil {
+		return err
+	}
+
 	return outputResults(stdout, ".", results)
 }
@@ -0,0 +1,86 @@
+package main
+
+import (
+	"context"
+	"io"
+
+	"github.com/aquasecurity/defsec/pkg/framework"
+
+	"github.com/aquasecurity/defsec/pkg/scanners/cloud/azure"
+
+	"github.com/spf13/cobra"
+
+	"github.com/aquasecurity/defsec/pkg/scanners/options"
+)
+
+func init() {
+	azureCmd := &cobra.Command{
+		Use:   "azure",
+		Short: "Scan an Azure subscription for misconfigurations",
+		Long: `Scan an Azure subscription for misconfigurations.
+
+Credentials are read from AZURE_ACCESS_TOKEN (e.g. from 'az account get-access-token') if it is set, and otherwise
+from the AZURE_TENANT_ID, AZURE_CLIENT_ID and AZURE_CLIENT_SECRET of a service principal, a workload or managed
+identity, or the Azure CLI login.`,
+		Args: cobra.ExactArgs(0),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cmd.SilenceUsage = true
+			cmd.SilenceErrors = true
+			return scanAzure(cmd.OutOrStdout(), cmd.ErrOrStderr())
+		},
+	}
+	azureCmd.Flags().StringVarP(&flagFramework, "framework", "k", flagFramework, "framework to use (default, all)")
+	azureCmd.Flags().StringVar(&flagAzureSubscription, "subscription", flagAzureSubscription, "Azure subscription ID to scan (defaults to AZURE_SUBSCRIPTION_ID)")
+	azureCmd.Flags().StringSliceVarP(&flagAzureServices, "services", "s", flagAzureServices, "Azure services to scan")
+	addSelectionFlags(azureCmd)
+	addStateCacheFlags(azureCmd)
+	addThrottlingFlags(azureCmd)
+	rootCmd.AddCommand(azureCmd)
+}
+
+var (
+	flagAzureSubscription string
+	flagAzureServices     []string
+)
+
+func scanAzure(stdout, stderr io.Writer) error {
+
+	opts := []options.ScannerOption{
+		options.ScannerWithEmbeddedPolicies(true),
+	}
+
+	if flagDebug {
+		opts = append(opts, options.ScannerWithDebug(stderr))
+	}
+
+	if flagAzureSubscription != "" {
+		opts = append(opts, azure.ScannerWithAzureSubscription(flagAzureSubscription))
+	}
+
+	if len(flagAzureServices) > 0 {
+		opts = append(opts, azure.ScannerWithAzureServices(flagAzureServices...))
+	}
+
+	opts = append(opts, selectionOptions()...)
+	opts = append(opts, stateCacheOptions()...)
+	opts = append(opts, throttlingOptions()...)
+	opts = append(opts, options.ScannerWithFrameworks(framework.Framework(flagFramework)))
+
+	scanner := azure.New(opts...)
+
+	st, err := scanner.CreateState(context.TODO())
+	if err != nil {
+		return err
+	}
+
+	results, err := scanner.Scan(context.TODO(), st)
+	if err != nil {
+		return err
+	}
+
+	if err := writeCoverage(stderr, scanner.Coverage()); err != nil {
+		return err
+	}
+
+	return outputResults(stdout, ".", results)
+}
@@ -0,0 +1,29 @@
+package main
+
+import (
+	"github.com/aquasecurity/defsec/pkg/scanners/cloud/cache"
+	"github.com/aquasecurity/defsec/pkg/scanners/options"
+	"github.com/spf13/cobra"
+)
+
+var (
+	flagCacheDir    string
+	flagMaxCacheAge = cache.DefaultMaxAge
+	flagUpdateCache bool
+)
+
+func addStateCacheFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&flagCacheDir, "cache-dir", flagCacheDir, "directory to cache adapted cloud state in, so later scans reuse it (disabled if empty)")
+	cmd.Flags().DurationVar(&flagMaxCacheAge, "max-cache-age", flagMaxCacheAge, "how long cached cloud state is reused for")
+	cmd.Flags().BoolVar(&flagUpdateCache, "update-cache", flagUpdateCache, "ignore cached cloud state and read it from the cloud again")
+}
+
+// stateCacheOptions returns the options which enable the cloud state cache, if a cache directory was given
+func stateCacheOptions() []options.ScannerOption {
+	if flagCacheDir == "" {
+		return nil
+	}
+	stateCache := cache.New(flagCacheDir, flagMaxCacheAge)
+	stateCache.SetRefresh(flagUpdateCache)
+	return []options.ScannerOption{cache.ScannerWithStateCache(stateCache)}
+}
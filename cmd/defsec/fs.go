@@ -3,11 +3,15 @@ package main
 import (
 	"context"
 	"io"
+	"io/fs"
 	"path/filepath"
 
 	"github.com/spf13/cobra"
 
+	"github.com/aquasecurity/defsec/pkg/config"
 	"github.com/aquasecurity/defsec/pkg/extrafs"
+	"github.com/aquasecurity/defsec/pkg/ignore"
+	"github.com/aquasecurity/defsec/pkg/plugins"
 	"github.com/aquasecurity/defsec/pkg/scanners/options"
 	"github.com/aquasecurity/defsec/pkg/scanners/universal"
 )
@@ -23,9 +27,22 @@ func init() {
 			return scanFS(args[0], cmd.OutOrStdout(), cmd.ErrOrStderr())
 		},
 	}
+	fsCmd.Flags().StringVarP(&flagConfig, "config", "c", flagConfig, "path to a "+config.FileName+" file (default: "+config.FileName+" in the scanned directory, if present)")
+	fsCmd.Flags().StringSliceVar(&flagPluginDirs, "plugin-dir", flagPluginDirs, "directories to load "+plugins.Prefix+"* executables from")
+	fsCmd.Flags().BoolVar(&flagLenient, "lenient", flagLenient, "accept JSON with comments and trailing commas, and read ambiguous yaml values (e.g. yes/no, 0644) as YAML 1.1 does")
+	fsCmd.Flags().BoolVar(&flagFollowSymlinks, "follow-symlinks", flagFollowSymlinks, "walk into symlinked directories, skipping any which loop back to a directory containing them")
+	fsCmd.Flags().BoolVar(&flagStayWithinRoot, "stay-within-root", flagStayWithinRoot, "skip symlinks which point outside the scanned directory")
 	rootCmd.AddCommand(fsCmd)
 }
 
+var (
+	flagConfig         string
+	flagPluginDirs     []string
+	flagLenient        bool
+	flagFollowSymlinks bool
+	flagStayWithinRoot bool
+)
+
 func scanFS(dir string, stdout, stderr io.Writer) error {
 
 	abs, err := filepath.Abs(dir)
@@ -41,8 +58,38 @@ func scanFS(dir string, stdout, stderr io.Writer) error {
 	if flagDebug {
 		opts = append(opts, options.ScannerWithDebug(stderr))
 	}
+	if flagLenient {
+		opts = append(opts, options.ScannerWithLenientParsing(true))
+	}
+	if flagFollowSymlinks {
+		opts = append(opts, options.ScannerWithFollowSymlinks(true))
+	}
+	if flagStayWithinRoot {
+		opts = append(opts, options.ScannerWithStayWithinRoot(true))
+	}
 
-	scanner := universal.New(opts...)
+	ignoreFile, err := ignore.Find(filesystem, ".")
+	if err != nil {
+		return err
+	}
+	if ignoreFile != nil {
+		opts = append(opts, ignore.ScannerWithIgnoreFile(ignoreFile))
+	}
+
+	cfg, err := loadConfig(filesystem)
+	if err != nil {
+		return err
+	}
+
+	scanner, err := universal.NewFromConfig(cfg, opts...)
+	if err != nil {
+		return err
+	}
+
+	manager, err := plugins.Discover(context.TODO(), flagPluginDirs...)
+	if err != nil {
+		return err
+	}
 
 	// Execute the filesystem based scanners
 	results, err := scanner.ScanFS(context.TODO(), filesystem, ".")
@@ -50,5 +97,24 @@ func scanFS(dir string, stdout, stderr io.Writer) error {
 		return err
 	}
 
+	configured, err := cfg.Options()
+	if err != nil {
+		return err
+	}
+	for _, pluginScanner := range manager.FSScanners(append(opts, configured...)...) {
+		pluginResults, err := pluginScanner.ScanFS(context.TODO(), filesystem, ".")
+		if err != nil {
+			return err
+		}
+		results = append(results, pluginResults...)
+	}
+
 	return outputResults(stdout, abs, results)
 }
+
+func loadConfig(filesystem fs.FS) (*config.Config, error) {
+	if flagConfig != "" {
+		return config.Load(flagConfig)
+	}
+	return config.Find(filesystem, ".")
+}
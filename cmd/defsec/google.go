@@ -0,0 +1,99 @@
+package main
+
+import (
+	"context"
+	"io"
+
+	"github.com/aquasecurity/defsec/pkg/framework"
+
+	"github.com/aquasecurity/defsec/pkg/scanners/cloud/google"
+
+	"github.com/spf13/cobra"
+
+	"github.com/aquasecurity/defsec/pkg/scanners/options"
+)
+
+func init() {
+	googleCmd := &cobra.Command{
+		Use:   "google",
+		Short: "Scan a GCP project, folder or organization for misconfigurations",
+		Long: `Scan a GCP project, folder or organization for misconfigurations.
+
+Credentials are read from the environment: GOOGLE_OAUTH_ACCESS_TOKEN (e.g. from 'gcloud auth print-access-token'),
+the key file named by GOOGLE_APPLICATION_CREDENTIALS, the credentials written by
+'gcloud auth application-default login', or the service account attached to the environment, e.g. on GCE or GKE.`,
+		Args: cobra.ExactArgs(0),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cmd.SilenceUsage = true
+			cmd.SilenceErrors = true
+			return scanGoogle(cmd.OutOrStdout(), cmd.ErrOrStderr())
+		},
+	}
+	googleCmd.Flags().StringVarP(&flagFramework, "framework", "k", flagFramework, "framework to use (default, all)")
+	googleCmd.Flags().StringVar(&flagGoogleProject, "project", flagGoogleProject, "GCP project ID to scan (defaults to GOOGLE_CLOUD_PROJECT)")
+	googleCmd.Flags().StringVar(&flagGoogleFolder, "folder", flagGoogleFolder, "GCP folder ID to scan every project below")
+	googleCmd.Flags().StringVar(&flagGoogleOrganization, "organization", flagGoogleOrganization, "GCP organization ID to scan every project in")
+	googleCmd.Flags().StringSliceVarP(&flagGoogleServices, "services", "s", flagGoogleServices, "GCP services to scan")
+	googleCmd.MarkFlagsMutuallyExclusive("project", "folder", "organization")
+	addSelectionFlags(googleCmd)
+	addStateCacheFlags(googleCmd)
+	addThrottlingFlags(googleCmd)
+	rootCmd.AddCommand(googleCmd)
+}
+
+var (
+	flagGoogleProject      string
+	flagGoogleFolder       string
+	flagGoogleOrganization string
+	flagGoogleServices     []string
+)
+
+func scanGoogle(stdout, stderr io.Writer) error {
+
+	opts := []options.ScannerOption{
+		options.ScannerWithEmbeddedPolicies(true),
+	}
+
+	if flagDebug {
+		opts = append(opts, options.ScannerWithDebug(stderr))
+	}
+
+	if flagGoogleProject != "" {
+		opts = append(opts, google.ScannerWithGoogleProject(flagGoogleProject))
+	}
+
+	if flagGoogleFolder != "" {
+		opts = append(opts, google.ScannerWithGoogleFolder(flagGoogleFolder))
+	}
+
+	if flagGoogleOrganization != "" {
+		opts = append(opts, google.ScannerWithGoogleOrganization(flagGoogleOrganization))
+	}
+
+	if len(flagGoogleServices) > 0 {
+		opts = append(opts, google.ScannerWithGoogleServices(flagGoogleServices...))
+	}
+
+	opts = append(opts, selectionOptions()...)
+	opts = append(opts, stateCacheOptions()...)
+	opts = append(opts, throttlingOptions()...)
+	opts = append(opts, options.ScannerWithFrameworks(framework.Framework(flagFramework)))
+
+	scanner := google.New(opts...)
+
+	st, err := scanner.CreateState(context.TODO())
+	if err != nil {
+		return err
+	}
+
+	results, err := scanner.Scan(context.TODO(), st)
+	if err != nil {
+		return err
+	}
+
+	if err := writeCoverage(stderr, scanner.Coverage()); err != nil {
+		return err
+	}
+
+	return outputResults(stdout, ".", results)
+}
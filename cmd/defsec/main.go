@@ -20,7 +20,7 @@ var (
 func main() {
 
 	rootCmd.PersistentFlags().BoolVarP(&flagDebug, "debug", "d", flagDebug, "enable debug output")
-	rootCmd.PersistentFlags().StringVarP(&flagFormat, "format", "f", flagFormat, "output format (simple, sarif, json, csv, checkstyle, junit)")
+	rootCmd.PersistentFlags().StringVarP(&flagFormat, "format", "f", flagFormat, "output format (simple, sarif, json, csv, checkstyle, junit, html, markdown, github, github-annotations, gitlab, json-v1, ndjson, ocsf, asff)")
 
 	if err := rootCmd.Execute(); err != nil {
 		_, _ = fmt.Fprintf(os.Stderr, "Error: %s\n", err)
@@ -3,6 +3,7 @@ package main
 import (
 	"fmt"
 	"io"
+	"os"
 
 	"github.com/aquasecurity/defsec/pkg/formatters"
 	"github.com/aquasecurity/defsec/pkg/scan"
@@ -28,6 +29,31 @@ func outputResults(w io.Writer, baseDir string, results scan.Results) error {
 		factory.AsSimple()
 	case "checkstyle":
 		factory.AsCheckStyle()
+	case "html":
+		factory.AsHTML()
+	case "markdown":
+		factory.AsMarkdown()
+	case "github":
+		factory.AsGitHubActions()
+	case "github-annotations":
+		factory.AsGitHubAnnotations()
+	case "gitlab":
+		factory.AsGitLab()
+	case "json-v1":
+		factory.AsVersionedJSON()
+	case "ndjson":
+		factory.AsNDJSON()
+	case "ocsf":
+		factory.AsOCSF()
+	case "asff":
+		accountID := os.Getenv("AWS_ACCOUNT_ID")
+		if accountID == "" {
+			return fmt.Errorf("AWS_ACCOUNT_ID must be set to the account to import asff findings into")
+		}
+		if flagAWSRegion == "" {
+			return fmt.Errorf("--region must be set to the region to import asff findings into")
+		}
+		factory.AsASFF(accountID, flagAWSRegion)
 	default:
 		return fmt.Errorf("unsupported output format: %s", flagFormat)
 	}
@@ -0,0 +1,43 @@
+package main
+
+import (
+	"io"
+
+	"github.com/aquasecurity/defsec/pkg/scanners/cloud/aws"
+	"github.com/aquasecurity/defsec/pkg/scanners/cloud/azure"
+	"github.com/aquasecurity/defsec/pkg/scanners/cloud/coverage"
+	"github.com/aquasecurity/defsec/pkg/scanners/cloud/google"
+	"github.com/aquasecurity/defsec/pkg/scanners/options"
+	"github.com/spf13/cobra"
+)
+
+var (
+	flagSkipServices  []string
+	flagSkipExpensive bool
+)
+
+func addSelectionFlags(cmd *cobra.Command) {
+	cmd.Flags().StringSliceVar(&flagSkipServices, "skip-services", flagSkipServices, "services to exclude from the scan")
+	cmd.Flags().BoolVar(&flagSkipExpensive, "skip-expensive", flagSkipExpensive, "exclude services which make requests for every resource they find, unless selected with --services")
+}
+
+// selectionOptions returns the options which exclude services from a cloud scan. Each provider's scanner only applies
+// its own options, so all of them are returned.
+func selectionOptions() []options.ScannerOption {
+	return []options.ScannerOption{
+		aws.ScannerWithAWSSkipServices(flagSkipServices...),
+		aws.ScannerWithAWSSkipExpensive(flagSkipExpensive),
+		azure.ScannerWithAzureSkipServices(flagSkipServices...),
+		azure.ScannerWithAzureSkipExpensive(flagSkipExpensive),
+		google.ScannerWithGoogleSkipServices(flagSkipServices...),
+		google.ScannerWithGoogleSkipExpensive(flagSkipExpensive),
+	}
+}
+
+// writeCoverage writes which services a cloud scan covered and skipped
+func writeCoverage(w io.Writer, report *coverage.Report) error {
+	if report == nil {
+		return nil
+	}
+	return report.Write(w)
+}
@@ -0,0 +1,44 @@
+package main
+
+import (
+	"github.com/aquasecurity/defsec/pkg/concurrency"
+	"github.com/aquasecurity/defsec/pkg/scanners/cloud/aws"
+	"github.com/aquasecurity/defsec/pkg/scanners/cloud/azure"
+	"github.com/aquasecurity/defsec/pkg/scanners/cloud/google"
+	"github.com/aquasecurity/defsec/pkg/scanners/options"
+	"github.com/spf13/cobra"
+)
+
+var (
+	flagRateLimit      float64
+	flagMaxRetries     int
+	flagAPIConcurrency int
+)
+
+func addThrottlingFlags(cmd *cobra.Command) {
+	cmd.Flags().Float64Var(&flagRateLimit, "rate-limit", flagRateLimit, "maximum requests per second to each cloud service (unlimited if 0)")
+	cmd.Flags().IntVar(&flagMaxRetries, "max-retries", flagMaxRetries, "how many times to retry throttled requests (defaults to 5)")
+	cmd.Flags().IntVar(&flagAPIConcurrency, "api-concurrency", flagAPIConcurrency, "number of resources to read from the cloud at once (defaults to one per CPU)")
+}
+
+// throttlingOptions returns the options which limit the rate of cloud requests. Each provider's scanner only applies
+// its own options, so all of them are returned.
+func throttlingOptions() []options.ScannerOption {
+	opts := []options.ScannerOption{
+		aws.ScannerWithRateLimit(flagRateLimit),
+		aws.ScannerWithMaxRetries(flagMaxRetries),
+		azure.ScannerWithRateLimit(flagRateLimit),
+		azure.ScannerWithMaxRetries(flagMaxRetries),
+		google.ScannerWithRateLimit(flagRateLimit),
+		google.ScannerWithMaxRetries(flagMaxRetries),
+	}
+	if flagAPIConcurrency > 0 {
+		strategy := concurrency.Limit(flagAPIConcurrency)
+		opts = append(opts,
+			aws.ScannerWithConcurrencyStrategy(strategy),
+			azure.ScannerWithConcurrencyStrategy(strategy),
+			google.ScannerWithConcurrencyStrategy(strategy),
+		)
+	}
+	return opts
+}
@@ -17,6 +17,7 @@ import (
 	"github.com/aquasecurity/defsec/internal/adapters/arm/storage"
 	"github.com/aquasecurity/defsec/internal/adapters/arm/synapse"
 
+	"github.com/aquasecurity/defsec/pkg/providers"
 	"github.com/aquasecurity/defsec/pkg/providers/azure"
 	scanner "github.com/aquasecurity/defsec/pkg/scanners/azure"
 	"github.com/aquasecurity/defsec/pkg/state"
@@ -24,27 +25,38 @@ import (
 
 // Adapt ...
 func Adapt(ctx context.Context, deployment scanner.Deployment) *state.State {
-	return &state.State{
-		Azure: adaptAzure(deployment),
-	}
+	return AdaptServices(ctx, deployment, state.Selection{})
 }
 
-func adaptAzure(deployment scanner.Deployment) azure.Azure {
-
-	return azure.Azure{
-		AppService:     appservice.Adapt(deployment),
-		Authorization:  authorization.Adapt(deployment),
-		Compute:        compute.Adapt(deployment),
-		Container:      container.Adapt(deployment),
-		Database:       database.Adapt(deployment),
-		DataFactory:    datafactory.Adapt(deployment),
-		DataLake:       datalake.Adapt(deployment),
-		KeyVault:       keyvault.Adapt(deployment),
-		Monitor:        monitor.Adapt(deployment),
-		Network:        network.Adapt(deployment),
-		SecurityCenter: securitycenter.Adapt(deployment),
-		Storage:        storage.Adapt(deployment),
-		Synapse:        synapse.Adapt(deployment),
+// AdaptServices adapts only the services included in the selection, leaving the rest of the state empty. Each
+// selected service is adapted before any rule is evaluated, see state.Selection.
+func AdaptServices(_ context.Context, deployment scanner.Deployment, selection state.Selection) *state.State {
+	var s state.State
+	if selection.IncludesProvider(providers.AzureProvider) {
+		s.Azure = adaptAzure(deployment, selection)
 	}
+	return &s
+}
 
+func adaptAzure(deployment scanner.Deployment, selection state.Selection) azure.Azure {
+	var a azure.Azure
+	adapt := func(service string, f func()) {
+		if selection.Includes(providers.AzureProvider, service) {
+			f()
+		}
+	}
+	adapt("appservice", func() { a.AppService = appservice.Adapt(deployment) })
+	adapt("authorization", func() { a.Authorization = authorization.Adapt(deployment) })
+	adapt("compute", func() { a.Compute = compute.Adapt(deployment) })
+	adapt("container", func() { a.Container = container.Adapt(deployment) })
+	adapt("database", func() { a.Database = database.Adapt(deployment) })
+	adapt("datafactory", func() { a.DataFactory = datafactory.Adapt(deployment) })
+	adapt("datalake", func() { a.DataLake = datalake.Adapt(deployment) })
+	adapt("keyvault", func() { a.KeyVault = keyvault.Adapt(deployment) })
+	adapt("monitor", func() { a.Monitor = monitor.Adapt(deployment) })
+	adapt("network", func() { a.Network = network.Adapt(deployment) })
+	adapt("securitycenter", func() { a.SecurityCenter = securitycenter.Adapt(deployment) })
+	adapt("storage", func() { a.Storage = storage.Adapt(deployment) })
+	adapt("synapse", func() { a.Synapse = synapse.Adapt(deployment) })
+	return a
 }
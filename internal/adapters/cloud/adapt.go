@@ -4,6 +4,8 @@ import (
 	"context"
 
 	"github.com/aquasecurity/defsec/internal/adapters/cloud/aws"
+	"github.com/aquasecurity/defsec/internal/adapters/cloud/azure"
+	"github.com/aquasecurity/defsec/internal/adapters/cloud/google"
 	"github.com/aquasecurity/defsec/internal/adapters/cloud/options"
 	"github.com/aquasecurity/defsec/pkg/state"
 )
@@ -14,3 +16,23 @@ func Adapt(ctx context.Context, opt options.Options) (*state.State, error) {
 	err := aws.Adapt(ctx, cloudState, opt)
 	return cloudState, err
 }
+
+// AdaptAWSOrganization reads the resources in each account of an AWS organization into a state per account, keyed by
+// account ID
+func AdaptAWSOrganization(ctx context.Context, opt options.Options) (map[string]*state.State, error) {
+	return aws.AdaptOrganization(ctx, opt)
+}
+
+// AdaptAzure reads the resources in an Azure subscription into a new state
+func AdaptAzure(ctx context.Context, opt options.Options) (*state.State, error) {
+	cloudState := &state.State{}
+	err := azure.Adapt(ctx, cloudState, opt)
+	return cloudState, err
+}
+
+// AdaptGoogle reads the resources in a GCP project, folder or organization into a new state
+func AdaptGoogle(ctx context.Context, opt options.Options) (*state.State, error) {
+	cloudState := &state.State{}
+	err := google.Adapt(ctx, cloudState, opt)
+	return cloudState, err
+}
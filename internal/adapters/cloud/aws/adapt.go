@@ -13,7 +13,10 @@ import (
 	"github.com/aws/aws-sdk-go-v2/service/sts"
 
 	"github.com/aquasecurity/defsec/internal/adapters/cloud/options"
+	"github.com/aquasecurity/defsec/internal/adapters/cloud/throttle"
 	"github.com/aquasecurity/defsec/pkg/progress"
+	"github.com/aquasecurity/defsec/pkg/scanners/cloud/cache"
+	"github.com/aquasecurity/defsec/pkg/scanners/cloud/coverage"
 	"github.com/aquasecurity/defsec/pkg/state"
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/aws/arn"
@@ -140,22 +143,13 @@ func Adapt(ctx context.Context, state *state.State, opt options.Options) error {
 		concurrencyStrategy: opt.ConcurrencyStrategy,
 	}
 
-	cfg, err := config.LoadDefaultConfig(ctx)
+	cfg, err := c.loadConfig(opt)
 	if err != nil {
 		return err
 	}
 
 	c.sessionCfg = cfg
 
-	if opt.Region != "" {
-		c.Debug("Using region '%s'", opt.Region)
-		c.sessionCfg.Region = opt.Region
-	}
-	if opt.Endpoint != "" {
-		c.Debug("Using endpoint '%s'", opt.Endpoint)
-		c.sessionCfg.EndpointResolverWithOptions = createResolver(opt.Endpoint)
-	}
-
 	c.Debug("Discovering caller identity...")
 	stsClient := sts.NewFromConfig(c.sessionCfg)
 	result, err := stsClient.GetCallerIdentity(ctx, &sts.GetCallerIdentityInput{})
@@ -168,31 +162,117 @@ func Adapt(ctx context.Context, state *state.State, opt options.Options) error {
 	c.accountID = *result.Account
 	c.Debug("AWS account ID: %s", c.accountID)
 
-	if len(opt.Services) == 0 {
-		c.Debug("Preparing to run for all %d registered services...", len(registeredAdapters))
-		opt.ProgressTracker.SetTotalServices(len(registeredAdapters))
-	} else {
-		c.Debug("Preparing to run for %d filtered services...", len(opt.Services))
-		opt.ProgressTracker.SetTotalServices(len(opt.Services))
+	return c.adaptServices(state, opt)
+}
+
+// loadConfig loads the default AWS configuration, applying the region and endpoint options
+func (a *RootAdapter) loadConfig(opt options.Options) (aws.Config, error) {
+	cfg, err := config.LoadDefaultConfig(a.ctx, config.WithRetryer(func() aws.Retryer {
+		return newRetryer(opt.MaxRetries)
+	}))
+	if err != nil {
+		return cfg, err
+	}
+	if opt.RateLimit > 0 {
+		a.Debug("Limiting requests to %g per second per service", opt.RateLimit)
 	}
+	cfg.APIOptions = append(cfg.APIOptions, rateLimitMiddleware(throttle.NewLimiters(opt.RateLimit, 0)))
 
-	c.region = c.sessionCfg.Region
+	if opt.Region != "" {
+		a.Debug("Using region '%s'", opt.Region)
+		cfg.Region = opt.Region
+	} else if len(opt.Regions) > 0 {
+		cfg.Region = opt.Regions[0]
+	}
+	if opt.Endpoint != "" {
+		a.Debug("Using endpoint '%s'", opt.Endpoint)
+		cfg.EndpointResolverWithOptions = createResolver(opt.Endpoint)
+	}
+	return cfg, nil
+}
 
-	var adapterErrors []error
+// globalServices are not regional, so are adapted once rather than in every region
+var globalServices = map[string]bool{
+	"cloudfront": true,
+	"iam":        true,
+}
+
+// expensiveServices make several requests for every resource they find, e.g. s3 looks up the location and
+// configuration of every bucket in every region, and are not adapted when expensive services are skipped
+var expensiveServices = map[string]bool{
+	"s3": true,
+}
+
+// adaptServices runs the adapter of each selected service against the account of the root adapter, in each selected
+// region
+func (a *RootAdapter) adaptServices(cloudState *state.State, opt options.Options) error {
+
+	regions := opt.Regions
+	if len(regions) == 0 {
+		regions = []string{a.sessionCfg.Region}
+	}
 
-	for _, adapter := range registeredAdapters {
-		if len(opt.Services) != 0 && !contains(opt.Services, adapter.Name()) {
+	var selected []ServiceAdapter
+	for _, registered := range registeredAdapters {
+		if reason := opt.SkipReason(registered.Name(), expensiveServices[registered.Name()]); reason != "" {
+			opt.Coverage.Add(coverage.Service{
+				Provider: "aws",
+				Name:     registered.Name(),
+				Account:  a.accountID,
+				Status:   coverage.Skipped,
+				Reason:   reason,
+			})
 			continue
 		}
-		c.currentService = adapter.Name()
-		c.Debug("Running adapter for %s...", adapter.Name())
-		opt.ProgressTracker.StartService(adapter.Name())
+		selected = append(selected, registered)
+	}
+
+	var total int
+	for _, registered := range selected {
+		if globalServices[registered.Name()] {
+			total++
+		} else {
+			total += len(regions)
+		}
+	}
+	a.Debug("Preparing to run for %d services in %d regions...", len(selected), len(regions))
+	opt.ProgressTracker.SetTotalServices(total)
+
+	var adapterErrors []error
 
-		if err := adapter.Adapt(c, state); err != nil {
-			c.Debug("Error occurred while running adapter for %s: %s", adapter.Name(), err)
-			adapterErrors = append(adapterErrors, fmt.Errorf("failed to run adapter for %s: %w", adapter.Name(), err))
+	for i, region := range regions {
+		a.region = region
+		a.sessionCfg.Region = region
+		for _, registered := range selected {
+			if globalServices[registered.Name()] && i > 0 {
+				continue
+			}
+			adapter := newInstance(registered)
+			a.currentService = adapter.Name()
+			a.Debug("Running adapter for %s in %s...", adapter.Name(), region)
+			opt.ProgressTracker.StartService(adapter.Name())
+
+			covered := coverage.Service{
+				Provider: "aws",
+				Name:     adapter.Name(),
+				Account:  a.accountID,
+				Status:   coverage.Covered,
+			}
+			if !globalServices[adapter.Name()] {
+				covered.Region = region
+			}
+
+			key := cache.Key{Provider: "aws", Account: a.accountID, Region: covered.Region, Service: adapter.Name()}
+			if err := opt.StateCache.Adapt(key, cloudState, func(s *state.State) error {
+				return adapter.Adapt(a, s)
+			}); err != nil {
+				a.Debug("Error occurred while running adapter for %s: %s", adapter.Name(), err)
+				adapterErrors = append(adapterErrors, fmt.Errorf("failed to run adapter for %s in %s: %w", adapter.Name(), region, err))
+				covered.Status = coverage.Failed
+			}
+			opt.Coverage.Add(covered)
+			opt.ProgressTracker.FinishService()
 		}
-		opt.ProgressTracker.FinishService()
 	}
 
 	if len(adapterErrors) > 0 {
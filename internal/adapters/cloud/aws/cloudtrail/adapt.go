@@ -52,7 +52,12 @@ func (a *adapter) getTrails() ([]cloudtrail.Trail, error) {
 		if err != nil {
 			return nil, err
 		}
-		apiTrails = append(apiTrails, output.Trails...)
+		// trails are listed in every region they log, so each is only adapted in the region it was created in
+		for _, trail := range output.Trails {
+			if trail.HomeRegion == nil || *trail.HomeRegion == a.Region() {
+				apiTrails = append(apiTrails, trail)
+			}
+		}
 		a.Tracker().SetTotalResources(len(apiTrails))
 		if output.NextToken == nil {
 			break
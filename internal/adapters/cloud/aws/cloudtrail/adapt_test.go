@@ -0,0 +1,81 @@
+package cloudtrail
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	aws2 "github.com/aquasecurity/defsec/internal/adapters/cloud/aws"
+	"github.com/aquasecurity/defsec/pkg/progress"
+	"github.com/aquasecurity/defsec/pkg/state"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/credentials"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_TrailsAdaptedInHomeRegionOnly(t *testing.T) {
+
+	trails := map[string]string{
+		"arn:aws:cloudtrail:us-east-1:111111111111:trail/organization": "us-east-1",
+		"arn:aws:cloudtrail:eu-west-1:111111111111:trail/regional":     "eu-west-1",
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var input struct {
+			Name string
+		}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&input))
+
+		var body interface{}
+		switch target := r.Header.Get("X-Amz-Target"); {
+		case strings.HasSuffix(target, ".ListTrails"):
+			// the organization trail logs every region, so it is listed wherever it is requested from
+			var listed []interface{}
+			for trailARN, home := range trails {
+				listed = append(listed, map[string]interface{}{
+					"TrailARN":   trailARN,
+					"Name":       trailARN[strings.LastIndex(trailARN, "/")+1:],
+					"HomeRegion": home,
+				})
+			}
+			body = map[string]interface{}{"Trails": listed}
+		case strings.HasSuffix(target, ".GetTrail"):
+			body = map[string]interface{}{
+				"Trail": map[string]interface{}{
+					"Name":     input.Name[strings.LastIndex(input.Name, "/")+1:],
+					"TrailARN": input.Name,
+				},
+			}
+		case strings.HasSuffix(target, ".GetTrailStatus"):
+			body = map[string]interface{}{"IsLogging": true}
+		default:
+			t.Errorf("unexpected request: %s", target)
+		}
+		w.Header().Set("Content-Type", "application/x-amz-json-1.1")
+		require.NoError(t, json.NewEncoder(w).Encode(body))
+	}))
+	defer server.Close()
+
+	var total int
+	for _, region := range []string{"us-east-1", "eu-west-1", "ap-southeast-2"} {
+		cfg := aws.Config{
+			Region:      region,
+			Credentials: credentials.NewStaticCredentialsProvider("AKID", "SECRET", ""),
+			EndpointResolverWithOptions: aws.EndpointResolverWithOptionsFunc(func(_, region string, _ ...interface{}) (aws.Endpoint, error) {
+				return aws.Endpoint{URL: server.URL, SigningRegion: region}, nil
+			}),
+		}
+
+		testState := &state.State{}
+		require.NoError(t, (&adapter{}).Adapt(aws2.NewRootAdapter(context.TODO(), cfg, progress.NoProgress), testState))
+		for _, trail := range testState.AWS.CloudTrail.Trails {
+			assert.Equal(t, region, trails[trail.Metadata.Reference()])
+		}
+		total += len(testState.AWS.CloudTrail.Trails)
+	}
+	assert.Equal(t, len(trails), total)
+}
@@ -0,0 +1,281 @@
+package aws
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/aquasecurity/defsec/internal/adapters/cloud/options"
+	"github.com/aquasecurity/defsec/pkg/errs"
+	"github.com/aquasecurity/defsec/pkg/progress"
+	"github.com/aquasecurity/defsec/pkg/state"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/aws/arn"
+	v4 "github.com/aws/aws-sdk-go-v2/aws/signer/v4"
+	"github.com/aws/aws-sdk-go-v2/credentials/stscreds"
+	"github.com/aws/aws-sdk-go-v2/service/sts"
+)
+
+const (
+	// DefaultOrganizationRole is the role AWS Organizations creates in each account it creates, which the management
+	// account can assume
+	DefaultOrganizationRole = "OrganizationAccountAccessRole"
+
+	// accountWorkers is the number of accounts adapted at once. Each account also adapts its resources concurrently,
+	// so this is kept small to avoid being throttled.
+	accountWorkers = 4
+
+	roleSessionName = "defsec"
+)
+
+// Account is a member account of an organization
+type Account struct {
+	ID   string
+	Name string
+}
+
+// AdaptOrganization lists the active accounts of the caller's organization, assumes the organization role into each
+// of them, and reads each account's resources into its own state. The states are keyed by account ID. The caller must
+// be in the management account, or an account delegated to administer the organization.
+func AdaptOrganization(ctx context.Context, opt options.Options) (map[string]*state.State, error) {
+	c := &RootAdapter{
+		ctx:                 ctx,
+		tracker:             opt.ProgressTracker,
+		debugWriter:         opt.DebugWriter.Extend("adapt", "aws", "organization"),
+		concurrencyStrategy: opt.ConcurrencyStrategy,
+	}
+
+	cfg, err := c.loadConfig(opt)
+	if err != nil {
+		return nil, err
+	}
+	c.sessionCfg = cfg
+
+	c.Debug("Discovering caller identity...")
+	identity, err := sts.NewFromConfig(cfg).GetCallerIdentity(ctx, &sts.GetCallerIdentityInput{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to discover AWS caller identity: %w", err)
+	}
+	if identity.Account == nil || identity.Arn == nil {
+		return nil, fmt.Errorf("missing account id for aws account")
+	}
+	callerARN, err := arn.Parse(*identity.Arn)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse AWS caller identity: %w", err)
+	}
+
+	c.Debug("Listing organization accounts...")
+	accounts, err := listOrganizationAccounts(ctx, cfg, callerARN.Partition, opt.Endpoint)
+	if err != nil {
+		return nil, err
+	}
+	if len(opt.Accounts) > 0 {
+		var selected []Account
+		for _, account := range accounts {
+			if contains(opt.Accounts, account.ID) {
+				selected = append(selected, account)
+			}
+		}
+		accounts = selected
+	}
+	c.Debug("Found %d accounts to scan", len(accounts))
+
+	role := opt.OrganizationRole
+	if role == "" {
+		role = DefaultOrganizationRole
+	}
+
+	// accounts are adapted concurrently, so progress is reported per account rather than per service
+	var lock sync.Mutex
+	opt.ProgressTracker.SetTotalServices(len(accounts))
+
+	states := make(map[string]*state.State)
+	var accountErrors []error
+
+	work := make(chan Account)
+	var wg sync.WaitGroup
+	for i := 0; i < accountWorkers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for account := range work {
+				accountState, err := c.adaptAccount(account, *identity.Account, callerARN.Partition, role, opt)
+
+				lock.Lock()
+				opt.ProgressTracker.StartService(fmt.Sprintf("%s (%s)", account.Name, account.ID))
+				if accountState != nil {
+					states[account.ID] = accountState
+				}
+				if err != nil {
+					c.Debug("Error occurred while scanning account %s: %s", account.ID, err)
+					accountErrors = append(accountErrors, fmt.Errorf("account %s: %w", account.ID, err))
+				}
+				opt.ProgressTracker.FinishService()
+				lock.Unlock()
+			}
+		}()
+	}
+	for _, account := range accounts {
+		work <- account
+	}
+	close(work)
+	wg.Wait()
+
+	if len(accountErrors) > 0 {
+		return states, errs.NewAdapterError(accountErrors)
+	}
+	return states, nil
+}
+
+// adaptAccount reads the resources of a single member account. The caller's own account is read with the caller's
+// credentials, as the organization role does not exist in the management account.
+func (a *RootAdapter) adaptAccount(account Account, callerAccount string, partition string, role string, opt options.Options) (*state.State, error) {
+
+	cfg := a.sessionCfg.Copy()
+	if account.ID != callerAccount {
+		roleARN := arn.ARN{
+			Partition: partition,
+			Service:   "iam",
+			AccountID: account.ID,
+			Resource:  "role/" + role,
+		}.String()
+		a.Debug("Assuming role %s...", roleARN)
+		cfg.Credentials = aws.NewCredentialsCache(stscreds.NewAssumeRoleProvider(
+			sts.NewFromConfig(a.sessionCfg), roleARN, func(o *stscreds.AssumeRoleOptions) {
+				o.RoleSessionName = roleSessionName
+			},
+		))
+	}
+
+	c := &RootAdapter{
+		ctx:                 a.ctx,
+		sessionCfg:          cfg,
+		tracker:             progress.NoProgress,
+		accountID:           account.ID,
+		debugWriter:         a.debugWriter.Extend(account.ID),
+		concurrencyStrategy: a.concurrencyStrategy,
+	}
+
+	// fail early if the role cannot be assumed, rather than once for every service
+	if _, err := cfg.Credentials.Retrieve(a.ctx); err != nil {
+		return nil, fmt.Errorf("failed to assume role %s: %w", role, err)
+	}
+
+	opt.ProgressTracker = progress.NoProgress
+	accountState := &state.State{}
+	err := c.adaptServices(accountState, opt)
+	return accountState, err
+}
+
+// newInstance creates a new instance of a registered service adapter. Adapters hold the root adapter of the scan in
+// progress, so accounts scanned concurrently must not share them.
+func newInstance(adapter ServiceAdapter) ServiceAdapter {
+	return reflect.New(reflect.TypeOf(adapter).Elem()).Interface().(ServiceAdapter)
+}
+
+type organizationsAccount struct {
+	ID     string `json:"Id"`
+	Name   string `json:"Name"`
+	Status string `json:"Status"`
+}
+
+// listOrganizationAccounts calls the Organizations ListAccounts API, returning the active accounts sorted by ID
+func listOrganizationAccounts(ctx context.Context, cfg aws.Config, partition string, endpoint string) ([]Account, error) {
+
+	endpoint, signingRegion := organizationsEndpoint(partition, endpoint)
+	signer := v4.NewSigner()
+
+	var accounts []Account
+	var nextToken string
+	for {
+		body := map[string]string{}
+		if nextToken != "" {
+			body["NextToken"] = nextToken
+		}
+		payload, err := json.Marshal(body)
+		if err != nil {
+			return nil, err
+		}
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(payload))
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("Content-Type", "application/x-amz-json-1.1")
+		req.Header.Set("X-Amz-Target", "AWSOrganizationsV20161128.ListAccounts")
+
+		credentials, err := cfg.Credentials.Retrieve(ctx)
+		if err != nil {
+			return nil, err
+		}
+		hash := sha256.Sum256(payload)
+		if err := signer.SignHTTP(ctx, credentials, req, hex.EncodeToString(hash[:]), "organizations", signingRegion, time.Now()); err != nil {
+			return nil, err
+		}
+
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list organization accounts: %w", err)
+		}
+		data, err := io.ReadAll(resp.Body)
+		_ = resp.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("failed to list organization accounts: %w", err)
+		}
+		if resp.StatusCode != http.StatusOK {
+			var apiError struct {
+				Type    string `json:"__type"`
+				Message string `json:"Message"`
+			}
+			_ = json.Unmarshal(data, &apiError)
+			return nil, fmt.Errorf("failed to list organization accounts: %s: %s %s", resp.Status, apiError.Type, apiError.Message)
+		}
+
+		var page struct {
+			Accounts  []organizationsAccount `json:"Accounts"`
+			NextToken string                 `json:"NextToken"`
+		}
+		if err := json.Unmarshal(data, &page); err != nil {
+			return nil, fmt.Errorf("failed to list organization accounts: %w", err)
+		}
+		for _, account := range page.Accounts {
+			if account.Status != "ACTIVE" {
+				continue
+			}
+			accounts = append(accounts, Account{ID: account.ID, Name: account.Name})
+		}
+		if page.NextToken == "" {
+			break
+		}
+		nextToken = page.NextToken
+	}
+
+	sort.Slice(accounts, func(i, j int) bool {
+		return accounts[i].ID < accounts[j].ID
+	})
+	return accounts, nil
+}
+
+// organizationsEndpoint returns the global Organizations endpoint of a partition, and the region requests to it are
+// signed for
+func organizationsEndpoint(partition string, endpoint string) (string, string) {
+	switch {
+	case endpoint != "":
+		return strings.TrimSuffix(endpoint, "/") + "/", "us-east-1"
+	case partition == "aws-cn":
+		return "https://organizations.cn-northwest-1.amazonaws.com.cn/", "cn-northwest-1"
+	case partition == "aws-us-gov":
+		return "https://organizations.us-gov-west-1.amazonaws.com/", "us-gov-west-1"
+	default:
+		return "https://organizations.us-east-1.amazonaws.com/", "us-east-1"
+	}
+}
@@ -0,0 +1,78 @@
+package aws
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/credentials"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ListOrganizationAccounts(t *testing.T) {
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "AWSOrganizationsV20161128.ListAccounts", r.Header.Get("X-Amz-Target"))
+		assert.True(t, strings.HasPrefix(r.Header.Get("Authorization"), "AWS4-HMAC-SHA256 Credential=AKID/"))
+
+		var input struct {
+			NextToken string
+		}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&input))
+
+		var body interface{}
+		if input.NextToken == "" {
+			body = map[string]interface{}{
+				"Accounts": []interface{}{
+					map[string]interface{}{"Id": "222222222222", "Name": "workloads", "Status": "ACTIVE"},
+					map[string]interface{}{"Id": "333333333333", "Name": "closed", "Status": "SUSPENDED"},
+				},
+				"NextToken": "page-2",
+			}
+		} else {
+			assert.Equal(t, "page-2", input.NextToken)
+			body = map[string]interface{}{
+				"Accounts": []interface{}{
+					map[string]interface{}{"Id": "111111111111", "Name": "management", "Status": "ACTIVE"},
+				},
+			}
+		}
+		require.NoError(t, json.NewEncoder(w).Encode(body))
+	}))
+	defer server.Close()
+
+	cfg := aws.Config{
+		Region:      "eu-west-1",
+		Credentials: credentials.NewStaticCredentialsProvider("AKID", "SECRET", ""),
+	}
+
+	accounts, err := listOrganizationAccounts(context.TODO(), cfg, "aws", server.URL)
+	require.NoError(t, err)
+	assert.Equal(t, []Account{
+		{ID: "111111111111", Name: "management"},
+		{ID: "222222222222", Name: "workloads"},
+	}, accounts)
+}
+
+func Test_ListOrganizationAccountsError(t *testing.T) {
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`{"__type":"AWSOrganizationsNotInUseException","Message":"not in an organization"}`))
+	}))
+	defer server.Close()
+
+	cfg := aws.Config{
+		Region:      "eu-west-1",
+		Credentials: credentials.NewStaticCredentialsProvider("AKID", "SECRET", ""),
+	}
+
+	_, err := listOrganizationAccounts(context.TODO(), cfg, "aws", server.URL)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "AWSOrganizationsNotInUseException")
+}
@@ -0,0 +1,42 @@
+package aws
+
+import (
+	"context"
+
+	"github.com/aquasecurity/defsec/internal/adapters/cloud/throttle"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awsmiddleware "github.com/aws/aws-sdk-go-v2/aws/middleware"
+	"github.com/aws/aws-sdk-go-v2/aws/ratelimit"
+	"github.com/aws/aws-sdk-go-v2/aws/retry"
+	"github.com/aws/smithy-go/middleware"
+)
+
+// retryQuota is the number of retry tokens each client may spend. The SDK's default quota is exhausted after around
+// a hundred throttled requests, which large accounts easily reach, after which throttled requests fail immediately.
+const retryQuota = 10000
+
+// newRetryer creates a retryer which retries throttled and transient errors (e.g. Throttling, RequestLimitExceeded)
+// with exponential backoff and jitter
+func newRetryer(maxRetries int) aws.Retryer {
+	backoff := throttle.NewBackoff(maxRetries)
+	return retry.NewStandard(func(o *retry.StandardOptions) {
+		o.MaxAttempts = backoff.MaxRetries + 1
+		o.MaxBackoff = backoff.MaxDelay
+		o.RateLimiter = ratelimit.NewTokenRateLimit(retryQuota)
+	})
+}
+
+// rateLimitMiddleware waits for the limiter of the service each request is sent to before each attempt, including
+// retries
+func rateLimitMiddleware(limiters *throttle.Limiters) func(*middleware.Stack) error {
+	return func(stack *middleware.Stack) error {
+		return stack.Finalize.Add(middleware.FinalizeMiddlewareFunc("RateLimit", func(
+			ctx context.Context, in middleware.FinalizeInput, next middleware.FinalizeHandler,
+		) (middleware.FinalizeOutput, middleware.Metadata, error) {
+			if err := limiters.For(awsmiddleware.GetServiceID(ctx)).Wait(ctx); err != nil {
+				return middleware.FinalizeOutput{}, middleware.Metadata{}, err
+			}
+			return next.HandleFinalize(ctx, in)
+		}), middleware.After)
+	}
+}
@@ -0,0 +1,195 @@
+package azure
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"reflect"
+	"strings"
+
+	"github.com/aquasecurity/defsec/internal/adapters/cloud/options"
+	"github.com/aquasecurity/defsec/pkg/concurrency"
+	"github.com/aquasecurity/defsec/pkg/debug"
+	"github.com/aquasecurity/defsec/pkg/errs"
+	"github.com/aquasecurity/defsec/pkg/progress"
+	azureState "github.com/aquasecurity/defsec/pkg/providers/azure"
+	"github.com/aquasecurity/defsec/pkg/scanners/cloud/cache"
+	"github.com/aquasecurity/defsec/pkg/scanners/cloud/coverage"
+	"github.com/aquasecurity/defsec/pkg/state"
+	"github.com/aquasecurity/defsec/pkg/types"
+)
+
+var registeredAdapters []ServiceAdapter
+
+func RegisterServiceAdapter(adapter ServiceAdapter) {
+	for _, existing := range registeredAdapters {
+		if existing.Name() == adapter.Name() {
+			panic(fmt.Sprintf("duplicate service adapter: %s", adapter.Name()))
+		}
+	}
+	registeredAdapters = append(registeredAdapters, adapter)
+}
+
+type ServiceAdapter interface {
+	Name() string
+	Provider() string
+	Adapt(root *RootAdapter, state *state.State) error
+}
+
+type RootAdapter struct {
+	ctx                 context.Context
+	client              *Client
+	tracker             progress.ServiceTracker
+	subscriptionID      string
+	debugWriter         debug.Logger
+	concurrencyStrategy concurrency.Strategy
+}
+
+func NewRootAdapter(ctx context.Context, client *Client, subscriptionID string, tracker progress.ServiceTracker) *RootAdapter {
+	return &RootAdapter{
+		ctx:            ctx,
+		client:         client,
+		tracker:        tracker,
+		subscriptionID: subscriptionID,
+	}
+}
+
+func (a *RootAdapter) SubscriptionID() string {
+	return a.subscriptionID
+}
+
+func (a *RootAdapter) Debug(format string, args ...interface{}) {
+	a.debugWriter.Log(format, args...)
+}
+
+func (a *RootAdapter) ConcurrencyStrategy() concurrency.Strategy {
+	return a.concurrencyStrategy
+}
+
+func (a *RootAdapter) Client() *Client {
+	return a.client
+}
+
+func (a *RootAdapter) Context() context.Context {
+	return a.ctx
+}
+
+func (a *RootAdapter) Tracker() progress.ServiceTracker {
+	return a.tracker
+}
+
+// CreateMetadata creates metadata for a resource from its Azure resource ID
+func (a *RootAdapter) CreateMetadata(resourceID string) types.Metadata {
+	return types.NewRemoteMetadata(resourceID)
+}
+
+func AllServices() []string {
+	var services []string
+	for _, reg := range registeredAdapters {
+		services = append(services, reg.Name())
+	}
+	return services
+}
+
+// unsupportedServices returns the services of the Azure state which no adapter reads. Their rules are evaluated
+// against an empty state, so would otherwise appear to pass.
+func unsupportedServices() []string {
+	adapted := make(map[string]bool)
+	for _, name := range AllServices() {
+		adapted[name] = true
+	}
+	var unsupported []string
+	services := reflect.TypeOf(azureState.Azure{})
+	for i := 0; i < services.NumField(); i++ {
+		if name := strings.ToLower(services.Field(i).Name); !adapted[name] {
+			unsupported = append(unsupported, name)
+		}
+	}
+	return unsupported
+}
+
+// Adapt reads the resources in an Azure subscription into the state. The subscription is taken from the options,
+// or from AZURE_SUBSCRIPTION_ID, and credentials from the environment, see CredentialFromEnvironment. Services
+// which cannot be read yet are reported as unsupported in the coverage report.
+func Adapt(ctx context.Context, cloudState *state.State, opt options.Options) error {
+	c := &RootAdapter{
+		ctx:                 ctx,
+		tracker:             opt.ProgressTracker,
+		debugWriter:         opt.DebugWriter.Extend("adapt", "azure"),
+		concurrencyStrategy: opt.ConcurrencyStrategy,
+		subscriptionID:      opt.Subscription,
+	}
+
+	if c.subscriptionID == "" {
+		c.subscriptionID = os.Getenv("AZURE_SUBSCRIPTION_ID")
+	}
+	if c.subscriptionID == "" {
+		return fmt.Errorf("no azure subscription specified: set AZURE_SUBSCRIPTION_ID or use the subscription option")
+	}
+	c.Debug("Azure subscription ID: %s", c.subscriptionID)
+
+	credential, err := CredentialFromEnvironment(opt.Endpoint)
+	if err != nil {
+		return fmt.Errorf("failed to load azure credentials: %w", err)
+	}
+	if opt.Endpoint != "" {
+		c.Debug("Using endpoint '%s'", opt.Endpoint)
+	}
+	c.client = NewClient(opt.Endpoint, credential)
+	c.client.SetThrottling(opt.RateLimit, opt.MaxRetries)
+
+	var selected []ServiceAdapter
+	for _, registered := range registeredAdapters {
+		if reason := opt.SkipReason(registered.Name(), false); reason != "" {
+			opt.Coverage.Add(coverage.Service{
+				Provider: "azure",
+				Name:     registered.Name(),
+				Account:  c.subscriptionID,
+				Status:   coverage.Skipped,
+				Reason:   reason,
+			})
+			continue
+		}
+		selected = append(selected, registered)
+	}
+	for _, name := range unsupportedServices() {
+		opt.Coverage.Add(coverage.Service{
+			Provider: "azure",
+			Name:     name,
+			Account:  c.subscriptionID,
+			Status:   coverage.Unsupported,
+		})
+	}
+	c.Debug("Preparing to run for %d services...", len(selected))
+	opt.ProgressTracker.SetTotalServices(len(selected))
+
+	var adapterErrors []error
+
+	for _, adapter := range selected {
+		c.Debug("Running adapter for %s...", adapter.Name())
+		opt.ProgressTracker.StartService(adapter.Name())
+
+		covered := coverage.Service{
+			Provider: "azure",
+			Name:     adapter.Name(),
+			Account:  c.subscriptionID,
+			Status:   coverage.Covered,
+		}
+		key := cache.Key{Provider: "azure", Account: c.subscriptionID, Service: adapter.Name()}
+		if err := opt.StateCache.Adapt(key, cloudState, func(s *state.State) error {
+			return adapter.Adapt(c, s)
+		}); err != nil {
+			c.Debug("Error occurred while running adapter for %s: %s", adapter.Name(), err)
+			adapterErrors = append(adapterErrors, fmt.Errorf("failed to run adapter for %s: %w", adapter.Name(), err))
+			covered.Status = coverage.Failed
+		}
+		opt.Coverage.Add(covered)
+		opt.ProgressTracker.FinishService()
+	}
+
+	if len(adapterErrors) > 0 {
+		return errs.NewAdapterError(adapterErrors)
+	}
+
+	return nil
+}
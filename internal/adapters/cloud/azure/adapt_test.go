@@ -0,0 +1,27 @@
+package azure
+
+import (
+	"testing"
+
+	"github.com/aquasecurity/defsec/pkg/state"
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeAdapter string
+
+func (f fakeAdapter) Name() string                               { return string(f) }
+func (f fakeAdapter) Provider() string                           { return "azure" }
+func (f fakeAdapter) Adapt(_ *RootAdapter, _ *state.State) error { return nil }
+
+func Test_UnsupportedServices(t *testing.T) {
+	registered := registeredAdapters
+	defer func() { registeredAdapters = registered }()
+	registeredAdapters = []ServiceAdapter{fakeAdapter("storage"), fakeAdapter("keyvault")}
+
+	unsupported := unsupportedServices()
+	assert.Contains(t, unsupported, "appservice")
+	assert.Contains(t, unsupported, "securitycenter")
+	assert.NotContains(t, unsupported, "storage")
+	assert.NotContains(t, unsupported, "keyvault")
+	assert.Len(t, unsupported, 11)
+}
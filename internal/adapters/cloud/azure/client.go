@@ -0,0 +1,152 @@
+package azure
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/arm"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/cloud"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/runtime"
+	"github.com/Azure/azure-sdk-for-go/sdk/azidentity"
+
+	"github.com/aquasecurity/defsec/internal/adapters/cloud/throttle"
+)
+
+// StaticToken is a credential which always returns the same access token, e.g. from `az account get-access-token`
+type StaticToken string
+
+func (t StaticToken) GetToken(_ context.Context, _ policy.TokenRequestOptions) (azcore.AccessToken, error) {
+	// the expiry of the token is unknown, so it is assumed to outlive the scan
+	return azcore.AccessToken{Token: string(t), ExpiresOn: time.Now().Add(time.Hour)}, nil
+}
+
+// CredentialFromEnvironment returns AZURE_ACCESS_TOKEN if it is set, and otherwise the default Azure credential,
+// which authenticates with a service principal configured by AZURE_TENANT_ID, AZURE_CLIENT_ID and
+// AZURE_CLIENT_SECRET, a workload or managed identity, or the Azure CLI login, in that order.
+func CredentialFromEnvironment(endpoint string) (azcore.TokenCredential, error) {
+	if token := os.Getenv("AZURE_ACCESS_TOKEN"); token != "" {
+		return StaticToken(token), nil
+	}
+	return azidentity.NewDefaultAzureCredential(&azidentity.DefaultAzureCredentialOptions{
+		ClientOptions: azcore.ClientOptions{
+			Cloud: cloudConfiguration(endpoint),
+		},
+	})
+}
+
+// Client holds the credential and options used to create the Azure SDK client of each service
+type Client struct {
+	credential azcore.TokenCredential
+	options    arm.ClientOptions
+	transport  *throttle.Transport
+}
+
+// NewClient creates a client for the Azure Resource Manager API at the given endpoint, or the public cloud if the
+// endpoint is empty
+func NewClient(endpoint string, credential azcore.TokenCredential) *Client {
+	c := &Client{
+		credential: credential,
+		transport: &throttle.Transport{
+			Base:     http.DefaultTransport,
+			Limiters: throttle.NewLimiters(0, 0),
+			Service:  resourceProvider,
+		},
+	}
+	c.options.Cloud = cloudConfiguration(endpoint)
+	c.options.Transport = &http.Client{Transport: c.transport}
+	c.options.Retry.MaxRetries = throttle.DefaultMaxRetries
+	return c
+}
+
+// SetThrottling limits requests to each resource provider to rate per second, and retries throttled requests up to
+// maxRetries times. A rate of zero or less does not limit requests, and zero retries uses the default.
+func (c *Client) SetThrottling(rate float64, maxRetries int) {
+	// requests are limited by the transport, but retried by the SDK, which already honours Retry-After
+	c.transport.Limiters = throttle.NewLimiters(rate, 0)
+	if maxRetries <= 0 {
+		maxRetries = throttle.DefaultMaxRetries
+	}
+	c.options.Retry.MaxRetries = int32(maxRetries)
+}
+
+// SetTransport sends requests using the given transport, e.g. one which trusts a private certificate authority
+func (c *Client) SetTransport(transport http.RoundTripper) {
+	c.transport.Base = transport
+}
+
+// Credential returns the credential to create SDK clients with
+func (c *Client) Credential() azcore.TokenCredential {
+	return c.credential
+}
+
+// Options returns the options to create SDK clients with
+func (c *Client) Options() *arm.ClientOptions {
+	return c.options.Clone()
+}
+
+// cloudConfiguration returns the configuration of the Azure cloud whose Resource Manager API is at the given
+// endpoint. Endpoints which do not belong to a known cloud are assumed to be their own token audience.
+func cloudConfiguration(endpoint string) cloud.Configuration {
+	endpoint = strings.TrimSuffix(endpoint, "/")
+	if endpoint == "" {
+		return cloud.AzurePublic
+	}
+	for _, known := range []cloud.Configuration{cloud.AzurePublic, cloud.AzureGovernment, cloud.AzureChina} {
+		if strings.EqualFold(strings.TrimSuffix(known.Services[cloud.ResourceManager].Endpoint, "/"), endpoint) {
+			return known
+		}
+	}
+	return cloud.Configuration{
+		ActiveDirectoryAuthorityHost: cloud.AzurePublic.ActiveDirectoryAuthorityHost,
+		Services: map[cloud.ServiceName]cloud.ServiceConfiguration{
+			cloud.ResourceManager: {
+				Endpoint: endpoint,
+				Audience: endpoint,
+			},
+		},
+	}
+}
+
+// resourceProvider returns the namespace of the resource provider a request is sent to, e.g. Microsoft.Storage, as
+// Resource Manager throttles each provider separately
+func resourceProvider(req *http.Request) string {
+	parts := strings.Split(req.URL.Path, "/")
+	for i := 0; i < len(parts)-1; i++ {
+		if strings.EqualFold(parts[i], "providers") {
+			return strings.ToLower(parts[i+1])
+		}
+	}
+	return "resources"
+}
+
+// ListAll reads every page of a pager, returning the items of each page
+func ListAll[Page any, Item any](ctx context.Context, pager *runtime.Pager[Page], items func(Page) []*Item) ([]*Item, error) {
+	var all []*Item
+	for pager.More() {
+		page, err := pager.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, item := range items(page) {
+			if item != nil {
+				all = append(all, item)
+			}
+		}
+	}
+	return all, nil
+}
+
+// ParseResourceID splits a resource ID into the resource group and name of the resource, which the SDK clients
+// use to address the resources nested beneath it
+func ParseResourceID(id string) (resourceGroup string, name string, err error) {
+	resourceID, err := arm.ParseResourceID(id)
+	if err != nil {
+		return "", "", err
+	}
+	return resourceID.ResourceGroupName, resourceID.Name, nil
+}
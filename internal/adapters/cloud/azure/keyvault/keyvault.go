@@ -0,0 +1,147 @@
+package keyvault
+
+import (
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/keyvault/armkeyvault"
+
+	azure2 "github.com/aquasecurity/defsec/internal/adapters/cloud/azure"
+	"github.com/aquasecurity/defsec/pkg/concurrency"
+	"github.com/aquasecurity/defsec/pkg/providers/azure/keyvault"
+	"github.com/aquasecurity/defsec/pkg/state"
+	defsecTypes "github.com/aquasecurity/defsec/pkg/types"
+)
+
+type adapter struct {
+	*azure2.RootAdapter
+}
+
+func init() {
+	azure2.RegisterServiceAdapter(&adapter{})
+}
+
+func (a *adapter) Provider() string {
+	return "azure"
+}
+
+func (a *adapter) Name() string {
+	return "keyvault"
+}
+
+func (a *adapter) Adapt(root *azure2.RootAdapter, state *state.State) error {
+
+	a.RootAdapter = root
+	var err error
+
+	state.Azure.KeyVault.Vaults, err = a.getVaults()
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (a *adapter) getVaults() ([]keyvault.Vault, error) {
+
+	a.Tracker().SetServiceLabel("Discovering key vaults...")
+	client, err := armkeyvault.NewVaultsClient(a.SubscriptionID(), a.Client().Credential(), a.Client().Options())
+	if err != nil {
+		return nil, err
+	}
+	apiVaults, err := azure2.ListAll(a.Context(), client.NewListBySubscriptionPager(nil), func(page armkeyvault.VaultsClientListBySubscriptionResponse) []*armkeyvault.Vault {
+		return page.Value
+	})
+	if err != nil {
+		return nil, err
+	}
+	a.Tracker().SetTotalResources(len(apiVaults))
+
+	a.Tracker().SetServiceLabel("Adapting key vaults...")
+	return concurrency.Adapt(apiVaults, a.RootAdapter, a.adaptVault), nil
+}
+
+func (a *adapter) adaptVault(apiVault *armkeyvault.Vault) (*keyvault.Vault, error) {
+
+	metadata := a.CreateMetadata(*apiVault.ID)
+
+	vault := &keyvault.Vault{
+		Metadata:                metadata,
+		EnablePurgeProtection:   defsecTypes.BoolDefault(false, metadata),
+		SoftDeleteRetentionDays: defsecTypes.IntDefault(90, metadata),
+		NetworkACLs: keyvault.NetworkACLs{
+			Metadata:      metadata,
+			DefaultAction: defsecTypes.StringDefault("Allow", metadata),
+		},
+	}
+
+	if properties := apiVault.Properties; properties != nil {
+		if properties.EnablePurgeProtection != nil {
+			vault.EnablePurgeProtection = defsecTypes.Bool(*properties.EnablePurgeProtection, metadata)
+		}
+		if properties.SoftDeleteRetentionInDays != nil {
+			vault.SoftDeleteRetentionDays = defsecTypes.Int(int(*properties.SoftDeleteRetentionInDays), metadata)
+		}
+		if acls := properties.NetworkACLs; acls != nil && acls.DefaultAction != nil {
+			vault.NetworkACLs.DefaultAction = defsecTypes.String(string(*acls.DefaultAction), metadata)
+		}
+	}
+
+	// only the metadata of keys and secrets is available from the management plane, never their values
+	resourceGroup, name, err := azure2.ParseResourceID(*apiVault.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	keysClient, err := armkeyvault.NewKeysClient(a.SubscriptionID(), a.Client().Credential(), a.Client().Options())
+	if err != nil {
+		return nil, err
+	}
+	keys, err := azure2.ListAll(a.Context(), keysClient.NewListPager(resourceGroup, name, nil), func(page armkeyvault.KeysClientListResponse) []*armkeyvault.Key {
+		return page.Value
+	})
+	if err != nil {
+		return nil, err
+	}
+	for _, key := range keys {
+		keyMetadata := a.CreateMetadata(*key.ID)
+		expires := time.Time{}
+		if key.Properties != nil && key.Properties.Attributes != nil && key.Properties.Attributes.Expires != nil {
+			expires = time.Unix(*key.Properties.Attributes.Expires, 0)
+		}
+		vault.Keys = append(vault.Keys, keyvault.Key{
+			Metadata:   keyMetadata,
+			ExpiryDate: defsecTypes.Time(expires, keyMetadata),
+		})
+	}
+
+	secretsClient, err := armkeyvault.NewSecretsClient(a.SubscriptionID(), a.Client().Credential(), a.Client().Options())
+	if err != nil {
+		return nil, err
+	}
+	secrets, err := azure2.ListAll(a.Context(), secretsClient.NewListPager(resourceGroup, name, nil), func(page armkeyvault.SecretsClientListResponse) []*armkeyvault.Secret {
+		return page.Value
+	})
+	if err != nil {
+		return nil, err
+	}
+	for _, secret := range secrets {
+		secretMetadata := a.CreateMetadata(*secret.ID)
+		var contentType string
+		expires := time.Time{}
+		if properties := secret.Properties; properties != nil {
+			if properties.ContentType != nil {
+				contentType = *properties.ContentType
+			}
+			if properties.Attributes != nil && properties.Attributes.Expires != nil {
+				expires = *properties.Attributes.Expires
+			}
+		}
+		vault.Secrets = append(vault.Secrets, keyvault.Secret{
+			Metadata:    secretMetadata,
+			ContentType: defsecTypes.String(contentType, secretMetadata),
+			ExpiryDate:  defsecTypes.Time(expires, secretMetadata),
+		})
+	}
+
+	return vault, nil
+}
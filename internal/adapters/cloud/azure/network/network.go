@@ -0,0 +1,153 @@
+package network
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/network/armnetwork/v4"
+
+	azure2 "github.com/aquasecurity/defsec/internal/adapters/cloud/azure"
+	"github.com/aquasecurity/defsec/pkg/concurrency"
+	"github.com/aquasecurity/defsec/pkg/providers/azure/network"
+	"github.com/aquasecurity/defsec/pkg/state"
+	defsecTypes "github.com/aquasecurity/defsec/pkg/types"
+)
+
+type adapter struct {
+	*azure2.RootAdapter
+}
+
+func init() {
+	azure2.RegisterServiceAdapter(&adapter{})
+}
+
+func (a *adapter) Provider() string {
+	return "azure"
+}
+
+func (a *adapter) Name() string {
+	return "network"
+}
+
+func (a *adapter) Adapt(root *azure2.RootAdapter, state *state.State) error {
+
+	a.RootAdapter = root
+	var err error
+
+	state.Azure.Network.SecurityGroups, err = a.getSecurityGroups()
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (a *adapter) getSecurityGroups() ([]network.SecurityGroup, error) {
+
+	a.Tracker().SetServiceLabel("Discovering network security groups...")
+	client, err := armnetwork.NewSecurityGroupsClient(a.SubscriptionID(), a.Client().Credential(), a.Client().Options())
+	if err != nil {
+		return nil, err
+	}
+	apiGroups, err := azure2.ListAll(a.Context(), client.NewListAllPager(nil), func(page armnetwork.SecurityGroupsClientListAllResponse) []*armnetwork.SecurityGroup {
+		return page.Value
+	})
+	if err != nil {
+		return nil, err
+	}
+	a.Tracker().SetTotalResources(len(apiGroups))
+
+	a.Tracker().SetServiceLabel("Adapting network security groups...")
+	return concurrency.Adapt(apiGroups, a.RootAdapter, a.adaptSecurityGroup), nil
+}
+
+func (a *adapter) adaptSecurityGroup(apiGroup *armnetwork.SecurityGroup) (*network.SecurityGroup, error) {
+	group := &network.SecurityGroup{
+		Metadata: a.CreateMetadata(*apiGroup.ID),
+	}
+	if apiGroup.Properties != nil {
+		for _, rule := range apiGroup.Properties.SecurityRules {
+			if rule != nil && rule.ID != nil && rule.Properties != nil {
+				group.Rules = append(group.Rules, a.adaptSecurityRule(*rule.ID, rule.Properties))
+			}
+		}
+	}
+	return group, nil
+}
+
+func (a *adapter) adaptSecurityRule(id string, properties *armnetwork.SecurityRulePropertiesFormat) network.SecurityGroupRule {
+	metadata := a.CreateMetadata(id)
+
+	allow := defsecTypes.BoolDefault(false, metadata)
+	if properties.Access != nil && *properties.Access == armnetwork.SecurityRuleAccessAllow {
+		allow = defsecTypes.Bool(true, metadata)
+	}
+
+	outbound := defsecTypes.BoolDefault(false, metadata)
+	if properties.Direction != nil && *properties.Direction == armnetwork.SecurityRuleDirectionOutbound {
+		outbound = defsecTypes.Bool(true, metadata)
+	}
+
+	var protocol string
+	if properties.Protocol != nil {
+		protocol = string(*properties.Protocol)
+	}
+
+	return network.SecurityGroupRule{
+		Metadata:             metadata,
+		Outbound:             outbound,
+		Allow:                allow,
+		SourceAddresses:      adaptAddresses(properties.SourceAddressPrefix, properties.SourceAddressPrefixes, metadata),
+		SourcePorts:          adaptPortRanges(properties.SourcePortRange, properties.SourcePortRanges, metadata),
+		DestinationAddresses: adaptAddresses(properties.DestinationAddressPrefix, properties.DestinationAddressPrefixes, metadata),
+		DestinationPorts:     adaptPortRanges(properties.DestinationPortRange, properties.DestinationPortRanges, metadata),
+		Protocol:             defsecTypes.String(protocol, metadata),
+	}
+}
+
+func adaptAddresses(prefix *string, prefixes []*string, metadata defsecTypes.Metadata) []defsecTypes.StringValue {
+	var addresses []defsecTypes.StringValue
+	for _, address := range append(prefixes, prefix) {
+		if address != nil && *address != "" {
+			addresses = append(addresses, defsecTypes.String(*address, metadata))
+		}
+	}
+	return addresses
+}
+
+func adaptPortRanges(portRange *string, portRanges []*string, metadata defsecTypes.Metadata) []network.PortRange {
+	var ranges []network.PortRange
+	for _, r := range append(portRanges, portRange) {
+		if r != nil && *r != "" {
+			ranges = append(ranges, expandRange(*r, metadata))
+		}
+	}
+	return ranges
+}
+
+func expandRange(r string, m defsecTypes.Metadata) network.PortRange {
+	start := 0
+	end := 65535
+	switch {
+	case r == "*":
+	case strings.Contains(r, "-"):
+		if parts := strings.Split(r, "-"); len(parts) == 2 {
+			if p1, err := strconv.ParseInt(parts[0], 10, 32); err == nil {
+				start = int(p1)
+			}
+			if p2, err := strconv.ParseInt(parts[1], 10, 32); err == nil {
+				end = int(p2)
+			}
+		}
+	default:
+		if val, err := strconv.ParseInt(r, 10, 32); err == nil {
+			start = int(val)
+			end = int(val)
+		}
+	}
+	return network.PortRange{
+		Metadata: m,
+		Start:    start,
+		End:      end,
+	}
+}
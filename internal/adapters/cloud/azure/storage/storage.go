@@ -0,0 +1,139 @@
+package storage
+
+import (
+	"strings"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/storage/armstorage"
+
+	azure2 "github.com/aquasecurity/defsec/internal/adapters/cloud/azure"
+	"github.com/aquasecurity/defsec/pkg/concurrency"
+	"github.com/aquasecurity/defsec/pkg/providers/azure/storage"
+	"github.com/aquasecurity/defsec/pkg/state"
+	defsecTypes "github.com/aquasecurity/defsec/pkg/types"
+)
+
+type adapter struct {
+	*azure2.RootAdapter
+}
+
+func init() {
+	azure2.RegisterServiceAdapter(&adapter{})
+}
+
+func (a *adapter) Provider() string {
+	return "azure"
+}
+
+func (a *adapter) Name() string {
+	return "storage"
+}
+
+func (a *adapter) Adapt(root *azure2.RootAdapter, state *state.State) error {
+
+	a.RootAdapter = root
+	var err error
+
+	state.Azure.Storage.Accounts, err = a.getAccounts()
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (a *adapter) getAccounts() ([]storage.Account, error) {
+
+	a.Tracker().SetServiceLabel("Discovering storage accounts...")
+	client, err := armstorage.NewAccountsClient(a.SubscriptionID(), a.Client().Credential(), a.Client().Options())
+	if err != nil {
+		return nil, err
+	}
+	apiAccounts, err := azure2.ListAll(a.Context(), client.NewListPager(nil), func(page armstorage.AccountsClientListResponse) []*armstorage.Account {
+		return page.Value
+	})
+	if err != nil {
+		return nil, err
+	}
+	a.Tracker().SetTotalResources(len(apiAccounts))
+
+	a.Tracker().SetServiceLabel("Adapting storage accounts...")
+	return concurrency.Adapt(apiAccounts, a.RootAdapter, a.adaptAccount), nil
+}
+
+func (a *adapter) adaptAccount(apiAccount *armstorage.Account) (*storage.Account, error) {
+
+	metadata := a.CreateMetadata(*apiAccount.ID)
+
+	account := &storage.Account{
+		Metadata:          metadata,
+		EnforceHTTPS:      defsecTypes.BoolDefault(true, metadata),
+		MinimumTLSVersion: defsecTypes.StringDefault("TLS1_0", metadata),
+		QueueProperties: storage.QueueProperties{
+			Metadata: metadata,
+			// queue logging is configured through the data plane, which is not read
+			EnableLogging: defsecTypes.BoolUnresolvable(metadata),
+		},
+	}
+
+	if properties := apiAccount.Properties; properties != nil {
+		if properties.EnableHTTPSTrafficOnly != nil {
+			account.EnforceHTTPS = defsecTypes.Bool(*properties.EnableHTTPSTrafficOnly, metadata)
+		}
+		if properties.MinimumTLSVersion != nil {
+			account.MinimumTLSVersion = defsecTypes.String(string(*properties.MinimumTLSVersion), metadata)
+		}
+		if acls := properties.NetworkRuleSet; acls != nil {
+			rule := storage.NetworkRule{
+				Metadata:       metadata,
+				AllowByDefault: defsecTypes.Bool(acls.DefaultAction != nil && strings.EqualFold(string(*acls.DefaultAction), "Allow"), metadata),
+			}
+			if acls.Bypass != nil {
+				for _, bypass := range strings.Split(string(*acls.Bypass), ",") {
+					if bypass = strings.TrimSpace(bypass); bypass != "" && !strings.EqualFold(bypass, "None") {
+						rule.Bypass = append(rule.Bypass, defsecTypes.String(bypass, metadata))
+					}
+				}
+			}
+			account.NetworkRules = append(account.NetworkRules, rule)
+		}
+	}
+
+	resourceGroup, name, err := azure2.ParseResourceID(*apiAccount.ID)
+	if err != nil {
+		return nil, err
+	}
+	client, err := armstorage.NewBlobContainersClient(a.SubscriptionID(), a.Client().Credential(), a.Client().Options())
+	if err != nil {
+		return nil, err
+	}
+	containers, err := azure2.ListAll(a.Context(), client.NewListPager(resourceGroup, name, nil), func(page armstorage.BlobContainersClientListResponse) []*armstorage.ListContainerItem {
+		return page.Value
+	})
+	if err != nil {
+		return nil, err
+	}
+	for _, container := range containers {
+		containerMetadata := a.CreateMetadata(*container.ID)
+		var publicAccess string
+		if container.Properties != nil && container.Properties.PublicAccess != nil {
+			publicAccess = string(*container.Properties.PublicAccess)
+		}
+		account.Containers = append(account.Containers, storage.Container{
+			Metadata:     containerMetadata,
+			PublicAccess: defsecTypes.String(adaptPublicAccess(publicAccess), containerMetadata),
+		})
+	}
+
+	return account, nil
+}
+
+func adaptPublicAccess(publicAccess string) string {
+	switch strings.ToLower(publicAccess) {
+	case "blob":
+		return storage.PublicAccessBlob
+	case "container":
+		return storage.PublicAccessContainer
+	default:
+		return storage.PublicAccessOff
+	}
+}
@@ -0,0 +1,123 @@
+package storage
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	azure2 "github.com/aquasecurity/defsec/internal/adapters/cloud/azure"
+	"github.com/aquasecurity/defsec/pkg/progress"
+	"github.com/aquasecurity/defsec/pkg/providers/azure/storage"
+	"github.com/aquasecurity/defsec/pkg/state"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	subscription = "00000000-0000-0000-0000-000000000000"
+	secureID     = "/subscriptions/" + subscription + "/resourceGroups/rg/providers/Microsoft.Storage/storageAccounts/secure"
+	insecureID   = "/subscriptions/" + subscription + "/resourceGroups/rg/providers/Microsoft.Storage/storageAccounts/insecure"
+)
+
+func Test_StorageAccounts(t *testing.T) {
+
+	var server *httptest.Server
+	// the SDK only sends credentials over TLS
+	server = httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer token", r.Header.Get("Authorization"))
+		assert.NotEmpty(t, r.URL.Query().Get("api-version"))
+
+		var body interface{}
+		switch r.URL.Path {
+		case "/subscriptions/" + subscription + "/providers/Microsoft.Storage/storageAccounts":
+			if r.URL.Query().Get("page") == "" {
+				body = map[string]interface{}{
+					"value": []interface{}{
+						map[string]interface{}{
+							"id": secureID,
+							"properties": map[string]interface{}{
+								"supportsHttpsTrafficOnly": true,
+								"minimumTlsVersion":        "TLS1_2",
+								"networkAcls": map[string]interface{}{
+									"bypass":        "AzureServices, Logging",
+									"defaultAction": "Deny",
+								},
+							},
+						},
+					},
+					"nextLink": server.URL + r.URL.Path + "?" + r.URL.RawQuery + "&page=2",
+				}
+			} else {
+				body = map[string]interface{}{
+					"value": []interface{}{
+						map[string]interface{}{
+							"id": insecureID,
+							"properties": map[string]interface{}{
+								"supportsHttpsTrafficOnly": false,
+							},
+						},
+					},
+				}
+			}
+		case secureID + "/blobServices/default/containers":
+			body = map[string]interface{}{
+				"value": []interface{}{
+					map[string]interface{}{
+						"id":         secureID + "/blobServices/default/containers/private",
+						"properties": map[string]interface{}{"publicAccess": "None"},
+					},
+				},
+			}
+		case insecureID + "/blobServices/default/containers":
+			body = map[string]interface{}{
+				"value": []interface{}{
+					map[string]interface{}{
+						"id":         insecureID + "/blobServices/default/containers/public",
+						"properties": map[string]interface{}{"publicAccess": "Container"},
+					},
+				},
+			}
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			body = map[string]interface{}{
+				"error": map[string]interface{}{"code": "NotFound", "message": r.URL.Path},
+			}
+		}
+		require.NoError(t, json.NewEncoder(w).Encode(body))
+	}))
+	defer server.Close()
+
+	client := azure2.NewClient(server.URL, azure2.StaticToken("token"))
+	client.SetTransport(server.Client().Transport)
+	root := azure2.NewRootAdapter(context.TODO(), client, subscription, progress.NoProgress)
+
+	testState := &state.State{}
+	require.NoError(t, (&adapter{}).Adapt(root, testState))
+
+	accounts := testState.Azure.Storage.Accounts
+	require.Len(t, accounts, 2)
+
+	byID := make(map[string]storage.Account)
+	for _, account := range accounts {
+		byID[account.Metadata.Range().GetLocalFilename()] = account
+	}
+
+	secure := byID[secureID]
+	assert.True(t, secure.EnforceHTTPS.IsTrue())
+	assert.Equal(t, "TLS1_2", secure.MinimumTLSVersion.Value())
+	require.Len(t, secure.NetworkRules, 1)
+	assert.False(t, secure.NetworkRules[0].AllowByDefault.IsTrue())
+	require.Len(t, secure.NetworkRules[0].Bypass, 2)
+	assert.Equal(t, "Logging", secure.NetworkRules[0].Bypass[1].Value())
+	require.Len(t, secure.Containers, 1)
+	assert.Equal(t, storage.PublicAccessOff, secure.Containers[0].PublicAccess.Value())
+
+	insecure := byID[insecureID]
+	assert.True(t, insecure.EnforceHTTPS.IsFalse())
+	assert.Equal(t, "TLS1_0", insecure.MinimumTLSVersion.Value())
+	assert.Empty(t, insecure.NetworkRules)
+	require.Len(t, insecure.Containers, 1)
+	assert.Equal(t, storage.PublicAccessContainer, insecure.Containers[0].PublicAccess.Value())
+}
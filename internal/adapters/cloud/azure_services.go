@@ -0,0 +1,7 @@
+package cloud
+
+import (
+	_ "github.com/aquasecurity/defsec/internal/adapters/cloud/azure/keyvault"
+	_ "github.com/aquasecurity/defsec/internal/adapters/cloud/azure/network"
+	_ "github.com/aquasecurity/defsec/internal/adapters/cloud/azure/storage"
+)
@@ -0,0 +1,237 @@
+package google
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/aquasecurity/defsec/internal/adapters/cloud/options"
+	"github.com/aquasecurity/defsec/pkg/concurrency"
+	"github.com/aquasecurity/defsec/pkg/debug"
+	"github.com/aquasecurity/defsec/pkg/errs"
+	"github.com/aquasecurity/defsec/pkg/progress"
+	"github.com/aquasecurity/defsec/pkg/scanners/cloud/cache"
+	"github.com/aquasecurity/defsec/pkg/scanners/cloud/coverage"
+	"github.com/aquasecurity/defsec/pkg/state"
+	"github.com/aquasecurity/defsec/pkg/types"
+)
+
+var registeredAdapters []ServiceAdapter
+
+// expensiveServices make a request for every resource they find in every project, e.g. storage reads the IAM policy
+// of every bucket, and are not adapted when expensive services are skipped
+var expensiveServices = map[string]bool{
+	"storage": true,
+}
+
+func RegisterServiceAdapter(adapter ServiceAdapter) {
+	for _, existing := range registeredAdapters {
+		if existing.Name() == adapter.Name() {
+			panic(fmt.Sprintf("duplicate service adapter: %s", adapter.Name()))
+		}
+	}
+	registeredAdapters = append(registeredAdapters, adapter)
+}
+
+type ServiceAdapter interface {
+	Name() string
+	Provider() string
+	Adapt(root *RootAdapter, state *state.State) error
+}
+
+type RootAdapter struct {
+	ctx                 context.Context
+	client              *Client
+	tracker             progress.ServiceTracker
+	scope               Scope
+	hierarchy           *Node
+	projects            []Project
+	debugWriter         debug.Logger
+	concurrencyStrategy concurrency.Strategy
+}
+
+// NewRootAdapter creates an adapter for the projects in the given scope, discovering them if the scope is a folder or
+// organization
+func NewRootAdapter(ctx context.Context, client *Client, scope Scope, tracker progress.ServiceTracker) (*RootAdapter, error) {
+	root := &RootAdapter{
+		ctx:     ctx,
+		client:  client,
+		tracker: tracker,
+		scope:   scope,
+	}
+	if err := root.resolveScope(); err != nil {
+		return nil, err
+	}
+	return root, nil
+}
+
+// Scope is the part of the resource hierarchy being scanned. The most specific of the fields set is used.
+type Scope struct {
+	Project      string
+	Folder       string
+	Organization string
+}
+
+func (s Scope) String() string {
+	switch {
+	case s.Project != "":
+		return "project " + s.Project
+	case s.Folder != "":
+		return "folder " + s.Folder
+	default:
+		return "organization " + s.Organization
+	}
+}
+
+func (a *RootAdapter) Scope() Scope {
+	return a.scope
+}
+
+// Projects returns every active project in the scope
+func (a *RootAdapter) Projects() []Project {
+	return a.projects
+}
+
+// Hierarchy returns the organization or folder being scanned, or nil if the scope is a single project
+func (a *RootAdapter) Hierarchy() *Node {
+	return a.hierarchy
+}
+
+// ForEachProject calls fn for each project in the scope. A failure in one project, e.g. because an API is not
+// enabled there, does not prevent the others from being read.
+func (a *RootAdapter) ForEachProject(fn func(project Project) error) error {
+	var projectErrors []error
+	for _, project := range a.projects {
+		if err := fn(project); err != nil {
+			a.Debug("Failed to read project %s: %s", project.ID, err)
+			projectErrors = append(projectErrors, fmt.Errorf("project %s: %w", project.ID, err))
+		}
+	}
+	if len(projectErrors) > 0 {
+		return errs.NewAdapterError(projectErrors)
+	}
+	return nil
+}
+
+func (a *RootAdapter) Debug(format string, args ...interface{}) {
+	a.debugWriter.Log(format, args...)
+}
+
+func (a *RootAdapter) ConcurrencyStrategy() concurrency.Strategy {
+	return a.concurrencyStrategy
+}
+
+func (a *RootAdapter) Client() *Client {
+	return a.client
+}
+
+func (a *RootAdapter) Context() context.Context {
+	return a.ctx
+}
+
+func (a *RootAdapter) Tracker() progress.ServiceTracker {
+	return a.tracker
+}
+
+// CreateMetadata creates metadata for a resource from its self link or full resource name
+func (a *RootAdapter) CreateMetadata(resource string) types.Metadata {
+	return types.NewRemoteMetadata(resource)
+}
+
+func AllServices() []string {
+	var services []string
+	for _, reg := range registeredAdapters {
+		services = append(services, reg.Name())
+	}
+	return services
+}
+
+// Adapt reads the resources in a GCP project, folder or organization into the state. When no scope is given in the
+// options, the project is taken from GOOGLE_CLOUD_PROJECT or CLOUDSDK_CORE_PROJECT. Credentials are read from the
+// environment, see CredentialFromEnvironment.
+func Adapt(ctx context.Context, cloudState *state.State, opt options.Options) error {
+	c := &RootAdapter{
+		ctx:                 ctx,
+		tracker:             opt.ProgressTracker,
+		debugWriter:         opt.DebugWriter.Extend("adapt", "google"),
+		concurrencyStrategy: opt.ConcurrencyStrategy,
+		scope: Scope{
+			Project:      opt.Project,
+			Folder:       opt.Folder,
+			Organization: opt.Organization,
+		},
+	}
+
+	if c.scope == (Scope{}) {
+		c.scope.Project = os.Getenv("GOOGLE_CLOUD_PROJECT")
+		if c.scope.Project == "" {
+			c.scope.Project = os.Getenv("CLOUDSDK_CORE_PROJECT")
+		}
+	}
+	if c.scope == (Scope{}) {
+		return fmt.Errorf("no google project, folder or organization specified: set GOOGLE_CLOUD_PROJECT or use the scope options")
+	}
+
+	credential, err := CredentialFromEnvironment(ctx)
+	if err != nil {
+		return err
+	}
+	if opt.Endpoint != "" {
+		c.Debug("Using endpoint '%s'", opt.Endpoint)
+	}
+	c.client = NewClient(opt.Endpoint, credential)
+	c.client.SetThrottling(opt.RateLimit, opt.MaxRetries)
+
+	c.Debug("Discovering projects in %s...", c.scope)
+	if err := c.resolveScope(); err != nil {
+		return err
+	}
+	c.Debug("Found %d projects to scan", len(c.projects))
+
+	var selected []ServiceAdapter
+	for _, registered := range registeredAdapters {
+		if reason := opt.SkipReason(registered.Name(), expensiveServices[registered.Name()]); reason != "" {
+			opt.Coverage.Add(coverage.Service{
+				Provider: "google",
+				Name:     registered.Name(),
+				Account:  c.scope.String(),
+				Status:   coverage.Skipped,
+				Reason:   reason,
+			})
+			continue
+		}
+		selected = append(selected, registered)
+	}
+	c.Debug("Preparing to run for %d services...", len(selected))
+	opt.ProgressTracker.SetTotalServices(len(selected))
+
+	var adapterErrors []error
+
+	for _, adapter := range selected {
+		c.Debug("Running adapter for %s...", adapter.Name())
+		opt.ProgressTracker.StartService(adapter.Name())
+
+		covered := coverage.Service{
+			Provider: "google",
+			Name:     adapter.Name(),
+			Account:  c.scope.String(),
+			Status:   coverage.Covered,
+		}
+		key := cache.Key{Provider: "google", Account: c.scope.String(), Service: adapter.Name()}
+		if err := opt.StateCache.Adapt(key, cloudState, func(s *state.State) error {
+			return adapter.Adapt(c, s)
+		}); err != nil {
+			c.Debug("Error occurred while running adapter for %s: %s", adapter.Name(), err)
+			adapterErrors = append(adapterErrors, fmt.Errorf("failed to run adapter for %s: %w", adapter.Name(), err))
+			covered.Status = coverage.Failed
+		}
+		opt.Coverage.Add(covered)
+		opt.ProgressTracker.FinishService()
+	}
+
+	if len(adapterErrors) > 0 {
+		return errs.NewAdapterError(adapterErrors)
+	}
+
+	return nil
+}
@@ -0,0 +1,243 @@
+package google
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+
+	"golang.org/x/oauth2"
+	googleauth "golang.org/x/oauth2/google"
+
+	"github.com/aquasecurity/defsec/internal/adapters/cloud/throttle"
+)
+
+// CloudPlatformScope grants read access to every API used by the adapters, subject to the IAM roles of the caller
+const CloudPlatformScope = "https://www.googleapis.com/auth/cloud-platform.read-only"
+
+// StaticToken returns a credential which always provides the same access token, e.g. from
+// `gcloud auth print-access-token`
+func StaticToken(token string) oauth2.TokenSource {
+	return oauth2.StaticTokenSource(&oauth2.Token{AccessToken: token})
+}
+
+// CredentialFromEnvironment returns GOOGLE_OAUTH_ACCESS_TOKEN if it is set, and otherwise the application default
+// credentials: the key file named by GOOGLE_APPLICATION_CREDENTIALS, the credentials written by
+// `gcloud auth application-default login`, or the service account of the environment, e.g. on GCE or GKE.
+func CredentialFromEnvironment(ctx context.Context) (oauth2.TokenSource, error) {
+	if token := os.Getenv("GOOGLE_OAUTH_ACCESS_TOKEN"); token != "" {
+		return StaticToken(token), nil
+	}
+	credentials, err := googleauth.FindDefaultCredentials(ctx, CloudPlatformScope)
+	if err != nil {
+		return nil, fmt.Errorf("no google credentials found: set GOOGLE_OAUTH_ACCESS_TOKEN or GOOGLE_APPLICATION_CREDENTIALS, or run 'gcloud auth application-default login': %w", err)
+	}
+	return credentials.TokenSource, nil
+}
+
+// Client makes requests to the Google Cloud REST APIs, authenticated with an OAuth 2.0 token source. Responses are
+// decoded into the few fields each adapter reads, rather than using the generated google.golang.org/api package of
+// each API, as those packages are very large (the compute package alone is several megabytes of source) and would
+// add far more to the binary than the adapters use.
+type Client struct {
+	endpoint   string
+	credential oauth2.TokenSource
+	httpClient *http.Client
+}
+
+// NewClient creates a client which sends requests for each API to https://<api>.googleapis.com, or, when an endpoint
+// is given, sends requests for every API to that endpoint instead.
+func NewClient(endpoint string, credential oauth2.TokenSource) *Client {
+	c := &Client{
+		endpoint: strings.TrimSuffix(endpoint, "/"),
+		// tokens are reused until shortly before they expire
+		credential: oauth2.ReuseTokenSource(nil, credential),
+	}
+	c.SetThrottling(0, 0)
+	return c
+}
+
+// SetThrottling limits requests to each API to rate per second, and retries throttled requests up to maxRetries
+// times. A rate of zero or less does not limit requests.
+func (c *Client) SetThrottling(rate float64, maxRetries int) {
+	c.httpClient = &http.Client{
+		Transport: &oauth2.Transport{
+			Source: c.credential,
+			Base:   throttle.NewTransport(rate, maxRetries, apiName),
+		},
+	}
+}
+
+// apiName returns the API a request is sent to, e.g. compute, as each API has its own quota. Requests sent to an
+// overridden endpoint are named by the first element of their path instead.
+func apiName(req *http.Request) string {
+	if host := req.URL.Hostname(); strings.HasSuffix(host, ".googleapis.com") {
+		return strings.TrimSuffix(host, ".googleapis.com")
+	}
+	api, _, _ := strings.Cut(strings.TrimPrefix(req.URL.Path, "/"), "/")
+	return api
+}
+
+// Get reads the resource at the given path of an API, e.g. ("storage", "storage/v1/b/my-bucket"), into target
+func (c *Client) Get(ctx context.Context, api string, path string, target interface{}) error {
+	return c.do(ctx, http.MethodGet, c.resourceURL(api, path, nil), nil, target)
+}
+
+// Post sends body to the given path of an API and reads the response into target. Some read-only methods, such as
+// getIamPolicy, are POST requests.
+func (c *Client) Post(ctx context.Context, api string, path string, body interface{}, target interface{}) error {
+	return c.do(ctx, http.MethodPost, c.resourceURL(api, path, nil), body, target)
+}
+
+// Pages calls fn with each page of the collection at the given path of an API, following the page tokens
+func (c *Client) Pages(ctx context.Context, api string, path string, fn func(json.RawMessage) error) error {
+	var pageToken string
+	for {
+		query := url.Values{}
+		if pageToken != "" {
+			query.Set("pageToken", pageToken)
+		}
+		var page json.RawMessage
+		if err := c.do(ctx, http.MethodGet, c.resourceURL(api, path, query), nil, &page); err != nil {
+			return err
+		}
+		if err := fn(page); err != nil {
+			return err
+		}
+		var next struct {
+			NextPageToken string `json:"nextPageToken"`
+		}
+		if err := json.Unmarshal(page, &next); err != nil {
+			return err
+		}
+		if next.NextPageToken == "" {
+			return nil
+		}
+		pageToken = next.NextPageToken
+	}
+}
+
+// ListAll reads every resource in the collection at the given path of an API. Each page lists its resources in the
+// named field, e.g. "items" or "clusters".
+func ListAll[T any](ctx context.Context, client *Client, api string, path string, field string) ([]T, error) {
+	var items []T
+	err := client.Pages(ctx, api, path, func(raw json.RawMessage) error {
+		var page map[string]json.RawMessage
+		if err := json.Unmarshal(raw, &page); err != nil {
+			return err
+		}
+		if page[field] == nil {
+			return nil
+		}
+		var pageItems []T
+		if err := json.Unmarshal(page[field], &pageItems); err != nil {
+			return err
+		}
+		items = append(items, pageItems...)
+		return nil
+	})
+	return items, err
+}
+
+func (c *Client) resourceURL(api string, path string, query url.Values) string {
+	base := c.endpoint
+	if base == "" {
+		base = fmt.Sprintf("https://%s.googleapis.com", api)
+	}
+	// paths may already carry a query, e.g. a parent filter
+	requestURL := base + "/" + strings.TrimPrefix(path, "/")
+	if len(query) > 0 {
+		separator := "?"
+		if strings.Contains(requestURL, "?") {
+			separator = "&"
+		}
+		requestURL += separator + query.Encode()
+	}
+	return requestURL
+}
+
+func (c *Client) do(ctx context.Context, method string, requestURL string, body interface{}, target interface{}) error {
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reader = bytes.NewReader(data)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, requestURL, reader)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		return newRequestError(requestURL, resp)
+	}
+	return json.NewDecoder(resp.Body).Decode(target)
+}
+
+// RequestError is returned when an API responds with an error status
+type RequestError struct {
+	URL        string
+	StatusCode int
+	Status     string
+	Message    string
+}
+
+func (e *RequestError) Error() string {
+	if e.Message == "" {
+		return fmt.Sprintf("google request to %s failed: %s", e.URL, e.Status)
+	}
+	return fmt.Sprintf("google request to %s failed: %s: %s", e.URL, e.Status, e.Message)
+}
+
+// IsNotFound reports whether err is a response from an API saying the requested resource does not exist
+func IsNotFound(err error) bool {
+	var requestError *RequestError
+	return errors.As(err, &requestError) && requestError.StatusCode == http.StatusNotFound
+}
+
+func newRequestError(requestURL string, resp *http.Response) error {
+	requestError := &RequestError{
+		URL:        redactQuery(requestURL),
+		StatusCode: resp.StatusCode,
+		Status:     resp.Status,
+	}
+	var body struct {
+		Error struct {
+			Status  string `json:"status"`
+			Message string `json:"message"`
+		} `json:"error"`
+	}
+	data, _ := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err := json.Unmarshal(data, &body); err == nil && body.Error.Message != "" {
+		requestError.Message = body.Error.Message
+		if body.Error.Status != "" {
+			requestError.Status = body.Error.Status
+		}
+	}
+	return requestError
+}
+
+func redactQuery(requestURL string) string {
+	if i := strings.IndexByte(requestURL, '?'); i >= 0 {
+		return requestURL[:i]
+	}
+	return requestURL
+}
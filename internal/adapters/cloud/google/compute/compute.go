@@ -0,0 +1,139 @@
+package compute
+
+import (
+	"encoding/json"
+	"strconv"
+	"strings"
+
+	google2 "github.com/aquasecurity/defsec/internal/adapters/cloud/google"
+	"github.com/aquasecurity/defsec/pkg/state"
+	defsecTypes "github.com/aquasecurity/defsec/pkg/types"
+)
+
+type adapter struct {
+	*google2.RootAdapter
+}
+
+func init() {
+	google2.RegisterServiceAdapter(&adapter{})
+}
+
+func (a *adapter) Provider() string {
+	return "google"
+}
+
+func (a *adapter) Name() string {
+	return "compute"
+}
+
+func (a *adapter) Adapt(root *google2.RootAdapter, state *state.State) error {
+
+	a.RootAdapter = root
+	var err error
+
+	state.Google.Compute.Disks, err = a.getDisks()
+	if err != nil {
+		return err
+	}
+
+	state.Google.Compute.Networks, err = a.getNetworks()
+	if err != nil {
+		return err
+	}
+
+	state.Google.Compute.SSLPolicies, err = a.getSSLPolicies()
+	if err != nil {
+		return err
+	}
+
+	state.Google.Compute.ProjectMetadata, err = a.getProjectMetadata()
+	if err != nil {
+		return err
+	}
+
+	state.Google.Compute.Instances, err = a.getInstances()
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func projectPath(project google2.Project, collection string) string {
+	return "compute/v1/projects/" + project.ID + "/" + collection
+}
+
+// listAggregated reads a zonal or regional collection across every zone or region of a project. Aggregated lists
+// group their resources by scope, each under the named field.
+func listAggregated[T any](a *adapter, project google2.Project, collection string, field string) ([]T, error) {
+	var items []T
+	err := a.Client().Pages(a.Context(), "compute", projectPath(project, "aggregated/"+collection), func(raw json.RawMessage) error {
+		var page struct {
+			Items map[string]map[string]json.RawMessage `json:"items"`
+		}
+		if err := json.Unmarshal(raw, &page); err != nil {
+			return err
+		}
+		for _, scoped := range page.Items {
+			if scoped[field] == nil {
+				continue
+			}
+			var scopeItems []T
+			if err := json.Unmarshal(scoped[field], &scopeItems); err != nil {
+				return err
+			}
+			items = append(items, scopeItems...)
+		}
+		return nil
+	})
+	return items, err
+}
+
+type apiMetadata struct {
+	Items []struct {
+		Key   string `json:"key"`
+		Value string `json:"value"`
+	} `json:"items"`
+}
+
+// lookup returns the value of a metadata key, and whether it is set
+func (m apiMetadata) lookup(key string) (string, bool) {
+	for _, item := range m.Items {
+		if item.Key == key {
+			return item.Value, true
+		}
+	}
+	return "", false
+}
+
+// isTrue reports whether a metadata key is set to one of the values compute engine treats as true
+func (m apiMetadata) isTrue(key string) bool {
+	value, _ := m.lookup(key)
+	switch strings.ToLower(value) {
+	case "true", "1", "y", "yes":
+		return true
+	}
+	return false
+}
+
+func expandPorts(ports []string, metadata defsecTypes.Metadata) []defsecTypes.IntValue {
+	var output []defsecTypes.IntValue
+	for _, port := range ports {
+		start, end := port, port
+		if parts := strings.Split(port, "-"); len(parts) == 2 {
+			start, end = parts[0], parts[1]
+		}
+		from, err := strconv.Atoi(start)
+		if err != nil {
+			continue
+		}
+		to, err := strconv.Atoi(end)
+		if err != nil {
+			continue
+		}
+		for i := from; i <= to; i++ {
+			output = append(output, defsecTypes.Int(i, metadata))
+		}
+	}
+	return output
+}
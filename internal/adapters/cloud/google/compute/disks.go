@@ -0,0 +1,55 @@
+package compute
+
+import (
+	google2 "github.com/aquasecurity/defsec/internal/adapters/cloud/google"
+	"github.com/aquasecurity/defsec/pkg/concurrency"
+	"github.com/aquasecurity/defsec/pkg/providers/google/compute"
+	defsecTypes "github.com/aquasecurity/defsec/pkg/types"
+)
+
+type apiDisk struct {
+	Name              string            `json:"name"`
+	SelfLink          string            `json:"selfLink"`
+	DiskEncryptionKey *apiEncryptionKey `json:"diskEncryptionKey"`
+}
+
+type apiEncryptionKey struct {
+	KMSKeyName string `json:"kmsKeyName"`
+}
+
+func (a *adapter) getDisks() ([]compute.Disk, error) {
+
+	a.Tracker().SetServiceLabel("Discovering disks...")
+	var apiDisks []apiDisk
+	err := a.ForEachProject(func(project google2.Project) error {
+		disks, err := listAggregated[apiDisk](a, project, "disks", "disks")
+		apiDisks = append(apiDisks, disks...)
+		return err
+	})
+	a.Tracker().SetTotalResources(len(apiDisks))
+
+	a.Tracker().SetServiceLabel("Adapting disks...")
+	return concurrency.Adapt(apiDisks, a.RootAdapter, a.adaptDisk), err
+}
+
+func (a *adapter) adaptDisk(apiDisk apiDisk) (*compute.Disk, error) {
+	disk := adaptDisk(apiDisk.Name, apiDisk.DiskEncryptionKey, a.CreateMetadata(apiDisk.SelfLink))
+	return &disk, nil
+}
+
+func adaptDisk(name string, key *apiEncryptionKey, metadata defsecTypes.Metadata) compute.Disk {
+	disk := compute.Disk{
+		Metadata: metadata,
+		Name:     defsecTypes.String(name, metadata),
+		Encryption: compute.DiskEncryption{
+			Metadata: metadata,
+			// customer-supplied keys are never returned by the API, so there is no plaintext key to find
+			RawKey:     defsecTypes.BytesDefault(nil, metadata),
+			KMSKeyLink: defsecTypes.StringDefault("", metadata),
+		},
+	}
+	if key != nil && key.KMSKeyName != "" {
+		disk.Encryption.KMSKeyLink = defsecTypes.String(key.KMSKeyName, metadata)
+	}
+	return disk
+}
@@ -0,0 +1,130 @@
+package compute
+
+import (
+	"path"
+
+	google2 "github.com/aquasecurity/defsec/internal/adapters/cloud/google"
+	"github.com/aquasecurity/defsec/pkg/concurrency"
+	"github.com/aquasecurity/defsec/pkg/providers/google/compute"
+	defsecTypes "github.com/aquasecurity/defsec/pkg/types"
+)
+
+type apiInstance struct {
+	Name              string `json:"name"`
+	SelfLink          string `json:"selfLink"`
+	CanIPForward      bool   `json:"canIpForward"`
+	NetworkInterfaces []struct {
+		Network       string `json:"network"`
+		Subnetwork    string `json:"subnetwork"`
+		AccessConfigs []struct {
+			NatIP string `json:"natIP"`
+		} `json:"accessConfigs"`
+	} `json:"networkInterfaces"`
+	ShieldedInstanceConfig *struct {
+		EnableSecureBoot          bool `json:"enableSecureBoot"`
+		EnableVtpm                bool `json:"enableVtpm"`
+		EnableIntegrityMonitoring bool `json:"enableIntegrityMonitoring"`
+	} `json:"shieldedInstanceConfig"`
+	ServiceAccounts []struct {
+		Email  string   `json:"email"`
+		Scopes []string `json:"scopes"`
+	} `json:"serviceAccounts"`
+	Metadata apiMetadata `json:"metadata"`
+	Disks    []struct {
+		Boot              bool              `json:"boot"`
+		Source            string            `json:"source"`
+		DiskEncryptionKey *apiEncryptionKey `json:"diskEncryptionKey"`
+	} `json:"disks"`
+}
+
+func (a *adapter) getInstances() ([]compute.Instance, error) {
+
+	a.Tracker().SetServiceLabel("Discovering instances...")
+	var apiInstances []apiInstance
+	err := a.ForEachProject(func(project google2.Project) error {
+		instances, err := listAggregated[apiInstance](a, project, "instances", "instances")
+		apiInstances = append(apiInstances, instances...)
+		return err
+	})
+	a.Tracker().SetTotalResources(len(apiInstances))
+
+	a.Tracker().SetServiceLabel("Adapting instances...")
+	return concurrency.Adapt(apiInstances, a.RootAdapter, a.adaptInstance), err
+}
+
+func (a *adapter) adaptInstance(apiInstance apiInstance) (*compute.Instance, error) {
+
+	metadata := a.CreateMetadata(apiInstance.SelfLink)
+
+	instance := &compute.Instance{
+		Metadata:     metadata,
+		Name:         defsecTypes.String(apiInstance.Name, metadata),
+		CanIPForward: defsecTypes.Bool(apiInstance.CanIPForward, metadata),
+		ShieldedVM: compute.ShieldedVMConfig{
+			Metadata:                   metadata,
+			SecureBootEnabled:          defsecTypes.BoolDefault(false, metadata),
+			IntegrityMonitoringEnabled: defsecTypes.BoolDefault(false, metadata),
+			VTPMEnabled:                defsecTypes.BoolDefault(false, metadata),
+		},
+		ServiceAccount: compute.ServiceAccount{
+			Metadata:  metadata,
+			Email:     defsecTypes.StringDefault("", metadata),
+			IsDefault: defsecTypes.BoolDefault(false, metadata),
+		},
+		EnableProjectSSHKeyBlocking: defsecTypes.Bool(apiInstance.Metadata.isTrue("block-project-ssh-keys"), metadata),
+		EnableSerialPort:            defsecTypes.Bool(apiInstance.Metadata.isTrue("serial-port-enable"), metadata),
+		OSLoginEnabled:              defsecTypes.BoolDefault(true, metadata),
+	}
+
+	// OS Login is inherited from the project unless the instance metadata overrides it
+	if _, ok := apiInstance.Metadata.lookup("enable-oslogin"); ok {
+		instance.OSLoginEnabled = defsecTypes.Bool(apiInstance.Metadata.isTrue("enable-oslogin"), metadata)
+	}
+
+	if config := apiInstance.ShieldedInstanceConfig; config != nil {
+		instance.ShieldedVM.SecureBootEnabled = defsecTypes.Bool(config.EnableSecureBoot, metadata)
+		instance.ShieldedVM.IntegrityMonitoringEnabled = defsecTypes.Bool(config.EnableIntegrityMonitoring, metadata)
+		instance.ShieldedVM.VTPMEnabled = defsecTypes.Bool(config.EnableVtpm, metadata)
+	}
+
+	if len(apiInstance.ServiceAccounts) > 0 {
+		account := apiInstance.ServiceAccounts[0]
+		instance.ServiceAccount.Email = defsecTypes.String(account.Email, metadata)
+		instance.ServiceAccount.IsDefault = defsecTypes.Bool(google2.IsDefaultServiceAccount(account.Email), metadata)
+		for _, scope := range account.Scopes {
+			instance.ServiceAccount.Scopes = append(instance.ServiceAccount.Scopes, defsecTypes.String(scope, metadata))
+		}
+	}
+
+	for _, apiInterface := range apiInstance.NetworkInterfaces {
+		networkInterface := compute.NetworkInterface{
+			Metadata:    metadata,
+			Network:     &compute.Network{Metadata: a.CreateMetadata(apiInterface.Network)},
+			HasPublicIP: defsecTypes.Bool(len(apiInterface.AccessConfigs) > 0, metadata),
+			NATIP:       defsecTypes.StringDefault("", metadata),
+		}
+		if apiInterface.Subnetwork != "" {
+			subnetMetadata := a.CreateMetadata(apiInterface.Subnetwork)
+			networkInterface.SubNetwork = &compute.SubNetwork{
+				Metadata:       subnetMetadata,
+				Name:           defsecTypes.String(path.Base(apiInterface.Subnetwork), subnetMetadata),
+				EnableFlowLogs: defsecTypes.BoolUnresolvable(subnetMetadata),
+			}
+		}
+		if len(apiInterface.AccessConfigs) > 0 && apiInterface.AccessConfigs[0].NatIP != "" {
+			networkInterface.NATIP = defsecTypes.String(apiInterface.AccessConfigs[0].NatIP, metadata)
+		}
+		instance.NetworkInterfaces = append(instance.NetworkInterfaces, networkInterface)
+	}
+
+	for _, apiDisk := range apiInstance.Disks {
+		disk := adaptDisk(path.Base(apiDisk.Source), apiDisk.DiskEncryptionKey, a.CreateMetadata(apiDisk.Source))
+		if apiDisk.Boot {
+			instance.BootDisks = append(instance.BootDisks, disk)
+		} else {
+			instance.AttachedDisks = append(instance.AttachedDisks, disk)
+		}
+	}
+
+	return instance, nil
+}
@@ -0,0 +1,47 @@
+package compute
+
+import (
+	google2 "github.com/aquasecurity/defsec/internal/adapters/cloud/google"
+	"github.com/aquasecurity/defsec/pkg/providers/google/compute"
+	defsecTypes "github.com/aquasecurity/defsec/pkg/types"
+)
+
+type apiProject struct {
+	SelfLink               string      `json:"selfLink"`
+	CommonInstanceMetadata apiMetadata `json:"commonInstanceMetadata"`
+}
+
+// getProjectMetadata reads the common instance metadata of each project. The state holds a single project's metadata,
+// so when several projects are scanned, the first one without OS Login enabled is reported.
+func (a *adapter) getProjectMetadata() (compute.ProjectMetadata, error) {
+
+	a.Tracker().SetServiceLabel("Discovering project metadata...")
+	a.Tracker().SetTotalResources(len(a.Projects()))
+
+	var projectMetadata *compute.ProjectMetadata
+	err := a.ForEachProject(func(project google2.Project) error {
+		defer a.Tracker().IncrementResource()
+		var apiProject apiProject
+		if err := a.Client().Get(a.Context(), "compute", "compute/v1/projects/"+project.ID, &apiProject); err != nil {
+			return err
+		}
+		metadata := a.CreateMetadata(apiProject.SelfLink)
+		enabled := apiProject.CommonInstanceMetadata.isTrue("enable-oslogin")
+		if projectMetadata == nil || (!enabled && projectMetadata.EnableOSLogin.IsTrue()) {
+			projectMetadata = &compute.ProjectMetadata{
+				Metadata:      metadata,
+				EnableOSLogin: defsecTypes.Bool(enabled, metadata),
+			}
+		}
+		return nil
+	})
+
+	if projectMetadata == nil {
+		metadata := defsecTypes.NewUnmanagedMetadata()
+		return compute.ProjectMetadata{
+			Metadata:      metadata,
+			EnableOSLogin: defsecTypes.BoolDefault(false, metadata),
+		}, err
+	}
+	return *projectMetadata, err
+}
@@ -0,0 +1,162 @@
+package compute
+
+import (
+	"strings"
+
+	google2 "github.com/aquasecurity/defsec/internal/adapters/cloud/google"
+	"github.com/aquasecurity/defsec/pkg/providers/google/compute"
+	defsecTypes "github.com/aquasecurity/defsec/pkg/types"
+)
+
+type apiNetwork struct {
+	SelfLink string `json:"selfLink"`
+}
+
+type apiSubnetwork struct {
+	Name      string `json:"name"`
+	SelfLink  string `json:"selfLink"`
+	Network   string `json:"network"`
+	LogConfig *struct {
+		Enable bool `json:"enable"`
+	} `json:"logConfig"`
+}
+
+type apiFirewall struct {
+	Name              string            `json:"name"`
+	SelfLink          string            `json:"selfLink"`
+	Network           string            `json:"network"`
+	Direction         string            `json:"direction"`
+	Disabled          bool              `json:"disabled"`
+	Allowed           []apiFirewallRule `json:"allowed"`
+	Denied            []apiFirewallRule `json:"denied"`
+	SourceRanges      []string          `json:"sourceRanges"`
+	DestinationRanges []string          `json:"destinationRanges"`
+	SourceTags        []string          `json:"sourceTags"`
+	TargetTags        []string          `json:"targetTags"`
+}
+
+type apiFirewallRule struct {
+	IPProtocol string   `json:"IPProtocol"`
+	Ports      []string `json:"ports"`
+}
+
+func (a *adapter) getNetworks() ([]compute.Network, error) {
+
+	a.Tracker().SetServiceLabel("Discovering networks...")
+	var networks []compute.Network
+	err := a.ForEachProject(func(project google2.Project) error {
+		projectNetworks, err := a.getProjectNetworks(project)
+		networks = append(networks, projectNetworks...)
+		return err
+	})
+	return networks, err
+}
+
+// getProjectNetworks reads the networks of a project with their subnetworks. A network holds a single firewall, so
+// each firewall is added as a further entry for the network it applies to.
+func (a *adapter) getProjectNetworks(project google2.Project) ([]compute.Network, error) {
+
+	apiNetworks, err := google2.ListAll[apiNetwork](a.Context(), a.Client(), "compute", projectPath(project, "global/networks"), "items")
+	if err != nil {
+		return nil, err
+	}
+	apiSubnetworks, err := listAggregated[apiSubnetwork](a, project, "subnetworks", "subnetworks")
+	if err != nil {
+		return nil, err
+	}
+	apiFirewalls, err := google2.ListAll[apiFirewall](a.Context(), a.Client(), "compute", projectPath(project, "global/firewalls"), "items")
+	if err != nil {
+		return nil, err
+	}
+	a.Tracker().SetTotalResources(len(apiNetworks) + len(apiFirewalls))
+
+	a.Tracker().SetServiceLabel("Adapting networks...")
+	var networks []compute.Network
+	for _, apiNetwork := range apiNetworks {
+		network := compute.Network{
+			Metadata: a.CreateMetadata(apiNetwork.SelfLink),
+		}
+		for _, apiSubnetwork := range apiSubnetworks {
+			if apiSubnetwork.Network != apiNetwork.SelfLink {
+				continue
+			}
+			metadata := a.CreateMetadata(apiSubnetwork.SelfLink)
+			network.Subnetworks = append(network.Subnetworks, compute.SubNetwork{
+				Metadata:       metadata,
+				Name:           defsecTypes.String(apiSubnetwork.Name, metadata),
+				EnableFlowLogs: defsecTypes.Bool(apiSubnetwork.LogConfig != nil && apiSubnetwork.LogConfig.Enable, metadata),
+			})
+		}
+		networks = append(networks, network)
+		a.Tracker().IncrementResource()
+	}
+
+	for _, apiFirewall := range apiFirewalls {
+		networks = append(networks, compute.Network{
+			Metadata: a.CreateMetadata(apiFirewall.Network),
+			Firewall: a.adaptFirewall(apiFirewall),
+		})
+		a.Tracker().IncrementResource()
+	}
+
+	return networks, nil
+}
+
+func (a *adapter) adaptFirewall(apiFirewall apiFirewall) *compute.Firewall {
+	metadata := a.CreateMetadata(apiFirewall.SelfLink)
+
+	firewall := &compute.Firewall{
+		Metadata: metadata,
+		Name:     defsecTypes.String(apiFirewall.Name, metadata),
+	}
+	for _, tag := range apiFirewall.SourceTags {
+		firewall.SourceTags = append(firewall.SourceTags, defsecTypes.String(tag, metadata))
+	}
+	for _, tag := range apiFirewall.TargetTags {
+		firewall.TargetTags = append(firewall.TargetTags, defsecTypes.String(tag, metadata))
+	}
+
+	var rules []compute.FirewallRule
+	for _, allowed := range apiFirewall.Allowed {
+		rules = append(rules, adaptFirewallRule(apiFirewall, allowed, true, metadata))
+	}
+	for _, denied := range apiFirewall.Denied {
+		rules = append(rules, adaptFirewallRule(apiFirewall, denied, false, metadata))
+	}
+
+	for _, rule := range rules {
+		if strings.EqualFold(apiFirewall.Direction, "EGRESS") {
+			firewall.EgressRules = append(firewall.EgressRules, compute.EgressRule{
+				Metadata:          metadata,
+				FirewallRule:      rule,
+				DestinationRanges: adaptRanges(apiFirewall.DestinationRanges, metadata),
+			})
+		} else {
+			firewall.IngressRules = append(firewall.IngressRules, compute.IngressRule{
+				Metadata:     metadata,
+				FirewallRule: rule,
+				SourceRanges: adaptRanges(apiFirewall.SourceRanges, metadata),
+			})
+		}
+	}
+
+	return firewall
+}
+
+func adaptFirewallRule(apiFirewall apiFirewall, rule apiFirewallRule, allow bool, metadata defsecTypes.Metadata) compute.FirewallRule {
+	return compute.FirewallRule{
+		Metadata: metadata,
+		Enforced: defsecTypes.Bool(!apiFirewall.Disabled, metadata),
+		IsAllow:  defsecTypes.Bool(allow, metadata),
+		Protocol: defsecTypes.String(rule.IPProtocol, metadata),
+		Ports:    expandPorts(rule.Ports, metadata),
+	}
+}
+
+func adaptRanges(ranges []string, metadata defsecTypes.Metadata) []defsecTypes.StringValue {
+	var output []defsecTypes.StringValue
+	for _, r := range ranges {
+		output = append(output, defsecTypes.String(r, metadata))
+	}
+	return output
+}
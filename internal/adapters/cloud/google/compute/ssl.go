@@ -0,0 +1,42 @@
+package compute
+
+import (
+	google2 "github.com/aquasecurity/defsec/internal/adapters/cloud/google"
+	"github.com/aquasecurity/defsec/pkg/concurrency"
+	"github.com/aquasecurity/defsec/pkg/providers/google/compute"
+	defsecTypes "github.com/aquasecurity/defsec/pkg/types"
+)
+
+type apiSSLPolicy struct {
+	Name          string `json:"name"`
+	SelfLink      string `json:"selfLink"`
+	Profile       string `json:"profile"`
+	MinTLSVersion string `json:"minTlsVersion"`
+}
+
+func (a *adapter) getSSLPolicies() ([]compute.SSLPolicy, error) {
+
+	a.Tracker().SetServiceLabel("Discovering SSL policies...")
+	var apiPolicies []apiSSLPolicy
+	err := a.ForEachProject(func(project google2.Project) error {
+		policies, err := google2.ListAll[apiSSLPolicy](
+			a.Context(), a.Client(), "compute", projectPath(project, "global/sslPolicies"), "items",
+		)
+		apiPolicies = append(apiPolicies, policies...)
+		return err
+	})
+	a.Tracker().SetTotalResources(len(apiPolicies))
+
+	a.Tracker().SetServiceLabel("Adapting SSL policies...")
+	return concurrency.Adapt(apiPolicies, a.RootAdapter, a.adaptSSLPolicy), err
+}
+
+func (a *adapter) adaptSSLPolicy(apiPolicy apiSSLPolicy) (*compute.SSLPolicy, error) {
+	metadata := a.CreateMetadata(apiPolicy.SelfLink)
+	return &compute.SSLPolicy{
+		Metadata:          metadata,
+		Name:              defsecTypes.String(apiPolicy.Name, metadata),
+		Profile:           defsecTypes.String(apiPolicy.Profile, metadata),
+		MinimumTLSVersion: defsecTypes.String(apiPolicy.MinTLSVersion, metadata),
+	}, nil
+}
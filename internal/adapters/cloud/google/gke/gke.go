@@ -0,0 +1,265 @@
+package gke
+
+import (
+	google2 "github.com/aquasecurity/defsec/internal/adapters/cloud/google"
+	"github.com/aquasecurity/defsec/pkg/concurrency"
+	"github.com/aquasecurity/defsec/pkg/providers/google/gke"
+	"github.com/aquasecurity/defsec/pkg/state"
+	defsecTypes "github.com/aquasecurity/defsec/pkg/types"
+)
+
+type adapter struct {
+	*google2.RootAdapter
+}
+
+func init() {
+	google2.RegisterServiceAdapter(&adapter{})
+}
+
+func (a *adapter) Provider() string {
+	return "google"
+}
+
+func (a *adapter) Name() string {
+	return "gke"
+}
+
+func (a *adapter) Adapt(root *google2.RootAdapter, state *state.State) error {
+
+	a.RootAdapter = root
+	var err error
+
+	state.Google.GKE.Clusters, err = a.getClusters()
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+type apiCluster struct {
+	SelfLink           string            `json:"selfLink"`
+	NodeConfig         *apiNodeConfig    `json:"nodeConfig"`
+	NodePools          []apiNodePool     `json:"nodePools"`
+	LoggingService     string            `json:"loggingService"`
+	MonitoringService  string            `json:"monitoringService"`
+	ResourceLabels     map[string]string `json:"resourceLabels"`
+	IPAllocationPolicy *struct {
+		UseIPAliases bool `json:"useIpAliases"`
+	} `json:"ipAllocationPolicy"`
+	MasterAuthorizedNetworksConfig *struct {
+		Enabled    bool `json:"enabled"`
+		CIDRBlocks []struct {
+			CIDRBlock string `json:"cidrBlock"`
+		} `json:"cidrBlocks"`
+	} `json:"masterAuthorizedNetworksConfig"`
+	NetworkPolicy *struct {
+		Enabled bool `json:"enabled"`
+	} `json:"networkPolicy"`
+	PrivateClusterConfig *struct {
+		EnablePrivateNodes bool `json:"enablePrivateNodes"`
+	} `json:"privateClusterConfig"`
+	PodSecurityPolicyConfig *struct {
+		Enabled bool `json:"enabled"`
+	} `json:"podSecurityPolicyConfig"`
+	MasterAuth *struct {
+		Username                string `json:"username"`
+		Password                string `json:"password"`
+		ClientCertificateConfig *struct {
+			IssueClientCertificate bool `json:"issueClientCertificate"`
+		} `json:"clientCertificateConfig"`
+	} `json:"masterAuth"`
+	ShieldedNodes *struct {
+		Enabled bool `json:"enabled"`
+	} `json:"shieldedNodes"`
+	LegacyABAC *struct {
+		Enabled bool `json:"enabled"`
+	} `json:"legacyAbac"`
+}
+
+type apiNodePool struct {
+	Name       string         `json:"name"`
+	SelfLink   string         `json:"selfLink"`
+	Config     *apiNodeConfig `json:"config"`
+	Management *struct {
+		AutoRepair  bool `json:"autoRepair"`
+		AutoUpgrade bool `json:"autoUpgrade"`
+	} `json:"management"`
+}
+
+type apiNodeConfig struct {
+	ImageType              string            `json:"imageType"`
+	ServiceAccount         string            `json:"serviceAccount"`
+	Metadata               map[string]string `json:"metadata"`
+	WorkloadMetadataConfig *struct {
+		Mode string `json:"mode"`
+	} `json:"workloadMetadataConfig"`
+}
+
+// defaultNodePool is the name of the node pool created with a cluster, unless it is removed
+const defaultNodePool = "default-pool"
+
+func (a *adapter) getClusters() ([]gke.Cluster, error) {
+
+	a.Tracker().SetServiceLabel("Discovering clusters...")
+	var apiClusters []apiCluster
+	err := a.ForEachProject(func(project google2.Project) error {
+		clusters, err := google2.ListAll[apiCluster](
+			a.Context(), a.Client(), "container", "v1/projects/"+project.ID+"/locations/-/clusters", "clusters",
+		)
+		apiClusters = append(apiClusters, clusters...)
+		return err
+	})
+	a.Tracker().SetTotalResources(len(apiClusters))
+
+	a.Tracker().SetServiceLabel("Adapting clusters...")
+	return concurrency.Adapt(apiClusters, a.RootAdapter, a.adaptCluster), err
+}
+
+func (a *adapter) adaptCluster(apiCluster apiCluster) (*gke.Cluster, error) {
+
+	metadata := a.CreateMetadata(apiCluster.SelfLink)
+
+	cluster := &gke.Cluster{
+		Metadata: metadata,
+		IPAllocationPolicy: gke.IPAllocationPolicy{
+			Metadata: metadata,
+			Enabled:  defsecTypes.BoolDefault(false, metadata),
+		},
+		MasterAuthorizedNetworks: gke.MasterAuthorizedNetworks{
+			Metadata: metadata,
+			Enabled:  defsecTypes.BoolDefault(false, metadata),
+		},
+		NetworkPolicy: gke.NetworkPolicy{
+			Metadata: metadata,
+			Enabled:  defsecTypes.BoolDefault(false, metadata),
+		},
+		PrivateCluster: gke.PrivateCluster{
+			Metadata:           metadata,
+			EnablePrivateNodes: defsecTypes.BoolDefault(false, metadata),
+		},
+		LoggingService:    defsecTypes.String(apiCluster.LoggingService, metadata),
+		MonitoringService: defsecTypes.String(apiCluster.MonitoringService, metadata),
+		PodSecurityPolicy: gke.PodSecurityPolicy{
+			Metadata: metadata,
+			Enabled:  defsecTypes.BoolDefault(false, metadata),
+		},
+		MasterAuth: gke.MasterAuth{
+			Metadata: metadata,
+			ClientCertificate: gke.ClientCertificate{
+				Metadata:         metadata,
+				IssueCertificate: defsecTypes.BoolDefault(false, metadata),
+			},
+			Username: defsecTypes.StringDefault("", metadata),
+			Password: defsecTypes.StringDefault("", metadata),
+		},
+		EnableShieldedNodes:   defsecTypes.BoolDefault(false, metadata),
+		EnableLegacyABAC:      defsecTypes.BoolDefault(false, metadata),
+		ResourceLabels:        defsecTypes.Map(apiCluster.ResourceLabels, metadata),
+		RemoveDefaultNodePool: defsecTypes.Bool(true, metadata),
+	}
+
+	if policy := apiCluster.IPAllocationPolicy; policy != nil {
+		cluster.IPAllocationPolicy.Enabled = defsecTypes.Bool(policy.UseIPAliases, metadata)
+	}
+	if config := apiCluster.MasterAuthorizedNetworksConfig; config != nil {
+		cluster.MasterAuthorizedNetworks.Enabled = defsecTypes.Bool(config.Enabled, metadata)
+		for _, block := range config.CIDRBlocks {
+			cluster.MasterAuthorizedNetworks.CIDRs = append(cluster.MasterAuthorizedNetworks.CIDRs, defsecTypes.String(block.CIDRBlock, metadata))
+		}
+	}
+	if policy := apiCluster.NetworkPolicy; policy != nil {
+		cluster.NetworkPolicy.Enabled = defsecTypes.Bool(policy.Enabled, metadata)
+	}
+	if config := apiCluster.PrivateClusterConfig; config != nil {
+		cluster.PrivateCluster.EnablePrivateNodes = defsecTypes.Bool(config.EnablePrivateNodes, metadata)
+	}
+	if config := apiCluster.PodSecurityPolicyConfig; config != nil {
+		cluster.PodSecurityPolicy.Enabled = defsecTypes.Bool(config.Enabled, metadata)
+	}
+	if auth := apiCluster.MasterAuth; auth != nil {
+		cluster.MasterAuth.Username = defsecTypes.String(auth.Username, metadata)
+		cluster.MasterAuth.Password = defsecTypes.String(auth.Password, metadata)
+		if config := auth.ClientCertificateConfig; config != nil {
+			cluster.MasterAuth.ClientCertificate.IssueCertificate = defsecTypes.Bool(config.IssueClientCertificate, metadata)
+		}
+	}
+	if nodes := apiCluster.ShieldedNodes; nodes != nil {
+		cluster.EnableShieldedNodes = defsecTypes.Bool(nodes.Enabled, metadata)
+	}
+	if abac := apiCluster.LegacyABAC; abac != nil {
+		cluster.EnableLegacyABAC = defsecTypes.Bool(abac.Enabled, metadata)
+	}
+
+	// the cluster-wide node config is deprecated in favour of node pools, so fall back to the default pool's
+	clusterNodeConfig := apiCluster.NodeConfig
+	for _, apiPool := range apiCluster.NodePools {
+		if apiPool.Name == defaultNodePool {
+			cluster.RemoveDefaultNodePool = defsecTypes.Bool(false, metadata)
+			if clusterNodeConfig == nil {
+				clusterNodeConfig = apiPool.Config
+			}
+		}
+		cluster.NodePools = append(cluster.NodePools, a.adaptNodePool(apiPool))
+	}
+	cluster.NodeConfig = adaptNodeConfig(clusterNodeConfig, metadata)
+
+	return cluster, nil
+}
+
+func (a *adapter) adaptNodePool(apiPool apiNodePool) gke.NodePool {
+	metadata := a.CreateMetadata(apiPool.SelfLink)
+	pool := gke.NodePool{
+		Metadata: metadata,
+		Management: gke.Management{
+			Metadata:          metadata,
+			EnableAutoRepair:  defsecTypes.BoolDefault(false, metadata),
+			EnableAutoUpgrade: defsecTypes.BoolDefault(false, metadata),
+		},
+		NodeConfig: adaptNodeConfig(apiPool.Config, metadata),
+	}
+	if management := apiPool.Management; management != nil {
+		pool.Management.EnableAutoRepair = defsecTypes.Bool(management.AutoRepair, metadata)
+		pool.Management.EnableAutoUpgrade = defsecTypes.Bool(management.AutoUpgrade, metadata)
+	}
+	return pool
+}
+
+func adaptNodeConfig(apiConfig *apiNodeConfig, metadata defsecTypes.Metadata) gke.NodeConfig {
+	config := gke.NodeConfig{
+		Metadata:  metadata,
+		ImageType: defsecTypes.StringDefault("", metadata),
+		WorkloadMetadataConfig: gke.WorkloadMetadataConfig{
+			Metadata:     metadata,
+			NodeMetadata: defsecTypes.StringDefault("UNSPECIFIED", metadata),
+		},
+		ServiceAccount:        defsecTypes.StringDefault("", metadata),
+		EnableLegacyEndpoints: defsecTypes.BoolDefault(true, metadata),
+	}
+	if apiConfig == nil {
+		return config
+	}
+
+	config.ImageType = defsecTypes.String(apiConfig.ImageType, metadata)
+	config.ServiceAccount = defsecTypes.String(apiConfig.ServiceAccount, metadata)
+	if value, ok := apiConfig.Metadata["disable-legacy-endpoints"]; ok {
+		config.EnableLegacyEndpoints = defsecTypes.Bool(value != "true", metadata)
+	}
+	if workload := apiConfig.WorkloadMetadataConfig; workload != nil {
+		config.WorkloadMetadataConfig.NodeMetadata = defsecTypes.String(adaptWorkloadMetadataMode(workload.Mode), metadata)
+	}
+	return config
+}
+
+// adaptWorkloadMetadataMode converts the API's workload metadata mode to the node metadata values used by the
+// terraform provider, which the rules are written against
+func adaptWorkloadMetadataMode(mode string) string {
+	switch mode {
+	case "GKE_METADATA":
+		return "GKE_METADATA_SERVER"
+	case "GCE_METADATA":
+		return "EXPOSE"
+	default:
+		return "UNSPECIFIED"
+	}
+}
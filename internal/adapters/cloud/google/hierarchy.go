@@ -0,0 +1,99 @@
+package google
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// Project is a project in the scope being scanned
+type Project struct {
+	// ID is the project ID used in API paths, e.g. "my-project"
+	ID string
+	// Name is the resource name of the project, e.g. "projects/123456789"
+	Name string
+}
+
+// Node is an organization or folder, with the folders and projects below it
+type Node struct {
+	// Name is the resource name of the node, e.g. "organizations/123" or "folders/456"
+	Name     string
+	Folders  []*Node
+	Projects []Project
+}
+
+// IsOrganization reports whether the node is an organization rather than a folder
+func (n *Node) IsOrganization() bool {
+	return strings.HasPrefix(n.Name, "organizations/")
+}
+
+// AllProjects returns the projects directly below the node and below each of its descendant folders
+func (n *Node) AllProjects() []Project {
+	projects := append([]Project{}, n.Projects...)
+	for _, folder := range n.Folders {
+		projects = append(projects, folder.AllProjects()...)
+	}
+	return projects
+}
+
+type apiProject struct {
+	Name      string `json:"name"`
+	ProjectID string `json:"projectId"`
+	State     string `json:"state"`
+}
+
+type apiFolder struct {
+	Name  string `json:"name"`
+	State string `json:"state"`
+}
+
+func (a *RootAdapter) resolveScope() error {
+	switch {
+	case a.scope.Project != "":
+		a.projects = []Project{{ID: a.scope.Project, Name: "projects/" + a.scope.Project}}
+		return nil
+	case a.scope.Folder != "":
+		a.hierarchy = &Node{Name: "folders/" + strings.TrimPrefix(a.scope.Folder, "folders/")}
+	case a.scope.Organization != "":
+		a.hierarchy = &Node{Name: "organizations/" + strings.TrimPrefix(a.scope.Organization, "organizations/")}
+	default:
+		return fmt.Errorf("no google project, folder or organization specified")
+	}
+	if err := a.discover(a.hierarchy); err != nil {
+		return fmt.Errorf("failed to discover projects in %s: %w", a.hierarchy.Name, err)
+	}
+	a.projects = a.hierarchy.AllProjects()
+	return nil
+}
+
+// discover fills in the folders and projects below a node, recursively
+func (a *RootAdapter) discover(node *Node) error {
+	parent := url.QueryEscape(node.Name)
+
+	projects, err := ListAll[apiProject](a.ctx, a.client, "cloudresourcemanager", "v3/projects?parent="+parent, "projects")
+	if err != nil {
+		return err
+	}
+	for _, project := range projects {
+		if project.State != "" && project.State != "ACTIVE" {
+			continue
+		}
+		node.Projects = append(node.Projects, Project{ID: project.ProjectID, Name: project.Name})
+	}
+
+	folders, err := ListAll[apiFolder](a.ctx, a.client, "cloudresourcemanager", "v3/folders?parent="+parent, "folders")
+	if err != nil {
+		return err
+	}
+	for _, folder := range folders {
+		if folder.State != "" && folder.State != "ACTIVE" {
+			continue
+		}
+		child := &Node{Name: folder.Name}
+		if err := a.discover(child); err != nil {
+			return err
+		}
+		node.Folders = append(node.Folders, child)
+	}
+	return nil
+}
@@ -0,0 +1,152 @@
+package iam
+
+import (
+	google2 "github.com/aquasecurity/defsec/internal/adapters/cloud/google"
+	"github.com/aquasecurity/defsec/pkg/providers/google/iam"
+	"github.com/aquasecurity/defsec/pkg/state"
+	defsecTypes "github.com/aquasecurity/defsec/pkg/types"
+)
+
+type adapter struct {
+	*google2.RootAdapter
+}
+
+func init() {
+	google2.RegisterServiceAdapter(&adapter{})
+}
+
+func (a *adapter) Provider() string {
+	return "google"
+}
+
+func (a *adapter) Name() string {
+	return "iam"
+}
+
+func (a *adapter) Adapt(root *google2.RootAdapter, state *state.State) error {
+
+	a.RootAdapter = root
+
+	organization, err := a.getOrganization()
+	if err != nil {
+		return err
+	}
+	state.Google.IAM.Organizations = []iam.Organization{organization}
+
+	return nil
+}
+
+// getOrganization reads the IAM policies of the scope being scanned. When the scope is a folder or a single project,
+// it is placed in an unmanaged organization, as the policies of its parents are not read.
+func (a *adapter) getOrganization() (iam.Organization, error) {
+
+	a.Tracker().SetServiceLabel("Discovering IAM policies...")
+	a.Tracker().SetTotalResources(len(a.Projects()))
+
+	node := a.Hierarchy()
+	if node == nil {
+		projects, err := a.adaptProjects(a.Projects())
+		return iam.Organization{
+			Metadata: defsecTypes.NewUnmanagedMetadata(),
+			Projects: projects,
+		}, err
+	}
+
+	if !node.IsOrganization() {
+		folder, err := a.adaptFolder(node)
+		return iam.Organization{
+			Metadata: defsecTypes.NewUnmanagedMetadata(),
+			Folders:  []iam.Folder{folder},
+		}, err
+	}
+
+	metadata := a.CreateMetadata(resourceName(node.Name))
+	bindings, err := a.getBindings(node.Name, metadata)
+	if err != nil {
+		return iam.Organization{}, err
+	}
+	organization := iam.Organization{
+		Metadata: metadata,
+		Bindings: bindings,
+	}
+	for _, child := range node.Folders {
+		folder, err := a.adaptFolder(child)
+		if err != nil {
+			return organization, err
+		}
+		organization.Folders = append(organization.Folders, folder)
+	}
+	organization.Projects, err = a.adaptProjects(node.Projects)
+	return organization, err
+}
+
+func (a *adapter) adaptFolder(node *google2.Node) (iam.Folder, error) {
+	metadata := a.CreateMetadata(resourceName(node.Name))
+	bindings, err := a.getBindings(node.Name, metadata)
+	if err != nil {
+		return iam.Folder{}, err
+	}
+	folder := iam.Folder{
+		Metadata: metadata,
+		Bindings: bindings,
+	}
+	for _, child := range node.Folders {
+		childFolder, err := a.adaptFolder(child)
+		if err != nil {
+			return folder, err
+		}
+		folder.Folders = append(folder.Folders, childFolder)
+	}
+	folder.Projects, err = a.adaptProjects(node.Projects)
+	return folder, err
+}
+
+func (a *adapter) adaptProjects(projects []google2.Project) ([]iam.Project, error) {
+	var adapted []iam.Project
+	for _, project := range projects {
+		metadata := a.CreateMetadata(resourceName(project.Name))
+		bindings, err := a.getBindings("projects/"+project.ID, metadata)
+		if err != nil {
+			return adapted, err
+		}
+		adapted = append(adapted, iam.Project{
+			Metadata:          metadata,
+			AutoCreateNetwork: a.hasDefaultNetwork(project, metadata),
+			Bindings:          bindings,
+		})
+		a.Tracker().IncrementResource()
+	}
+	return adapted, nil
+}
+
+// hasDefaultNetwork reports whether the project still has the network created automatically with it
+func (a *adapter) hasDefaultNetwork(project google2.Project, metadata defsecTypes.Metadata) defsecTypes.BoolValue {
+	var network struct {
+		SelfLink string `json:"selfLink"`
+	}
+	err := a.Client().Get(a.Context(), "compute", "compute/v1/projects/"+project.ID+"/global/networks/default", &network)
+	switch {
+	case err == nil:
+		return defsecTypes.Bool(true, metadata)
+	case google2.IsNotFound(err):
+		return defsecTypes.Bool(false, metadata)
+	default:
+		// e.g. the compute API is not enabled in the project
+		a.Debug("Could not check for the default network in project %s: %s", project.ID, err)
+		return defsecTypes.BoolUnresolvable(metadata)
+	}
+}
+
+func (a *adapter) getBindings(name string, metadata defsecTypes.Metadata) ([]iam.Binding, error) {
+	var policy google2.Policy
+	if err := a.Client().Post(
+		a.Context(), "cloudresourcemanager", "v3/"+name+":getIamPolicy", map[string]interface{}{}, &policy,
+	); err != nil {
+		return nil, err
+	}
+	return google2.AdaptBindings(policy, metadata), nil
+}
+
+func resourceName(name string) string {
+	return "//cloudresourcemanager.googleapis.com/" + name
+}
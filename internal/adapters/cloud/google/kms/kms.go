@@ -0,0 +1,113 @@
+package kms
+
+import (
+	"strconv"
+	"strings"
+
+	google2 "github.com/aquasecurity/defsec/internal/adapters/cloud/google"
+	"github.com/aquasecurity/defsec/pkg/concurrency"
+	"github.com/aquasecurity/defsec/pkg/providers/google/kms"
+	"github.com/aquasecurity/defsec/pkg/state"
+	defsecTypes "github.com/aquasecurity/defsec/pkg/types"
+)
+
+type adapter struct {
+	*google2.RootAdapter
+}
+
+func init() {
+	google2.RegisterServiceAdapter(&adapter{})
+}
+
+func (a *adapter) Provider() string {
+	return "google"
+}
+
+func (a *adapter) Name() string {
+	return "kms"
+}
+
+func (a *adapter) Adapt(root *google2.RootAdapter, state *state.State) error {
+
+	a.RootAdapter = root
+	var err error
+
+	state.Google.KMS.KeyRings, err = a.getKeyRings()
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+type apiLocation struct {
+	LocationID string `json:"locationId"`
+}
+
+type apiKeyRing struct {
+	Name string `json:"name"`
+}
+
+type apiCryptoKey struct {
+	Name string `json:"name"`
+	// RotationPeriod is a duration in seconds, e.g. "7776000s", or unset if the key is not rotated automatically
+	RotationPeriod string `json:"rotationPeriod"`
+}
+
+func (a *adapter) getKeyRings() ([]kms.KeyRing, error) {
+
+	a.Tracker().SetServiceLabel("Discovering key rings...")
+	var apiKeyRings []apiKeyRing
+	err := a.ForEachProject(func(project google2.Project) error {
+		// key rings cannot be listed across locations, so each location is listed in turn
+		locations, err := google2.ListAll[apiLocation](
+			a.Context(), a.Client(), "cloudkms", "v1/projects/"+project.ID+"/locations", "locations",
+		)
+		if err != nil {
+			return err
+		}
+		for _, location := range locations {
+			keyRings, err := google2.ListAll[apiKeyRing](
+				a.Context(), a.Client(), "cloudkms", "v1/projects/"+project.ID+"/locations/"+location.LocationID+"/keyRings", "keyRings",
+			)
+			if err != nil {
+				return err
+			}
+			apiKeyRings = append(apiKeyRings, keyRings...)
+		}
+		return nil
+	})
+	a.Tracker().SetTotalResources(len(apiKeyRings))
+
+	a.Tracker().SetServiceLabel("Adapting key rings...")
+	return concurrency.Adapt(apiKeyRings, a.RootAdapter, a.adaptKeyRing), err
+}
+
+func (a *adapter) adaptKeyRing(apiKeyRing apiKeyRing) (*kms.KeyRing, error) {
+
+	keyRing := &kms.KeyRing{
+		Metadata: a.CreateMetadata(resourceName(apiKeyRing.Name)),
+	}
+
+	keys, err := google2.ListAll[apiCryptoKey](a.Context(), a.Client(), "cloudkms", "v1/"+apiKeyRing.Name+"/cryptoKeys", "cryptoKeys")
+	if err != nil {
+		return nil, err
+	}
+	for _, apiKey := range keys {
+		metadata := a.CreateMetadata(resourceName(apiKey.Name))
+		key := kms.Key{
+			Metadata:              metadata,
+			RotationPeriodSeconds: defsecTypes.IntDefault(-1, metadata),
+		}
+		if seconds, err := strconv.Atoi(strings.TrimSuffix(apiKey.RotationPeriod, "s")); err == nil {
+			key.RotationPeriodSeconds = defsecTypes.Int(seconds, metadata)
+		}
+		keyRing.Keys = append(keyRing.Keys, key)
+	}
+
+	return keyRing, nil
+}
+
+func resourceName(name string) string {
+	return "//cloudkms.googleapis.com/" + name
+}
@@ -0,0 +1,44 @@
+package google
+
+import (
+	"strings"
+
+	"github.com/aquasecurity/defsec/pkg/providers/google/iam"
+	"github.com/aquasecurity/defsec/pkg/types"
+)
+
+// Policy is an IAM policy, as returned by the getIamPolicy method of a resource
+type Policy struct {
+	Bindings []struct {
+		Role    string   `json:"role"`
+		Members []string `json:"members"`
+	} `json:"bindings"`
+}
+
+// AdaptBindings converts the bindings of an IAM policy, attributing them to the resource the policy is attached to
+func AdaptBindings(policy Policy, metadata types.Metadata) []iam.Binding {
+	var bindings []iam.Binding
+	for _, apiBinding := range policy.Bindings {
+		binding := iam.Binding{
+			Metadata:                      metadata,
+			Role:                          types.String(apiBinding.Role, metadata),
+			IncludesDefaultServiceAccount: types.BoolDefault(false, metadata),
+		}
+		for _, member := range apiBinding.Members {
+			binding.Members = append(binding.Members, types.String(member, metadata))
+			if IsDefaultServiceAccount(member) {
+				binding.IncludesDefaultServiceAccount = types.Bool(true, metadata)
+			}
+		}
+		bindings = append(bindings, binding)
+	}
+	return bindings
+}
+
+// IsDefaultServiceAccount reports whether an IAM member, or a bare email address, is the Compute Engine or App Engine
+// default service account of a project
+func IsDefaultServiceAccount(member string) bool {
+	email := strings.TrimPrefix(member, "serviceAccount:")
+	return strings.HasSuffix(email, "-compute@developer.gserviceaccount.com") ||
+		strings.HasSuffix(email, "@appspot.gserviceaccount.com")
+}
@@ -0,0 +1,176 @@
+package sql
+
+import (
+	"strconv"
+
+	google2 "github.com/aquasecurity/defsec/internal/adapters/cloud/google"
+	"github.com/aquasecurity/defsec/pkg/concurrency"
+	"github.com/aquasecurity/defsec/pkg/providers/google/sql"
+	"github.com/aquasecurity/defsec/pkg/state"
+	defsecTypes "github.com/aquasecurity/defsec/pkg/types"
+)
+
+type adapter struct {
+	*google2.RootAdapter
+}
+
+func init() {
+	google2.RegisterServiceAdapter(&adapter{})
+}
+
+func (a *adapter) Provider() string {
+	return "google"
+}
+
+func (a *adapter) Name() string {
+	return "sql"
+}
+
+func (a *adapter) Adapt(root *google2.RootAdapter, state *state.State) error {
+
+	a.RootAdapter = root
+	var err error
+
+	state.Google.SQL.Instances, err = a.getInstances()
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+type apiInstance struct {
+	SelfLink           string `json:"selfLink"`
+	DatabaseVersion    string `json:"databaseVersion"`
+	MasterInstanceName string `json:"masterInstanceName"`
+	Settings           struct {
+		DatabaseFlags []struct {
+			Name  string `json:"name"`
+			Value string `json:"value"`
+		} `json:"databaseFlags"`
+		BackupConfiguration *struct {
+			Enabled bool `json:"enabled"`
+		} `json:"backupConfiguration"`
+		IPConfiguration *struct {
+			RequireSSL         bool   `json:"requireSsl"`
+			SSLMode            string `json:"sslMode"`
+			IPv4Enabled        bool   `json:"ipv4Enabled"`
+			AuthorizedNetworks []struct {
+				Name  string `json:"name"`
+				Value string `json:"value"`
+			} `json:"authorizedNetworks"`
+		} `json:"ipConfiguration"`
+	} `json:"settings"`
+}
+
+func (a *adapter) getInstances() ([]sql.DatabaseInstance, error) {
+
+	a.Tracker().SetServiceLabel("Discovering SQL instances...")
+	var apiInstances []apiInstance
+	err := a.ForEachProject(func(project google2.Project) error {
+		instances, err := google2.ListAll[apiInstance](
+			a.Context(), a.Client(), "sqladmin", "v1/projects/"+project.ID+"/instances", "items",
+		)
+		apiInstances = append(apiInstances, instances...)
+		return err
+	})
+	a.Tracker().SetTotalResources(len(apiInstances))
+
+	a.Tracker().SetServiceLabel("Adapting SQL instances...")
+	return concurrency.Adapt(apiInstances, a.RootAdapter, a.adaptInstance), err
+}
+
+func (a *adapter) adaptInstance(apiInstance apiInstance) (*sql.DatabaseInstance, error) {
+
+	metadata := a.CreateMetadata(apiInstance.SelfLink)
+
+	instance := &sql.DatabaseInstance{
+		Metadata:        metadata,
+		DatabaseVersion: defsecTypes.String(apiInstance.DatabaseVersion, metadata),
+		IsReplica:       defsecTypes.Bool(apiInstance.MasterInstanceName != "", metadata),
+		Settings: sql.Settings{
+			Metadata: metadata,
+			Flags: sql.Flags{
+				Metadata:                        metadata,
+				LogTempFileSize:                 defsecTypes.IntDefault(-1, metadata),
+				LocalInFile:                     defsecTypes.BoolDefault(false, metadata),
+				ContainedDatabaseAuthentication: defsecTypes.BoolDefault(true, metadata),
+				CrossDBOwnershipChaining:        defsecTypes.BoolDefault(true, metadata),
+				LogCheckpoints:                  defsecTypes.BoolDefault(false, metadata),
+				LogConnections:                  defsecTypes.BoolDefault(false, metadata),
+				LogDisconnections:               defsecTypes.BoolDefault(false, metadata),
+				LogLockWaits:                    defsecTypes.BoolDefault(false, metadata),
+				LogMinMessages:                  defsecTypes.StringDefault("", metadata),
+				LogMinDurationStatement:         defsecTypes.IntDefault(-1, metadata),
+			},
+			Backups: sql.Backups{
+				Metadata: metadata,
+				Enabled:  defsecTypes.BoolDefault(false, metadata),
+			},
+			IPConfiguration: sql.IPConfiguration{
+				Metadata:   metadata,
+				RequireTLS: defsecTypes.BoolDefault(false, metadata),
+				EnableIPv4: defsecTypes.BoolDefault(true, metadata),
+			},
+		},
+	}
+
+	settings := apiInstance.Settings
+	for _, flag := range settings.DatabaseFlags {
+		adaptFlag(flag.Name, flag.Value, &instance.Settings.Flags, metadata)
+	}
+
+	if backups := settings.BackupConfiguration; backups != nil {
+		instance.Settings.Backups.Enabled = defsecTypes.Bool(backups.Enabled, metadata)
+	}
+
+	if config := settings.IPConfiguration; config != nil {
+		// sslMode supersedes requireSsl, and only allows unencrypted connections when explicitly set to do so
+		requireTLS := config.RequireSSL
+		if config.SSLMode != "" {
+			requireTLS = config.SSLMode != "ALLOW_UNENCRYPTED_AND_ENCRYPTED"
+		}
+		instance.Settings.IPConfiguration.RequireTLS = defsecTypes.Bool(requireTLS, metadata)
+		instance.Settings.IPConfiguration.EnableIPv4 = defsecTypes.Bool(config.IPv4Enabled, metadata)
+		for _, network := range config.AuthorizedNetworks {
+			instance.Settings.IPConfiguration.AuthorizedNetworks = append(instance.Settings.IPConfiguration.AuthorizedNetworks, struct {
+				Name defsecTypes.StringValue
+				CIDR defsecTypes.StringValue
+			}{
+				Name: defsecTypes.String(network.Name, metadata),
+				CIDR: defsecTypes.String(network.Value, metadata),
+			})
+		}
+	}
+
+	return instance, nil
+}
+
+func adaptFlag(name string, value string, flags *sql.Flags, metadata defsecTypes.Metadata) {
+	switch name {
+	case "log_temp_files":
+		if size, err := strconv.Atoi(value); err == nil {
+			flags.LogTempFileSize = defsecTypes.Int(size, metadata)
+		}
+	case "log_min_messages":
+		flags.LogMinMessages = defsecTypes.String(value, metadata)
+	case "log_min_duration_statement":
+		if duration, err := strconv.Atoi(value); err == nil {
+			flags.LogMinDurationStatement = defsecTypes.Int(duration, metadata)
+		}
+	case "local_infile":
+		flags.LocalInFile = defsecTypes.Bool(value == "on", metadata)
+	case "log_checkpoints":
+		flags.LogCheckpoints = defsecTypes.Bool(value == "on", metadata)
+	case "log_connections":
+		flags.LogConnections = defsecTypes.Bool(value == "on", metadata)
+	case "log_disconnections":
+		flags.LogDisconnections = defsecTypes.Bool(value == "on", metadata)
+	case "log_lock_waits":
+		flags.LogLockWaits = defsecTypes.Bool(value == "on", metadata)
+	case "contained database authentication":
+		flags.ContainedDatabaseAuthentication = defsecTypes.Bool(value == "on", metadata)
+	case "cross db ownership chaining":
+		flags.CrossDBOwnershipChaining = defsecTypes.Bool(value == "on", metadata)
+	}
+}
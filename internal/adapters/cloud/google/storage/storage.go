@@ -0,0 +1,101 @@
+package storage
+
+import (
+	"net/url"
+
+	google2 "github.com/aquasecurity/defsec/internal/adapters/cloud/google"
+	"github.com/aquasecurity/defsec/pkg/concurrency"
+	"github.com/aquasecurity/defsec/pkg/providers/google/storage"
+	"github.com/aquasecurity/defsec/pkg/state"
+	defsecTypes "github.com/aquasecurity/defsec/pkg/types"
+)
+
+type adapter struct {
+	*google2.RootAdapter
+}
+
+func init() {
+	google2.RegisterServiceAdapter(&adapter{})
+}
+
+func (a *adapter) Provider() string {
+	return "google"
+}
+
+func (a *adapter) Name() string {
+	return "storage"
+}
+
+func (a *adapter) Adapt(root *google2.RootAdapter, state *state.State) error {
+
+	a.RootAdapter = root
+	var err error
+
+	state.Google.Storage.Buckets, err = a.getBuckets()
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+type apiBucket struct {
+	Name             string `json:"name"`
+	SelfLink         string `json:"selfLink"`
+	Location         string `json:"location"`
+	IAMConfiguration struct {
+		UniformBucketLevelAccess struct {
+			Enabled bool `json:"enabled"`
+		} `json:"uniformBucketLevelAccess"`
+	} `json:"iamConfiguration"`
+	Encryption *struct {
+		DefaultKMSKeyName string `json:"defaultKmsKeyName"`
+	} `json:"encryption"`
+}
+
+func (a *adapter) getBuckets() ([]storage.Bucket, error) {
+
+	a.Tracker().SetServiceLabel("Discovering buckets...")
+	var apiBuckets []apiBucket
+	err := a.ForEachProject(func(project google2.Project) error {
+		buckets, err := google2.ListAll[apiBucket](
+			a.Context(), a.Client(), "storage", "storage/v1/b?project="+url.QueryEscape(project.ID), "items",
+		)
+		apiBuckets = append(apiBuckets, buckets...)
+		return err
+	})
+	a.Tracker().SetTotalResources(len(apiBuckets))
+
+	a.Tracker().SetServiceLabel("Adapting buckets...")
+	return concurrency.Adapt(apiBuckets, a.RootAdapter, a.adaptBucket), err
+}
+
+func (a *adapter) adaptBucket(apiBucket apiBucket) (*storage.Bucket, error) {
+
+	metadata := a.CreateMetadata(apiBucket.SelfLink)
+
+	bucket := &storage.Bucket{
+		Metadata:                       metadata,
+		Name:                           defsecTypes.String(apiBucket.Name, metadata),
+		Location:                       defsecTypes.String(apiBucket.Location, metadata),
+		EnableUniformBucketLevelAccess: defsecTypes.Bool(apiBucket.IAMConfiguration.UniformBucketLevelAccess.Enabled, metadata),
+		Encryption: storage.BucketEncryption{
+			Metadata:          metadata,
+			DefaultKMSKeyName: defsecTypes.StringDefault("", metadata),
+		},
+	}
+
+	if apiBucket.Encryption != nil && apiBucket.Encryption.DefaultKMSKeyName != "" {
+		bucket.Encryption.DefaultKMSKeyName = defsecTypes.String(apiBucket.Encryption.DefaultKMSKeyName, metadata)
+	}
+
+	var policy google2.Policy
+	if err := a.Client().Get(
+		a.Context(), "storage", "storage/v1/b/"+url.PathEscape(apiBucket.Name)+"/iam", &policy,
+	); err != nil {
+		return nil, err
+	}
+	bucket.Bindings = google2.AdaptBindings(policy, metadata)
+
+	return bucket, nil
+}
@@ -0,0 +1,107 @@
+package storage
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	google2 "github.com/aquasecurity/defsec/internal/adapters/cloud/google"
+	"github.com/aquasecurity/defsec/pkg/progress"
+	"github.com/aquasecurity/defsec/pkg/providers/google/storage"
+	"github.com/aquasecurity/defsec/pkg/state"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const project = "my-project"
+
+func Test_Buckets(t *testing.T) {
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer token", r.Header.Get("Authorization"))
+
+		var body interface{}
+		switch r.URL.Path {
+		case "/storage/v1/b":
+			assert.Equal(t, project, r.URL.Query().Get("project"))
+			if r.URL.Query().Get("pageToken") == "" {
+				body = map[string]interface{}{
+					"items": []interface{}{
+						map[string]interface{}{
+							"name":     "secure",
+							"selfLink": "https://www.googleapis.com/storage/v1/b/secure",
+							"location": "EU",
+							"iamConfiguration": map[string]interface{}{
+								"uniformBucketLevelAccess": map[string]interface{}{"enabled": true},
+							},
+							"encryption": map[string]interface{}{
+								"defaultKmsKeyName": "projects/my-project/locations/eu/keyRings/ring/cryptoKeys/key",
+							},
+						},
+					},
+					"nextPageToken": "2",
+				}
+			} else {
+				body = map[string]interface{}{
+					"items": []interface{}{
+						map[string]interface{}{
+							"name":     "public",
+							"selfLink": "https://www.googleapis.com/storage/v1/b/public",
+							"location": "US",
+						},
+					},
+				}
+			}
+		case "/storage/v1/b/secure/iam":
+			body = map[string]interface{}{}
+		case "/storage/v1/b/public/iam":
+			body = map[string]interface{}{
+				"bindings": []interface{}{
+					map[string]interface{}{
+						"role":    "roles/storage.objectViewer",
+						"members": []string{"allUsers"},
+					},
+				},
+			}
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			body = map[string]interface{}{
+				"error": map[string]interface{}{"status": "NOT_FOUND", "message": r.URL.Path},
+			}
+		}
+		require.NoError(t, json.NewEncoder(w).Encode(body))
+	}))
+	defer server.Close()
+
+	client := google2.NewClient(server.URL, google2.StaticToken("token"))
+	root, err := google2.NewRootAdapter(context.TODO(), client, google2.Scope{Project: project}, progress.NoProgress)
+	require.NoError(t, err)
+
+	testState := &state.State{}
+	require.NoError(t, (&adapter{}).Adapt(root, testState))
+
+	buckets := testState.Google.Storage.Buckets
+	require.Len(t, buckets, 2)
+
+	byName := make(map[string]storage.Bucket)
+	for _, bucket := range buckets {
+		byName[bucket.Name.Value()] = bucket
+	}
+
+	secure := byName["secure"]
+	assert.Equal(t, "EU", secure.Location.Value())
+	assert.True(t, secure.EnableUniformBucketLevelAccess.IsTrue())
+	assert.Equal(t, "projects/my-project/locations/eu/keyRings/ring/cryptoKeys/key", secure.Encryption.DefaultKMSKeyName.Value())
+	assert.Empty(t, secure.Bindings)
+
+	public := byName["public"]
+	assert.True(t, public.EnableUniformBucketLevelAccess.IsFalse())
+	assert.Equal(t, "", public.Encryption.DefaultKMSKeyName.Value())
+	require.Len(t, public.Bindings, 1)
+	assert.Equal(t, "roles/storage.objectViewer", public.Bindings[0].Role.Value())
+	require.Len(t, public.Bindings[0].Members, 1)
+	assert.Equal(t, "allUsers", public.Bindings[0].Members[0].Value())
+	assert.Equal(t, "https://www.googleapis.com/storage/v1/b/public", public.Metadata.Range().GetFilename())
+}
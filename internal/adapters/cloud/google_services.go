@@ -0,0 +1,10 @@
+package cloud
+
+import (
+	_ "github.com/aquasecurity/defsec/internal/adapters/cloud/google/compute"
+	_ "github.com/aquasecurity/defsec/internal/adapters/cloud/google/gke"
+	_ "github.com/aquasecurity/defsec/internal/adapters/cloud/google/iam"
+	_ "github.com/aquasecurity/defsec/internal/adapters/cloud/google/kms"
+	_ "github.com/aquasecurity/defsec/internal/adapters/cloud/google/sql"
+	_ "github.com/aquasecurity/defsec/internal/adapters/cloud/google/storage"
+)
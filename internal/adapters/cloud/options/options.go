@@ -4,13 +4,51 @@ import (
 	"github.com/aquasecurity/defsec/pkg/concurrency"
 	"github.com/aquasecurity/defsec/pkg/debug"
 	"github.com/aquasecurity/defsec/pkg/progress"
+	"github.com/aquasecurity/defsec/pkg/scanners/cloud/cache"
+	"github.com/aquasecurity/defsec/pkg/scanners/cloud/coverage"
 )
 
 type Options struct {
 	ProgressTracker     progress.Tracker
 	Region              string
+	Regions             []string
 	Endpoint            string
+	Subscription        string
+	Project             string
+	Folder              string
+	Organization        string
 	Services            []string
+	SkipServices        []string
+	SkipExpensive       bool
+	OrganizationRole    string
+	Accounts            []string
+	StateCache          *cache.Cache
+	RateLimit           float64
+	MaxRetries          int
+	Coverage            *coverage.Report
 	DebugWriter         debug.Logger
 	ConcurrencyStrategy concurrency.Strategy
 }
+
+// SkipReason returns why a service should not be scanned, or an empty string if it should be. Expensive services
+// are only skipped when expensive services are to be skipped, and the service was not explicitly selected.
+func (o Options) SkipReason(service string, expensive bool) string {
+	switch {
+	case len(o.Services) > 0 && !contains(o.Services, service):
+		return "not selected"
+	case contains(o.SkipServices, service):
+		return "excluded"
+	case expensive && o.SkipExpensive && len(o.Services) == 0:
+		return "expensive"
+	}
+	return ""
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
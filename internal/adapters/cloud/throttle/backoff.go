@@ -0,0 +1,65 @@
+package throttle
+
+import (
+	"context"
+	"math/rand"
+	"time"
+)
+
+const (
+	// DefaultMaxRetries is how many times a throttled request is retried before its error is returned
+	DefaultMaxRetries = 5
+
+	defaultBaseDelay = 500 * time.Millisecond
+	defaultMaxDelay  = 20 * time.Second
+)
+
+// Backoff retries throttled requests, waiting exponentially longer after each attempt
+type Backoff struct {
+	MaxRetries int
+	BaseDelay  time.Duration
+	MaxDelay   time.Duration
+}
+
+// NewBackoff creates a backoff which retries up to maxRetries times. Zero or less uses DefaultMaxRetries.
+func NewBackoff(maxRetries int) Backoff {
+	if maxRetries <= 0 {
+		maxRetries = DefaultMaxRetries
+	}
+	return Backoff{
+		MaxRetries: maxRetries,
+		BaseDelay:  defaultBaseDelay,
+		MaxDelay:   defaultMaxDelay,
+	}
+}
+
+// Delay returns how long to wait before the given retry, counting from zero. The delay doubles with each retry up
+// to the maximum, and is jittered so that concurrent requests throttled together do not retry together.
+func (b Backoff) Delay(retry int) time.Duration {
+	ceiling := b.MaxDelay
+	if retry < 32 {
+		if exp := b.BaseDelay << retry; exp > 0 && exp < ceiling {
+			ceiling = exp
+		}
+	}
+	if ceiling <= 0 {
+		return 0
+	}
+	// #nosec G404 - jitter does not need to be cryptographically random
+	return ceiling/2 + time.Duration(rand.Int63n(int64(ceiling/2)+1))
+}
+
+// Sleep waits for the given duration, or until the context is done
+func Sleep(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,110 @@
+package throttle
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Limiter is a token bucket which allows a steady rate of requests, with bursts of up to its capacity
+type Limiter struct {
+	lock   sync.Mutex
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+	now    func() time.Time
+}
+
+// NewLimiter creates a limiter which allows rate requests per second, and bursts of up to burst requests. A burst of
+// zero or less allows a second's worth of requests at once, and a rate of zero or less allows every request.
+func NewLimiter(rate float64, burst int) *Limiter {
+	if burst < 1 {
+		burst = int(rate)
+	}
+	if burst < 1 {
+		burst = 1
+	}
+	return &Limiter{
+		rate:   rate,
+		burst:  float64(burst),
+		tokens: float64(burst),
+		now:    time.Now,
+	}
+}
+
+// Wait blocks until a request is allowed, or the context is done. It is safe to call on a nil limiter, which allows
+// every request.
+func (l *Limiter) Wait(ctx context.Context) error {
+	if l == nil || l.rate <= 0 {
+		return nil
+	}
+	delay := l.reserve()
+	if delay <= 0 {
+		return nil
+	}
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// reserve takes a token, returning how long the caller must wait for it to become available. Tokens are taken
+// even when they are not yet available, so that waiting callers are served in order.
+func (l *Limiter) reserve() time.Duration {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	now := l.now()
+	if !l.last.IsZero() {
+		l.tokens += now.Sub(l.last).Seconds() * l.rate
+		if l.tokens > l.burst {
+			l.tokens = l.burst
+		}
+	}
+	l.last = now
+
+	l.tokens--
+	if l.tokens >= 0 {
+		return 0
+	}
+	return time.Duration(-l.tokens / l.rate * float64(time.Second))
+}
+
+// Limiters holds a limiter for each service, so that a throttled service does not slow requests to the others
+type Limiters struct {
+	lock     sync.Mutex
+	rate     float64
+	burst    int
+	limiters map[string]*Limiter
+}
+
+// NewLimiters creates limiters which each allow rate requests per second to their service, and bursts of up to
+// burst requests
+func NewLimiters(rate float64, burst int) *Limiters {
+	return &Limiters{
+		rate:     rate,
+		burst:    burst,
+		limiters: make(map[string]*Limiter),
+	}
+}
+
+// For returns the limiter of a service, creating it on first use. It is safe to call on nil limiters, returning a
+// nil limiter which allows every request.
+func (l *Limiters) For(service string) *Limiter {
+	if l == nil || l.rate <= 0 {
+		return nil
+	}
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	limiter, ok := l.limiters[service]
+	if !ok {
+		limiter = NewLimiter(l.rate, l.burst)
+		l.limiters[service] = limiter
+	}
+	return limiter
+}
@@ -0,0 +1,120 @@
+package throttle
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_LimiterAllowsBurstThenSteadyRate(t *testing.T) {
+	limiter := NewLimiter(2, 2)
+	now := time.Now()
+	limiter.now = func() time.Time { return now }
+
+	assert.Equal(t, time.Duration(0), limiter.reserve())
+	assert.Equal(t, time.Duration(0), limiter.reserve())
+	assert.Equal(t, 500*time.Millisecond, limiter.reserve())
+	assert.Equal(t, time.Second, limiter.reserve())
+
+	now = now.Add(2 * time.Second)
+	assert.Equal(t, time.Duration(0), limiter.reserve())
+}
+
+func Test_LimitersAreSeparatePerService(t *testing.T) {
+	limiters := NewLimiters(1, 1)
+	assert.Same(t, limiters.For("ec2"), limiters.For("ec2"))
+	assert.NotSame(t, limiters.For("ec2"), limiters.For("s3"))
+
+	var unlimited *Limiters
+	assert.Nil(t, unlimited.For("ec2"))
+	assert.NoError(t, unlimited.For("ec2").Wait(context.TODO()))
+}
+
+func Test_BackoffDelayIsCapped(t *testing.T) {
+	backoff := Backoff{MaxRetries: 10, BaseDelay: time.Second, MaxDelay: 4 * time.Second}
+	for retry := 0; retry < 40; retry++ {
+		delay := backoff.Delay(retry)
+		assert.LessOrEqual(t, delay, 4*time.Second)
+		assert.Greater(t, delay, time.Duration(0))
+	}
+}
+
+func Test_TransportRetriesThrottledRequests(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		assert.Equal(t, "payload", string(body))
+		switch atomic.AddInt32(&calls, 1) {
+		case 1:
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+		case 2:
+			w.WriteHeader(http.StatusForbidden)
+			_, _ = w.Write([]byte(`{"error":{"errors":[{"reason":"userRateLimitExceeded"}]}}`))
+		default:
+			_, _ = w.Write([]byte("ok"))
+		}
+	}))
+	defer server.Close()
+
+	transport := NewTransport(0, 3, nil)
+	transport.Backoff.BaseDelay = time.Millisecond
+	client := &http.Client{Transport: transport}
+
+	resp, err := client.Post(server.URL, "text/plain", strings.NewReader("payload"))
+	require.NoError(t, err)
+	defer func() { _ = resp.Body.Close() }()
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, int32(3), atomic.LoadInt32(&calls))
+}
+
+func Test_TransportReturnsResponseWhenRetriesAreExhausted(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(http.StatusForbidden)
+		_, _ = w.Write([]byte(`{"error":{"errors":[{"reason":"rateLimitExceeded"}]}}`))
+	}))
+	defer server.Close()
+
+	transport := NewTransport(0, 2, nil)
+	transport.Backoff.BaseDelay = time.Millisecond
+	client := &http.Client{Transport: transport}
+
+	resp, err := client.Get(server.URL)
+	require.NoError(t, err)
+	defer func() { _ = resp.Body.Close() }()
+
+	assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+	assert.Equal(t, int32(3), atomic.LoadInt32(&calls))
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	assert.Contains(t, string(body), "rateLimitExceeded")
+}
+
+func Test_TransportDoesNotRetryOtherErrors(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(http.StatusForbidden)
+		_, _ = w.Write([]byte(`{"error":{"message":"permission denied"}}`))
+	}))
+	defer server.Close()
+
+	client := &http.Client{Transport: NewTransport(0, 3, nil)}
+	resp, err := client.Get(server.URL)
+	require.NoError(t, err)
+	_ = resp.Body.Close()
+
+	assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+}
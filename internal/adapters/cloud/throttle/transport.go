@@ -0,0 +1,114 @@
+package throttle
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Transport limits the rate of requests to each service, and retries requests which are throttled or fail with a
+// transient server error
+type Transport struct {
+	Base     http.RoundTripper
+	Limiters *Limiters
+	Backoff  Backoff
+	// Service names the service a request is sent to, so that each service is limited separately
+	Service func(req *http.Request) string
+}
+
+// NewTransport creates a transport which limits requests to each service to rate per second, retrying throttled
+// requests up to maxRetries times. A rate of zero or less does not limit requests, and zero retries uses
+// DefaultMaxRetries.
+func NewTransport(rate float64, maxRetries int, service func(req *http.Request) string) *Transport {
+	return &Transport{
+		Base:     http.DefaultTransport,
+		Limiters: NewLimiters(rate, 0),
+		Backoff:  NewBackoff(maxRetries),
+		Service:  service,
+	}
+}
+
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	base := t.Base
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	service := req.URL.Host
+	if t.Service != nil {
+		service = t.Service(req)
+	}
+	limiter := t.Limiters.For(service)
+
+	for retry := 0; ; retry++ {
+		if err := limiter.Wait(req.Context()); err != nil {
+			return nil, err
+		}
+
+		attempt := req
+		if retry > 0 && req.Body != nil {
+			if req.GetBody == nil {
+				// the body has already been read, so the request cannot be sent again
+				return nil, io.ErrUnexpectedEOF
+			}
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			attempt = req.Clone(req.Context())
+			attempt.Body = body
+		}
+
+		resp, err := base.RoundTrip(attempt)
+		if err != nil {
+			return nil, err
+		}
+		if retry >= t.Backoff.MaxRetries || !isThrottled(resp) {
+			return resp, nil
+		}
+
+		delay := t.Backoff.Delay(retry)
+		if after, ok := retryAfter(resp); ok && after > delay {
+			delay = after
+		}
+		_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 1<<20))
+		_ = resp.Body.Close()
+
+		if err := Sleep(req.Context(), delay); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// isThrottled reports whether a response asks for the request to be retried later. Some APIs report rate limits as
+// forbidden, so the reason is read from the body, which is then restored for the caller.
+func isThrottled(resp *http.Response) bool {
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests, http.StatusServiceUnavailable, http.StatusBadGateway, http.StatusGatewayTimeout:
+		return true
+	case http.StatusForbidden:
+		data, _ := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+		_ = resp.Body.Close()
+		resp.Body = io.NopCloser(bytes.NewReader(data))
+		reason := bytes.ToLower(data)
+		return bytes.Contains(reason, []byte("ratelimitexceeded")) || bytes.Contains(reason, []byte("rate_limit_exceeded"))
+	}
+	return false
+}
+
+// retryAfter reads the Retry-After header of a response, given either in seconds or as a date
+func retryAfter(resp *http.Response) (time.Duration, bool) {
+	value := strings.TrimSpace(resp.Header.Get("Retry-After"))
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		return time.Duration(seconds) * time.Second, true
+	}
+	if at, err := http.ParseTime(value); err == nil {
+		return time.Until(at), true
+	}
+	return 0, false
+}
@@ -2,13 +2,21 @@ package cloudformation
 
 import (
 	"github.com/aquasecurity/defsec/internal/adapters/cloudformation/aws"
+	"github.com/aquasecurity/defsec/pkg/providers"
 	"github.com/aquasecurity/defsec/pkg/scanners/cloudformation/parser"
 	"github.com/aquasecurity/defsec/pkg/state"
 )
 
 // Adapt ...
 func Adapt(cfFile parser.FileContext) *state.State {
-	return &state.State{
-		AWS: aws.Adapt(cfFile),
+	return AdaptServices(cfFile, state.Selection{})
+}
+
+// AdaptServices adapts only the services included in the selection, leaving the rest of the state empty
+func AdaptServices(cfFile parser.FileContext, selection state.Selection) *state.State {
+	var s state.State
+	if selection.IncludesProvider(providers.AWSProvider) {
+		s.AWS = aws.AdaptServices(cfFile, selection)
 	}
+	return &s
 }
@@ -32,43 +32,55 @@ import (
 	"github.com/aquasecurity/defsec/internal/adapters/cloudformation/aws/sqs"
 	"github.com/aquasecurity/defsec/internal/adapters/cloudformation/aws/ssm"
 	"github.com/aquasecurity/defsec/internal/adapters/cloudformation/aws/workspaces"
+	"github.com/aquasecurity/defsec/pkg/providers"
 	"github.com/aquasecurity/defsec/pkg/providers/aws"
 	"github.com/aquasecurity/defsec/pkg/scanners/cloudformation/parser"
+	"github.com/aquasecurity/defsec/pkg/state"
 )
 
 // Adapt ...
 func Adapt(cfFile parser.FileContext) aws.AWS {
-	return aws.AWS{
-		APIGateway:    apigateway.Adapt(cfFile),
-		Athena:        athena.Adapt(cfFile),
-		Cloudfront:    cloudfront.Adapt(cfFile),
-		CloudTrail:    cloudtrail.Adapt(cfFile),
-		CloudWatch:    cloudwatch.Adapt(cfFile),
-		CodeBuild:     codebuild.Adapt(cfFile),
-		Config:        config.Adapt(cfFile),
-		DocumentDB:    documentdb.Adapt(cfFile),
-		DynamoDB:      dynamodb.Adapt(cfFile),
-		EC2:           ec2.Adapt(cfFile),
-		ECR:           ecr.Adapt(cfFile),
-		ECS:           ecs.Adapt(cfFile),
-		EFS:           efs.Adapt(cfFile),
-		IAM:           iam.Adapt(cfFile),
-		EKS:           eks.Adapt(cfFile),
-		ElastiCache:   elasticache.Adapt(cfFile),
-		Elasticsearch: elasticsearch.Adapt(cfFile),
-		ELB:           elb.Adapt(cfFile),
-		MSK:           msk.Adapt(cfFile),
-		MQ:            mq.Adapt(cfFile),
-		Kinesis:       kinesis.Adapt(cfFile),
-		Lambda:        lambda.Adapt(cfFile),
-		Neptune:       neptune.Adapt(cfFile),
-		RDS:           rds.Adapt(cfFile),
-		Redshift:      redshift.Adapt(cfFile),
-		S3:            s3.Adapt(cfFile),
-		SAM:           sam.Adapt(cfFile),
-		SNS:           sns.Adapt(cfFile),
-		SQS:           sqs.Adapt(cfFile),
-		SSM:           ssm.Adapt(cfFile),
-		WorkSpaces:    workspaces.Adapt(cfFile),
+	return AdaptServices(cfFile, state.Selection{})
+}
+
+// AdaptServices adapts only the services included in the selection
+func AdaptServices(cfFile parser.FileContext, selection state.Selection) aws.AWS {
+	var a aws.AWS
+	adapt := func(service string, f func()) {
+		if selection.Includes(providers.AWSProvider, service) {
+			f()
+		}
 	}
+	adapt("apigateway", func() { a.APIGateway = apigateway.Adapt(cfFile) })
+	adapt("athena", func() { a.Athena = athena.Adapt(cfFile) })
+	adapt("cloudfront", func() { a.Cloudfront = cloudfront.Adapt(cfFile) })
+	adapt("cloudtrail", func() { a.CloudTrail = cloudtrail.Adapt(cfFile) })
+	adapt("cloudwatch", func() { a.CloudWatch = cloudwatch.Adapt(cfFile) })
+	adapt("codebuild", func() { a.CodeBuild = codebuild.Adapt(cfFile) })
+	adapt("config", func() { a.Config = config.Adapt(cfFile) })
+	adapt("documentdb", func() { a.DocumentDB = documentdb.Adapt(cfFile) })
+	adapt("dynamodb", func() { a.DynamoDB = dynamodb.Adapt(cfFile) })
+	adapt("ec2", func() { a.EC2 = ec2.Adapt(cfFile) })
+	adapt("ecr", func() { a.ECR = ecr.Adapt(cfFile) })
+	adapt("ecs", func() { a.ECS = ecs.Adapt(cfFile) })
+	adapt("efs", func() { a.EFS = efs.Adapt(cfFile) })
+	adapt("iam", func() { a.IAM = iam.Adapt(cfFile) })
+	adapt("eks", func() { a.EKS = eks.Adapt(cfFile) })
+	adapt("elasticache", func() { a.ElastiCache = elasticache.Adapt(cfFile) })
+	adapt("elasticsearch", func() { a.Elasticsearch = elasticsearch.Adapt(cfFile) })
+	adapt("elb", func() { a.ELB = elb.Adapt(cfFile) })
+	adapt("msk", func() { a.MSK = msk.Adapt(cfFile) })
+	adapt("mq", func() { a.MQ = mq.Adapt(cfFile) })
+	adapt("kinesis", func() { a.Kinesis = kinesis.Adapt(cfFile) })
+	adapt("lambda", func() { a.Lambda = lambda.Adapt(cfFile) })
+	adapt("neptune", func() { a.Neptune = neptune.Adapt(cfFile) })
+	adapt("rds", func() { a.RDS = rds.Adapt(cfFile) })
+	adapt("redshift", func() { a.Redshift = redshift.Adapt(cfFile) })
+	adapt("s3", func() { a.S3 = s3.Adapt(cfFile) })
+	adapt("sam", func() { a.SAM = sam.Adapt(cfFile) })
+	adapt("sns", func() { a.SNS = sns.Adapt(cfFile) })
+	adapt("sqs", func() { a.SQS = sqs.Adapt(cfFile) })
+	adapt("ssm", func() { a.SSM = ssm.Adapt(cfFile) })
+	adapt("workspaces", func() { a.WorkSpaces = workspaces.Adapt(cfFile) })
+	return a
 }
@@ -10,20 +10,45 @@ import (
 	"github.com/aquasecurity/defsec/internal/adapters/terraform/kubernetes"
 	"github.com/aquasecurity/defsec/internal/adapters/terraform/openstack"
 	"github.com/aquasecurity/defsec/internal/adapters/terraform/oracle"
+	"github.com/aquasecurity/defsec/pkg/providers"
 	"github.com/aquasecurity/defsec/pkg/state"
 	"github.com/aquasecurity/defsec/pkg/terraform"
 )
 
 func Adapt(modules terraform.Modules) *state.State {
-	return &state.State{
-		AWS:          aws.Adapt(modules),
-		Azure:        azure.Adapt(modules),
-		CloudStack:   cloudstack.Adapt(modules),
-		DigitalOcean: digitalocean.Adapt(modules),
-		GitHub:       github.Adapt(modules),
-		Google:       google.Adapt(modules),
-		Kubernetes:   kubernetes.Adapt(modules),
-		OpenStack:    openstack.Adapt(modules),
-		Oracle:       oracle.Adapt(modules),
+	return AdaptServices(modules, state.Selection{})
+}
+
+// AdaptServices adapts only the services included in the selection, leaving the rest of the state empty. CloudStack,
+// Kubernetes and Oracle have a single service each, so are adapted whole if any of their services is selected.
+func AdaptServices(modules terraform.Modules, selection state.Selection) *state.State {
+	var s state.State
+	if selection.IncludesProvider(providers.AWSProvider) {
+		s.AWS = aws.AdaptServices(modules, selection)
+	}
+	if selection.IncludesProvider(providers.AzureProvider) {
+		s.Azure = azure.AdaptServices(modules, selection)
+	}
+	if selection.IncludesProvider(providers.CloudStackProvider) {
+		s.CloudStack = cloudstack.Adapt(modules)
+	}
+	if selection.IncludesProvider(providers.DigitalOceanProvider) {
+		s.DigitalOcean = digitalocean.AdaptServices(modules, selection)
+	}
+	if selection.IncludesProvider(providers.GitHubProvider) {
+		s.GitHub = github.AdaptServices(modules, selection)
+	}
+	if selection.IncludesProvider(providers.GoogleProvider) {
+		s.Google = google.AdaptServices(modules, selection)
+	}
+	if selection.IncludesProvider(providers.KubernetesProvider) {
+		s.Kubernetes = kubernetes.Adapt(modules)
+	}
+	if selection.IncludesProvider(providers.OpenStackProvider) {
+		s.OpenStack = openstack.AdaptServices(modules, selection)
+	}
+	if selection.IncludesProvider(providers.OracleProvider) {
+		s.Oracle = oracle.Adapt(modules)
 	}
+	return &s
 }
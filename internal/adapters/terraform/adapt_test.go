@@ -0,0 +1,87 @@
+package terraform
+
+import (
+	"testing"
+
+	"github.com/aquasecurity/defsec/internal/adapters/terraform/tftestutil"
+	"github.com/aquasecurity/defsec/pkg/providers"
+	"github.com/aquasecurity/defsec/pkg/state"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_AdaptServices(t *testing.T) {
+	modules := tftestutil.CreateModulesFromSource(t, `
+resource "aws_s3_bucket" "example" {
+	bucket = "bucketname"
+}
+
+resource "aws_sqs_queue" "example" {
+	name = "queue"
+}
+
+resource "google_storage_bucket" "example" {
+	name = "bucket"
+}
+`, ".tf")
+
+	selection := state.NewSelection()
+	selection.Add(providers.AWSProvider, "s3")
+
+	adapted := AdaptServices(modules, selection)
+	assert.Len(t, adapted.AWS.S3.Buckets, 1)
+	assert.Len(t, adapted.AWS.SQS.Queues, 0)
+	assert.Len(t, adapted.Google.Storage.Buckets, 0)
+
+	adapted = Adapt(modules)
+	assert.Len(t, adapted.AWS.S3.Buckets, 1)
+	assert.Len(t, adapted.AWS.SQS.Queues, 1)
+	assert.Len(t, adapted.Google.Storage.Buckets, 1)
+}
+
+func Test_AdaptServicesWithinProvider(t *testing.T) {
+	modules := tftestutil.CreateModulesFromSource(t, `
+resource "github_repository" "example" {
+	name = "repo"
+}
+
+resource "github_actions_environment_secret" "example" {
+	secret_name     = "secret"
+	plaintext_value = "plaintext"
+}
+
+resource "digitalocean_droplet" "example" {
+	name = "droplet"
+}
+
+resource "digitalocean_spaces_bucket" "example" {
+	name = "bucket"
+}
+
+resource "openstack_compute_instance_v2" "example" {
+	name = "instance"
+}
+
+resource "openstack_networking_secgroup_v2" "example" {
+	name = "group"
+}
+`, ".tf")
+
+	selection := state.NewSelection()
+	selection.Add(providers.GitHubProvider, "actions")
+	selection.Add(providers.DigitalOceanProvider, "spaces")
+	selection.Add(providers.OpenStackProvider, "networking")
+
+	adapted := AdaptServices(modules, selection)
+	assert.Len(t, adapted.GitHub.Repositories, 0)
+	assert.Len(t, adapted.GitHub.EnvironmentSecrets, 1)
+	assert.Len(t, adapted.DigitalOcean.Compute.Droplets, 0)
+	assert.Len(t, adapted.DigitalOcean.Spaces.Buckets, 1)
+	assert.Len(t, adapted.OpenStack.Compute.Instances, 0)
+	assert.Len(t, adapted.OpenStack.Networking.SecurityGroups, 1)
+
+	adapted = Adapt(modules)
+	assert.Len(t, adapted.GitHub.Repositories, 1)
+	assert.Len(t, adapted.DigitalOcean.Compute.Droplets, 1)
+	assert.Len(t, adapted.OpenStack.Compute.Instances, 1)
+}
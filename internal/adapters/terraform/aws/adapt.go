@@ -33,43 +33,55 @@ import (
 	"github.com/aquasecurity/defsec/internal/adapters/terraform/aws/sqs"
 	"github.com/aquasecurity/defsec/internal/adapters/terraform/aws/ssm"
 	"github.com/aquasecurity/defsec/internal/adapters/terraform/aws/workspaces"
+	"github.com/aquasecurity/defsec/pkg/providers"
 	"github.com/aquasecurity/defsec/pkg/providers/aws"
+	"github.com/aquasecurity/defsec/pkg/state"
 	"github.com/aquasecurity/defsec/pkg/terraform"
 )
 
 func Adapt(modules terraform.Modules) aws.AWS {
-	return aws.AWS{
-		APIGateway:    apigateway.Adapt(modules),
-		Athena:        athena.Adapt(modules),
-		Cloudfront:    cloudfront.Adapt(modules),
-		CloudTrail:    cloudtrail.Adapt(modules),
-		CloudWatch:    cloudwatch.Adapt(modules),
-		CodeBuild:     codebuild.Adapt(modules),
-		Config:        config.Adapt(modules),
-		DocumentDB:    documentdb.Adapt(modules),
-		DynamoDB:      dynamodb.Adapt(modules),
-		EC2:           ec2.Adapt(modules),
-		ECR:           ecr.Adapt(modules),
-		ECS:           ecs.Adapt(modules),
-		EFS:           efs.Adapt(modules),
-		EKS:           eks.Adapt(modules),
-		ElastiCache:   elasticache.Adapt(modules),
-		Elasticsearch: elasticsearch.Adapt(modules),
-		ELB:           elb.Adapt(modules),
-		EMR:           emr.Adapt(modules),
-		IAM:           iam.Adapt(modules),
-		Kinesis:       kinesis.Adapt(modules),
-		KMS:           kms.Adapt(modules),
-		Lambda:        lambda.Adapt(modules),
-		MQ:            mq.Adapt(modules),
-		MSK:           msk.Adapt(modules),
-		Neptune:       neptune.Adapt(modules),
-		RDS:           rds.Adapt(modules),
-		Redshift:      redshift.Adapt(modules),
-		S3:            s3.Adapt(modules),
-		SNS:           sns.Adapt(modules),
-		SQS:           sqs.Adapt(modules),
-		SSM:           ssm.Adapt(modules),
-		WorkSpaces:    workspaces.Adapt(modules),
+	return AdaptServices(modules, state.Selection{})
+}
+
+// AdaptServices adapts only the services included in the selection
+func AdaptServices(modules terraform.Modules, selection state.Selection) aws.AWS {
+	var a aws.AWS
+	adapt := func(service string, f func()) {
+		if selection.Includes(providers.AWSProvider, service) {
+			f()
+		}
 	}
+	adapt("apigateway", func() { a.APIGateway = apigateway.Adapt(modules) })
+	adapt("athena", func() { a.Athena = athena.Adapt(modules) })
+	adapt("cloudfront", func() { a.Cloudfront = cloudfront.Adapt(modules) })
+	adapt("cloudtrail", func() { a.CloudTrail = cloudtrail.Adapt(modules) })
+	adapt("cloudwatch", func() { a.CloudWatch = cloudwatch.Adapt(modules) })
+	adapt("codebuild", func() { a.CodeBuild = codebuild.Adapt(modules) })
+	adapt("config", func() { a.Config = config.Adapt(modules) })
+	adapt("documentdb", func() { a.DocumentDB = documentdb.Adapt(modules) })
+	adapt("dynamodb", func() { a.DynamoDB = dynamodb.Adapt(modules) })
+	adapt("ec2", func() { a.EC2 = ec2.Adapt(modules) })
+	adapt("ecr", func() { a.ECR = ecr.Adapt(modules) })
+	adapt("ecs", func() { a.ECS = ecs.Adapt(modules) })
+	adapt("efs", func() { a.EFS = efs.Adapt(modules) })
+	adapt("eks", func() { a.EKS = eks.Adapt(modules) })
+	adapt("elasticache", func() { a.ElastiCache = elasticache.Adapt(modules) })
+	adapt("elasticsearch", func() { a.Elasticsearch = elasticsearch.Adapt(modules) })
+	adapt("elb", func() { a.ELB = elb.Adapt(modules) })
+	adapt("emr", func() { a.EMR = emr.Adapt(modules) })
+	adapt("iam", func() { a.IAM = iam.Adapt(modules) })
+	adapt("kinesis", func() { a.Kinesis = kinesis.Adapt(modules) })
+	adapt("kms", func() { a.KMS = kms.Adapt(modules) })
+	adapt("lambda", func() { a.Lambda = lambda.Adapt(modules) })
+	adapt("mq", func() { a.MQ = mq.Adapt(modules) })
+	adapt("msk", func() { a.MSK = msk.Adapt(modules) })
+	adapt("neptune", func() { a.Neptune = neptune.Adapt(modules) })
+	adapt("rds", func() { a.RDS = rds.Adapt(modules) })
+	adapt("redshift", func() { a.Redshift = redshift.Adapt(modules) })
+	adapt("s3", func() { a.S3 = s3.Adapt(modules) })
+	adapt("sns", func() { a.SNS = sns.Adapt(modules) })
+	adapt("sqs", func() { a.SQS = sqs.Adapt(modules) })
+	adapt("ssm", func() { a.SSM = ssm.Adapt(modules) })
+	adapt("workspaces", func() { a.WorkSpaces = workspaces.Adapt(modules) })
+	return a
 }
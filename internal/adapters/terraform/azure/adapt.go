@@ -14,24 +14,36 @@ import (
 	"github.com/aquasecurity/defsec/internal/adapters/terraform/azure/securitycenter"
 	"github.com/aquasecurity/defsec/internal/adapters/terraform/azure/storage"
 	"github.com/aquasecurity/defsec/internal/adapters/terraform/azure/synapse"
+	"github.com/aquasecurity/defsec/pkg/providers"
 	"github.com/aquasecurity/defsec/pkg/providers/azure"
+	"github.com/aquasecurity/defsec/pkg/state"
 	"github.com/aquasecurity/defsec/pkg/terraform"
 )
 
 func Adapt(modules terraform.Modules) azure.Azure {
-	return azure.Azure{
-		AppService:     appservice.Adapt(modules),
-		Authorization:  authorization.Adapt(modules),
-		Compute:        compute.Adapt(modules),
-		Container:      container.Adapt(modules),
-		Database:       database.Adapt(modules),
-		DataFactory:    datafactory.Adapt(modules),
-		DataLake:       datalake.Adapt(modules),
-		KeyVault:       keyvault.Adapt(modules),
-		Monitor:        monitor.Adapt(modules),
-		Network:        network.Adapt(modules),
-		SecurityCenter: securitycenter.Adapt(modules),
-		Storage:        storage.Adapt(modules),
-		Synapse:        synapse.Adapt(modules),
+	return AdaptServices(modules, state.Selection{})
+}
+
+// AdaptServices adapts only the services included in the selection
+func AdaptServices(modules terraform.Modules, selection state.Selection) azure.Azure {
+	var a azure.Azure
+	adapt := func(service string, f func()) {
+		if selection.Includes(providers.AzureProvider, service) {
+			f()
+		}
 	}
+	adapt("appservice", func() { a.AppService = appservice.Adapt(modules) })
+	adapt("authorization", func() { a.Authorization = authorization.Adapt(modules) })
+	adapt("compute", func() { a.Compute = compute.Adapt(modules) })
+	adapt("container", func() { a.Container = container.Adapt(modules) })
+	adapt("database", func() { a.Database = database.Adapt(modules) })
+	adapt("datafactory", func() { a.DataFactory = datafactory.Adapt(modules) })
+	adapt("datalake", func() { a.DataLake = datalake.Adapt(modules) })
+	adapt("keyvault", func() { a.KeyVault = keyvault.Adapt(modules) })
+	adapt("monitor", func() { a.Monitor = monitor.Adapt(modules) })
+	adapt("network", func() { a.Network = network.Adapt(modules) })
+	adapt("securitycenter", func() { a.SecurityCenter = securitycenter.Adapt(modules) })
+	adapt("storage", func() { a.Storage = storage.Adapt(modules) })
+	adapt("synapse", func() { a.Synapse = synapse.Adapt(modules) })
+	return a
 }
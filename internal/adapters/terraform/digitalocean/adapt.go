@@ -3,13 +3,25 @@ package digitalocean
 import (
 	"github.com/aquasecurity/defsec/internal/adapters/terraform/digitalocean/compute"
 	"github.com/aquasecurity/defsec/internal/adapters/terraform/digitalocean/spaces"
+	"github.com/aquasecurity/defsec/pkg/providers"
 	"github.com/aquasecurity/defsec/pkg/providers/digitalocean"
+	"github.com/aquasecurity/defsec/pkg/state"
 	"github.com/aquasecurity/defsec/pkg/terraform"
 )
 
 func Adapt(modules terraform.Modules) digitalocean.DigitalOcean {
-	return digitalocean.DigitalOcean{
-		Compute: compute.Adapt(modules),
-		Spaces:  spaces.Adapt(modules),
+	return AdaptServices(modules, state.Selection{})
+}
+
+// AdaptServices adapts only the services included in the selection
+func AdaptServices(modules terraform.Modules, selection state.Selection) digitalocean.DigitalOcean {
+	var d digitalocean.DigitalOcean
+	adapt := func(service string, f func()) {
+		if selection.Includes(providers.DigitalOceanProvider, service) {
+			f()
+		}
 	}
+	adapt("compute", func() { d.Compute = compute.Adapt(modules) })
+	adapt("spaces", func() { d.Spaces = spaces.Adapt(modules) })
+	return d
 }
@@ -4,14 +4,29 @@ import (
 	"github.com/aquasecurity/defsec/internal/adapters/terraform/github/branch_protections"
 	"github.com/aquasecurity/defsec/internal/adapters/terraform/github/repositories"
 	"github.com/aquasecurity/defsec/internal/adapters/terraform/github/secrets"
+	"github.com/aquasecurity/defsec/pkg/providers"
 	"github.com/aquasecurity/defsec/pkg/providers/github"
+	"github.com/aquasecurity/defsec/pkg/state"
 	"github.com/aquasecurity/defsec/pkg/terraform"
 )
 
 func Adapt(modules terraform.Modules) github.GitHub {
-	return github.GitHub{
-		Repositories:       repositories.Adapt(modules),
-		EnvironmentSecrets: secrets.Adapt(modules),
-		BranchProtections:  branch_protections.Adapt(modules),
+	return AdaptServices(modules, state.Selection{})
+}
+
+// AdaptServices adapts only the services included in the selection
+func AdaptServices(modules terraform.Modules, selection state.Selection) github.GitHub {
+	var g github.GitHub
+	adapt := func(service string, f func()) {
+		if selection.Includes(providers.GitHubProvider, service) {
+			f()
+		}
+	}
+	adapt("repositories", func() { g.Repositories = repositories.Adapt(modules) })
+	// environment secrets are checked by the rules of the actions service
+	if selection.Includes(providers.GitHubProvider, "actions") || selection.Includes(providers.GitHubProvider, "environment_secrets") {
+		g.EnvironmentSecrets = secrets.Adapt(modules)
 	}
+	adapt("branch_protections", func() { g.BranchProtections = branch_protections.Adapt(modules) })
+	return g
 }
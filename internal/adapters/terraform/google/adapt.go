@@ -9,19 +9,31 @@ import (
 	"github.com/aquasecurity/defsec/internal/adapters/terraform/google/kms"
 	"github.com/aquasecurity/defsec/internal/adapters/terraform/google/sql"
 	"github.com/aquasecurity/defsec/internal/adapters/terraform/google/storage"
+	"github.com/aquasecurity/defsec/pkg/providers"
 	"github.com/aquasecurity/defsec/pkg/providers/google"
+	"github.com/aquasecurity/defsec/pkg/state"
 	"github.com/aquasecurity/defsec/pkg/terraform"
 )
 
 func Adapt(modules terraform.Modules) google.Google {
-	return google.Google{
-		BigQuery: bigquery.Adapt(modules),
-		Compute:  compute.Adapt(modules),
-		DNS:      dns.Adapt(modules),
-		GKE:      gke.Adapt(modules),
-		KMS:      kms.Adapt(modules),
-		IAM:      iam.Adapt(modules),
-		SQL:      sql.Adapt(modules),
-		Storage:  storage.Adapt(modules),
+	return AdaptServices(modules, state.Selection{})
+}
+
+// AdaptServices adapts only the services included in the selection
+func AdaptServices(modules terraform.Modules, selection state.Selection) google.Google {
+	var a google.Google
+	adapt := func(service string, f func()) {
+		if selection.Includes(providers.GoogleProvider, service) {
+			f()
+		}
 	}
+	adapt("bigquery", func() { a.BigQuery = bigquery.Adapt(modules) })
+	adapt("compute", func() { a.Compute = compute.Adapt(modules) })
+	adapt("dns", func() { a.DNS = dns.Adapt(modules) })
+	adapt("gke", func() { a.GKE = gke.Adapt(modules) })
+	adapt("kms", func() { a.KMS = kms.Adapt(modules) })
+	adapt("iam", func() { a.IAM = iam.Adapt(modules) })
+	adapt("sql", func() { a.SQL = sql.Adapt(modules) })
+	adapt("storage", func() { a.Storage = storage.Adapt(modules) })
+	return a
 }
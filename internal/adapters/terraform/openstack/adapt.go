@@ -1,15 +1,27 @@
 package openstack
 
 import (
+	"github.com/aquasecurity/defsec/pkg/providers"
 	"github.com/aquasecurity/defsec/pkg/providers/openstack"
+	"github.com/aquasecurity/defsec/pkg/state"
 	"github.com/aquasecurity/defsec/pkg/terraform"
 )
 
 func Adapt(modules terraform.Modules) openstack.OpenStack {
-	return openstack.OpenStack{
-		Compute:    adaptCompute(modules),
-		Networking: adaptNetworking(modules),
+	return AdaptServices(modules, state.Selection{})
+}
+
+// AdaptServices adapts only the services included in the selection
+func AdaptServices(modules terraform.Modules, selection state.Selection) openstack.OpenStack {
+	var o openstack.OpenStack
+	adapt := func(service string, f func()) {
+		if selection.Includes(providers.OpenStackProvider, service) {
+			f()
+		}
 	}
+	adapt("compute", func() { o.Compute = adaptCompute(modules) })
+	adapt("networking", func() { o.Networking = adaptNetworking(modules) })
+	return o
 }
 
 func adaptCompute(modules terraform.Modules) openstack.Compute {
@@ -0,0 +1,36 @@
+package rules
+
+import (
+	"github.com/aquasecurity/defsec/pkg/scan"
+	"github.com/aquasecurity/defsec/pkg/severity"
+)
+
+// FilterByMinimumSeverity returns the rules whose severity is at least threshold. All rules are returned when
+// threshold is severity.None.
+func FilterByMinimumSeverity(registered []RegisteredRule, threshold severity.Severity) []RegisteredRule {
+	if threshold == severity.None {
+		return registered
+	}
+	var filtered []RegisteredRule
+	for _, rule := range registered {
+		if rule.rule.Severity.AtLeast(threshold) {
+			filtered = append(filtered, rule)
+		}
+	}
+	return filtered
+}
+
+// FilterBySelection returns the rules chosen by the given selection. All rules are returned when the selection
+// is empty.
+func FilterBySelection(registered []RegisteredRule, selection scan.RuleSelection) []RegisteredRule {
+	if selection.IsEmpty() {
+		return registered
+	}
+	var filtered []RegisteredRule
+	for _, rule := range registered {
+		if selection.Selects(rule.rule) {
+			filtered = append(filtered, rule)
+		}
+	}
+	return filtered
+}
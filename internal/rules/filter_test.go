@@ -0,0 +1,48 @@
+package rules
+
+import (
+	"testing"
+
+	"github.com/aquasecurity/defsec/pkg/scan"
+	"github.com/aquasecurity/defsec/pkg/severity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_FilterByMinimumSeverity(t *testing.T) {
+	registered := []RegisteredRule{
+		{rule: scan.Rule{AVDID: "AVD-TEST-0001", Severity: severity.Low}},
+		{rule: scan.Rule{AVDID: "AVD-TEST-0002", Severity: severity.Medium}},
+		{rule: scan.Rule{AVDID: "AVD-TEST-0003", Severity: severity.High}},
+		{rule: scan.Rule{AVDID: "AVD-TEST-0004", Severity: severity.Critical}},
+	}
+
+	ids := func(filtered []RegisteredRule) []string {
+		var out []string
+		for _, rule := range filtered {
+			out = append(out, rule.Rule().AVDID)
+		}
+		return out
+	}
+
+	assert.Len(t, FilterByMinimumSeverity(registered, severity.None), 4)
+	assert.Equal(t, []string{"AVD-TEST-0003", "AVD-TEST-0004"}, ids(FilterByMinimumSeverity(registered, severity.High)))
+	assert.Equal(t, []string{"AVD-TEST-0004"}, ids(FilterByMinimumSeverity(registered, severity.Critical)))
+}
+
+func Test_FilterBySelection(t *testing.T) {
+	registered := []RegisteredRule{
+		{rule: scan.Rule{AVDID: "AVD-AWS-0001", Provider: "aws", Service: "s3"}},
+		{rule: scan.Rule{AVDID: "AVD-AWS-0002", Provider: "aws", Service: "ec2"}},
+		{rule: scan.Rule{AVDID: "AVD-AZU-0001", Provider: "azure", Service: "storage"}},
+	}
+
+	assert.Len(t, FilterBySelection(registered, scan.RuleSelection{}), 3)
+
+	filtered := FilterBySelection(registered, scan.RuleSelection{
+		Include: scan.RuleSet{Prefixes: []string{"AVD-AWS-"}},
+		Exclude: scan.RuleSet{Services: []string{"ec2"}},
+	})
+	require.Len(t, filtered, 1)
+	assert.Equal(t, "AVD-AWS-0001", filtered[0].Rule().AVDID)
+}
@@ -68,6 +68,7 @@ func (r *registry) register(rule scan.Rule, f scan.CheckFunc) RegisteredRule {
 	if len(rule.Frameworks) == 0 {
 		rule.Frameworks = map[framework.Framework][]string{framework.Default: nil}
 	}
+	scan.RegisterAliases(rule.AVDID, append([]string{rule.LongID()}, rule.Aliases...)...)
 	registeredRule := RegisteredRule{
 		number:    r.index,
 		rule:      rule,
@@ -160,3 +161,18 @@ func GetSpecRules(spec string) []RegisteredRule {
 
 	return GetFrameworkRules()
 }
+
+// ServiceSelection returns the set of services which must be adapted into state to evaluate the given rules
+func ServiceSelection(registered []RegisteredRule) state.Selection {
+	selection := state.NewSelection()
+	for _, rule := range registered {
+		if !rule.HasLogic() {
+			continue
+		}
+		selection.Add(rule.rule.Provider, rule.rule.Service)
+		for _, service := range rule.rule.RequiredServices {
+			selection.Add(rule.rule.Provider, service)
+		}
+	}
+	return selection
+}
@@ -0,0 +1,146 @@
+package baseline
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+
+	"github.com/aquasecurity/defsec/pkg/scan"
+	"github.com/aquasecurity/defsec/pkg/scanners/options"
+)
+
+const currentVersion = 1
+
+// Baseline is a record of known failures. Results matching a baseline entry are reported with
+// scan.StatusBaselined instead of scan.StatusFailed, so only new failures need to be addressed.
+type Baseline struct {
+	Version int     `json:"version"`
+	Entries []Entry `json:"entries"`
+	index   map[string]struct{}
+}
+
+// Entry records a single known failure. Only the fingerprint is used for matching - the other
+// fields are there to make the baseline file reviewable by humans.
+type Entry struct {
+	Fingerprint string `json:"fingerprint"`
+	RuleID      string `json:"rule_id,omitempty"`
+	Filename    string `json:"filename,omitempty"`
+	Resource    string `json:"resource,omitempty"`
+}
+
+// FromResults creates a baseline containing all failed results.
+func FromResults(results scan.Results) *Baseline {
+	b := &Baseline{
+		Version: currentVersion,
+		Entries: []Entry{},
+	}
+	seen := make(map[string]struct{})
+	for _, result := range results.GetFailed() {
+		fingerprint := result.Fingerprint()
+		if _, ok := seen[fingerprint]; ok {
+			continue
+		}
+		seen[fingerprint] = struct{}{}
+		flat := result.Flatten()
+		b.Entries = append(b.Entries, Entry{
+			Fingerprint: fingerprint,
+			RuleID:      flat.RuleID,
+			Filename:    flat.Location.Filename,
+			Resource:    flat.Resource,
+		})
+	}
+	sort.Slice(b.Entries, func(i, j int) bool {
+		if b.Entries[i].Filename != b.Entries[j].Filename {
+			return b.Entries[i].Filename < b.Entries[j].Filename
+		}
+		if b.Entries[i].RuleID != b.Entries[j].RuleID {
+			return b.Entries[i].RuleID < b.Entries[j].RuleID
+		}
+		return b.Entries[i].Fingerprint < b.Entries[j].Fingerprint
+	})
+	b.index = seen
+	return b
+}
+
+// Read parses a baseline previously written with Write.
+func Read(r io.Reader) (*Baseline, error) {
+	var b Baseline
+	if err := json.NewDecoder(r).Decode(&b); err != nil {
+		return nil, fmt.Errorf("failed to parse baseline: %w", err)
+	}
+	if b.Version > currentVersion {
+		return nil, fmt.Errorf("unsupported baseline version %d", b.Version)
+	}
+	b.buildIndex()
+	return &b, nil
+}
+
+// Load reads a baseline from the file at the given path.
+func Load(path string) (*Baseline, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = f.Close() }()
+	return Read(f)
+}
+
+// Write writes the baseline as JSON.
+func (b *Baseline) Write(w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(b)
+}
+
+// Save writes the baseline to the file at the given path, replacing any existing file.
+func (b *Baseline) Save(path string) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := b.Write(f); err != nil {
+		_ = f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// Contains reports whether the given fingerprint is recorded in the baseline.
+func (b *Baseline) Contains(fingerprint string) bool {
+	if b == nil {
+		return false
+	}
+	if b.index == nil {
+		b.buildIndex()
+	}
+	_, ok := b.index[fingerprint]
+	return ok
+}
+
+// Apply marks failed results which are recorded in the baseline as baselined. It can be used
+// directly as an options.ResultProcessor.
+func (b *Baseline) Apply(results scan.Results) scan.Results {
+	for i, result := range results {
+		if result.Status() != scan.StatusFailed {
+			continue
+		}
+		if b.Contains(result.Fingerprint()) {
+			results[i].OverrideStatus(scan.StatusBaselined)
+		}
+	}
+	return results
+}
+
+func (b *Baseline) buildIndex() {
+	b.index = make(map[string]struct{}, len(b.Entries))
+	for _, entry := range b.Entries {
+		b.index[entry.Fingerprint] = struct{}{}
+	}
+}
+
+// ScannerWithBaseline marks failures recorded in the given baseline as baselined in the results of every scan.
+func ScannerWithBaseline(b *Baseline) options.ScannerOption {
+	return options.ScannerWithResultProcessors(b.Apply)
+}
@@ -0,0 +1,52 @@
+package baseline
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/aquasecurity/defsec/pkg/scan"
+	"github.com/aquasecurity/defsec/pkg/severity"
+	defsecTypes "github.com/aquasecurity/defsec/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testResults(refs ...string) scan.Results {
+	var results scan.Results
+	for i, ref := range refs {
+		results.Add("something is wrong", defsecTypes.NewMetadata(
+			defsecTypes.NewRange("main.tf", i+1, i+1, "", nil),
+			ref,
+		))
+	}
+	results.SetRule(scan.Rule{AVDID: "AVD-TEST-0001", Severity: severity.High})
+	return results
+}
+
+func Test_BaselineRoundTrip(t *testing.T) {
+	b := FromResults(testResults("aws_s3_bucket.a", "aws_s3_bucket.b"))
+	require.Len(t, b.Entries, 2)
+
+	buffer := bytes.NewBuffer(nil)
+	require.NoError(t, b.Write(buffer))
+
+	loaded, err := Read(buffer)
+	require.NoError(t, err)
+	assert.Equal(t, b.Entries, loaded.Entries)
+}
+
+func Test_BaselineApply(t *testing.T) {
+	b := FromResults(testResults("aws_s3_bucket.a"))
+
+	results := b.Apply(testResults("aws_s3_bucket.a", "aws_s3_bucket.b"))
+	require.Len(t, results, 2)
+	assert.Equal(t, scan.StatusBaselined, results[0].Status())
+	assert.Equal(t, scan.StatusFailed, results[1].Status())
+	assert.Len(t, results.GetFailed(), 1)
+	assert.Len(t, results.GetBaselined(), 1)
+}
+
+func Test_BaselineUnsupportedVersion(t *testing.T) {
+	_, err := Read(bytes.NewBufferString(`{"version": 99, "entries": []}`))
+	assert.Error(t, err)
+}
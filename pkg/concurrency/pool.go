@@ -0,0 +1,91 @@
+package concurrency
+
+import (
+	"context"
+	"runtime"
+	"sync"
+)
+
+// Workers returns the number of workers to use for the given parallelism setting. Values below 1 mean one worker per CPU.
+func Workers(parallelism int) int {
+	if parallelism < 1 {
+		return runtime.NumCPU()
+	}
+	return parallelism
+}
+
+// Process calls fn for every item using at most the given number of workers. Outputs are returned in the same
+// order as the items they were produced from. If fn returns an error, or the context is cancelled, no further
+// items are processed and the first error is returned.
+func Process[T any, S any](ctx context.Context, items []T, workers int, fn func(context.Context, T) (S, error)) ([]S, error) {
+	outputs := make([]S, len(items))
+
+	if workers <= 1 || len(items) <= 1 {
+		for i, item := range items {
+			if err := ctx.Err(); err != nil {
+				return nil, err
+			}
+			out, err := fn(ctx, item)
+			if err != nil {
+				return nil, err
+			}
+			outputs[i] = out
+		}
+		return outputs, nil
+	}
+
+	if workers > len(items) {
+		workers = len(items)
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var firstErr error
+	var errOnce sync.Once
+	fail := func(err error) {
+		errOnce.Do(func() {
+			firstErr = err
+			cancel()
+		})
+	}
+
+	indices := make(chan int)
+	wg := sync.WaitGroup{}
+	wg.Add(workers)
+	for w := 0; w < workers; w++ {
+		go func() {
+			defer wg.Done()
+			for i := range indices {
+				out, err := fn(ctx, items[i])
+				if err != nil {
+					fail(err)
+					continue
+				}
+				outputs[i] = out
+			}
+		}()
+	}
+
+	func() {
+		defer close(indices)
+		for i := range items {
+			if err := ctx.Err(); err != nil {
+				fail(err)
+				return
+			}
+			select {
+			case <-ctx.Done():
+				fail(ctx.Err())
+				return
+			case indices <- i:
+			}
+		}
+	}()
+	wg.Wait()
+
+	if firstErr != nil {
+		return nil, firstErr
+	}
+	return outputs, nil
+}
@@ -0,0 +1,57 @@
+package concurrency
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ProcessPreservesOrder(t *testing.T) {
+	var items []int
+	for i := 0; i < 100; i++ {
+		items = append(items, i)
+	}
+
+	var active, peak int32
+	outputs, err := Process(context.Background(), items, 4, func(_ context.Context, i int) (string, error) {
+		current := atomic.AddInt32(&active, 1)
+		defer atomic.AddInt32(&active, -1)
+		for {
+			seen := atomic.LoadInt32(&peak)
+			if current <= seen || atomic.CompareAndSwapInt32(&peak, seen, current) {
+				break
+			}
+		}
+		return fmt.Sprintf("item-%d", i), nil
+	})
+	require.NoError(t, err)
+	require.Len(t, outputs, 100)
+	for i, output := range outputs {
+		assert.Equal(t, fmt.Sprintf("item-%d", i), output)
+	}
+	assert.LessOrEqual(t, atomic.LoadInt32(&peak), int32(4))
+}
+
+func Test_ProcessReturnsFirstError(t *testing.T) {
+	items := []int{1, 2, 3, 4, 5, 6, 7, 8}
+	_, err := Process(context.Background(), items, 3, func(_ context.Context, i int) (int, error) {
+		if i == 5 {
+			return 0, fmt.Errorf("failed on %d", i)
+		}
+		return i, nil
+	})
+	assert.EqualError(t, err, "failed on 5")
+}
+
+func Test_ProcessHonoursCancellation(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err := Process(ctx, []int{1, 2, 3}, 2, func(_ context.Context, i int) (int, error) {
+		return i, nil
+	})
+	assert.ErrorIs(t, err, context.Canceled)
+}
@@ -10,7 +10,23 @@ const (
 	OneAtATimeStrategy
 )
 
+// limitedStrategy is added to the process count of strategies created by Limit, keeping them clear of the named
+// strategies
+const limitedStrategy Strategy = 1 << 16
+
+// Limit returns a strategy which uses the given number of processes, e.g. to reduce the rate of requests made to a
+// cloud API. Counts below 1 use the default strategy.
+func Limit(processes int) Strategy {
+	if processes < 1 {
+		return DefaultStrategy
+	}
+	return limitedStrategy + Strategy(processes)
+}
+
 func getProcessCount(strategy Strategy) int {
+	if strategy > limitedStrategy {
+		return int(strategy - limitedStrategy)
+	}
 	switch strategy {
 	case OneAtATimeStrategy:
 		return 1
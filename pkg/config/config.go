@@ -0,0 +1,302 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/aquasecurity/defsec/pkg/framework"
+	"github.com/aquasecurity/defsec/pkg/ignore"
+	"github.com/aquasecurity/defsec/pkg/overrides"
+	"github.com/aquasecurity/defsec/pkg/scan"
+	"github.com/aquasecurity/defsec/pkg/scanners/options"
+	"github.com/aquasecurity/defsec/pkg/severity"
+)
+
+// FileName is the name of the configuration file looked for at the root of a scan
+const FileName = "defsec.yaml"
+
+// Config is the declarative configuration of a scan, shared by the defsec CLI and anything embedding the scanners.
+// Every field is optional, and an empty Config produces no options:
+//
+//	policies:
+//	  dirs: [policies]
+//	  namespaces: [custom]
+//	  data-dirs: [data]
+//	  embedded: true
+//	severity:
+//	  minimum: medium       # checks below this severity are not evaluated
+//	  reporting: high       # results below this severity are discarded
+//	  overrides:
+//	    - rule: AVD-AWS-0086
+//	      severity: low
+//	frameworks: [default, cis-aws-1.4]
+//	rules:
+//	  include:
+//	    services: [aws/s3]
+//	  exclude:
+//	    ids: [AVD-AWS-0090]
+//	paths:
+//	  exclude: [vendor, "**/testdata/**"]
+//	  follow-symlinks: true # walk into symlinked directories, skipping any which loop
+//	  within-root: true     # skip symlinks which point outside the scanned directory
+//	ignores:
+//	  - rule:aws-s3-enable-versioning path:modules/legacy/**
+//	scanners:
+//	  helm:
+//	    enabled: false
+//	parsing:
+//	  lenient: true         # accept JSON with comments, and read yaml values such as yes/no as YAML 1.1 does
+type Config struct {
+	Policies   Policies                   `yaml:"policies"`
+	Severity   Severity                   `yaml:"severity"`
+	Frameworks []framework.Framework      `yaml:"frameworks"`
+	Rules      Rules                      `yaml:"rules"`
+	Paths      Paths                      `yaml:"paths"`
+	Ignores    []string                   `yaml:"ignores"`
+	Scanners   map[string]ScannerSettings `yaml:"scanners"`
+	Parsing    Parsing                    `yaml:"parsing"`
+
+	ignoreFile *ignore.File
+}
+
+type Policies struct {
+	Dirs       []string `yaml:"dirs"`
+	Namespaces []string `yaml:"namespaces"`
+	DataDirs   []string `yaml:"data-dirs"`
+	// Embedded enables the policies built into defsec, and is left unchanged if not set
+	Embedded *bool `yaml:"embedded"`
+}
+
+type Severity struct {
+	Minimum   severity.Severity            `yaml:"minimum"`
+	Reporting severity.Severity            `yaml:"reporting"`
+	Overrides []overrides.SeverityOverride `yaml:"overrides"`
+}
+
+// Rules selects the checks which are evaluated, see scan.RuleSelection
+type Rules struct {
+	Include RuleSet `yaml:"include"`
+	Exclude RuleSet `yaml:"exclude"`
+}
+
+type RuleSet struct {
+	IDs      []string `yaml:"ids"`
+	Prefixes []string `yaml:"prefixes"`
+	Services []string `yaml:"services"`
+}
+
+// Paths selects the files walked by filesystem scans, see options.PathFilter and extrafs.SymlinkOptions
+type Paths struct {
+	Include        []string `yaml:"include"`
+	Exclude        []string `yaml:"exclude"`
+	FollowSymlinks bool     `yaml:"follow-symlinks"`
+	WithinRoot     bool     `yaml:"within-root"`
+}
+
+// Parsing configures how files are read
+type Parsing struct {
+	Lenient bool `yaml:"lenient"`
+}
+
+// ScannerSettings configures a single scanner, keyed by one of the names in Scanners
+type ScannerSettings struct {
+	Enabled *bool `yaml:"enabled"`
+}
+
+// Scanners are the names which can be used as keys of Config.Scanners
+var Scanners = []string{
+	"terraform",
+	"cloudformation",
+	"dockerfile",
+	"kubernetes",
+	"json",
+	"yaml",
+	"toml",
+	"helm",
+	"arm",
+	"aws",
+}
+
+// Read parses and validates a configuration file. Unknown fields are reported as errors, so that typos are not
+// silently ignored. Paths in ignores are taken to be relative to the root of the scanned filesystem.
+func Read(r io.Reader) (*Config, error) {
+	var c Config
+	decoder := yaml.NewDecoder(r)
+	decoder.KnownFields(true)
+	if err := decoder.Decode(&c); err != nil && err != io.EOF {
+		return nil, fmt.Errorf("failed to parse %s: %w", FileName, err)
+	}
+	if err := c.validate(); err != nil {
+		return nil, fmt.Errorf("invalid %s: %w", FileName, err)
+	}
+	return &c, nil
+}
+
+// Load reads the configuration file at the given path.
+func Load(path string) (*Config, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = f.Close() }()
+	return Read(f)
+}
+
+// Find reads the configuration file in the given directory of a filesystem, returning nil if there is none.
+func Find(fsys fs.FS, dir string) (*Config, error) {
+	f, err := fsys.Open(path.Join(dir, FileName))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = f.Close() }()
+	return Read(f)
+}
+
+func (c *Config) validate() error {
+	for _, field := range []struct {
+		name  string
+		value *severity.Severity
+	}{
+		{name: "severity.minimum", value: &c.Severity.Minimum},
+		{name: "severity.reporting", value: &c.Severity.Reporting},
+	} {
+		if *field.value == severity.None {
+			continue
+		}
+		sev := severity.StringToSeverity(string(*field.value))
+		if sev == severity.None {
+			return fmt.Errorf("%s: invalid severity '%s'", field.name, *field.value)
+		}
+		*field.value = sev
+	}
+
+	severityOverrides := overrides.Overrides{Severities: c.Severity.Overrides}
+	if err := severityOverrides.Validate(); err != nil {
+		return err
+	}
+
+	for name := range c.Scanners {
+		if !isScanner(name) {
+			return fmt.Errorf("scanners: unknown scanner '%s', expected one of: %s", name, strings.Join(Scanners, ", "))
+		}
+	}
+
+	c.ignoreFile = nil
+	if len(c.Ignores) > 0 {
+		ignoreFile, err := ignore.Read(strings.NewReader(strings.Join(c.Ignores, "\n")))
+		if err != nil {
+			return fmt.Errorf("ignores: %w", err)
+		}
+		c.ignoreFile = ignoreFile
+	}
+	return nil
+}
+
+func isScanner(name string) bool {
+	for _, scanner := range Scanners {
+		if strings.EqualFold(name, scanner) {
+			return true
+		}
+	}
+	return false
+}
+
+// ScannerEnabled reports whether the named scanner should be run. Scanners are enabled unless explicitly disabled.
+func (c *Config) ScannerEnabled(name string) bool {
+	if c == nil {
+		return true
+	}
+	for key, settings := range c.Scanners {
+		if strings.EqualFold(key, name) && settings.Enabled != nil {
+			return *settings.Enabled
+		}
+	}
+	return true
+}
+
+// RuleSelection returns the checks selected by the rules section
+func (c *Config) RuleSelection() scan.RuleSelection {
+	return scan.RuleSelection{
+		Include: scan.RuleSet(c.Rules.Include),
+		Exclude: scan.RuleSet(c.Rules.Exclude),
+	}
+}
+
+// Options returns the scanner options which apply the configuration, returning an error if it is invalid. Options
+// given after these (e.g. from command line flags) take precedence where they set the same thing.
+func (c *Config) Options() ([]options.ScannerOption, error) {
+	if c == nil {
+		return nil, nil
+	}
+	if err := c.validate(); err != nil {
+		return nil, fmt.Errorf("invalid %s: %w", FileName, err)
+	}
+
+	var opts []options.ScannerOption
+
+	if len(c.Policies.Dirs) > 0 {
+		opts = append(opts, options.ScannerWithPolicyDirs(c.Policies.Dirs...))
+	}
+	if len(c.Policies.Namespaces) > 0 {
+		opts = append(opts, options.ScannerWithPolicyNamespaces(c.Policies.Namespaces...))
+	}
+	if len(c.Policies.DataDirs) > 0 {
+		opts = append(opts, options.ScannerWithDataDirs(c.Policies.DataDirs...))
+	}
+	if c.Policies.Embedded != nil {
+		opts = append(opts, options.ScannerWithEmbeddedPolicies(*c.Policies.Embedded))
+	}
+
+	if c.Severity.Minimum != severity.None {
+		opts = append(opts, options.ScannerWithMinimumSeverity(c.Severity.Minimum))
+	}
+	if c.Severity.Reporting != severity.None {
+		opts = append(opts, options.ScannerWithMinimumReportingSeverity(c.Severity.Reporting))
+	}
+	if len(c.Severity.Overrides) > 0 {
+		opts = append(opts, overrides.ScannerWithSeverityOverrides(&overrides.Overrides{
+			Severities: c.Severity.Overrides,
+		}))
+	}
+
+	if len(c.Frameworks) > 0 {
+		opts = append(opts, options.ScannerWithFrameworks(c.Frameworks...))
+	}
+
+	if selection := c.RuleSelection(); !selection.IsEmpty() {
+		opts = append(opts, options.ScannerWithRuleSelection(selection))
+	}
+
+	if len(c.Paths.Include) > 0 {
+		opts = append(opts, options.ScannerWithIncludePaths(c.Paths.Include...))
+	}
+	if len(c.Paths.Exclude) > 0 {
+		opts = append(opts, options.ScannerWithExcludePaths(c.Paths.Exclude...))
+	}
+	if c.Paths.FollowSymlinks {
+		opts = append(opts, options.ScannerWithFollowSymlinks(true))
+	}
+	if c.Paths.WithinRoot {
+		opts = append(opts, options.ScannerWithStayWithinRoot(true))
+	}
+
+	if c.ignoreFile != nil {
+		opts = append(opts, ignore.ScannerWithIgnoreFile(c.ignoreFile))
+	}
+
+	if c.Parsing.Lenient {
+		opts = append(opts, options.ScannerWithLenientParsing(true))
+	}
+
+	return opts, nil
+}
@@ -0,0 +1,112 @@
+package config
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/aquasecurity/defsec/pkg/framework"
+	"github.com/aquasecurity/defsec/pkg/scan"
+	"github.com/aquasecurity/defsec/pkg/severity"
+	"github.com/aquasecurity/defsec/test/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ReadConfig(t *testing.T) {
+	c, err := Read(strings.NewReader(`
+policies:
+  dirs: [policies]
+  namespaces: [custom]
+  embedded: false
+severity:
+  minimum: medium
+  reporting: high
+  overrides:
+    - rule: AVD-AWS-0086
+      severity: low
+frameworks: [default, cis-aws-1.4]
+rules:
+  include:
+    services: [aws/s3]
+  exclude:
+    ids: [AVD-AWS-0090]
+paths:
+  exclude: [vendor]
+  follow-symlinks: true
+  within-root: true
+ignores:
+  - rule:aws-s3-enable-versioning path:modules/legacy/**
+scanners:
+  helm:
+    enabled: false
+parsing:
+  lenient: true
+`))
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"policies"}, c.Policies.Dirs)
+	require.NotNil(t, c.Policies.Embedded)
+	assert.False(t, *c.Policies.Embedded)
+	assert.Equal(t, severity.Medium, c.Severity.Minimum)
+	assert.Equal(t, severity.High, c.Severity.Reporting)
+	assert.Equal(t, severity.Low, c.Severity.Overrides[0].Severity)
+	assert.Equal(t, []framework.Framework{framework.Default, framework.CIS_AWS_1_4}, c.Frameworks)
+	assert.Equal(t, scan.RuleSelection{
+		Include: scan.RuleSet{Services: []string{"aws/s3"}},
+		Exclude: scan.RuleSet{IDs: []string{"AVD-AWS-0090"}},
+	}, c.RuleSelection())
+
+	assert.False(t, c.ScannerEnabled("helm"))
+	assert.True(t, c.ScannerEnabled("terraform"))
+	assert.True(t, c.Parsing.Lenient)
+	assert.True(t, c.Paths.FollowSymlinks)
+	assert.True(t, c.Paths.WithinRoot)
+
+	opts, err := c.Options()
+	require.NoError(t, err)
+	assert.Len(t, opts, 13)
+}
+
+func Test_ReadConfigInvalid(t *testing.T) {
+	tests := map[string]string{
+		"unknown field":    "policy-dirs: [policies]",
+		"invalid severity": "severity:\n  minimum: urgent",
+		"invalid override": "severity:\n  overrides:\n    - severity: low",
+		"unknown scanner":  "scanners:\n  ansible:\n    enabled: true",
+		"invalid ignore":   "ignores:\n  - colour:blue",
+	}
+	for name, input := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := Read(strings.NewReader(input))
+			assert.Error(t, err)
+		})
+	}
+}
+
+func Test_FindConfig(t *testing.T) {
+	fsys := testutil.CreateFS(t, map[string]string{
+		"code/defsec.yaml": "paths:\n  exclude: [vendor]\n",
+	})
+
+	c, err := Find(fsys, "code")
+	require.NoError(t, err)
+	require.NotNil(t, c)
+	assert.Equal(t, []string{"vendor"}, c.Paths.Exclude)
+
+	c, err = Find(fsys, ".")
+	require.NoError(t, err)
+	assert.Nil(t, c)
+	assert.True(t, c.ScannerEnabled("terraform"))
+
+	opts, err := c.Options()
+	require.NoError(t, err)
+	assert.Empty(t, opts)
+}
+
+func Test_EmptyConfig(t *testing.T) {
+	c, err := Read(strings.NewReader(""))
+	require.NoError(t, err)
+	opts, err := c.Options()
+	require.NoError(t, err)
+	assert.Empty(t, opts)
+}
@@ -12,32 +12,99 @@ import (
 
 const timeFormat = "04:05.000000000"
 
+// Logger writes records for a single component to a Handler. The zero value discards everything.
 type Logger struct {
-	writer io.Writer
-	prefix string
+	handler Handler
+	prefix  string
+	fields  []interface{}
 }
 
+// New returns a Logger which writes debug records as text to w. Nothing is written if w is nil.
 func New(w io.Writer, parts ...string) Logger {
+	var handler Handler
+	if w != nil {
+		handler = newDebugHandler(w)
+	}
+	return NewWithHandler(handler, parts...)
+}
+
+// NewWithHandler returns a Logger which passes records to the given handler. Nothing is logged if it is nil.
+func NewWithHandler(handler Handler, parts ...string) Logger {
 	return Logger{
-		writer: w,
-		prefix: strings.Join(parts, "."),
+		handler: handler,
+		prefix:  strings.Join(parts, "."),
 	}
 }
 
+// Handler returns the handler records are passed to, so it can be shared with other components
+func (l *Logger) Handler() Handler {
+	return l.handler
+}
+
 func (l *Logger) Extend(parts ...string) Logger {
 	return Logger{
-		writer: l.writer,
-		prefix: strings.Join(append([]string{l.prefix}, parts...), "."),
+		handler: l.handler,
+		prefix:  strings.Join(append([]string{l.prefix}, parts...), "."),
+		fields:  l.fields,
 	}
 }
 
+// With returns a Logger which adds the given key-value pairs to every record
+func (l *Logger) With(fields ...interface{}) Logger {
+	return Logger{
+		handler: l.handler,
+		prefix:  l.prefix,
+		fields:  append(l.fields[:len(l.fields):len(l.fields)], fields...),
+	}
+}
+
+// Enabled reports whether records at the given level will be handled, so expensive fields can be skipped
+func (l *Logger) Enabled(level Level) bool {
+	return l.handler != nil && l.handler.Enabled(level)
+}
+
+// Log writes a formatted debug message
 func (l *Logger) Log(format string, args ...interface{}) {
-	if l.writer == nil {
+	if !l.Enabled(LevelDebug) {
+		return
+	}
+	l.write(LevelDebug, fmt.Sprintf(format, args...), nil)
+}
+
+func (l *Logger) Debug(msg string, fields ...interface{}) {
+	l.log(LevelDebug, msg, fields)
+}
+
+func (l *Logger) Info(msg string, fields ...interface{}) {
+	l.log(LevelInfo, msg, fields)
+}
+
+func (l *Logger) Warn(msg string, fields ...interface{}) {
+	l.log(LevelWarn, msg, fields)
+}
+
+func (l *Logger) Error(msg string, fields ...interface{}) {
+	l.log(LevelError, msg, fields)
+}
+
+func (l *Logger) log(level Level, msg string, fields []interface{}) {
+	if !l.Enabled(level) {
 		return
 	}
-	message := fmt.Sprintf(format, args...)
-	line := fmt.Sprintf("%s %-32s %s\n", time.Now().Format(timeFormat), l.prefix, message)
-	_, _ = l.writer.Write([]byte(line))
+	l.write(level, msg, fields)
+}
+
+func (l *Logger) write(level Level, msg string, fields []interface{}) {
+	if len(l.fields) > 0 {
+		fields = append(l.fields[:len(l.fields):len(l.fields)], fields...)
+	}
+	l.handler.Handle(Record{
+		Time:      time.Now(),
+		Level:     level,
+		Component: l.prefix,
+		Message:   msg,
+		Fields:    fields,
+	})
 }
 
 func LogSystemInfo(w io.Writer, appVersion string) {
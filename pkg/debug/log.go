@@ -0,0 +1,107 @@
+package debug
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Level is the importance of a log record. The values match those of slog.Level, so a Level can be converted
+// to a slog.Level directly.
+type Level int
+
+const (
+	LevelDebug Level = -4
+	LevelInfo  Level = 0
+	LevelWarn  Level = 4
+	LevelError Level = 8
+)
+
+func (l Level) String() string {
+	switch {
+	case l < LevelInfo:
+		return "DEBUG"
+	case l < LevelWarn:
+		return "INFO"
+	case l < LevelError:
+		return "WARN"
+	default:
+		return "ERROR"
+	}
+}
+
+// Record is a single log entry
+type Record struct {
+	Time  time.Time
+	Level Level
+	// Component identifies the part of defsec which produced the record, e.g. "terraform.parser"
+	Component string
+	Message   string
+	// Fields holds alternating keys and values, in the same form accepted by slog
+	Fields []interface{}
+}
+
+// Handler receives the records produced by every Logger it is attached to. Handlers must be safe for concurrent use.
+type Handler interface {
+	Enabled(level Level) bool
+	Handle(record Record)
+}
+
+type textHandler struct {
+	sync.Mutex
+	writer    io.Writer
+	level     Level
+	showLevel bool
+}
+
+// NewTextHandler returns a Handler which writes records at or above the given level to w, one per line, with the
+// level of each record after its timestamp
+func NewTextHandler(w io.Writer, level Level) Handler {
+	return &textHandler{
+		writer:    w,
+		level:     level,
+		showLevel: true,
+	}
+}
+
+// newDebugHandler returns the handler used by New, which writes every record in the original debug line format,
+// without a level column, so existing debug output is unchanged
+func newDebugHandler(w io.Writer) Handler {
+	return &textHandler{
+		writer: w,
+		level:  LevelDebug,
+	}
+}
+
+func (h *textHandler) Enabled(level Level) bool {
+	return level >= h.level
+}
+
+func (h *textHandler) Handle(record Record) {
+	var fields strings.Builder
+	for i := 0; i < len(record.Fields); i += 2 {
+		key := fmt.Sprint(record.Fields[i])
+		if i+1 == len(record.Fields) {
+			_, _ = fmt.Fprintf(&fields, " !BADKEY=%v", key)
+			break
+		}
+		_, _ = fmt.Fprintf(&fields, " %s=%v", key, record.Fields[i+1])
+	}
+	var level string
+	if h.showLevel {
+		level = fmt.Sprintf("%-5s ", record.Level)
+	}
+	line := fmt.Sprintf(
+		"%s %s%-32s %s%s\n",
+		record.Time.Format(timeFormat),
+		level,
+		record.Component,
+		record.Message,
+		fields.String(),
+	)
+	h.Lock()
+	defer h.Unlock()
+	_, _ = h.writer.Write([]byte(line))
+}
@@ -0,0 +1,91 @@
+package debug
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type recordingHandler struct {
+	sync.Mutex
+	level   Level
+	records []Record
+}
+
+func (h *recordingHandler) Enabled(level Level) bool {
+	return level >= h.level
+}
+
+func (h *recordingHandler) Handle(record Record) {
+	h.Lock()
+	defer h.Unlock()
+	h.records = append(h.records, record)
+}
+
+func Test_LoggerWithHandler(t *testing.T) {
+	handler := &recordingHandler{level: LevelInfo}
+	logger := NewWithHandler(handler, "terraform", "parser")
+
+	logger.Log("not handled %d", 1)
+	logger.Debug("not handled")
+	logger.Info("parsed file", "path", "main.tf")
+
+	child := logger.With("module", "root")
+	extended := child.Extend("resolver")
+	extended.Warn("module not found", "source", "./missing")
+	logger.Error("failed")
+
+	require.Len(t, handler.records, 3)
+
+	assert.Equal(t, LevelInfo, handler.records[0].Level)
+	assert.Equal(t, "terraform.parser", handler.records[0].Component)
+	assert.Equal(t, "parsed file", handler.records[0].Message)
+	assert.Equal(t, []interface{}{"path", "main.tf"}, handler.records[0].Fields)
+
+	assert.Equal(t, LevelWarn, handler.records[1].Level)
+	assert.Equal(t, "terraform.parser.resolver", handler.records[1].Component)
+	assert.Equal(t, []interface{}{"module", "root", "source", "./missing"}, handler.records[1].Fields)
+
+	assert.Equal(t, LevelError, handler.records[2].Level)
+	assert.Empty(t, handler.records[2].Fields)
+}
+
+func Test_TextHandler(t *testing.T) {
+	buffer := bytes.NewBuffer(nil)
+	logger := NewWithHandler(NewTextHandler(buffer, LevelInfo), "rego", "scanner")
+
+	logger.Log("hidden")
+	logger.Warn("no input selectors", "module", "test.rego")
+
+	lines := strings.Split(strings.TrimSpace(buffer.String()), "\n")
+	require.Len(t, lines, 1)
+	assert.Contains(t, lines[0], "WARN  rego.scanner")
+	assert.True(t, strings.HasSuffix(lines[0], "no input selectors module=test.rego"))
+}
+
+func Test_DefaultHandlerKeepsDebugLineFormat(t *testing.T) {
+	buffer := bytes.NewBuffer(nil)
+	logger := New(buffer, "rego", "scanner")
+
+	logger.Log("loaded %d policies", 3)
+
+	line := strings.TrimSuffix(buffer.String(), "\n")
+	parts := strings.SplitN(line, " ", 2)
+	require.Len(t, parts, 2)
+	assert.Equal(t, fmt.Sprintf("%-32s %s", "rego.scanner", "loaded 3 policies"), parts[1])
+	assert.NotContains(t, line, "DEBUG")
+}
+
+func Test_ZeroLoggerDiscards(t *testing.T) {
+	var logger Logger
+	assert.False(t, logger.Enabled(LevelError))
+	logger.Error("discarded", "key", "value")
+
+	logger = New(nil, "json", "scanner")
+	logger.Log("discarded")
+}
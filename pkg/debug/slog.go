@@ -0,0 +1,31 @@
+//go:build go1.21
+
+package debug
+
+import (
+	"context"
+	"log/slog"
+)
+
+type slogHandler struct {
+	logger *slog.Logger
+}
+
+// NewSlogHandler returns a Handler which passes records to the given slog.Logger. The component which produced
+// each record is added as the "component" attribute.
+func NewSlogHandler(logger *slog.Logger) Handler {
+	return &slogHandler{
+		logger: logger,
+	}
+}
+
+func (h *slogHandler) Enabled(level Level) bool {
+	return h.logger.Enabled(context.Background(), slog.Level(level))
+}
+
+func (h *slogHandler) Handle(record Record) {
+	r := slog.NewRecord(record.Time, slog.Level(record.Level), record.Message, 0)
+	r.Add("component", record.Component)
+	r.Add(record.Fields...)
+	_ = h.logger.Handler().Handle(context.Background(), r)
+}
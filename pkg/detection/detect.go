@@ -5,9 +5,12 @@ import (
 	"encoding/json"
 	"io"
 	"path/filepath"
+	"sort"
 	"strings"
+	"sync"
 
 	"github.com/aquasecurity/defsec/pkg/scanners/azure/arm/parser/armjson"
+	"github.com/aquasecurity/defsec/pkg/scanners/charset"
 	"github.com/aquasecurity/defsec/pkg/types"
 	"gopkg.in/yaml.v3"
 )
@@ -28,59 +31,142 @@ const (
 	FileTypeAzureARM       FileType = "azure-arm"
 )
 
-var matchers = map[FileType]func(name string, r io.ReadSeeker) bool{}
+// Confidence is how sure a detector is that a file is of a given type
+type Confidence int
+
+const (
+	// ConfidenceNone means the file is not of the type
+	ConfidenceNone Confidence = iota
+	// ConfidenceLow means the name is consistent with the type, but equally with others, e.g. any .yaml file may
+	// be a helm template
+	ConfidenceLow
+	// ConfidenceMedium means the name identifies the type, but the content was not checked, either because no
+	// reader was given or because the type cannot be confirmed from its content
+	ConfidenceMedium
+	// ConfidenceHigh means the content was inspected and confirms the type
+	ConfidenceHigh
+)
+
+func (c Confidence) String() string {
+	switch c {
+	case ConfidenceLow:
+		return "low"
+	case ConfidenceMedium:
+		return "medium"
+	case ConfidenceHigh:
+		return "high"
+	default:
+		return "none"
+	}
+}
+
+// Detector reports how confident it is that the named file is of a particular type. The reader may be nil, in
+// which case only the name can be considered. Detectors do not need to rewind the reader before returning.
+type Detector func(name string, r io.ReadSeeker) Confidence
+
+// Match is a file type detected for a file
+type Match struct {
+	Type       FileType
+	Confidence Confidence
+}
+
+var (
+	detectors    = map[FileType][]Detector{}
+	detectorLock sync.RWMutex
+)
+
+// Register adds a detector for the given file type, which may be one of the built-in types or a custom one. When a
+// type has several detectors, the most confident result is used, so a custom detector can recognise files the
+// built-in one does not but cannot prevent it from matching.
+func Register(t FileType, detector Detector) {
+	detectorLock.Lock()
+	defer detectorLock.Unlock()
+	detectors[t] = append(detectors[t], detector)
+}
+
+// RegisteredTypes returns every file type with at least one detector, in name order
+func RegisteredTypes() []FileType {
+	detectorLock.RLock()
+	defer detectorLock.RUnlock()
+	types := make([]FileType, 0, len(detectors))
+	for t := range detectors {
+		types = append(types, t)
+	}
+	sort.Slice(types, func(i, j int) bool { return types[i] < types[j] })
+	return types
+}
+
+// detectorsFor returns a copy of the detectors for a type, so they can be run without holding the lock. Detectors
+// commonly call IsType themselves.
+func detectorsFor(t FileType) []Detector {
+	detectorLock.RLock()
+	defer detectorLock.RUnlock()
+	return append([]Detector(nil), detectors[t]...)
+}
+
+func matchedBy(matched bool) Confidence {
+	if matched {
+		return ConfidenceHigh
+	}
+	return ConfidenceNone
+}
+
+// confirmedBy adapts a check of a file's content into a Detector
+func confirmedBy(check func(name string, r io.ReadSeeker) bool) Detector {
+	return func(name string, r io.ReadSeeker) Confidence {
+		return matchedBy(check(name, r))
+	}
+}
 
 // nolint
 func init() {
 
-	matchers[FileTypeJSON] = func(name string, r io.ReadSeeker) bool {
+	Register(FileTypeJSON, func(name string, r io.ReadSeeker) Confidence {
 		ext := filepath.Ext(filepath.Base(name))
 		if !strings.EqualFold(ext, ".json") {
-			return false
+			return ConfidenceNone
 		}
 		if resetReader(r) == nil {
-			return true
+			return ConfidenceMedium
 		}
 
 		var content interface{}
-		return json.NewDecoder(r).Decode(&content) == nil
-	}
+		return matchedBy(json.NewDecoder(r).Decode(&content) == nil)
+	})
 
-	matchers[FileTypeYAML] = func(name string, r io.ReadSeeker) bool {
+	Register(FileTypeYAML, func(name string, r io.ReadSeeker) Confidence {
 		ext := filepath.Ext(filepath.Base(name))
 		if !strings.EqualFold(ext, ".yaml") && !strings.EqualFold(ext, ".yml") {
-			return false
+			return ConfidenceNone
 		}
 		if resetReader(r) == nil {
-			return true
+			return ConfidenceMedium
 		}
 
 		var content interface{}
-		return yaml.NewDecoder(r).Decode(&content) == nil
-	}
-
-	matchers[FileTypeHelm] = func(name string, r io.ReadSeeker) bool {
-		if IsHelmChartArchive(name, r) {
-			return true
-		}
-
-		return strings.HasSuffix(name, "hart.yaml")
-	}
+		return matchedBy(yaml.NewDecoder(r).Decode(&content) == nil)
+	})
 
-	matchers[FileTypeTOML] = func(name string, r io.ReadSeeker) bool {
+	Register(FileTypeTOML, func(name string, r io.ReadSeeker) Confidence {
 		ext := filepath.Ext(filepath.Base(name))
-		return strings.EqualFold(ext, ".toml")
-	}
+		if strings.EqualFold(ext, ".toml") {
+			return ConfidenceMedium
+		}
+		return ConfidenceNone
+	})
 
-	matchers[FileTypeTerraform] = func(name string, _ io.ReadSeeker) bool {
+	Register(FileTypeTerraform, func(name string, _ io.ReadSeeker) Confidence {
 		ext := filepath.Ext(filepath.Base(name))
-		return strings.EqualFold(ext, ".tf") || strings.EqualFold(ext, ".tf.json")
-	}
+		if strings.EqualFold(ext, ".tf") || strings.EqualFold(ext, ".tf.json") {
+			return ConfidenceMedium
+		}
+		return ConfidenceNone
+	})
 
-	matchers[FileTypeTerraformPlan] = func(name string, r io.ReadSeeker) bool {
+	Register(FileTypeTerraformPlan, func(name string, r io.ReadSeeker) Confidence {
 		if IsType(name, r, FileTypeJSON) {
 			if resetReader(r) == nil {
-				return false
+				return ConfidenceNone
 			}
 
 			contents := make(map[string]interface{})
@@ -88,14 +174,14 @@ func init() {
 			if err == nil {
 				if _, ok := contents["terraform_version"]; ok {
 					_, stillOk := contents["format_version"]
-					return stillOk
+					return matchedBy(stillOk)
 				}
 			}
 		}
-		return false
-	}
+		return ConfidenceNone
+	})
 
-	matchers[FileTypeCloudFormation] = func(name string, r io.ReadSeeker) bool {
+	Register(FileTypeCloudFormation, confirmedBy(func(name string, r io.ReadSeeker) bool {
 		sniff := struct {
 			Resources map[string]map[string]interface{} `json:"Resources" yaml:"Resources"`
 		}{}
@@ -120,9 +206,9 @@ func init() {
 		}
 
 		return sniff.Resources != nil
-	}
+	}))
 
-	matchers[FileTypeAzureARM] = func(name string, r io.ReadSeeker) bool {
+	Register(FileTypeAzureARM, confirmedBy(func(name string, r io.ReadSeeker) bool {
 
 		if resetReader(r) == nil {
 			return false
@@ -140,41 +226,41 @@ func init() {
 
 		return (sniff.Parameters != nil && len(sniff.Parameters) > 0) ||
 			(sniff.Resources != nil && len(sniff.Resources) > 0)
-	}
+	}))
 
-	matchers[FileTypeDockerfile] = func(name string, _ io.ReadSeeker) bool {
+	Register(FileTypeDockerfile, func(name string, _ io.ReadSeeker) Confidence {
 		requiredFiles := []string{"Dockerfile", "Containerfile"}
 		for _, requiredFile := range requiredFiles {
 			base := filepath.Base(name)
 			ext := filepath.Ext(base)
 			if strings.TrimSuffix(base, ext) == requiredFile {
-				return true
+				return ConfidenceMedium
 			}
 			if strings.EqualFold(ext, "."+requiredFile) {
-				return true
+				return ConfidenceMedium
 			}
 		}
-		return false
-	}
+		return ConfidenceNone
+	})
 
-	matchers[FileTypeHelm] = func(name string, r io.ReadSeeker) bool {
+	Register(FileTypeHelm, func(name string, r io.ReadSeeker) Confidence {
 		helmFiles := []string{"Chart.yaml", ".helmignore", "values.schema.json", "NOTES.txt"}
 		for _, expected := range helmFiles {
 			if strings.HasSuffix(name, expected) {
-				return true
+				return ConfidenceMedium
 			}
 		}
 		helmFileExtensions := []string{".yaml", ".tpl"}
 		ext := filepath.Ext(filepath.Base(name))
 		for _, expected := range helmFileExtensions {
 			if strings.EqualFold(ext, expected) {
-				return true
+				return ConfidenceLow
 			}
 		}
-		return IsHelmChartArchive(name, r)
-	}
+		return matchedBy(IsHelmChartArchive(name, r))
+	})
 
-	matchers[FileTypeKubernetes] = func(name string, r io.ReadSeeker) bool {
+	Register(FileTypeKubernetes, confirmedBy(func(name string, r io.ReadSeeker) bool {
 
 		if !IsType(name, r, FileTypeYAML) && !IsType(name, r, FileTypeJSON) {
 			return false
@@ -234,40 +320,72 @@ func init() {
 		}
 
 		return false
-	}
+	}))
 }
 
+// IsType reports whether the named file is of the given type, with any confidence
 func IsType(name string, r io.ReadSeeker, t FileType) bool {
+	return DetectType(name, r, t) > ConfidenceNone
+}
+
+// DetectType returns how confident the detectors for the given type are that the named file is of that type
+func DetectType(name string, r io.ReadSeeker, t FileType) Confidence {
 	r = ensureSeeker(r)
-	f, ok := matchers[t]
-	if !ok {
-		return false
+	confidence := ConfidenceNone
+	for _, detector := range detectorsFor(t) {
+		if c := detector(name, r); c > confidence {
+			confidence = c
+		}
+		resetReader(r)
 	}
-	return f(name, r)
+	return confidence
 }
 
+// GetTypes returns every type the named file may be, with any confidence
 func GetTypes(name string, r io.ReadSeeker) []FileType {
 	var matched []FileType
+	for _, match := range Detect(name, r) {
+		matched = append(matched, match.Type)
+	}
+	return matched
+}
+
+// Detect returns every type the named file may be, most confident first. Types with equal confidence are in name
+// order.
+func Detect(name string, r io.ReadSeeker) []Match {
+	var matched []Match
 	r = ensureSeeker(r)
-	for check, f := range matchers {
-		if f(name, r) {
-			matched = append(matched, check)
+	for _, t := range RegisteredTypes() {
+		if confidence := DetectType(name, r, t); confidence > ConfidenceNone {
+			matched = append(matched, Match{Type: t, Confidence: confidence})
 		}
 		resetReader(r)
 	}
+	sort.SliceStable(matched, func(i, j int) bool {
+		return matched[i].Confidence > matched[j].Confidence
+	})
 	return matched
 }
 
+// ensureSeeker returns a seeker of the content of r, decoded to UTF-8 if it has a byte order mark or is UTF-16 so
+// that detectors can inspect it
 func ensureSeeker(r io.Reader) io.ReadSeeker {
 	if r == nil {
 		return nil
 	}
 	if seeker, ok := r.(io.ReadSeeker); ok {
-		return seeker
+		prefix := make([]byte, 4)
+		n, _ := io.ReadFull(seeker, prefix)
+		if _, err := seeker.Seek(0, io.SeekStart); err != nil {
+			return nil
+		}
+		if !charset.IsEncoded(prefix[:n]) {
+			return seeker
+		}
 	}
 
 	var buf bytes.Buffer
-	if _, err := io.Copy(&buf, r); err == nil {
+	if _, err := io.Copy(&buf, charset.NewReader(r)); err == nil {
 		return bytes.NewReader(buf.Bytes())
 	}
 
@@ -350,6 +350,101 @@ rules:
 	}
 }
 
+func Test_DetectConfidence(t *testing.T) {
+	tests := []struct {
+		name     string
+		path     string
+		r        io.ReadSeeker
+		expected []Match
+	}{
+		{
+			name: "yaml, no reader",
+			path: "main.yaml",
+			expected: []Match{
+				{Type: FileTypeYAML, Confidence: ConfidenceMedium},
+				{Type: FileTypeHelm, Confidence: ConfidenceLow},
+			},
+		},
+		{
+			name: "kubernetes manifest",
+			path: "pod.yaml",
+			r: strings.NewReader(`apiVersion: v1
+kind: Pod
+metadata:
+  name: example
+`),
+			expected: []Match{
+				{Type: FileTypeKubernetes, Confidence: ConfidenceHigh},
+				{Type: FileTypeYAML, Confidence: ConfidenceHigh},
+				{Type: FileTypeHelm, Confidence: ConfidenceLow},
+			},
+		},
+		{
+			name: "terraform",
+			path: "main.tf",
+			r:    strings.NewReader(`resource "aws_s3_bucket" "example" {}`),
+			expected: []Match{
+				{Type: FileTypeTerraform, Confidence: ConfidenceMedium},
+			},
+		},
+		{
+			name:     "invalid json",
+			path:     "broken.json",
+			r:        strings.NewReader(`{`),
+			expected: nil,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, Detect(test.path, test.r))
+		})
+	}
+}
+
+func Test_RegisterCustomDetector(t *testing.T) {
+	const fileTypeNomad FileType = "nomad"
+	Register(fileTypeNomad, func(name string, r io.ReadSeeker) Confidence {
+		if !strings.HasSuffix(name, ".nomad") {
+			return ConfidenceNone
+		}
+		if r == nil {
+			return ConfidenceMedium
+		}
+		data, err := io.ReadAll(r)
+		if err != nil || !bytes.Contains(data, []byte("job ")) {
+			return ConfidenceNone
+		}
+		return ConfidenceHigh
+	})
+	defer func() {
+		detectorLock.Lock()
+		defer detectorLock.Unlock()
+		delete(detectors, fileTypeNomad)
+	}()
+
+	assert.Contains(t, RegisteredTypes(), fileTypeNomad)
+	assert.Equal(t, ConfidenceMedium, DetectType("example.nomad", nil, fileTypeNomad))
+	assert.Equal(t, ConfidenceHigh, DetectType("example.nomad", strings.NewReader(`job "example" {}`), fileTypeNomad))
+	assert.False(t, IsType("example.nomad", strings.NewReader(`variable "x" {}`), fileTypeNomad))
+	assert.Equal(t, []FileType{fileTypeNomad}, GetTypes("example.nomad", strings.NewReader(`job "example" {}`)))
+}
+
+func Test_DetectEncodedFiles(t *testing.T) {
+	source := `{
+  "$schema": "https://schema.management.azure.com/schemas/2019-04-01/deploymentTemplate.json#",
+  "resources": [{"type": "Microsoft.Storage/storageAccounts"}]
+}`
+	var utf16le []byte
+	for _, c := range source {
+		utf16le = append(utf16le, byte(c), 0)
+	}
+
+	assert.True(t, IsType("template.json", bytes.NewReader(append([]byte{0xef, 0xbb, 0xbf}, source...)), FileTypeAzureARM))
+	assert.True(t, IsType("template.json", bytes.NewReader(append([]byte{0xff, 0xfe}, utf16le...)), FileTypeAzureARM))
+	assert.True(t, IsType("template.json", bytes.NewReader(utf16le), FileTypeAzureARM))
+}
+
 func BenchmarkIsType_SmallFile(b *testing.B) {
 	data, err := os.ReadFile(fmt.Sprintf("./testdata/%s", "small.file"))
 	assert.Nil(b, err)
@@ -0,0 +1,39 @@
+package extrafs
+
+import (
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/bmatcuk/doublestar"
+)
+
+// MatchGlob reports whether a slash separated relative path matches a glob pattern. Patterns use the doublestar
+// syntax, where ** matches any number of directories (e.g. "**/testdata/**"). A pattern also matches everything
+// beneath a directory it matches, and a pattern without a slash matches any single path element, so "vendor"
+// matches every file inside any directory named vendor, and "*.tf" every file with that extension. A leading "./"
+// or "/" and a trailing "/" are ignored. Invalid patterns match nothing.
+func MatchGlob(pattern string, name string) bool {
+	pattern = strings.TrimPrefix(strings.TrimPrefix(filepath.ToSlash(pattern), "./"), "/")
+	pattern = strings.TrimSuffix(pattern, "/")
+	name = strings.TrimPrefix(path.Clean(filepath.ToSlash(name)), "/")
+	if pattern == "" || name == "" || name == "." {
+		return false
+	}
+	elements := strings.Split(name, "/")
+	if !strings.Contains(pattern, "/") {
+		for _, element := range elements {
+			if ok, err := doublestar.Match(pattern, element); err == nil && ok {
+				return true
+			}
+		}
+		return false
+	}
+	// check the path itself along with each of its parent directories
+	for i := len(elements); i > 0; i-- {
+		if ok, err := doublestar.Match(pattern, strings.Join(elements[:i], "/")); err == nil && ok {
+			return true
+		}
+	}
+	return false
+}
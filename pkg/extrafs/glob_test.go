@@ -0,0 +1,37 @@
+package extrafs
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_MatchGlob(t *testing.T) {
+	tests := []struct {
+		pattern string
+		name    string
+		matches bool
+	}{
+		{pattern: "vendor", name: "vendor/lib/main.tf", matches: true},
+		{pattern: "vendor", name: "modules/vendor/main.tf", matches: true},
+		{pattern: "vendor", name: "vendored/main.tf", matches: false},
+		{pattern: "*.json", name: "template.json", matches: true},
+		{pattern: "*.json", name: "config/app.json", matches: true},
+		{pattern: "vendor/**", name: "vendor/lib/main.tf", matches: true},
+		{pattern: "dev/", name: "dev/main.tf", matches: true},
+		{pattern: "./dev", name: "dev/main.tf", matches: true},
+		{pattern: "/dev", name: "dev/main.tf", matches: true},
+		{pattern: "test/fixtures", name: "test/fixtures/bad.json", matches: true},
+		{pattern: "test/fixtures", name: "src/test/fixtures/bad.json", matches: false},
+		{pattern: "modules/**/*.tf", name: "modules/compute/nested/main.tf", matches: true},
+		{pattern: "modules/**/*.tf", name: "main.tf", matches: false},
+		{pattern: "**/main.tf", name: "main.tf", matches: true},
+		{pattern: "**/testdata/**", name: "deploy/testdata/app.yaml", matches: true},
+		{pattern: "other/**", name: "main.tf", matches: false},
+		{pattern: "[a-", name: "a/main.tf", matches: false},
+		{pattern: "", name: "main.tf", matches: false},
+	}
+	for _, test := range tests {
+		assert.Equal(t, test.matches, MatchGlob(test.pattern, test.name), "%s ~ %s", test.pattern, test.name)
+	}
+}
@@ -0,0 +1,75 @@
+package extrafs
+
+import (
+	"path"
+	"strings"
+)
+
+// SlashPath converts a path from any platform to the slash separated form used by io/fs and in results, so that
+// scans run on Windows refer to files the same way as scans run elsewhere. A Windows volume name (e.g. C: or
+// \\server\share) is kept, but lower-cased, as Windows treats it case-insensitively.
+func SlashPath(name string) string {
+	name = strings.ReplaceAll(name, "\\", "/")
+	volume := volumeName(name)
+	return strings.ToLower(volume) + name[len(volume):]
+}
+
+// RelPath returns the path of name relative to the directory root, with both converted by SlashPath and cleaned,
+// and whether name is inside root. The path is empty for root itself, and is all of name without its volume name
+// or a leading slash if it is outside root. Windows paths, i.e. those with a volume name, are compared
+// case-insensitively as Windows compares them; any others are compared exactly.
+func RelPath(root string, name string) (string, bool) {
+	rootVolume, root := splitVolume(root)
+	nameVolume, name := splitVolume(name)
+
+	equal := func(a, b string) bool { return a == b }
+	if rootVolume != "" || nameVolume != "" {
+		if rootVolume != "" && nameVolume != "" && rootVolume != nameVolume {
+			return name, false
+		}
+		equal = strings.EqualFold
+	}
+
+	switch {
+	case root == "":
+		return name, true
+	case equal(name, root):
+		return "", true
+	case len(name) > len(root) && name[len(root)] == '/' && equal(name[:len(root)], root):
+		return name[len(root)+1:], true
+	}
+	return name, false
+}
+
+// splitVolume converts a path by SlashPath, returning its volume name, and the rest of the path cleaned without a
+// leading slash or current directory
+func splitVolume(name string) (string, string) {
+	name = SlashPath(name)
+	volume := volumeName(name)
+	name = strings.TrimPrefix(path.Clean(name[len(volume):]), "/")
+	if name == "." {
+		name = ""
+	}
+	return volume, name
+}
+
+// volumeName returns the Windows volume name at the start of a slash separated path: a drive letter followed by a
+// colon, or the server and share of a UNC path
+func volumeName(name string) string {
+	if len(name) >= 2 && name[1] == ':' && isLetter(name[0]) {
+		return name[:2]
+	}
+	if !strings.HasPrefix(name, "//") || strings.HasPrefix(name, "///") {
+		return ""
+	}
+	// //server/share
+	parts := strings.SplitN(name[2:], "/", 3)
+	if len(parts) < 2 || parts[0] == "" || parts[1] == "" {
+		return ""
+	}
+	return "//" + parts[0] + "/" + parts[1]
+}
+
+func isLetter(c byte) bool {
+	return ('a' <= c && c <= 'z') || ('A' <= c && c <= 'Z')
+}
@@ -0,0 +1,53 @@
+package extrafs
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_SlashPath(t *testing.T) {
+	tests := map[string]string{
+		"main.tf":                          "main.tf",
+		"modules/vpc/main.tf":              "modules/vpc/main.tf",
+		`modules\vpc\main.tf`:              "modules/vpc/main.tf",
+		"/home/user/code/main.tf":          "/home/user/code/main.tf",
+		`C:\Users\user\code\main.tf`:       "c:/Users/user/code/main.tf",
+		"c:/Users/user/code/main.tf":       "c:/Users/user/code/main.tf",
+		`\\Server\Share\code\main.tf`:      "//server/share/code/main.tf",
+		`C:main.tf`:                        "c:main.tf",
+		"":                                 "",
+		"github.com/org/repo//modules/vpc": "github.com/org/repo//modules/vpc",
+	}
+	for input, expected := range tests {
+		assert.Equal(t, expected, SlashPath(input), input)
+	}
+}
+
+func Test_RelPath(t *testing.T) {
+	tests := []struct {
+		root     string
+		name     string
+		expected string
+		inside   bool
+	}{
+		{root: ".", name: "modules/vpc/main.tf", expected: "modules/vpc/main.tf", inside: true},
+		{root: "/", name: "/modules/main.tf", expected: "modules/main.tf", inside: true},
+		{root: "code", name: "code/main.tf", expected: "main.tf", inside: true},
+		{root: "code", name: "./code/main.tf", expected: "main.tf", inside: true},
+		{root: "code", name: "code", expected: "", inside: true},
+		{root: "code", name: "codebase/main.tf", expected: "codebase/main.tf", inside: false},
+		{root: "code", name: "Code/main.tf", expected: "Code/main.tf", inside: false},
+		{root: `code\modules`, name: `code\modules\vpc\main.tf`, expected: "vpc/main.tf", inside: true},
+		{root: `C:\Code`, name: `c:\code\modules\main.tf`, expected: "modules/main.tf", inside: true},
+		{root: `C:\Code`, name: `D:\Code\main.tf`, expected: "Code/main.tf", inside: false},
+		{root: `c:/code`, name: `C:\Code\main.tf`, expected: "main.tf", inside: true},
+		{root: `\\server\share`, name: `\\SERVER\share\code\main.tf`, expected: "code/main.tf", inside: true},
+		{root: `\\server\share`, name: `\\server\other\main.tf`, expected: "main.tf", inside: false},
+	}
+	for _, test := range tests {
+		rel, inside := RelPath(test.root, test.name)
+		assert.Equal(t, test.expected, rel, "%s in %s", test.name, test.root)
+		assert.Equal(t, test.inside, inside, "%s in %s", test.name, test.root)
+	}
+}
@@ -0,0 +1,192 @@
+package extrafs
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+var (
+	// ErrSymlinkLoop is reported for a symlink to a directory containing it, which would otherwise be walked forever
+	ErrSymlinkLoop = errors.New("symlink loops back to a directory containing it")
+	// ErrOutsideRoot is reported for a symlink to a path outside the root of the filesystem
+	ErrOutsideRoot = errors.New("symlink points outside the scanned directory")
+)
+
+// SymlinkOptions control how symlinks are handled when a filesystem is walked. By default, symlinks to files are
+// read as the files they point to, wherever they are, and symlinks to directories are not walked into.
+type SymlinkOptions struct {
+	// Follow walks into symlinked directories, as if they were directories. Symlinks to a directory containing them
+	// are skipped.
+	Follow bool
+	// WithinRoot skips symlinks which point outside the root of the filesystem, and refuses to open paths which
+	// resolve to outside it
+	WithinRoot bool
+	// Skipped is called once with each symlink which is skipped, and why. It may be nil.
+	Skipped func(path string, err error)
+}
+
+// WithSymlinks returns a filesystem which handles symlinks as configured. Only filesystems created by OSDir can
+// contain symlinks, so any other filesystem is returned unchanged.
+func WithSymlinks(fsys fs.FS, opts SymlinkOptions) fs.FS {
+	if wrapped, ok := fsys.(*symlinkFS); ok {
+		fsys = wrapped.filesystem
+	}
+	f, ok := fsys.(*filesystem)
+	if !ok || (!opts.Follow && !opts.WithinRoot) {
+		return fsys
+	}
+	root, err := realPath(f.root)
+	if err != nil {
+		return fsys
+	}
+	return &symlinkFS{
+		filesystem: f,
+		opts:       opts,
+		root:       root,
+		reported:   make(map[string]bool),
+	}
+}
+
+type symlinkFS struct {
+	*filesystem
+	opts SymlinkOptions
+	// root is the real path of the root of the filesystem
+	root     string
+	lock     sync.Mutex
+	reported map[string]bool
+}
+
+func (s *symlinkFS) Open(name string) (fs.File, error) {
+	if err := s.checkWithinRoot(name); err != nil {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: err}
+	}
+	return s.filesystem.Open(name)
+}
+
+func (s *symlinkFS) Stat(name string) (fs.FileInfo, error) {
+	if err := s.checkWithinRoot(name); err != nil {
+		return nil, &fs.PathError{Op: "stat", Path: name, Err: err}
+	}
+	return s.filesystem.Stat(name)
+}
+
+// ReadDir lists a directory, leaving out the symlinks which are skipped. Followed symlinks are listed as the
+// files or directories they point to.
+func (s *symlinkFS) ReadDir(name string) ([]fs.DirEntry, error) {
+	if err := s.checkWithinRoot(name); err != nil {
+		return nil, &fs.PathError{Op: "readdir", Path: name, Err: err}
+	}
+	entries, err := fs.ReadDir(s.filesystem.underlying, name)
+
+	listed := make([]fs.DirEntry, 0, len(entries))
+	for _, entry := range entries {
+		if entry.Type()&fs.ModeSymlink == 0 {
+			listed = append(listed, entry)
+			continue
+		}
+		linkPath := path.Join(name, entry.Name())
+		target, resolveErr := s.realPath(linkPath)
+		if resolveErr != nil {
+			// broken symlinks are left for the scanners to fail to open, as they would without these options
+			listed = append(listed, entry)
+			continue
+		}
+		if s.opts.WithinRoot && !within(s.root, target) {
+			s.skip(linkPath, ErrOutsideRoot)
+			continue
+		}
+		if !s.opts.Follow {
+			listed = append(listed, entry)
+			continue
+		}
+		info, statErr := os.Stat(target)
+		if statErr != nil {
+			listed = append(listed, entry)
+			continue
+		}
+		if info.IsDir() && s.loops(name, target) {
+			s.skip(linkPath, ErrSymlinkLoop)
+			continue
+		}
+		listed = append(listed, fs.FileInfoToDirEntry(linkInfo{FileInfo: info, name: entry.Name()}))
+	}
+	return listed, err
+}
+
+// checkWithinRoot returns ErrOutsideRoot if the path resolves to outside the root of the filesystem. Paths which
+// cannot be resolved are left for the underlying filesystem to fail to open.
+func (s *symlinkFS) checkWithinRoot(name string) error {
+	if !s.opts.WithinRoot {
+		return nil
+	}
+	target, err := s.realPath(name)
+	if err != nil || within(s.root, target) {
+		return nil
+	}
+	s.skip(name, ErrOutsideRoot)
+	return ErrOutsideRoot
+}
+
+// loops reports whether a symlink in dir to target points to dir or one of the directories containing it, as
+// reached through any symlinks which were followed to get to dir
+func (s *symlinkFS) loops(dir string, target string) bool {
+	for current := dir; ; current = path.Dir(current) {
+		if resolved, err := s.realPath(current); err == nil && within(target, resolved) {
+			return true
+		}
+		if current == "." || current == "/" || current == "" {
+			return false
+		}
+	}
+}
+
+func (s *symlinkFS) realPath(name string) (string, error) {
+	return realPath(filepath.Join(s.filesystem.root, filepath.FromSlash(name)))
+}
+
+func (s *symlinkFS) skip(name string, err error) {
+	s.lock.Lock()
+	if s.reported[name] {
+		s.lock.Unlock()
+		return
+	}
+	s.reported[name] = true
+	s.lock.Unlock()
+	if s.opts.Skipped != nil {
+		s.opts.Skipped(name, err)
+	}
+}
+
+func realPath(path string) (string, error) {
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return "", err
+	}
+	return filepath.EvalSymlinks(abs)
+}
+
+// within reports whether path is dir or inside it
+func within(dir string, path string) bool {
+	if path == dir {
+		return true
+	}
+	if !strings.HasSuffix(dir, string(filepath.Separator)) {
+		dir += string(filepath.Separator)
+	}
+	return strings.HasPrefix(path, dir)
+}
+
+// linkInfo describes the file a symlink points to, by the name of the symlink
+type linkInfo struct {
+	fs.FileInfo
+	name string
+}
+
+func (i linkInfo) Name() string {
+	return i.name
+}
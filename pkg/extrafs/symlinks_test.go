@@ -0,0 +1,144 @@
+package extrafs
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// createTree creates a directory containing:
+//
+//	root/main.tf
+//	root/modules/vpc/main.tf
+//	root/linked -> root/modules        (symlinked module layout)
+//	root/loop -> root                  (loop)
+//	root/outside -> other              (outside the root)
+//	root/outside.tf -> other/secret.tf (outside the root)
+//	other/secret.tf
+func createTree(t *testing.T) (string, string) {
+	base := t.TempDir()
+	root := filepath.Join(base, "root")
+	other := filepath.Join(base, "other")
+	require.NoError(t, os.MkdirAll(filepath.Join(root, "modules", "vpc"), 0o700))
+	require.NoError(t, os.MkdirAll(other, 0o700))
+	require.NoError(t, os.WriteFile(filepath.Join(root, "main.tf"), []byte("root"), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(root, "modules", "vpc", "main.tf"), []byte("vpc"), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(other, "secret.tf"), []byte("secret"), 0o600))
+	for link, target := range map[string]string{
+		"linked":     filepath.Join(root, "modules"),
+		"loop":       root,
+		"outside":    other,
+		"outside.tf": filepath.Join(other, "secret.tf"),
+	} {
+		if err := os.Symlink(target, filepath.Join(root, link)); err != nil {
+			t.Skipf("symlinks are not supported: %s", err)
+		}
+	}
+	return root, other
+}
+
+func walk(t *testing.T, fsys fs.FS) []string {
+	var files []string
+	require.NoError(t, fs.WalkDir(fsys, ".", func(path string, d fs.DirEntry, err error) error {
+		require.NoError(t, err)
+		if !d.IsDir() {
+			files = append(files, path)
+		}
+		return nil
+	}))
+	sort.Strings(files)
+	return files
+}
+
+func Test_SymlinksAreNotFollowedByDefault(t *testing.T) {
+	root, _ := createTree(t)
+	fsys := WithSymlinks(OSDir(root), SymlinkOptions{})
+	assert.Equal(t, []string{
+		"linked",
+		"loop",
+		"main.tf",
+		"modules/vpc/main.tf",
+		"outside",
+		"outside.tf",
+	}, walk(t, fsys))
+}
+
+func Test_FollowSymlinks(t *testing.T) {
+	root, _ := createTree(t)
+	var skipped []string
+	fsys := WithSymlinks(OSDir(root), SymlinkOptions{
+		Follow: true,
+		Skipped: func(path string, err error) {
+			assert.ErrorIs(t, err, ErrSymlinkLoop)
+			skipped = append(skipped, path)
+		},
+	})
+	assert.Equal(t, []string{
+		"linked/vpc/main.tf",
+		"main.tf",
+		"modules/vpc/main.tf",
+		"outside.tf",
+		"outside/secret.tf",
+	}, walk(t, fsys))
+	assert.Equal(t, []string{"loop"}, skipped)
+
+	data, err := fs.ReadFile(fsys, "linked/vpc/main.tf")
+	require.NoError(t, err)
+	assert.Equal(t, "vpc", string(data))
+}
+
+func Test_StayWithinRoot(t *testing.T) {
+	root, _ := createTree(t)
+	skipped := make(map[string]error)
+	fsys := WithSymlinks(OSDir(root), SymlinkOptions{
+		Follow:     true,
+		WithinRoot: true,
+		Skipped: func(path string, err error) {
+			skipped[path] = err
+		},
+	})
+	assert.Equal(t, []string{
+		"linked/vpc/main.tf",
+		"main.tf",
+		"modules/vpc/main.tf",
+	}, walk(t, fsys))
+	assert.Equal(t, map[string]error{
+		"loop":       ErrSymlinkLoop,
+		"outside":    ErrOutsideRoot,
+		"outside.tf": ErrOutsideRoot,
+	}, skipped)
+
+	_, err := fs.ReadFile(fsys, "outside.tf")
+	assert.ErrorIs(t, err, ErrOutsideRoot)
+	_, err = fs.ReadFile(fsys, "outside/secret.tf")
+	assert.ErrorIs(t, err, ErrOutsideRoot)
+}
+
+func Test_LoopThroughSeveralSymlinks(t *testing.T) {
+	base := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(base, "a"), 0o700))
+	require.NoError(t, os.MkdirAll(filepath.Join(base, "b"), 0o700))
+	require.NoError(t, os.WriteFile(filepath.Join(base, "b", "main.tf"), []byte("b"), 0o600))
+	if err := os.Symlink(filepath.Join(base, "b"), filepath.Join(base, "a", "to-b")); err != nil {
+		t.Skipf("symlinks are not supported: %s", err)
+	}
+	require.NoError(t, os.Symlink(filepath.Join(base, "a"), filepath.Join(base, "b", "to-a")))
+
+	var skipped []string
+	fsys := WithSymlinks(OSDir(base), SymlinkOptions{
+		Follow: true,
+		Skipped: func(path string, err error) {
+			skipped = append(skipped, path)
+		},
+	})
+	assert.Equal(t, []string{
+		"a/to-b/main.tf",
+		"b/main.tf",
+	}, walk(t, fsys))
+	assert.Equal(t, []string{"a/to-b/to-a", "b/to-a/to-b"}, skipped)
+}
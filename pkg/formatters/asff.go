@@ -0,0 +1,276 @@
+package formatters
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws/arn"
+
+	"github.com/aquasecurity/defsec/pkg/severity"
+
+	"github.com/aquasecurity/defsec/pkg/scan"
+)
+
+// see https://docs.aws.amazon.com/securityhub/latest/userguide/securityhub-findings-format-syntax.html
+const (
+	asffSchemaVersion     = "2018-10-08"
+	asffMaxTitle          = 256
+	asffMaxDescription    = 1024
+	asffMaxRemediation    = 512
+	asffFindingTypePrefix = "Software and Configuration Checks/Industry and Regulatory Standards"
+)
+
+// asffNow is replaced in tests so finding times are deterministic
+var asffNow = time.Now
+
+type asffReport struct {
+	Findings []asffFinding `json:"Findings"`
+}
+
+type asffFinding struct {
+	SchemaVersion string            `json:"SchemaVersion"`
+	ID            string            `json:"Id"`
+	ProductArn    string            `json:"ProductArn"`
+	GeneratorID   string            `json:"GeneratorId"`
+	AwsAccountID  string            `json:"AwsAccountId"`
+	Region        string            `json:"Region,omitempty"`
+	Types         []string          `json:"Types"`
+	CreatedAt     string            `json:"CreatedAt"`
+	UpdatedAt     string            `json:"UpdatedAt"`
+	Severity      asffSeverity      `json:"Severity"`
+	Title         string            `json:"Title"`
+	Description   string            `json:"Description"`
+	Remediation   *asffRemediation  `json:"Remediation,omitempty"`
+	ProductFields map[string]string `json:"ProductFields"`
+	Resources     []asffResource    `json:"Resources"`
+	Compliance    asffCompliance    `json:"Compliance"`
+	Workflow      asffWorkflow      `json:"Workflow"`
+	RecordState   string            `json:"RecordState"`
+}
+
+type asffSeverity struct {
+	Label string `json:"Label"`
+}
+
+type asffRemediation struct {
+	Recommendation asffRecommendation `json:"Recommendation"`
+}
+
+type asffRecommendation struct {
+	Text string `json:"Text"`
+	URL  string `json:"Url,omitempty"`
+}
+
+type asffResource struct {
+	Type      string            `json:"Type"`
+	ID        string            `json:"Id"`
+	Partition string            `json:"Partition,omitempty"`
+	Region    string            `json:"Region,omitempty"`
+	Details   *asffOtherDetails `json:"Details,omitempty"`
+}
+
+type asffOtherDetails struct {
+	Other map[string]string `json:"Other"`
+}
+
+type asffCompliance struct {
+	Status string `json:"Status"`
+}
+
+type asffWorkflow struct {
+	Status string `json:"Status"`
+}
+
+// asffResourceTypes maps the service and resource type of an ARN to a Security Hub resource type
+var asffResourceTypes = map[string]string{
+	"s3/":                               "AwsS3Bucket",
+	"ec2/instance":                      "AwsEc2Instance",
+	"ec2/security-group":                "AwsEc2SecurityGroup",
+	"ec2/vpc":                           "AwsEc2Vpc",
+	"ec2/subnet":                        "AwsEc2Subnet",
+	"ec2/volume":                        "AwsEc2Volume",
+	"ec2/network-acl":                   "AwsEc2NetworkAcl",
+	"iam/user":                          "AwsIamUser",
+	"iam/role":                          "AwsIamRole",
+	"iam/group":                         "AwsIamGroup",
+	"iam/policy":                        "AwsIamPolicy",
+	"kms/key":                           "AwsKmsKey",
+	"lambda/function":                   "AwsLambdaFunction",
+	"rds/db":                            "AwsRdsDbInstance",
+	"rds/cluster":                       "AwsRdsDbCluster",
+	"dynamodb/table":                    "AwsDynamoDbTable",
+	"sns/":                              "AwsSnsTopic",
+	"sqs/":                              "AwsSqsQueue",
+	"cloudtrail/trail":                  "AwsCloudTrailTrail",
+	"ecr/repository":                    "AwsEcrRepository",
+	"eks/cluster":                       "AwsEksCluster",
+	"elasticloadbalancing/loadbalancer": "AwsElbv2LoadBalancer",
+}
+
+func outputASFF(b ConfigurableFormatter, results scan.Results, accountID string, region string) error {
+	// findings are imported into the Security Hub of the configured account and region, so both are required
+	if accountID == "" {
+		return fmt.Errorf("an AWS account ID is required to output ASFF findings")
+	}
+	if region == "" {
+		return fmt.Errorf("an AWS region is required to output ASFF findings")
+	}
+	now := asffNow().UTC().Format(time.RFC3339)
+	report := asffReport{
+		Findings: []asffFinding{},
+	}
+	for _, res := range results {
+		switch res.Status() {
+		case scan.StatusIgnored, scan.StatusBaselined:
+			if !b.IncludeIgnored() {
+				continue
+			}
+		case scan.StatusPassed:
+			if !b.IncludePassed() {
+				continue
+			}
+		}
+		report.Findings = append(report.Findings, newASFFFinding(b, res, accountID, region, now))
+	}
+	jsonWriter := json.NewEncoder(b.Writer())
+	jsonWriter.SetIndent("", "\t")
+	return jsonWriter.Encode(report)
+}
+
+func newASFFFinding(b ConfigurableFormatter, res scan.Result, accountID string, region string, now string) asffFinding {
+	rule := res.Rule()
+	// Security Hub only accepts findings for the account importing them, so a resource in another account is
+	// identified by its ARN alone
+	resource := asffResourceFor(b, res, region)
+
+	ruleID := rule.AVDID
+	if ruleID == "" {
+		ruleID = rule.LongID()
+	}
+
+	finding := asffFinding{
+		SchemaVersion: asffSchemaVersion,
+		ID:            fmt.Sprintf("%s/%s", ruleID, res.Fingerprint()),
+		ProductArn:    fmt.Sprintf("arn:aws:securityhub:%s:%s:product/%s/default", region, accountID, accountID),
+		GeneratorID:   ruleID,
+		AwsAccountID:  accountID,
+		Region:        region,
+		Types:         []string{asffFindingTypePrefix},
+		CreatedAt:     now,
+		UpdatedAt:     now,
+		Severity:      asffSeverity{Label: asffSeverityLabel(res.Severity())},
+		Title:         asffTruncate(fmt.Sprintf("%s: %s", ruleID, asffTitle(rule)), asffMaxTitle),
+		Description:   asffTruncate(res.Description(), asffMaxDescription),
+		ProductFields: map[string]string{
+			"Product Name": "defsec",
+			"LongID":       rule.LongID(),
+			"Provider":     string(rule.Provider),
+			"Service":      rule.Service,
+		},
+		Resources:   []asffResource{resource},
+		Compliance:  asffCompliance{Status: "FAILED"},
+		Workflow:    asffWorkflow{Status: "NEW"},
+		RecordState: "ACTIVE",
+	}
+
+	switch res.Status() {
+	case scan.StatusPassed:
+		finding.Compliance.Status = "PASSED"
+		finding.Workflow.Status = "RESOLVED"
+		finding.RecordState = "ARCHIVED"
+	case scan.StatusIgnored, scan.StatusBaselined:
+		finding.Workflow.Status = "SUPPRESSED"
+	}
+
+	if rule.Resolution != "" {
+		finding.Remediation = &asffRemediation{
+			Recommendation: asffRecommendation{
+				Text: asffTruncate(rule.Resolution, asffMaxRemediation),
+			},
+		}
+		if links := b.GetLinks(res); len(links) > 0 {
+			finding.Remediation.Recommendation.URL = links[0]
+		}
+	}
+
+	return finding
+}
+
+func asffResourceFor(b ConfigurableFormatter, res scan.Result, region string) asffResource {
+	reference := res.Flatten().Resource
+	if parsed, err := arn.Parse(reference); err == nil {
+		resource := asffResource{
+			Type:      asffResourceType(parsed),
+			ID:        reference,
+			Partition: parsed.Partition,
+			Region:    parsed.Region,
+		}
+		if resource.Region == "" {
+			resource.Region = region
+		}
+		return resource
+	}
+
+	// results from infrastructure as code have no ARN, so the resource is identified by its location instead
+	rng := res.Range()
+	path := b.Path(res, res.Metadata())
+	id := path
+	if rng.GetStartLine() > 0 {
+		id = fmt.Sprintf("%s:%d-%d", path, rng.GetStartLine(), rng.GetEndLine())
+	}
+	if reference != "" {
+		id = fmt.Sprintf("%s/%s", id, reference)
+	}
+	details := map[string]string{
+		"Filename": path,
+	}
+	if reference != "" {
+		details["Resource"] = reference
+	}
+	return asffResource{
+		Type:      "Other",
+		ID:        id,
+		Partition: "aws",
+		Region:    region,
+		Details: &asffOtherDetails{
+			Other: details,
+		},
+	}
+}
+
+func asffResourceType(parsed arn.ARN) string {
+	resourceType := strings.SplitN(strings.ReplaceAll(parsed.Resource, ":", "/"), "/", 2)[0]
+	if t, ok := asffResourceTypes[parsed.Service+"/"+resourceType]; ok {
+		return t
+	}
+	if t, ok := asffResourceTypes[parsed.Service+"/"]; ok {
+		return t
+	}
+	return "Other"
+}
+
+func asffTitle(rule scan.Rule) string {
+	if rule.Summary != "" {
+		return rule.Summary
+	}
+	return rule.LongID()
+}
+
+func asffSeverityLabel(s severity.Severity) string {
+	switch s {
+	case severity.Critical, severity.High, severity.Medium, severity.Low:
+		return string(s)
+	default:
+		return "INFORMATIONAL"
+	}
+}
+
+func asffTruncate(input string, max int) string {
+	runes := []rune(input)
+	if len(runes) <= max {
+		return input
+	}
+	return string(runes[:max-3]) + "..."
+}
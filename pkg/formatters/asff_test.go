@@ -0,0 +1,109 @@
+package formatters
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+	"time"
+
+	defsecTypes "github.com/aquasecurity/defsec/pkg/types"
+
+	"github.com/aquasecurity/defsec/pkg/severity"
+
+	"github.com/aquasecurity/defsec/pkg/scan"
+
+	"github.com/aquasecurity/defsec/pkg/providers"
+	"github.com/aquasecurity/defsec/pkg/providers/aws/s3"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ASFF(t *testing.T) {
+	defer func(now func() time.Time) { asffNow = now }(asffNow)
+	asffNow = func() time.Time { return time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC) }
+
+	buffer := bytes.NewBuffer([]byte{})
+	formatter := New().AsASFF("111111111111", "us-east-1").WithWriter(buffer).Build()
+	var results scan.Results
+	results.Add("Bucket does not have encryption enabled",
+		s3.Bucket{
+			Metadata: defsecTypes.NewRemoteMetadata("arn:aws:s3:::my-bucket"),
+		})
+	results.Add("Bucket does not have encryption enabled",
+		s3.Bucket{
+			Metadata: defsecTypes.NewMetadata(defsecTypes.NewRange("main.tf", 3, 7, "", nil), "aws_s3_bucket.logs"),
+		})
+	results.Add("Bucket does not have encryption enabled",
+		s3.Bucket{
+			Metadata: defsecTypes.NewRemoteMetadata("arn:aws:ec2:eu-west-1:222222222222:instance/i-0123456789"),
+		})
+	results.SetRule(scan.Rule{
+		AVDID:      "AVD-AWS-0088",
+		ShortCode:  "enable-bucket-encryption",
+		Summary:    "Unencrypted S3 bucket.",
+		Resolution: "Configure bucket encryption",
+		Provider:   providers.AWSProvider,
+		Service:    "s3",
+		Links: []string{
+			"https://avd.aquasec.com/misconfig/avd-aws-0088",
+		},
+		Severity: severity.High,
+	})
+	require.NoError(t, formatter.Output(results))
+
+	var report asffReport
+	require.NoError(t, json.Unmarshal(buffer.Bytes(), &report))
+	require.Len(t, report.Findings, 3)
+
+	live := report.Findings[0]
+	assert.Equal(t, "2018-10-08", live.SchemaVersion)
+	assert.Equal(t, "arn:aws:securityhub:us-east-1:111111111111:product/111111111111/default", live.ProductArn)
+	assert.Equal(t, "111111111111", live.AwsAccountID)
+	assert.Equal(t, "AVD-AWS-0088", live.GeneratorID)
+	assert.Equal(t, "2023-01-01T00:00:00Z", live.CreatedAt)
+	assert.Equal(t, "HIGH", live.Severity.Label)
+	assert.Equal(t, "AVD-AWS-0088: Unencrypted S3 bucket.", live.Title)
+	assert.Equal(t, "FAILED", live.Compliance.Status)
+	assert.Equal(t, "https://avd.aquasec.com/misconfig/avd-aws-0088", live.Remediation.Recommendation.URL)
+	require.Len(t, live.Resources, 1)
+	assert.Equal(t, "AwsS3Bucket", live.Resources[0].Type)
+	assert.Equal(t, "arn:aws:s3:::my-bucket", live.Resources[0].ID)
+	assert.Equal(t, "us-east-1", live.Resources[0].Region)
+
+	iac := report.Findings[1]
+	require.Len(t, iac.Resources, 1)
+	assert.Equal(t, "Other", iac.Resources[0].Type)
+	assert.Equal(t, "main.tf:3-7/aws_s3_bucket.logs", iac.Resources[0].ID)
+	assert.Equal(t, "aws_s3_bucket.logs", iac.Resources[0].Details.Other["Resource"])
+	assert.NotEqual(t, live.ID, iac.ID)
+
+	// findings for resources in other accounts are still attributed to the configured account and region
+	other := report.Findings[2]
+	assert.Equal(t, "arn:aws:securityhub:us-east-1:111111111111:product/111111111111/default", other.ProductArn)
+	assert.Equal(t, "111111111111", other.AwsAccountID)
+	assert.Equal(t, "us-east-1", other.Region)
+	require.Len(t, other.Resources, 1)
+	assert.Equal(t, "AwsEc2Instance", other.Resources[0].Type)
+	assert.Equal(t, "eu-west-1", other.Resources[0].Region)
+	assert.Contains(t, other.Resources[0].ID, ":222222222222:")
+}
+
+func Test_ASFF_RequiresAccountAndRegion(t *testing.T) {
+	var results scan.Results
+	results.Add("Bucket does not have encryption enabled",
+		s3.Bucket{
+			Metadata: defsecTypes.NewRemoteMetadata("arn:aws:s3:::my-bucket"),
+		})
+	results.SetRule(scan.Rule{
+		AVDID:    "AVD-AWS-0088",
+		Provider: providers.AWSProvider,
+		Service:  "s3",
+		Severity: severity.High,
+	})
+
+	buffer := bytes.NewBuffer([]byte{})
+	assert.Error(t, New().AsASFF("", "us-east-1").WithWriter(buffer).Build().Output(results))
+	assert.Error(t, New().AsASFF("111111111111", "").WithWriter(buffer).Build().Output(results))
+	assert.Empty(t, buffer.Bytes())
+}
@@ -39,7 +39,7 @@ func outputCheckStyle(b ConfigurableFormatter, results scan.Results) error {
 	for _, res := range results {
 
 		switch res.Status() {
-		case scan.StatusIgnored:
+		case scan.StatusIgnored, scan.StatusBaselined:
 			if !b.IncludeIgnored() {
 				continue
 			}
@@ -16,7 +16,7 @@ func outputCSV(b ConfigurableFormatter, results scan.Results) error {
 
 	for _, res := range results {
 		switch res.Status() {
-		case scan.StatusIgnored:
+		case scan.StatusIgnored, scan.StatusBaselined:
 			if !b.IncludeIgnored() {
 				continue
 			}
@@ -1,6 +1,7 @@
 package formatters
 
 import (
+	"html/template"
 	"io"
 
 	"github.com/aquasecurity/defsec/pkg/scan"
@@ -109,3 +110,60 @@ func (f *factory) AsSimple() *factory {
 	f.base.outputOverride = outputSimple
 	return f
 }
+
+func (f *factory) AsHTML() *factory {
+	f.base.outputOverride = outputHTML
+	return f
+}
+
+func (f *factory) AsHTMLWithTemplate(tmpl *template.Template) *factory {
+	f.base.outputOverride = func(b ConfigurableFormatter, results scan.Results) error {
+		return outputHTMLWithTemplate(b, results, tmpl)
+	}
+	return f
+}
+
+func (f *factory) AsMarkdown() *factory {
+	f.base.outputOverride = outputMarkdown
+	return f
+}
+
+func (f *factory) AsGitHubActions() *factory {
+	f.base.outputOverride = outputGitHubActions
+	return f
+}
+
+func (f *factory) AsGitHubAnnotations() *factory {
+	f.base.outputOverride = outputGitHubAnnotations
+	return f
+}
+
+func (f *factory) AsGitLab() *factory {
+	f.base.outputOverride = outputGitLab
+	return f
+}
+
+func (f *factory) AsVersionedJSON() *factory {
+	f.base.outputOverride = outputVersionedJSON
+	return f
+}
+
+func (f *factory) AsNDJSON() *factory {
+	f.base.outputOverride = outputNDJSON
+	return f
+}
+
+func (f *factory) AsOCSF() *factory {
+	f.base.outputOverride = outputOCSF
+	return f
+}
+
+// AsASFF outputs AWS Security Finding Format documents, to be imported into the Security Hub of the given account
+// and region. Every finding is attributed to that account and region; the region of a resource is taken from its
+// ARN when it has one, e.g. results from cloud scans.
+func (f *factory) AsASFF(accountID string, region string) *factory {
+	f.base.outputOverride = func(b ConfigurableFormatter, results scan.Results) error {
+		return outputASFF(b, results, accountID, region)
+	}
+	return f
+}
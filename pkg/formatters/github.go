@@ -0,0 +1,152 @@
+package formatters
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/aquasecurity/defsec/pkg/severity"
+
+	"github.com/aquasecurity/defsec/pkg/scan"
+)
+
+// see https://docs.github.com/en/rest/checks/runs#annotations-object
+type gitHubAnnotation struct {
+	Path            string `json:"path"`
+	StartLine       int    `json:"start_line"`
+	EndLine         int    `json:"end_line"`
+	AnnotationLevel string `json:"annotation_level"`
+	Title           string `json:"title"`
+	Message         string `json:"message"`
+	RawDetails      string `json:"raw_details,omitempty"`
+}
+
+// outputGitHubAnnotations writes a JSON array of check run annotations, ready to be sent to the checks API.
+func outputGitHubAnnotations(b ConfigurableFormatter, results scan.Results) error {
+	var annotations = []gitHubAnnotation{}
+	for _, res := range results {
+		if res.Status() != scan.StatusFailed {
+			continue
+		}
+		path := b.Path(res, res.Metadata())
+		if path == "" {
+			continue
+		}
+		startLine, endLine := gitHubLines(res)
+		annotation := gitHubAnnotation{
+			Path:            path,
+			StartLine:       startLine,
+			EndLine:         endLine,
+			AnnotationLevel: gitHubAnnotationLevel(res.Severity()),
+			Title:           gitHubTitle(res),
+			Message:         res.Description(),
+		}
+		links := b.GetLinks(res)
+		if len(links) > 0 {
+			annotation.RawDetails = strings.Join(links, "\n")
+		}
+		annotations = append(annotations, annotation)
+	}
+	jsonWriter := json.NewEncoder(b.Writer())
+	jsonWriter.SetIndent("", "\t")
+	return jsonWriter.Encode(annotations)
+}
+
+// outputGitHubActions writes workflow commands which are turned into annotations when printed by a GitHub Actions step.
+// see https://docs.github.com/en/actions/using-workflows/workflow-commands-for-github-actions
+func outputGitHubActions(b ConfigurableFormatter, results scan.Results) error {
+	for _, res := range results {
+		if res.Status() != scan.StatusFailed {
+			continue
+		}
+		properties := []string{
+			fmt.Sprintf("title=%s", escapeWorkflowProperty(gitHubTitle(res))),
+		}
+		if path := b.Path(res, res.Metadata()); path != "" {
+			startLine, endLine := gitHubLines(res)
+			properties = append(properties,
+				fmt.Sprintf("file=%s", escapeWorkflowProperty(path)),
+				fmt.Sprintf("line=%d", startLine),
+				fmt.Sprintf("endLine=%d", endLine),
+			)
+		}
+		message := res.Description()
+		links := b.GetLinks(res)
+		if len(links) > 0 {
+			message = fmt.Sprintf("%s\nSee %s", message, links[0])
+		}
+		if _, err := fmt.Fprintf(
+			b.Writer(),
+			"::%s %s::%s\n",
+			gitHubCommandLevel(res.Severity()),
+			strings.Join(properties, ","),
+			escapeWorkflowData(message),
+		); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func gitHubTitle(res scan.Result) string {
+	if res.Rule().AVDID == "" {
+		return fmt.Sprintf("[%s] %s", res.Severity(), res.Rule().LongID())
+	}
+	return fmt.Sprintf("[%s] %s (%s)", res.Severity(), res.Rule().LongID(), res.Rule().AVDID)
+}
+
+func gitHubLines(res scan.Result) (int, int) {
+	rng := res.Range()
+	start, end := rng.GetStartLine(), rng.GetEndLine()
+	if start <= 0 {
+		start = 1
+	}
+	if end < start {
+		end = start
+	}
+	return start, end
+}
+
+func gitHubAnnotationLevel(s severity.Severity) string {
+	switch s {
+	case severity.High, severity.Critical:
+		return "failure"
+	case severity.Medium:
+		return "warning"
+	default:
+		return "notice"
+	}
+}
+
+func gitHubCommandLevel(s severity.Severity) string {
+	switch s {
+	case severity.High, severity.Critical:
+		return "error"
+	case severity.Medium:
+		return "warning"
+	default:
+		return "notice"
+	}
+}
+
+var workflowDataReplacer = strings.NewReplacer(
+	"%", "%25",
+	"\r", "%0D",
+	"\n", "%0A",
+)
+
+var workflowPropertyReplacer = strings.NewReplacer(
+	"%", "%25",
+	"\r", "%0D",
+	"\n", "%0A",
+	":", "%3A",
+	",", "%2C",
+)
+
+func escapeWorkflowData(input string) string {
+	return workflowDataReplacer.Replace(input)
+}
+
+func escapeWorkflowProperty(input string) string {
+	return workflowPropertyReplacer.Replace(input)
+}
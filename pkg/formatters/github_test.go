@@ -0,0 +1,70 @@
+package formatters
+
+import (
+	"bytes"
+	"testing"
+
+	defsecTypes "github.com/aquasecurity/defsec/pkg/types"
+
+	"github.com/aquasecurity/defsec/pkg/severity"
+
+	"github.com/aquasecurity/defsec/pkg/scan"
+
+	"github.com/aquasecurity/defsec/pkg/providers"
+	"github.com/aquasecurity/defsec/pkg/providers/aws/dynamodb"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func gitHubTestResults() scan.Results {
+	var results scan.Results
+	results.Add("Cluster encryption is not enabled.",
+		dynamodb.ServerSideEncryption{
+			Metadata: defsecTypes.NewTestMetadata(),
+			Enabled:  defsecTypes.Bool(false, defsecTypes.NewTestMetadata()),
+		})
+	results.AddPassed(defsecTypes.NewTestMetadata(), "Everything is fine.")
+	results.SetRule(scan.Rule{
+		AVDID:     "AVD-AA-9999",
+		ShortCode: "enable-at-rest-encryption",
+		Provider:  providers.AWSProvider,
+		Service:   "dynamodb",
+		Links: []string{
+			"https://google.com",
+		},
+		Severity: severity.Medium,
+	})
+	return results
+}
+
+func Test_GitHubActions(t *testing.T) {
+	want := "::warning title=[MEDIUM] aws-dynamodb-enable-at-rest-encryption (AVD-AA-9999),file=test.test,line=123,endLine=123::Cluster encryption is not enabled.%0ASee https://google.com\n"
+	buffer := bytes.NewBuffer([]byte{})
+	formatter := New().AsGitHubActions().WithWriter(buffer).Build()
+	require.NoError(t, formatter.Output(gitHubTestResults()))
+	assert.Equal(t, want, buffer.String())
+}
+
+func Test_GitHubAnnotations(t *testing.T) {
+	want := `[
+	{
+		"path": "test.test",
+		"start_line": 123,
+		"end_line": 123,
+		"annotation_level": "warning",
+		"title": "[MEDIUM] aws-dynamodb-enable-at-rest-encryption (AVD-AA-9999)",
+		"message": "Cluster encryption is not enabled.",
+		"raw_details": "https://google.com"
+	}
+]
+`
+	buffer := bytes.NewBuffer([]byte{})
+	formatter := New().AsGitHubAnnotations().WithWriter(buffer).Build()
+	require.NoError(t, formatter.Output(gitHubTestResults()))
+	assert.Equal(t, want, buffer.String())
+}
+
+func Test_EscapeWorkflowProperty(t *testing.T) {
+	assert.Equal(t, "a%3Ab%2Cc%25d%0Ae", escapeWorkflowProperty("a:b,c%d\ne"))
+}
@@ -0,0 +1,69 @@
+package formatters
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/aquasecurity/defsec/pkg/severity"
+
+	"github.com/aquasecurity/defsec/pkg/scan"
+)
+
+// see https://docs.gitlab.com/ee/ci/testing/code_quality.html#implement-a-custom-tool
+type gitLabIssue struct {
+	Description string         `json:"description"`
+	CheckName   string         `json:"check_name"`
+	Fingerprint string         `json:"fingerprint"`
+	Severity    string         `json:"severity"`
+	Location    gitLabLocation `json:"location"`
+}
+
+type gitLabLocation struct {
+	Path  string      `json:"path"`
+	Lines gitLabLines `json:"lines"`
+}
+
+type gitLabLines struct {
+	Begin int `json:"begin"`
+	End   int `json:"end"`
+}
+
+func outputGitLab(b ConfigurableFormatter, results scan.Results) error {
+	var issues = []gitLabIssue{}
+	for _, res := range results {
+		if res.Status() != scan.StatusFailed {
+			continue
+		}
+		path := b.Path(res, res.Metadata())
+		rng := res.Range()
+		issues = append(issues, gitLabIssue{
+			Description: fmt.Sprintf("[%s] %s", res.Rule().LongID(), res.Description()),
+			CheckName:   res.Rule().LongID(),
+			Fingerprint: res.Fingerprint(),
+			Severity:    gitLabSeverity(res.Severity()),
+			Location: gitLabLocation{
+				Path: path,
+				Lines: gitLabLines{
+					Begin: rng.GetStartLine(),
+					End:   rng.GetEndLine(),
+				},
+			},
+		})
+	}
+	jsonWriter := json.NewEncoder(b.Writer())
+	jsonWriter.SetIndent("", "\t")
+	return jsonWriter.Encode(issues)
+}
+
+func gitLabSeverity(s severity.Severity) string {
+	switch s {
+	case severity.Critical:
+		return "critical"
+	case severity.High:
+		return "major"
+	case severity.Medium:
+		return "minor"
+	default:
+		return "info"
+	}
+}
@@ -0,0 +1,53 @@
+package formatters
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	defsecTypes "github.com/aquasecurity/defsec/pkg/types"
+
+	"github.com/aquasecurity/defsec/pkg/severity"
+
+	"github.com/aquasecurity/defsec/pkg/scan"
+
+	"github.com/aquasecurity/defsec/pkg/providers"
+	"github.com/aquasecurity/defsec/pkg/providers/aws/dynamodb"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_GitLab(t *testing.T) {
+	buffer := bytes.NewBuffer([]byte{})
+	formatter := New().AsGitLab().WithWriter(buffer).Build()
+	var results scan.Results
+	results.Add("Cluster encryption is not enabled.",
+		dynamodb.ServerSideEncryption{
+			Metadata: defsecTypes.NewTestMetadata(),
+			Enabled:  defsecTypes.Bool(false, defsecTypes.NewTestMetadata()),
+		})
+	results.AddPassed(defsecTypes.NewTestMetadata(), "Everything is fine.")
+	results.SetRule(scan.Rule{Severity: severity.High, Provider: providers.AWSProvider, Service: "dynamodb", ShortCode: "enable-at-rest-encryption"})
+	require.NoError(t, formatter.Output(results))
+
+	var issues []gitLabIssue
+	require.NoError(t, json.Unmarshal(buffer.Bytes(), &issues))
+	require.Len(t, issues, 1)
+
+	issue := issues[0]
+	assert.Equal(t, "[aws-dynamodb-enable-at-rest-encryption] Cluster encryption is not enabled.", issue.Description)
+	assert.Equal(t, "aws-dynamodb-enable-at-rest-encryption", issue.CheckName)
+	assert.Equal(t, "major", issue.Severity)
+	assert.Equal(t, "test.test", issue.Location.Path)
+	assert.Equal(t, gitLabLines{Begin: 123, End: 123}, issue.Location.Lines)
+	assert.Len(t, issue.Fingerprint, 64)
+}
+
+func Test_GitLabWithEmptyResults(t *testing.T) {
+	buffer := bytes.NewBuffer([]byte{})
+	formatter := New().AsGitLab().WithWriter(buffer).Build()
+	var results scan.Results
+	require.NoError(t, formatter.Output(results))
+	assert.Equal(t, "[]\n", buffer.String())
+}
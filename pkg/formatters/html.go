@@ -0,0 +1,233 @@
+package formatters
+
+import (
+	_ "embed"
+	"html/template"
+	"sort"
+	"strings"
+
+	"github.com/aquasecurity/defsec/pkg/severity"
+
+	"github.com/aquasecurity/defsec/pkg/scan"
+)
+
+//go:embed templates/report.html.tmpl
+var defaultHTMLTemplate string
+
+// DefaultHTMLTemplate returns the template used by AsHTML, so that callers can extend it
+// (e.g. by redefining individual blocks) rather than starting from scratch.
+func DefaultHTMLTemplate() (*template.Template, error) {
+	return template.New("report").Funcs(htmlFuncs).Parse(defaultHTMLTemplate)
+}
+
+// NewHTMLTemplate parses a custom report template. The template has access to the same
+// helper functions as the default template and is executed against an HTMLReport.
+func NewHTMLTemplate(name string, text string) (*template.Template, error) {
+	return template.New(name).Funcs(htmlFuncs).Parse(text)
+}
+
+var htmlFuncs = template.FuncMap{
+	"lower": strings.ToLower,
+	"join":  strings.Join,
+}
+
+// HTMLReport is the data passed to HTML report templates.
+type HTMLReport struct {
+	Total      int
+	BySeverity []HTMLCount
+	ByProvider []HTMLCount
+	ByService  []HTMLCount
+	Findings   []HTMLFinding
+}
+
+// HTMLCount is a single bar in one of the report summary charts.
+type HTMLCount struct {
+	Label   string
+	Count   int
+	Percent int
+}
+
+// HTMLFinding is a single result as presented in an HTML report.
+type HTMLFinding struct {
+	Index       int
+	RuleID      string
+	LongID      string
+	Summary     string
+	Description string
+	Severity    severity.Severity
+	Status      string
+	Provider    string
+	Service     string
+	Resource    string
+	Filename    string
+	StartLine   int
+	EndLine     int
+	Impact      string
+	Resolution  string
+	Remediation []HTMLRemediation
+	Links       []string
+	Code        []scan.Line
+}
+
+// HTMLRemediation holds remediation guidance for a particular IaC format.
+type HTMLRemediation struct {
+	Format   string
+	Markdown string
+	Example  string
+}
+
+func outputHTML(b ConfigurableFormatter, results scan.Results) error {
+	tmpl, err := DefaultHTMLTemplate()
+	if err != nil {
+		return err
+	}
+	return outputHTMLWithTemplate(b, results, tmpl)
+}
+
+func outputHTMLWithTemplate(b ConfigurableFormatter, results scan.Results, tmpl *template.Template) error {
+	return tmpl.Execute(b.Writer(), buildHTMLReport(b, results))
+}
+
+func buildHTMLReport(b ConfigurableFormatter, results scan.Results) HTMLReport {
+
+	var report HTMLReport
+
+	severities := make(map[string]int)
+	providers := make(map[string]int)
+	services := make(map[string]int)
+
+	for _, res := range results {
+		switch res.Status() {
+		case scan.StatusIgnored, scan.StatusBaselined:
+			if !b.IncludeIgnored() {
+				continue
+			}
+		case scan.StatusPassed:
+			if !b.IncludePassed() {
+				continue
+			}
+		}
+
+		rule := res.Rule()
+		rng := res.Range()
+
+		finding := HTMLFinding{
+			Index:       len(report.Findings) + 1,
+			RuleID:      rule.AVDID,
+			LongID:      rule.LongID(),
+			Summary:     rule.Summary,
+			Description: res.Description(),
+			Severity:    res.Severity(),
+			Status:      statusName(res.Status()),
+			Provider:    rule.Provider.DisplayName(),
+			Service:     rule.ServiceDisplayName(),
+			Resource:    res.Flatten().Resource,
+			Filename:    b.Path(res, res.Metadata()),
+			StartLine:   rng.GetStartLine(),
+			EndLine:     rng.GetEndLine(),
+			Impact:      rule.Impact,
+			Resolution:  rule.Resolution,
+			Remediation: buildHTMLRemediation(rule),
+			Links:       b.GetLinks(res),
+		}
+
+		if code, err := res.GetCode(scan.OptionCodeWithHighlighted(false)); err == nil {
+			finding.Code = code.Lines
+		}
+
+		report.Findings = append(report.Findings, finding)
+
+		if res.Status() == scan.StatusFailed {
+			severities[string(res.Severity())]++
+			providers[finding.Provider]++
+			services[finding.Service]++
+			report.Total++
+		}
+	}
+
+	for _, sev := range severity.ValidSeverity {
+		report.BySeverity = append(report.BySeverity, HTMLCount{
+			Label:   string(sev),
+			Count:   severities[string(sev)],
+			Percent: percentOf(severities[string(sev)], report.Total),
+		})
+	}
+	report.ByProvider = sortedCounts(providers, report.Total)
+	report.ByService = sortedCounts(services, report.Total)
+
+	return report
+}
+
+func buildHTMLRemediation(rule scan.Rule) []HTMLRemediation {
+	var remediation []HTMLRemediation
+	for _, engine := range []struct {
+		format   string
+		metadata *scan.EngineMetadata
+	}{
+		{format: "Terraform", metadata: rule.Terraform},
+		{format: "CloudFormation", metadata: rule.CloudFormation},
+	} {
+		if engine.metadata == nil {
+			continue
+		}
+		item := HTMLRemediation{
+			Format:   engine.format,
+			Markdown: engine.metadata.RemediationMarkdown,
+		}
+		if len(engine.metadata.GoodExamples) > 0 {
+			item.Example = strings.TrimSpace(engine.metadata.GoodExamples[0])
+		}
+		if item.Markdown == "" && item.Example == "" {
+			continue
+		}
+		remediation = append(remediation, item)
+	}
+	if rule.Remediation != nil {
+		for _, snippet := range rule.Remediation.Snippets {
+			remediation = append(remediation, HTMLRemediation{
+				Format:   string(snippet.Format),
+				Markdown: snippet.Description,
+				Example:  strings.TrimSpace(snippet.Code),
+			})
+		}
+	}
+	return remediation
+}
+
+func sortedCounts(counts map[string]int, total int) []HTMLCount {
+	var output []HTMLCount
+	for label, count := range counts {
+		output = append(output, HTMLCount{
+			Label:   label,
+			Count:   count,
+			Percent: percentOf(count, total),
+		})
+	}
+	sort.Slice(output, func(i, j int) bool {
+		if output[i].Count == output[j].Count {
+			return output[i].Label < output[j].Label
+		}
+		return output[i].Count > output[j].Count
+	})
+	return output
+}
+
+func percentOf(count int, total int) int {
+	if total == 0 {
+		return 0
+	}
+	return count * 100 / total
+}
+
+func statusName(status scan.Status) string {
+	switch status {
+	case scan.StatusPassed:
+		return "passed"
+	case scan.StatusIgnored:
+		return "ignored"
+	case scan.StatusBaselined:
+		return "baselined"
+	default:
+		return "failed"
+	}
+}
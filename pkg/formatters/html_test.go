@@ -0,0 +1,81 @@
+package formatters
+
+import (
+	"bytes"
+	"testing"
+
+	defsecTypes "github.com/aquasecurity/defsec/pkg/types"
+
+	"github.com/aquasecurity/defsec/pkg/severity"
+
+	"github.com/aquasecurity/defsec/pkg/scan"
+
+	"github.com/aquasecurity/defsec/pkg/providers"
+	"github.com/aquasecurity/defsec/pkg/providers/aws/dynamodb"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func htmlTestResults() scan.Results {
+	var results scan.Results
+	results.Add("Cluster encryption is not enabled.",
+		dynamodb.ServerSideEncryption{
+			Metadata: defsecTypes.NewTestMetadata(),
+			Enabled:  defsecTypes.Bool(false, defsecTypes.NewTestMetadata()),
+		})
+	results.AddPassed(defsecTypes.NewTestMetadata(), "Everything is fine.")
+	results.SetRule(scan.Rule{
+		AVDID:      "AVD-AA-9999",
+		ShortCode:  "enable-at-rest-encryption",
+		Summary:    "summary",
+		Resolution: "Enable encryption",
+		Provider:   providers.AWSProvider,
+		Service:    "dynamodb",
+		Severity:   severity.High,
+		Terraform: &scan.EngineMetadata{
+			GoodExamples: []string{`resource "aws_dax_cluster" "good" {}`},
+		},
+	})
+	return results
+}
+
+func Test_HTML(t *testing.T) {
+	buffer := bytes.NewBuffer([]byte{})
+	formatter := New().AsHTML().WithWriter(buffer).Build()
+	require.NoError(t, formatter.Output(htmlTestResults()))
+
+	output := buffer.String()
+	assert.Contains(t, output, "<!DOCTYPE html>")
+	assert.Contains(t, output, "aws-dynamodb-enable-at-rest-encryption")
+	assert.Contains(t, output, "Cluster encryption is not enabled.")
+	assert.Contains(t, output, "Enable encryption")
+	assert.Contains(t, output, "resource &#34;aws_dax_cluster&#34; &#34;good&#34; {}")
+	assert.Contains(t, output, "<code>test.test:123</code>")
+	assert.NotContains(t, output, "Everything is fine.")
+}
+
+func Test_HTMLReportSummary(t *testing.T) {
+	report := buildHTMLReport(New().WithIncludePassed(true).Build().(*Base), htmlTestResults())
+	require.Len(t, report.Findings, 2)
+	assert.Equal(t, 1, report.Total)
+	assert.Equal(t, []HTMLCount{{Label: "AWS", Count: 1, Percent: 100}}, report.ByProvider)
+	assert.Equal(t, []HTMLCount{{Label: "DynamoDB", Count: 1, Percent: 100}}, report.ByService)
+	for _, count := range report.BySeverity {
+		if count.Label == string(severity.High) {
+			assert.Equal(t, 1, count.Count)
+		} else {
+			assert.Equal(t, 0, count.Count)
+		}
+	}
+}
+
+func Test_HTMLWithCustomTemplate(t *testing.T) {
+	tmpl, err := NewHTMLTemplate("custom", `{{ range .Findings }}<p>{{ .LongID }} {{ .Severity }}</p>{{ end }}`)
+	require.NoError(t, err)
+
+	buffer := bytes.NewBuffer([]byte{})
+	formatter := New().AsHTMLWithTemplate(tmpl).WithWriter(buffer).Build()
+	require.NoError(t, formatter.Output(htmlTestResults()))
+	assert.Equal(t, "<p>aws-dynamodb-enable-at-rest-encryption HIGH</p>", buffer.String())
+}
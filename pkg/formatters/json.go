@@ -12,7 +12,7 @@ func outputJSON(b ConfigurableFormatter, results scan.Results) error {
 	var flatResults = []scan.FlatResult{}
 	for _, result := range results {
 		switch result.Status() {
-		case scan.StatusIgnored:
+		case scan.StatusIgnored, scan.StatusBaselined:
 			if !b.IncludeIgnored() {
 				continue
 			}
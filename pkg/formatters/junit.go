@@ -52,14 +52,14 @@ func outputJUnit(b ConfigurableFormatter, results scan.Results) error {
 		Tests:    fmt.Sprintf("%d", len(results)),
 	}
 
-	skipped := countWithStatus(results, scan.StatusIgnored)
+	skipped := countWithStatus(results, scan.StatusIgnored) + countWithStatus(results, scan.StatusBaselined)
 	if skipped > 0 {
 		output.Skipped = fmt.Sprintf("%d", skipped)
 	}
 
 	for _, res := range results {
 		switch res.Status() {
-		case scan.StatusIgnored:
+		case scan.StatusIgnored, scan.StatusBaselined:
 			if !b.IncludeIgnored() {
 				continue
 			}
@@ -133,7 +133,7 @@ func buildFailure(b ConfigurableFormatter, res scan.Result) *jUnitFailure {
 }
 
 func buildSkipped(res scan.Result) *jUnitSkipped {
-	if res.Status() != scan.StatusIgnored {
+	if res.Status() != scan.StatusIgnored && res.Status() != scan.StatusBaselined {
 		return nil
 	}
 	return &jUnitSkipped{
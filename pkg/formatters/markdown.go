@@ -0,0 +1,131 @@
+package formatters
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/aquasecurity/defsec/pkg/severity"
+
+	"github.com/aquasecurity/defsec/pkg/scan"
+)
+
+func outputMarkdown(b ConfigurableFormatter, results scan.Results) error {
+
+	var included scan.Results
+	counts := make(map[severity.Severity]int)
+	for _, res := range results {
+		switch res.Status() {
+		case scan.StatusIgnored, scan.StatusBaselined:
+			if !b.IncludeIgnored() {
+				continue
+			}
+		case scan.StatusPassed:
+			if !b.IncludePassed() {
+				continue
+			}
+		}
+		included = append(included, res)
+		if res.Status() == scan.StatusFailed {
+			counts[res.Severity()]++
+		}
+	}
+
+	w := &markdownWriter{w: b.Writer()}
+
+	w.printf("## defsec results\n\n")
+
+	w.printf("| Severity | Count |\n")
+	w.printf("|----------|------:|\n")
+	for _, sev := range severity.ValidSeverity {
+		w.printf("| %s | %d |\n", sev, counts[sev])
+	}
+	w.printf("\n")
+
+	if len(included) == 0 {
+		w.printf("No problems detected.\n")
+		return w.err
+	}
+
+	w.printf("| # | Severity | Rule | Location | Status |\n")
+	w.printf("|---|----------|------|----------|--------|\n")
+	for i, res := range included {
+		w.printf(
+			"| [%d](#result-%d) | %s | `%s` | `%s` | %s |\n",
+			i+1,
+			i+1,
+			res.Severity(),
+			res.Rule().LongID(),
+			markdownLocation(b, res),
+			statusName(res.Status()),
+		)
+	}
+	w.printf("\n")
+
+	for i, res := range included {
+		rule := res.Rule()
+		w.printf("### <a id=\"result-%d\"></a>%d. %s `%s`\n\n", i+1, i+1, res.Severity(), rule.LongID())
+		w.printf("%s\n\n", markdownEscape(res.Description()))
+		if rule.AVDID != "" {
+			w.printf("- **ID:** %s\n", rule.AVDID)
+		}
+		w.printf("- **Location:** `%s`\n", markdownLocation(b, res))
+		if resource := res.Flatten().Resource; resource != "" {
+			w.printf("- **Resource:** `%s`\n", resource)
+		}
+		if rule.Impact != "" {
+			w.printf("- **Impact:** %s\n", markdownEscape(rule.Impact))
+		}
+		if rule.Resolution != "" {
+			w.printf("- **Resolution:** %s\n", markdownEscape(rule.Resolution))
+		}
+		links := b.GetLinks(res)
+		if len(links) > 0 {
+			w.printf("- **More information:**\n")
+			for _, link := range links {
+				w.printf("  - %s\n", link)
+			}
+		}
+		w.printf("\n")
+	}
+
+	return w.err
+}
+
+func markdownLocation(b ConfigurableFormatter, res scan.Result) string {
+	path := b.Path(res, res.Metadata())
+	if path == "" {
+		return "-"
+	}
+	rng := res.Range()
+	if rng.GetStartLine() == 0 {
+		return path
+	}
+	if rng.GetStartLine() == rng.GetEndLine() {
+		return fmt.Sprintf("%s:%d", path, rng.GetStartLine())
+	}
+	return fmt.Sprintf("%s:%d-%d", path, rng.GetStartLine(), rng.GetEndLine())
+}
+
+var markdownReplacer = strings.NewReplacer(
+	"|", "\\|",
+	"\r\n", " ",
+	"\n", " ",
+)
+
+func markdownEscape(input string) string {
+	return markdownReplacer.Replace(input)
+}
+
+// markdownWriter keeps the first write error so the output function can stay linear
+type markdownWriter struct {
+	w   io.Writer
+	err error
+}
+
+func (m *markdownWriter) printf(format string, args ...interface{}) {
+	if m.err != nil {
+		return
+	}
+	_, m.err = fmt.Fprintf(m.w, format, args...)
+}
@@ -0,0 +1,75 @@
+package formatters
+
+import (
+	"bytes"
+	"testing"
+
+	defsecTypes "github.com/aquasecurity/defsec/pkg/types"
+
+	"github.com/aquasecurity/defsec/pkg/severity"
+
+	"github.com/aquasecurity/defsec/pkg/scan"
+
+	"github.com/aquasecurity/defsec/pkg/providers"
+	"github.com/aquasecurity/defsec/pkg/providers/aws/dynamodb"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Markdown(t *testing.T) {
+	want := "## defsec results\n\n" +
+		"| Severity | Count |\n" +
+		"|----------|------:|\n" +
+		"| CRITICAL | 0 |\n" +
+		"| HIGH | 1 |\n" +
+		"| MEDIUM | 0 |\n" +
+		"| LOW | 0 |\n" +
+		"\n" +
+		"| # | Severity | Rule | Location | Status |\n" +
+		"|---|----------|------|----------|--------|\n" +
+		"| [1](#result-1) | HIGH | `aws-dynamodb-enable-at-rest-encryption` | `test.test:123` | failed |\n" +
+		"\n" +
+		"### <a id=\"result-1\"></a>1. HIGH `aws-dynamodb-enable-at-rest-encryption`\n\n" +
+		"Cluster encryption is not enabled.\n\n" +
+		"- **ID:** AVD-AA-9999\n" +
+		"- **Location:** `test.test:123`\n" +
+		"- **Impact:** impact\n" +
+		"- **Resolution:** resolution\n" +
+		"- **More information:**\n" +
+		"  - https://google.com\n" +
+		"\n"
+
+	buffer := bytes.NewBuffer([]byte{})
+	formatter := New().AsMarkdown().WithWriter(buffer).Build()
+	var results scan.Results
+	results.Add("Cluster encryption is not enabled.",
+		dynamodb.ServerSideEncryption{
+			Metadata: defsecTypes.NewTestMetadata(),
+			Enabled:  defsecTypes.Bool(false, defsecTypes.NewTestMetadata()),
+		})
+	results.AddPassed(defsecTypes.NewTestMetadata(), "Everything is fine.")
+	results.SetRule(scan.Rule{
+		AVDID:      "AVD-AA-9999",
+		ShortCode:  "enable-at-rest-encryption",
+		Summary:    "summary",
+		Impact:     "impact",
+		Resolution: "resolution",
+		Provider:   providers.AWSProvider,
+		Service:    "dynamodb",
+		Links: []string{
+			"https://google.com",
+		},
+		Severity: severity.High,
+	})
+	require.NoError(t, formatter.Output(results))
+	assert.Equal(t, want, buffer.String())
+}
+
+func Test_MarkdownWithEmptyResults(t *testing.T) {
+	buffer := bytes.NewBuffer([]byte{})
+	formatter := New().AsMarkdown().WithWriter(buffer).Build()
+	var results scan.Results
+	require.NoError(t, formatter.Output(results))
+	assert.Contains(t, buffer.String(), "No problems detected.\n")
+}
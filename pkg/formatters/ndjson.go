@@ -0,0 +1,29 @@
+package formatters
+
+import (
+	"encoding/json"
+
+	"github.com/aquasecurity/defsec/pkg/scan"
+)
+
+// outputNDJSON writes one VersionedResult object per line, so consumers can process results without
+// reading the entire report into memory
+func outputNDJSON(b ConfigurableFormatter, results scan.Results) error {
+	jsonWriter := json.NewEncoder(b.Writer())
+	for _, res := range results {
+		switch res.Status() {
+		case scan.StatusIgnored, scan.StatusBaselined:
+			if !b.IncludeIgnored() {
+				continue
+			}
+		case scan.StatusPassed:
+			if !b.IncludePassed() {
+				continue
+			}
+		}
+		if err := jsonWriter.Encode(newVersionedResult(b, res)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
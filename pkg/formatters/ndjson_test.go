@@ -0,0 +1,65 @@
+package formatters
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	defsecTypes "github.com/aquasecurity/defsec/pkg/types"
+
+	"github.com/aquasecurity/defsec/pkg/severity"
+
+	"github.com/aquasecurity/defsec/pkg/scan"
+
+	"github.com/aquasecurity/defsec/pkg/providers"
+	"github.com/aquasecurity/defsec/pkg/providers/aws/dynamodb"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_NDJSON(t *testing.T) {
+	buffer := bytes.NewBuffer([]byte{})
+	formatter := New().AsNDJSON().WithWriter(buffer).Build()
+	var results scan.Results
+	results.Add("Cluster encryption is not enabled.",
+		dynamodb.ServerSideEncryption{
+			Metadata: defsecTypes.NewTestMetadata(),
+			Enabled:  defsecTypes.Bool(false, defsecTypes.NewTestMetadata()),
+		})
+	results.Add("Cluster encryption is still not enabled.",
+		dynamodb.ServerSideEncryption{
+			Metadata: defsecTypes.NewTestMetadata(),
+			Enabled:  defsecTypes.Bool(false, defsecTypes.NewTestMetadata()),
+		})
+	results.AddPassed(dynamodb.ServerSideEncryption{
+		Metadata: defsecTypes.NewTestMetadata(),
+		Enabled:  defsecTypes.Bool(true, defsecTypes.NewTestMetadata()),
+	})
+	results.SetRule(scan.Rule{
+		AVDID:     "AVD-AA-9999",
+		ShortCode: "enable-at-rest-encryption",
+		Summary:   "summary",
+		Provider:  providers.AWSProvider,
+		Service:   "dynamodb",
+		Severity:  severity.High,
+	})
+	require.NoError(t, formatter.Output(results))
+
+	var lines []VersionedResult
+	scanner := bufio.NewScanner(buffer)
+	for scanner.Scan() {
+		var result VersionedResult
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &result))
+		lines = append(lines, result)
+	}
+	require.NoError(t, scanner.Err())
+
+	require.Len(t, lines, 2)
+	assert.Equal(t, "Cluster encryption is not enabled.", lines[0].Description)
+	assert.Equal(t, "Cluster encryption is still not enabled.", lines[1].Description)
+	assert.Equal(t, "AVD-AA-9999", lines[1].Rule.ID)
+	assert.Equal(t, "failed", lines[1].Status)
+	assert.Equal(t, 123, lines[1].Location.StartLine)
+}
@@ -0,0 +1,210 @@
+package formatters
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/aquasecurity/defsec/pkg/severity"
+
+	"github.com/aquasecurity/defsec/pkg/scan"
+)
+
+// OCSF Security Finding class - see https://schema.ocsf.io/1.0.0/classes/security_finding
+const (
+	ocsfVersion          = "1.0.0"
+	ocsfCategoryUID      = 2
+	ocsfCategoryName     = "Findings"
+	ocsfClassUID         = 2001
+	ocsfClassName        = "Security Finding"
+	ocsfActivityCreate   = 1
+	ocsfStateNew         = 1
+	ocsfStateSuppressed  = 3
+	ocsfStateResolved    = 4
+	ocsfSeverityUnknown  = 0
+	ocsfSeverityLow      = 2
+	ocsfSeverityMedium   = 3
+	ocsfSeverityHigh     = 4
+	ocsfSeverityCritical = 5
+)
+
+// ocsfNow is replaced in tests so event times are deterministic
+var ocsfNow = time.Now
+
+type ocsfFinding struct {
+	ActivityID   int            `json:"activity_id"`
+	ActivityName string         `json:"activity_name"`
+	CategoryUID  int            `json:"category_uid"`
+	CategoryName string         `json:"category_name"`
+	ClassUID     int            `json:"class_uid"`
+	ClassName    string         `json:"class_name"`
+	TypeUID      int            `json:"type_uid"`
+	Time         int64          `json:"time"`
+	SeverityID   int            `json:"severity_id"`
+	Severity     string         `json:"severity"`
+	StateID      int            `json:"state_id"`
+	State        string         `json:"state"`
+	Message      string         `json:"message"`
+	Finding      ocsfFindingObj `json:"finding"`
+	Resources    []ocsfResource `json:"resources,omitempty"`
+	Metadata     ocsfMetadata   `json:"metadata"`
+	Unmapped     ocsfUnmapped   `json:"unmapped"`
+}
+
+type ocsfFindingObj struct {
+	UID         string           `json:"uid"`
+	Title       string           `json:"title"`
+	Desc        string           `json:"desc"`
+	Types       []string         `json:"types,omitempty"`
+	SrcURL      string           `json:"src_url,omitempty"`
+	Remediation *ocsfRemediation `json:"remediation,omitempty"`
+}
+
+type ocsfRemediation struct {
+	Desc       string   `json:"desc"`
+	KBArticles []string `json:"kb_articles,omitempty"`
+}
+
+type ocsfResource struct {
+	UID  string `json:"uid"`
+	Type string `json:"type,omitempty"`
+}
+
+type ocsfMetadata struct {
+	Version string      `json:"version"`
+	Product ocsfProduct `json:"product"`
+}
+
+type ocsfProduct struct {
+	Name       string `json:"name"`
+	VendorName string `json:"vendor_name"`
+}
+
+// ocsfUnmapped holds defsec specific attributes which have no equivalent in the OCSF schema
+type ocsfUnmapped struct {
+	RuleID     string `json:"rule_id"`
+	LongID     string `json:"long_id"`
+	Provider   string `json:"provider"`
+	Service    string `json:"service"`
+	Filename   string `json:"filename,omitempty"`
+	StartLine  int    `json:"start_line,omitempty"`
+	EndLine    int    `json:"end_line,omitempty"`
+	Resolution string `json:"resolution,omitempty"`
+}
+
+func outputOCSF(b ConfigurableFormatter, results scan.Results) error {
+	now := ocsfNow().UnixMilli()
+	var findings = []ocsfFinding{}
+	for _, res := range results {
+		switch res.Status() {
+		case scan.StatusIgnored, scan.StatusBaselined:
+			if !b.IncludeIgnored() {
+				continue
+			}
+		case scan.StatusPassed:
+			if !b.IncludePassed() {
+				continue
+			}
+		}
+		findings = append(findings, newOCSFFinding(b, res, now))
+	}
+	jsonWriter := json.NewEncoder(b.Writer())
+	jsonWriter.SetIndent("", "\t")
+	return jsonWriter.Encode(findings)
+}
+
+func newOCSFFinding(b ConfigurableFormatter, res scan.Result, now int64) ocsfFinding {
+	rule := res.Rule()
+	rng := res.Range()
+	path := b.Path(res, res.Metadata())
+	stateID, state := ocsfState(res.Status())
+	severityID, severityName := ocsfSeverity(res.Severity())
+
+	finding := ocsfFinding{
+		ActivityID:   ocsfActivityCreate,
+		ActivityName: "Create",
+		CategoryUID:  ocsfCategoryUID,
+		CategoryName: ocsfCategoryName,
+		ClassUID:     ocsfClassUID,
+		ClassName:    ocsfClassName,
+		TypeUID:      ocsfClassUID*100 + ocsfActivityCreate,
+		Time:         now,
+		SeverityID:   severityID,
+		Severity:     severityName,
+		StateID:      stateID,
+		State:        state,
+		Message:      res.Description(),
+		Finding: ocsfFindingObj{
+			UID:   res.Fingerprint(),
+			Title: rule.Summary,
+			Desc:  res.Description(),
+			Types: []string{fmt.Sprintf("%s/%s", rule.Provider, rule.Service)},
+		},
+		Metadata: ocsfMetadata{
+			Version: ocsfVersion,
+			Product: ocsfProduct{
+				Name:       "defsec",
+				VendorName: "Aqua Security",
+			},
+		},
+		Unmapped: ocsfUnmapped{
+			RuleID:     rule.AVDID,
+			LongID:     rule.LongID(),
+			Provider:   string(rule.Provider),
+			Service:    rule.Service,
+			Filename:   path,
+			StartLine:  rng.GetStartLine(),
+			EndLine:    rng.GetEndLine(),
+			Resolution: rule.Resolution,
+		},
+	}
+	if finding.Finding.Title == "" {
+		finding.Finding.Title = rule.LongID()
+	}
+
+	links := b.GetLinks(res)
+	if len(links) > 0 {
+		finding.Finding.SrcURL = links[0]
+	}
+	if rule.Resolution != "" {
+		finding.Finding.Remediation = &ocsfRemediation{
+			Desc:       rule.Resolution,
+			KBArticles: links,
+		}
+	}
+	if resource := res.Flatten().Resource; resource != "" {
+		finding.Resources = []ocsfResource{
+			{
+				UID:  resource,
+				Type: rule.Service,
+			},
+		}
+	}
+	return finding
+}
+
+func ocsfState(status scan.Status) (int, string) {
+	switch status {
+	case scan.StatusIgnored, scan.StatusBaselined:
+		return ocsfStateSuppressed, "Suppressed"
+	case scan.StatusPassed:
+		return ocsfStateResolved, "Resolved"
+	default:
+		return ocsfStateNew, "New"
+	}
+}
+
+func ocsfSeverity(s severity.Severity) (int, string) {
+	switch s {
+	case severity.Critical:
+		return ocsfSeverityCritical, "Critical"
+	case severity.High:
+		return ocsfSeverityHigh, "High"
+	case severity.Medium:
+		return ocsfSeverityMedium, "Medium"
+	case severity.Low:
+		return ocsfSeverityLow, "Low"
+	default:
+		return ocsfSeverityUnknown, "Unknown"
+	}
+}
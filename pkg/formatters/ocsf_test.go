@@ -0,0 +1,97 @@
+package formatters
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	defsecTypes "github.com/aquasecurity/defsec/pkg/types"
+
+	"github.com/aquasecurity/defsec/pkg/severity"
+
+	"github.com/aquasecurity/defsec/pkg/scan"
+
+	"github.com/aquasecurity/defsec/pkg/providers"
+	"github.com/aquasecurity/defsec/pkg/providers/aws/dynamodb"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_OCSF(t *testing.T) {
+	defer func(now func() time.Time) { ocsfNow = now }(ocsfNow)
+	ocsfNow = func() time.Time { return time.UnixMilli(1672531200000) }
+
+	want := `[
+	{
+		"activity_id": 1,
+		"activity_name": "Create",
+		"category_uid": 2,
+		"category_name": "Findings",
+		"class_uid": 2001,
+		"class_name": "Security Finding",
+		"type_uid": 200101,
+		"time": 1672531200000,
+		"severity_id": 4,
+		"severity": "High",
+		"state_id": 1,
+		"state": "New",
+		"message": "Cluster encryption is not enabled.",
+		"finding": {
+			"uid": "a2eea7073a3363cb01dc29a7fa9fb0548db17b6f404b0eaae3aec1b3a6066dd9",
+			"title": "summary",
+			"desc": "Cluster encryption is not enabled.",
+			"types": [
+				"aws/dynamodb"
+			],
+			"src_url": "https://google.com",
+			"remediation": {
+				"desc": "Enable encryption",
+				"kb_articles": [
+					"https://google.com"
+				]
+			}
+		},
+		"metadata": {
+			"version": "1.0.0",
+			"product": {
+				"name": "defsec",
+				"vendor_name": "Aqua Security"
+			}
+		},
+		"unmapped": {
+			"rule_id": "AVD-AA-9999",
+			"long_id": "aws-dynamodb-enable-at-rest-encryption",
+			"provider": "aws",
+			"service": "dynamodb",
+			"filename": "test.test",
+			"start_line": 123,
+			"end_line": 123,
+			"resolution": "Enable encryption"
+		}
+	}
+]
+`
+	buffer := bytes.NewBuffer([]byte{})
+	formatter := New().AsOCSF().WithWriter(buffer).Build()
+	var results scan.Results
+	results.Add("Cluster encryption is not enabled.",
+		dynamodb.ServerSideEncryption{
+			Metadata: defsecTypes.NewTestMetadata(),
+			Enabled:  defsecTypes.Bool(false, defsecTypes.NewTestMetadata()),
+		})
+	results.SetRule(scan.Rule{
+		AVDID:      "AVD-AA-9999",
+		ShortCode:  "enable-at-rest-encryption",
+		Summary:    "summary",
+		Resolution: "Enable encryption",
+		Provider:   providers.AWSProvider,
+		Service:    "dynamodb",
+		Links: []string{
+			"https://google.com",
+		},
+		Severity: severity.High,
+	})
+	require.NoError(t, formatter.Output(results))
+	assert.Equal(t, want, buffer.String())
+}
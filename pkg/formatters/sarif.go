@@ -20,7 +20,7 @@ func outputSARIF(b ConfigurableFormatter, results scan.Results) error {
 	for _, res := range results {
 
 		switch res.Status() {
-		case scan.StatusIgnored:
+		case scan.StatusIgnored, scan.StatusBaselined:
 			if !b.IncludeIgnored() {
 				continue
 			}
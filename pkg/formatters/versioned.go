@@ -0,0 +1,107 @@
+package formatters
+
+import (
+	_ "embed"
+	"encoding/json"
+
+	"github.com/aquasecurity/defsec/pkg/scan"
+)
+
+// ResultsSchemaVersion is the version of the document written by AsVersionedJSON. It is only incremented
+// for backwards-incompatible changes - new optional fields may be added without changing the version.
+const ResultsSchemaVersion = "1"
+
+// ResultsSchema is the JSON schema describing the document written by AsVersionedJSON.
+//
+//go:embed schemas/results-v1.json
+var ResultsSchema string
+
+// VersionedReport is the stable JSON result format. Unlike scan.FlatResult, the shape of this
+// document is fixed for a given ResultsSchemaVersion.
+type VersionedReport struct {
+	SchemaVersion string            `json:"schema_version"`
+	Results       []VersionedResult `json:"results"`
+}
+
+type VersionedResult struct {
+	Rule        VersionedRule     `json:"rule"`
+	Description string            `json:"description"`
+	Severity    string            `json:"severity"`
+	Status      string            `json:"status"`
+	Warning     bool              `json:"warning"`
+	Resource    string            `json:"resource,omitempty"`
+	Fingerprint string            `json:"fingerprint"`
+	Location    VersionedLocation `json:"location"`
+}
+
+type VersionedRule struct {
+	ID         string   `json:"id"`
+	LongID     string   `json:"long_id"`
+	Aliases    []string `json:"aliases,omitempty"`
+	Provider   string   `json:"provider"`
+	Service    string   `json:"service"`
+	Summary    string   `json:"summary,omitempty"`
+	Impact     string   `json:"impact,omitempty"`
+	Resolution string   `json:"resolution,omitempty"`
+	Severity   string   `json:"severity"`
+	Links      []string `json:"links,omitempty"`
+}
+
+type VersionedLocation struct {
+	Filename  string `json:"filename"`
+	StartLine int    `json:"start_line"`
+	EndLine   int    `json:"end_line"`
+}
+
+func outputVersionedJSON(b ConfigurableFormatter, results scan.Results) error {
+	report := VersionedReport{
+		SchemaVersion: ResultsSchemaVersion,
+		Results:       []VersionedResult{},
+	}
+	for _, res := range results {
+		switch res.Status() {
+		case scan.StatusIgnored, scan.StatusBaselined:
+			if !b.IncludeIgnored() {
+				continue
+			}
+		case scan.StatusPassed:
+			if !b.IncludePassed() {
+				continue
+			}
+		}
+		report.Results = append(report.Results, newVersionedResult(b, res))
+	}
+	jsonWriter := json.NewEncoder(b.Writer())
+	jsonWriter.SetIndent("", "\t")
+	return jsonWriter.Encode(report)
+}
+
+func newVersionedResult(b ConfigurableFormatter, res scan.Result) VersionedResult {
+	rule := res.Rule()
+	rng := res.Range()
+	return VersionedResult{
+		Rule: VersionedRule{
+			ID:         rule.AVDID,
+			LongID:     rule.LongID(),
+			Aliases:    rule.AllAliases(),
+			Provider:   string(rule.Provider),
+			Service:    rule.Service,
+			Summary:    rule.Summary,
+			Impact:     rule.Impact,
+			Resolution: rule.Resolution,
+			Severity:   string(rule.Severity),
+			Links:      b.GetLinks(res),
+		},
+		Description: res.Description(),
+		Severity:    string(res.Severity()),
+		Status:      statusName(res.Status()),
+		Warning:     res.IsWarning(),
+		Resource:    res.Flatten().Resource,
+		Fingerprint: res.Fingerprint(),
+		Location: VersionedLocation{
+			Filename:  b.Path(res, res.Metadata()),
+			StartLine: rng.GetStartLine(),
+			EndLine:   rng.GetEndLine(),
+		},
+	}
+}
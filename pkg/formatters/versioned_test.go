@@ -0,0 +1,97 @@
+package formatters
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	defsecTypes "github.com/aquasecurity/defsec/pkg/types"
+
+	"github.com/aquasecurity/defsec/pkg/severity"
+
+	"github.com/aquasecurity/defsec/pkg/scan"
+
+	"github.com/aquasecurity/defsec/pkg/providers"
+	"github.com/aquasecurity/defsec/pkg/providers/aws/dynamodb"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_VersionedJSON(t *testing.T) {
+	want := `{
+	"schema_version": "1",
+	"results": [
+		{
+			"rule": {
+				"id": "AVD-AA-9999",
+				"long_id": "aws-dynamodb-enable-at-rest-encryption",
+				"provider": "aws",
+				"service": "dynamodb",
+				"summary": "summary",
+				"severity": "HIGH",
+				"links": [
+					"https://google.com"
+				]
+			},
+			"description": "Cluster encryption is not enabled.",
+			"severity": "HIGH",
+			"status": "failed",
+			"warning": false,
+			"fingerprint": "a2eea7073a3363cb01dc29a7fa9fb0548db17b6f404b0eaae3aec1b3a6066dd9",
+			"location": {
+				"filename": "test.test",
+				"start_line": 123,
+				"end_line": 123
+			}
+		}
+	]
+}
+`
+	buffer := bytes.NewBuffer([]byte{})
+	formatter := New().AsVersionedJSON().WithWriter(buffer).Build()
+	var results scan.Results
+	results.Add("Cluster encryption is not enabled.",
+		dynamodb.ServerSideEncryption{
+			Metadata: defsecTypes.NewTestMetadata(),
+			Enabled:  defsecTypes.Bool(false, defsecTypes.NewTestMetadata()),
+		})
+	results.SetRule(scan.Rule{
+		AVDID:     "AVD-AA-9999",
+		ShortCode: "enable-at-rest-encryption",
+		Summary:   "summary",
+		Provider:  providers.AWSProvider,
+		Service:   "dynamodb",
+		Links: []string{
+			"https://google.com",
+		},
+		Severity: severity.High,
+	})
+	require.NoError(t, formatter.Output(results))
+	assert.Equal(t, want, buffer.String())
+}
+
+func Test_VersionedJSONWithEmptyResults(t *testing.T) {
+	want := `{
+	"schema_version": "1",
+	"results": []
+}
+`
+	buffer := bytes.NewBuffer([]byte{})
+	formatter := New().AsVersionedJSON().WithWriter(buffer).Build()
+	var results scan.Results
+	require.NoError(t, formatter.Output(results))
+	assert.Equal(t, want, buffer.String())
+}
+
+func Test_ResultsSchemaMatchesVersion(t *testing.T) {
+	var schema struct {
+		Properties struct {
+			SchemaVersion struct {
+				Const string `json:"const"`
+			} `json:"schema_version"`
+		} `json:"properties"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(ResultsSchema), &schema))
+	assert.Equal(t, ResultsSchemaVersion, schema.Properties.SchemaVersion.Const)
+}
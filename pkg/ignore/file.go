@@ -0,0 +1,211 @@
+package ignore
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/aquasecurity/defsec/pkg/extrafs"
+	"github.com/aquasecurity/defsec/pkg/scan"
+	"github.com/aquasecurity/defsec/pkg/scanners/options"
+)
+
+// FileName is the name of the ignore file looked for at the root of a scan
+const FileName = ".defsecignore"
+
+// File is a set of suppressions kept in a single file at the root of a repository, rather than scattered
+// through it as inline comments. Each line of the file is an entry made up of whitespace separated terms:
+//
+//	# comments start with a hash
+//	path:vendor/**
+//	rule:aws-s3-enable-versioning path:modules/legacy/**/*.tf
+//	rule:AVD-AWS-0086 resource:aws_s3_bucket.logs* exp:2025-01-01 reason:"tracked in ticket-123"
+//
+// A result is ignored when it matches every term of an entry. Paths are relative to the directory containing
+// the file, and are matched by extrafs.MatchGlob, so ** matches any number of directories and a pattern without a
+// slash matches any path element, e.g. "vendor" or "*.generated.tf". Resources use path.Match syntax.
+type File struct {
+	Entries []Entry
+	// root is the directory the file was found in, which paths are relative to
+	root string
+}
+
+type Entry struct {
+	Rule      string
+	Paths     []string
+	Resources []string
+	Expiry    *time.Time
+	Reason    string
+}
+
+// Read parses an ignore file. Paths in the file are taken to be relative to the root of the scanned filesystem.
+func Read(r io.Reader) (*File, error) {
+	var f File
+	scanner := bufio.NewScanner(r)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+		entry, err := parseEntry(text)
+		if err != nil {
+			return nil, fmt.Errorf("%s line %d: %w", FileName, line, err)
+		}
+		f.Entries = append(f.Entries, *entry)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return &f, nil
+}
+
+// Load reads the ignore file at the given path.
+func Load(path string) (*File, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = f.Close() }()
+	return Read(f)
+}
+
+// Find reads the ignore file in the given directory of a filesystem, returning nil if there is none. Paths in
+// the file are taken to be relative to that directory.
+func Find(fsys fs.FS, dir string) (*File, error) {
+	f, err := fsys.Open(path.Join(dir, FileName))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = f.Close() }()
+	ignoreFile, err := Read(f)
+	if err != nil {
+		return nil, err
+	}
+	ignoreFile.root = path.Clean(dir)
+	return ignoreFile, nil
+}
+
+func parseEntry(text string) (*Entry, error) {
+	var entry Entry
+	for _, term := range tokenise(text) {
+		key, value, ok := strings.Cut(term, ":")
+		if !ok || value == "" {
+			return nil, fmt.Errorf("invalid term '%s', expected key:value", term)
+		}
+		switch key {
+		case "rule":
+			entry.Rule = value
+		case "path":
+			// patterns written on Windows may use its separator
+			value = filepath.ToSlash(value)
+			if _, err := path.Match(strings.ReplaceAll(value, "**", "*"), ""); err != nil {
+				return nil, fmt.Errorf("invalid path pattern '%s': %w", value, err)
+			}
+			entry.Paths = append(entry.Paths, value)
+		case "resource":
+			if _, err := path.Match(value, ""); err != nil {
+				return nil, fmt.Errorf("invalid resource pattern '%s': %w", value, err)
+			}
+			entry.Resources = append(entry.Resources, value)
+		case "exp":
+			parsed, err := time.Parse("2006-01-02", value)
+			if err != nil {
+				return nil, fmt.Errorf("invalid expiry date '%s': %w", value, err)
+			}
+			entry.Expiry = &parsed
+		case "reason":
+			entry.Reason = unquote(value)
+		default:
+			return nil, fmt.Errorf("unknown key '%s'", key)
+		}
+	}
+	if entry.Rule == "" && len(entry.Paths) == 0 && len(entry.Resources) == 0 {
+		return nil, fmt.Errorf("entry must specify at least one rule, path or resource")
+	}
+	return &entry, nil
+}
+
+// Apply marks each failed result matching an unexpired entry as ignored. It can be used directly as an
+// options.ResultProcessor.
+func (f *File) Apply(results scan.Results) scan.Results {
+	if f == nil {
+		return results
+	}
+	for i := range results {
+		if results[i].Status() != scan.StatusFailed {
+			continue
+		}
+		for _, entry := range f.Entries {
+			if entry.matches(f.root, &results[i]) {
+				results[i].OverrideStatus(scan.StatusIgnored)
+				results[i].OverrideIgnoreReason(entry.Reason)
+				break
+			}
+		}
+	}
+	return results
+}
+
+func (e Entry) matches(root string, result *scan.Result) bool {
+	if e.Expiry != nil && time.Now().After(*e.Expiry) {
+		return false
+	}
+	if e.Rule != "" && e.Rule != "*" && !result.Rule().HasID(e.Rule) {
+		return false
+	}
+	if len(e.Paths) > 0 {
+		filename := relativePath(root, result.Range().GetLocalFilename())
+		if filename == "" || !matchesAnyPath(e.Paths, filename) {
+			return false
+		}
+	}
+	if len(e.Resources) > 0 {
+		resource := result.Flatten().Resource
+		if resource == "" || !matchesAnyResource(e.Resources, resource) {
+			return false
+		}
+	}
+	return true
+}
+
+// relativePath returns the path of a file relative to the directory containing the ignore file, or an empty
+// string if it is outside of it
+func relativePath(root string, filename string) string {
+	if rel, inside := extrafs.RelPath(root, filename); inside {
+		return rel
+	}
+	return ""
+}
+
+func matchesAnyPath(patterns []string, filename string) bool {
+	for _, pattern := range patterns {
+		if extrafs.MatchGlob(pattern, filename) {
+			return true
+		}
+	}
+	return false
+}
+
+func matchesAnyResource(patterns []string, resource string) bool {
+	for _, pattern := range patterns {
+		if ok, _ := path.Match(pattern, resource); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// ScannerWithIgnoreFile ignores results matching the entries of the given ignore file in every scan.
+func ScannerWithIgnoreFile(f *File) options.ScannerOption {
+	return options.ScannerWithResultProcessors(f.Apply)
+}
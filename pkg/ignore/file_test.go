@@ -0,0 +1,97 @@
+package ignore
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/aquasecurity/defsec/pkg/scan"
+	defsecTypes "github.com/aquasecurity/defsec/pkg/types"
+	"github.com/aquasecurity/defsec/test/testutil"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func fileTestResults() scan.Results {
+	var results scan.Results
+	results.Add("a", defsecTypes.NewMetadata(defsecTypes.NewRange("vendor/lib/main.tf", 1, 1, "", nil), "aws_s3_bucket.data"))
+	results.Add("b", defsecTypes.NewMetadata(defsecTypes.NewRange("prod/main.tf", 1, 1, "", nil), "aws_s3_bucket.logs"))
+	results.Add("c", defsecTypes.NewMetadata(defsecTypes.NewRange("prod/main.tf", 5, 5, "", nil), "aws_s3_bucket.data"))
+	results.Add("d", defsecTypes.NewMetadata(defsecTypes.NewRange("dev/main.tf", 1, 1, "", nil), "aws_s3_bucket.data"))
+	results.SetRule(scan.Rule{
+		AVDID:     "AVD-TEST-0001",
+		Provider:  "aws",
+		Service:   "s3",
+		ShortCode: "enable-versioning",
+	})
+	return results
+}
+
+func Test_IgnoreFile(t *testing.T) {
+	f, err := Read(strings.NewReader(`
+# third party code
+path:vendor/**
+
+rule:aws-s3-enable-versioning resource:aws_s3_bucket.log* reason:"logs are write once"
+rule:AVD-TEST-0001 path:dev/ exp:2000-01-01
+`))
+	require.NoError(t, err)
+	require.Len(t, f.Entries, 3)
+
+	results := f.Apply(fileTestResults())
+	require.Len(t, results.GetIgnored(), 2)
+	assert.Equal(t, "a", results.GetIgnored()[0].Description())
+	assert.Equal(t, "b", results.GetIgnored()[1].Description())
+	assert.Equal(t, "logs are write once", results.GetIgnored()[1].IgnoreReason())
+	assert.Len(t, results.GetFailed(), 2)
+}
+
+func Test_IgnoreFileWithWindowsPaths(t *testing.T) {
+	f, err := Read(strings.NewReader(`path:vendor/**`))
+	require.NoError(t, err)
+
+	var results scan.Results
+	results.Add("a", defsecTypes.NewMetadata(defsecTypes.NewRange(`vendor\lib\main.tf`, 1, 1, "", nil), "aws_s3_bucket.data"))
+	results.Add("b", defsecTypes.NewMetadata(defsecTypes.NewRange(`C:\code\vendor\lib\main.tf`, 1, 1, "", nil), "aws_s3_bucket.data"))
+	results.Add("c", defsecTypes.NewMetadata(defsecTypes.NewRange(`prod\main.tf`, 1, 1, "", nil), "aws_s3_bucket.data"))
+
+	results = f.Apply(results)
+	require.Len(t, results.GetIgnored(), 1)
+	assert.Equal(t, "a", results.GetIgnored()[0].Description())
+	assert.Equal(t, "vendor/lib/main.tf", results.GetIgnored()[0].Range().GetFilename())
+	assert.Len(t, results.GetFailed(), 2)
+}
+
+func Test_IgnoreFileInvalid(t *testing.T) {
+	for _, input := range []string{
+		"rule",
+		"colour:blue",
+		"rule:abc exp:tomorrow",
+		"path:[",
+		"reason:nothing",
+	} {
+		_, err := Read(strings.NewReader(input))
+		assert.Error(t, err, input)
+	}
+}
+
+func Test_FindIgnoreFileInScanRoot(t *testing.T) {
+	fsys := testutil.CreateFS(t, map[string]string{
+		"code/.defsecignore": "path:prod/*.tf",
+	})
+
+	f, err := Find(fsys, "code")
+	require.NoError(t, err)
+	require.NotNil(t, f)
+
+	var results scan.Results
+	results.Add("a", defsecTypes.NewMetadata(defsecTypes.NewRange("code/prod/main.tf", 1, 1, "", nil), ""))
+	results.Add("b", defsecTypes.NewMetadata(defsecTypes.NewRange("prod/main.tf", 1, 1, "", nil), ""))
+	results = f.Apply(results)
+	require.Len(t, results.GetIgnored(), 1)
+	assert.Equal(t, "a", results.GetIgnored()[0].Description())
+
+	f, err = Find(fsys, ".")
+	require.NoError(t, err)
+	assert.Nil(t, f)
+}
@@ -0,0 +1,279 @@
+// Package ignore suppresses results using ignore comments written inline in scanned files.
+package ignore
+
+import (
+	"fmt"
+	"io/fs"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/aquasecurity/defsec/pkg/scan"
+	"github.com/aquasecurity/defsec/pkg/scanners/charset"
+	defsecTypes "github.com/aquasecurity/defsec/pkg/types"
+)
+
+// prefixes introduce an ignore comment - tfsec and trivy are accepted for compatibility with existing comments
+var prefixes = []string{"defsec:", "tfsec:", "trivy:"}
+
+// Rule is an inline ignore comment, such as:
+//
+//	# defsec:ignore:aws-s3-enable-bucket-logging exp:2025-01-01 reason:"ticket-123"
+//
+// Options can also be chained onto the ignore with colons, e.g. tfsec:ignore:aws-s3-enable-versioning:exp:2025-01-01
+type Rule struct {
+	// Line is the line the comment is on. A comment on a line of its own which is followed by further ignore
+	// comments takes the line of the last of them, so a stack of comments applies to the line below it.
+	Line      int
+	RuleID    string
+	Params    map[string]string
+	Expiry    *time.Time
+	Workspace string
+	Reason    string
+	// Block is set when the comment is on a line of its own, rather than following other content
+	Block bool
+}
+
+// Parse returns the ignore rules declared in the given file content
+func Parse(data []byte) []Rule {
+	var rules []Rule
+	for i, line := range strings.Split(string(data), "\n") {
+		for _, rule := range ParseLine(line) {
+			rule.Line = i + 1
+			rules = append(rules, rule)
+		}
+	}
+	for a, ruleA := range rules {
+		if !ruleA.Block {
+			continue
+		}
+		for _, ruleB := range rules {
+			if ruleB.Block && ruleA.Line+1 == ruleB.Line {
+				ruleA.Line = ruleB.Line
+				rules[a] = ruleA
+			}
+		}
+	}
+	return rules
+}
+
+// ParseLine returns the ignore rules declared in a single line. Ignores with invalid options (such as an
+// unparseable expiry date) are dropped.
+func ParseLine(line string) []Rule {
+	line = strings.TrimSpace(line)
+	block := hasCommentMarker(line)
+
+	var rules []Rule
+	var current *Rule
+	valid := true
+	flush := func() {
+		if current != nil && valid {
+			rules = append(rules, *current)
+		}
+		current = nil
+	}
+
+	for _, token := range tokenise(line) {
+		token = strings.TrimSuffix(trimCommentMarkers(token), "*/")
+		if ignore, ok := cutPrefix(token); ok {
+			flush()
+			current = &Rule{
+				Params: make(map[string]string),
+				Block:  block,
+			}
+			valid = current.setOptions(ignore) == nil && current.RuleID != ""
+			continue
+		}
+		if current == nil {
+			continue
+		}
+		key, _, _ := strings.Cut(token, ":")
+		switch key {
+		case "exp", "ws", "reason":
+			if err := current.setOptions(token); err != nil {
+				valid = false
+			}
+		}
+	}
+	flush()
+	return rules
+}
+
+func (r *Rule) setOptions(input string) error {
+	for input != "" {
+		key, rest, ok := strings.Cut(input, ":")
+		if !ok {
+			return nil
+		}
+		var value string
+		if key == "reason" {
+			// the reason is free text, so may itself contain colons
+			value, input = rest, ""
+		} else {
+			value, input, _ = strings.Cut(rest, ":")
+		}
+		switch key {
+		case "ignore":
+			r.RuleID, r.Params = parseIDWithParams(value)
+		case "exp":
+			parsed, err := time.Parse("2006-01-02", value)
+			if err != nil {
+				return fmt.Errorf("invalid expiry date '%s': %w", value, err)
+			}
+			r.Expiry = &parsed
+		case "ws":
+			r.Workspace = value
+		case "reason":
+			r.Reason = unquote(value)
+		}
+	}
+	return nil
+}
+
+// Expired reports whether the ignore has passed its expiry date, after which the results it covers are reported again
+func (r Rule) Expired() bool {
+	return r.Expiry != nil && time.Now().After(*r.Expiry)
+}
+
+// MatchesRule reports whether the ignore applies to results of the given rule
+func (r Rule) MatchesRule(rule scan.Rule) bool {
+	return r.RuleID == "*" || r.RuleID == rule.ShortCode || rule.HasID(r.RuleID)
+}
+
+// ApplyInline marks failed results as ignored where they are covered by an unexpired ignore comment in the file
+// they were found in, recording the reason given by the comment. A comment covers a result when the result, or
+// any of its parents, starts on the same line as the comment or the line following it. Files are read from the
+// filesystem recorded in each result's range, or from fsys if there is none. Ignores restricted to a workspace
+// are not applied, as workspaces are only meaningful to Terraform.
+func ApplyInline(fsys fs.FS, results scan.Results) scan.Results {
+	files := make(map[string][]Rule)
+	for i, result := range results {
+		if result.Status() != scan.StatusFailed {
+			continue
+		}
+		metadata := result.Metadata()
+		for m := &metadata; m != nil; m = m.Parent() {
+			rules := fileRules(files, fsys, m.Range())
+			if rule := findCovering(rules, result.Rule(), *m); rule != nil {
+				results[i].OverrideStatus(scan.StatusIgnored)
+				results[i].OverrideIgnoreReason(rule.Reason)
+				break
+			}
+		}
+	}
+	return results
+}
+
+func findCovering(rules []Rule, rule scan.Rule, metadata defsecTypes.Metadata) *Rule {
+	start := metadata.Range().GetStartLine()
+	for i := range rules {
+		if rules[i].Workspace != "" || rules[i].Expired() || !rules[i].MatchesRule(rule) {
+			continue
+		}
+		if start == rules[i].Line || start == rules[i].Line+1 {
+			return &rules[i]
+		}
+	}
+	return nil
+}
+
+// fileRules returns the ignore rules in the file a range refers to, reading each file at most once
+func fileRules(files map[string][]Rule, fsys fs.FS, rng defsecTypes.Range) []Rule {
+	filesystem := rng.GetFS()
+	if filesystem == nil {
+		filesystem = fsys
+	}
+	filename := rng.GetLocalFilename()
+	if filesystem == nil || filename == "" {
+		return nil
+	}
+	key := rng.GetFSKey() + ":" + filename
+	if rules, ok := files[key]; ok {
+		return rules
+	}
+	data, err := fs.ReadFile(filesystem, strings.TrimPrefix(filepath.ToSlash(filename), "/"))
+	var rules []Rule
+	if err == nil {
+		// files are parsed as UTF-8 regardless of their encoding, so lines match the ranges of results
+		rules = Parse(charset.Decode(data))
+	}
+	files[key] = rules
+	return rules
+}
+
+func cutPrefix(token string) (string, bool) {
+	for _, prefix := range prefixes {
+		if strings.HasPrefix(token, prefix+"ignore:") {
+			return token[len(prefix):], true
+		}
+	}
+	return "", false
+}
+
+func hasCommentMarker(line string) bool {
+	return strings.HasPrefix(line, "#") || strings.HasPrefix(line, "//") || strings.HasPrefix(line, "/*")
+}
+
+func trimCommentMarkers(token string) string {
+	for {
+		trimmed := strings.TrimPrefix(token, "#")
+		trimmed = strings.TrimPrefix(trimmed, "//")
+		trimmed = strings.TrimPrefix(trimmed, "/*")
+		if trimmed == token {
+			return token
+		}
+		token = trimmed
+	}
+}
+
+// tokenise splits a line on whitespace, except where it is inside double quotes
+func tokenise(line string) []string {
+	var tokens []string
+	var current strings.Builder
+	quoted := false
+	for _, r := range line {
+		switch {
+		case r == '"':
+			quoted = !quoted
+			current.WriteRune(r)
+		case unicode.IsSpace(r) && !quoted:
+			if current.Len() > 0 {
+				tokens = append(tokens, current.String())
+				current.Reset()
+			}
+		default:
+			current.WriteRune(r)
+		}
+	}
+	if current.Len() > 0 {
+		tokens = append(tokens, current.String())
+	}
+	return tokens
+}
+
+func unquote(value string) string {
+	if unquoted, err := strconv.Unquote(value); err == nil {
+		return unquoted
+	}
+	return strings.Trim(value, `"`)
+}
+
+func parseIDWithParams(input string) (string, map[string]string) {
+	params := make(map[string]string)
+	if !strings.Contains(input, "[") {
+		return input, params
+	}
+	parts := strings.Split(input, "[")
+	id := parts[0]
+	paramStr := strings.TrimSuffix(parts[1], "]")
+	for _, pair := range strings.Split(paramStr, ",") {
+		parts := strings.Split(pair, "=")
+		if len(parts) != 2 {
+			continue
+		}
+		params[parts[0]] = parts[1]
+	}
+	return id, params
+}
@@ -0,0 +1,138 @@
+package ignore
+
+import (
+	"encoding/binary"
+	"testing"
+	"time"
+	"unicode/utf16"
+
+	"github.com/aquasecurity/defsec/pkg/scan"
+	defsecTypes "github.com/aquasecurity/defsec/pkg/types"
+	"github.com/aquasecurity/defsec/test/testutil"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ParseLine(t *testing.T) {
+	tests := []struct {
+		name  string
+		line  string
+		rules []Rule
+	}{
+		{
+			name: "defsec with options",
+			line: `# defsec:ignore:aws-iam-no-policy-wildcards exp:2025-01-01 reason:"see ticket-123: accepted"`,
+			rules: []Rule{{
+				RuleID: "aws-iam-no-policy-wildcards",
+				Params: map[string]string{},
+				Expiry: date(t, "2025-01-01"),
+				Reason: "see ticket-123: accepted",
+				Block:  true,
+			}},
+		},
+		{
+			name: "tfsec with chained options after content",
+			line: `secure = false // tfsec:ignore:aws-s3-enable-versioning:exp:2025-01-01:ws:dev`,
+			rules: []Rule{{
+				RuleID:    "aws-s3-enable-versioning",
+				Params:    map[string]string{},
+				Expiry:    date(t, "2025-01-01"),
+				Workspace: "dev",
+			}},
+		},
+		{
+			name: "multiple ignores with params",
+			line: `#trivy:ignore:abc[name=x] trivy:ignore:def reason:unquoted`,
+			rules: []Rule{
+				{RuleID: "abc", Params: map[string]string{"name": "x"}, Block: true},
+				{RuleID: "def", Params: map[string]string{}, Reason: "unquoted", Block: true},
+			},
+		},
+		{
+			name: "invalid expiry",
+			line: `# defsec:ignore:abc exp:2025-13-01`,
+		},
+		{
+			name: "not an ignore",
+			line: `# defsec is great`,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.rules, ParseLine(test.line))
+		})
+	}
+}
+
+func Test_ParseStackedComments(t *testing.T) {
+	rules := Parse([]byte("# defsec:ignore:abc\n# defsec:ignore:def\nresource:\n"))
+	require.Len(t, rules, 2)
+	assert.Equal(t, 2, rules[0].Line)
+	assert.Equal(t, 2, rules[1].Line)
+}
+
+type testSource struct {
+	Metadata defsecTypes.Metadata
+}
+
+func Test_ApplyInline(t *testing.T) {
+	fsys := testutil.CreateFS(t, map[string]string{
+		"Dockerfile": `FROM alpine
+# defsec:ignore:ds-root-user reason:"base image requires root"
+USER root
+# defsec:ignore:ds-root-user exp:2000-01-01
+USER root
+USER root # defsec:ignore:ds-other-rule
+`,
+	})
+
+	var results scan.Results
+	for _, line := range []int{3, 5, 6} {
+		results.Add("root user", testSource{
+			Metadata: defsecTypes.NewMetadata(defsecTypes.NewRange("Dockerfile", line, line, "", nil), ""),
+		})
+	}
+	results.SetRule(scan.Rule{AVDID: "AVD-DS-0002", Provider: "dockerfile", Service: "general", ShortCode: "root-user", Aliases: []string{"ds-root-user"}})
+
+	results = ApplyInline(fsys, results)
+	require.Len(t, results.GetIgnored(), 1)
+	assert.Equal(t, 3, results.GetIgnored()[0].Range().GetStartLine())
+	assert.Equal(t, "base image requires root", results.GetIgnored()[0].IgnoreReason())
+	assert.Len(t, results.GetFailed(), 2)
+}
+
+func Test_ApplyInlineUTF16(t *testing.T) {
+	content := `FROM alpine
+# defsec:ignore:ds-root-user
+USER root
+USER root
+`
+	// UTF-16LE with a byte order mark, as written by some Windows editors
+	encoded := []byte{0xff, 0xfe}
+	for _, unit := range utf16.Encode([]rune(content)) {
+		encoded = binary.LittleEndian.AppendUint16(encoded, unit)
+	}
+	fsys := testutil.CreateFS(t, map[string]string{
+		"Dockerfile": string(encoded),
+	})
+
+	var results scan.Results
+	for _, line := range []int{3, 4} {
+		results.Add("root user", testSource{
+			Metadata: defsecTypes.NewMetadata(defsecTypes.NewRange("Dockerfile", line, line, "", nil), ""),
+		})
+	}
+	results.SetRule(scan.Rule{AVDID: "AVD-DS-0002", Provider: "dockerfile", Service: "general", ShortCode: "root-user", Aliases: []string{"ds-root-user"}})
+
+	results = ApplyInline(fsys, results)
+	require.Len(t, results.GetIgnored(), 1)
+	assert.Equal(t, 3, results.GetIgnored()[0].Range().GetStartLine())
+	assert.Len(t, results.GetFailed(), 1)
+}
+
+func date(t *testing.T, value string) *time.Time {
+	parsed, err := time.Parse("2006-01-02", value)
+	require.NoError(t, err)
+	return &parsed
+}
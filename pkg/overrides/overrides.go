@@ -0,0 +1,128 @@
+package overrides
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/aquasecurity/defsec/pkg/scan"
+	"github.com/aquasecurity/defsec/pkg/scanners/options"
+	"github.com/aquasecurity/defsec/pkg/severity"
+)
+
+// Overrides is a list of severity overrides. When more than one override matches a result,
+// the last one in the list wins.
+type Overrides struct {
+	Severities []SeverityOverride `yaml:"severities" json:"severities"`
+}
+
+// SeverityOverride changes the severity of results for a rule. The override can optionally be
+// scoped to results in files matching one of Paths, or raised against resources matching one
+// of Resources. Patterns use path.Match syntax - a pattern without a slash is matched against
+// the base name of the file.
+type SeverityOverride struct {
+	Rule      string            `yaml:"rule" json:"rule"`
+	Severity  severity.Severity `yaml:"severity" json:"severity"`
+	Paths     []string          `yaml:"paths,omitempty" json:"paths,omitempty"`
+	Resources []string          `yaml:"resources,omitempty" json:"resources,omitempty"`
+}
+
+// Read parses overrides from YAML (or JSON) input.
+func Read(r io.Reader) (*Overrides, error) {
+	var o Overrides
+	if err := yaml.NewDecoder(r).Decode(&o); err != nil && err != io.EOF {
+		return nil, fmt.Errorf("failed to parse severity overrides: %w", err)
+	}
+	if err := o.Validate(); err != nil {
+		return nil, err
+	}
+	return &o, nil
+}
+
+// Validate checks that each override has a rule, a valid severity and valid patterns, normalising the severities
+// (e.g. "low" becomes LOW). It is called by Read, and only needs calling for overrides decoded by other means.
+func (o *Overrides) Validate() error {
+	for i, override := range o.Severities {
+		if override.Rule == "" {
+			return fmt.Errorf("severity override %d: rule is required", i)
+		}
+		sev := severity.StringToSeverity(string(override.Severity))
+		if sev == severity.None {
+			return fmt.Errorf("severity override %d: invalid severity '%s'", i, override.Severity)
+		}
+		o.Severities[i].Severity = sev
+		for _, pattern := range append(override.Paths, override.Resources...) {
+			if _, err := path.Match(pattern, ""); err != nil {
+				return fmt.Errorf("severity override %d: invalid pattern '%s': %w", i, pattern, err)
+			}
+		}
+	}
+	return nil
+}
+
+// Load reads overrides from the file at the given path.
+func Load(path string) (*Overrides, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = f.Close() }()
+	return Read(f)
+}
+
+// Apply overrides the severity of each matching result. It can be used directly as an options.ResultProcessor.
+func (o *Overrides) Apply(results scan.Results) scan.Results {
+	if o == nil {
+		return results
+	}
+	for i := range results {
+		for j := len(o.Severities) - 1; j >= 0; j-- {
+			if o.Severities[j].matches(&results[i]) {
+				results[i].OverrideSeverity(o.Severities[j].Severity)
+				break
+			}
+		}
+	}
+	return results
+}
+
+func (s SeverityOverride) matches(result *scan.Result) bool {
+	if !result.Rule().HasID(s.Rule) {
+		return false
+	}
+	if len(s.Paths) > 0 {
+		rng := result.Range()
+		if !matchesAny(s.Paths, strings.ReplaceAll(rng.GetLocalFilename(), "\\", "/")) {
+			return false
+		}
+	}
+	if len(s.Resources) > 0 && !matchesAny(s.Resources, result.Flatten().Resource) {
+		return false
+	}
+	return true
+}
+
+func matchesAny(patterns []string, target string) bool {
+	if target == "" {
+		return false
+	}
+	for _, pattern := range patterns {
+		candidate := target
+		if !strings.Contains(pattern, "/") {
+			candidate = path.Base(target)
+		}
+		if ok, _ := path.Match(pattern, candidate); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// ScannerWithSeverityOverrides applies the given severity overrides to the results of every scan.
+func ScannerWithSeverityOverrides(o *Overrides) options.ScannerOption {
+	return options.ScannerWithResultProcessors(o.Apply)
+}
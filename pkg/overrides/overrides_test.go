@@ -0,0 +1,53 @@
+package overrides
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/aquasecurity/defsec/pkg/scan"
+	"github.com/aquasecurity/defsec/pkg/severity"
+	defsecTypes "github.com/aquasecurity/defsec/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_SeverityOverrides(t *testing.T) {
+	o, err := Read(strings.NewReader(`
+severities:
+  - rule: AVD-TEST-0001
+    severity: low
+  - rule: aws-s3-enable-versioning
+    severity: critical
+    paths:
+      - prod/*.tf
+  - rule: AVD-TEST-0001
+    severity: medium
+    resources:
+      - aws_s3_bucket.logs
+`))
+	require.NoError(t, err)
+
+	var results scan.Results
+	results.Add("a", defsecTypes.NewMetadata(defsecTypes.NewRange("prod/main.tf", 1, 1, "", nil), "aws_s3_bucket.data"))
+	results.Add("b", defsecTypes.NewMetadata(defsecTypes.NewRange("dev/main.tf", 1, 1, "", nil), "aws_s3_bucket.logs"))
+	results.SetRule(scan.Rule{
+		AVDID:     "AVD-TEST-0001",
+		Provider:  "aws",
+		Service:   "s3",
+		ShortCode: "enable-versioning",
+		Severity:  severity.High,
+	})
+
+	results = o.Apply(results)
+	assert.Equal(t, severity.Critical, results[0].Severity())
+	assert.Equal(t, severity.Medium, results[1].Severity())
+}
+
+func Test_SeverityOverridesInvalid(t *testing.T) {
+	_, err := Read(strings.NewReader(`
+severities:
+  - rule: AVD-TEST-0001
+    severity: urgent
+`))
+	assert.Error(t, err)
+}
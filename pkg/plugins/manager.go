@@ -0,0 +1,101 @@
+package plugins
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+
+	"github.com/aquasecurity/defsec/pkg/scanners/options"
+)
+
+// Prefix is the prefix of the file name of every plugin executable, e.g. defsec-plugin-nomad
+const Prefix = "defsec-plugin-"
+
+// Manager holds the plugins discovered in one or more directories
+type Manager struct {
+	plugins []*Plugin
+}
+
+// Discover loads every plugin found in the given directories. Directories which do not exist are skipped. Plugins
+// are executables whose names start with Prefix, and are loaded in name order. If two plugins report the same
+// name, the one found first is used.
+func Discover(ctx context.Context, dirs ...string) (*Manager, error) {
+	m := &Manager{}
+	seen := make(map[string]bool)
+	for _, dir := range dirs {
+		entries, err := os.ReadDir(dir)
+		if errors.Is(err, os.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		var paths []string
+		for _, entry := range entries {
+			if entry.IsDir() || !strings.HasPrefix(entry.Name(), Prefix) {
+				continue
+			}
+			info, err := entry.Info()
+			if err != nil {
+				return nil, err
+			}
+			if !isExecutable(info) {
+				continue
+			}
+			paths = append(paths, filepath.Join(dir, entry.Name()))
+		}
+		sort.Strings(paths)
+		for _, path := range paths {
+			plugin, err := Load(ctx, path)
+			if err != nil {
+				return nil, fmt.Errorf("failed to load plugin: %w", err)
+			}
+			if seen[plugin.Name()] {
+				continue
+			}
+			seen[plugin.Name()] = true
+			m.plugins = append(m.plugins, plugin)
+		}
+	}
+	return m, nil
+}
+
+func isExecutable(info os.FileInfo) bool {
+	if runtime.GOOS == "windows" {
+		return strings.EqualFold(filepath.Ext(info.Name()), ".exe")
+	}
+	return info.Mode()&0o111 != 0
+}
+
+// Plugins returns every plugin discovered
+func (m *Manager) Plugins() []*Plugin {
+	if m == nil {
+		return nil
+	}
+	return m.plugins
+}
+
+// FSScanners returns a scanner for each plugin which can scan files
+func (m *Manager) FSScanners(opts ...options.ScannerOption) []*Scanner {
+	return m.scanners(CapabilityScanFiles, opts...)
+}
+
+// StateScanners returns a scanner for each plugin which provides checks against the adapted cloud state
+func (m *Manager) StateScanners(opts ...options.ScannerOption) []*Scanner {
+	return m.scanners(CapabilityCheckState, opts...)
+}
+
+func (m *Manager) scanners(capability Capability, opts ...options.ScannerOption) []*Scanner {
+	var scanners []*Scanner
+	for _, plugin := range m.Plugins() {
+		if plugin.Can(capability) {
+			scanners = append(scanners, NewScanner(plugin, opts...))
+		}
+	}
+	return scanners
+}
@@ -0,0 +1,103 @@
+package plugins
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os/exec"
+	"strings"
+	"time"
+)
+
+// DefaultTimeout is the time after which a plugin request is abandoned, unless overridden
+const DefaultTimeout = 5 * time.Minute
+
+// Plugin is an external executable which speaks the plugin protocol
+type Plugin struct {
+	path     string
+	manifest Manifest
+	timeout  time.Duration
+}
+
+// Load runs the executable at path to describe itself, returning an error if it does not speak the protocol
+func Load(ctx context.Context, path string) (*Plugin, error) {
+	p := &Plugin{
+		path:    path,
+		timeout: DefaultTimeout,
+	}
+	response, err := p.call(ctx, Request{Command: CommandDescribe})
+	if err != nil {
+		return nil, err
+	}
+	if response.Manifest == nil {
+		return nil, fmt.Errorf("plugin '%s' did not return a manifest", path)
+	}
+	if response.Manifest.Name == "" {
+		return nil, fmt.Errorf("plugin '%s' did not return a name", path)
+	}
+	for _, rule := range response.Manifest.Rules {
+		if rule.AVDID == "" {
+			return nil, fmt.Errorf("plugin '%s' returned a rule without an AVD ID", path)
+		}
+	}
+	p.manifest = *response.Manifest
+	return p, nil
+}
+
+func (p *Plugin) Name() string {
+	return p.manifest.Name
+}
+
+func (p *Plugin) Path() string {
+	return p.path
+}
+
+func (p *Plugin) Manifest() Manifest {
+	return p.manifest
+}
+
+// Can reports whether the plugin advertises the given capability
+func (p *Plugin) Can(capability Capability) bool {
+	for _, c := range p.manifest.Capabilities {
+		if c == capability {
+			return true
+		}
+	}
+	return false
+}
+
+func (p *Plugin) call(ctx context.Context, request Request) (*Response, error) {
+	request.Version = ProtocolVersion
+	input, err := json.Marshal(request)
+	if err != nil {
+		return nil, err
+	}
+
+	if p.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, p.timeout)
+		defer cancel()
+	}
+
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, p.path)
+	cmd.Stdin = bytes.NewReader(input)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		if ctx.Err() != nil {
+			return nil, fmt.Errorf("plugin '%s' %s request: %w", p.path, request.Command, ctx.Err())
+		}
+		return nil, fmt.Errorf("plugin '%s' %s request failed: %w: %s", p.path, request.Command, err, strings.TrimSpace(stderr.String()))
+	}
+
+	var response Response
+	if err := json.Unmarshal(stdout.Bytes(), &response); err != nil {
+		return nil, fmt.Errorf("plugin '%s' returned an invalid %s response: %w", p.path, request.Command, err)
+	}
+	if response.Error != "" {
+		return nil, fmt.Errorf("plugin '%s' %s request failed: %s", p.path, request.Command, response.Error)
+	}
+	return &response, nil
+}
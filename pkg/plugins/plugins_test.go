@@ -0,0 +1,143 @@
+package plugins
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/aquasecurity/defsec/pkg/scan"
+	"github.com/aquasecurity/defsec/pkg/scanners/options"
+	"github.com/aquasecurity/defsec/pkg/severity"
+	"github.com/aquasecurity/defsec/test/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testPlugin = `#!/bin/sh
+input=$(cat)
+case "$input" in
+*'"command":"describe"'*)
+	cat <<'JSON'
+{
+	"manifest": {
+		"name": "nomad",
+		"version": "0.1.0",
+		"capabilities": ["scan-files"],
+		"file_patterns": ["*.nomad"],
+		"rules": [
+			{"avd_id": "AVD-NMD-0001", "provider": "nomad", "service": "jobs", "short_code": "no-privileged", "severity": "HIGH"},
+			{"avd_id": "AVD-NMD-0002", "provider": "nomad", "service": "jobs", "short_code": "pin-images", "severity": "LOW"}
+		]
+	}
+}
+JSON
+	;;
+*'"path":"code/job.nomad"'*)
+	cat <<'JSON'
+{
+	"results": [
+		{"rule_id": "AVD-NMD-0001", "message": "Task runs privileged", "filename": "code/job.nomad", "start_line": 3, "end_line": 5, "resource": "task.web"},
+		{"rule_id": "nomad-jobs-pin-images", "passed": true, "filename": "code/job.nomad"}
+	]
+}
+JSON
+	;;
+*)
+	echo '{"error": "unexpected request"}'
+	;;
+esac
+`
+
+func writePlugin(t *testing.T, dir string, name string, script string) {
+	require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(script), 0o700))
+}
+
+func Test_DiscoverAndScan(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("test plugin is a shell script")
+	}
+
+	dir := t.TempDir()
+	writePlugin(t, dir, Prefix+"nomad", testPlugin)
+	// not a plugin, as it does not have the prefix
+	writePlugin(t, dir, "other", "#!/bin/sh\nexit 1\n")
+
+	manager, err := Discover(context.TODO(), dir, filepath.Join(dir, "missing"))
+	require.NoError(t, err)
+	require.Len(t, manager.Plugins(), 1)
+	assert.Equal(t, "nomad", manager.Plugins()[0].Name())
+	assert.Empty(t, manager.StateScanners())
+
+	scanners := manager.FSScanners()
+	require.Len(t, scanners, 1)
+
+	fs := testutil.CreateFS(t, map[string]string{
+		"code/job.nomad": "job \"web\" {\n}\n",
+		"code/main.tf":   "",
+	})
+
+	results, err := scanners[0].ScanFS(context.TODO(), fs, "code")
+	require.NoError(t, err)
+	require.Len(t, results, 2)
+
+	failed := results.GetFailed()
+	require.Len(t, failed, 1)
+	assert.Equal(t, "AVD-NMD-0001", failed[0].Rule().AVDID)
+	assert.Equal(t, severity.High, failed[0].Severity())
+	assert.Equal(t, "code/job.nomad", failed[0].Range().GetFilename())
+	assert.Equal(t, 3, failed[0].Range().GetStartLine())
+	assert.Equal(t, "Task runs privileged", failed[0].Description())
+
+	require.Len(t, results.GetPassed(), 1)
+	assert.Equal(t, "AVD-NMD-0002", results.GetPassed()[0].Rule().AVDID)
+}
+
+func Test_ScanSkipsUnselectedRules(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("test plugin is a shell script")
+	}
+
+	dir := t.TempDir()
+	writePlugin(t, dir, Prefix+"nomad", testPlugin)
+
+	plugin, err := Load(context.TODO(), filepath.Join(dir, Prefix+"nomad"))
+	require.NoError(t, err)
+
+	fs := testutil.CreateFS(t, map[string]string{
+		"code/job.nomad": "job \"web\" {\n}\n",
+	})
+
+	scanner := NewScanner(plugin,
+		options.ScannerWithRuleSelection(scan.RuleSelection{
+			Exclude: scan.RuleSet{IDs: []string{"AVD-NMD-0002"}},
+		}),
+	)
+	results, err := scanner.ScanFS(context.TODO(), fs, "code")
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	assert.Equal(t, "AVD-NMD-0001", results[0].Rule().AVDID)
+
+	// no files match the plugin's patterns when they are excluded, so the plugin is not run
+	scanner = NewScanner(plugin, options.ScannerWithExcludePaths("*.nomad"))
+	results, err = scanner.ScanFS(context.TODO(), fs, "code")
+	require.NoError(t, err)
+	assert.Empty(t, results)
+}
+
+func Test_LoadInvalidPlugin(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("test plugin is a shell script")
+	}
+
+	dir := t.TempDir()
+	writePlugin(t, dir, Prefix+"broken", "#!/bin/sh\necho 'not json'\n")
+	writePlugin(t, dir, Prefix+"failing", "#!/bin/sh\necho 'something went wrong' >&2\nexit 1\n")
+
+	_, err := Load(context.TODO(), filepath.Join(dir, Prefix+"broken"))
+	assert.ErrorContains(t, err, "invalid describe response")
+
+	_, err = Load(context.TODO(), filepath.Join(dir, Prefix+"failing"))
+	assert.ErrorContains(t, err, "something went wrong")
+}
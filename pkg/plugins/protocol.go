@@ -0,0 +1,82 @@
+package plugins
+
+import (
+	"github.com/aquasecurity/defsec/pkg/scan"
+)
+
+// ProtocolVersion is the version of the protocol spoken with plugins. Plugins must reject requests with a version
+// they do not support.
+const ProtocolVersion = 1
+
+// Plugins are executables which are run once per request. Each request is written as a single JSON object to the
+// plugin's standard input, and the plugin must write a single JSON response to its standard output before exiting.
+// Anything written to standard error is included in the error reported when a plugin fails.
+const (
+	// CommandDescribe asks a plugin for its Manifest
+	CommandDescribe = "describe"
+	// CommandScanFiles asks a plugin to check the files in the request
+	CommandScanFiles = "scan-files"
+	// CommandCheckState asks a plugin to check the adapted cloud state in the request
+	CommandCheckState = "check-state"
+)
+
+// Capability is something a plugin can do, advertised in its Manifest
+type Capability string
+
+const (
+	// CapabilityScanFiles plugins act as scanners, checking files in a format they understand
+	CapabilityScanFiles Capability = "scan-files"
+	// CapabilityCheckState plugins provide checks which are evaluated against the adapted cloud state, as
+	// produced by the in-tree scanners
+	CapabilityCheckState Capability = "check-state"
+)
+
+type Request struct {
+	Version int    `json:"version"`
+	Command string `json:"command"`
+	// Rules are the IDs of the rules the plugin should evaluate, after any rule selection and minimum severity
+	// have been applied. It is empty for describe requests.
+	Rules []string `json:"rules,omitempty"`
+	// Files are sent with scan-files requests
+	Files []File `json:"files,omitempty"`
+	// State is sent with check-state requests, in the same form given to rego policies as input
+	State interface{} `json:"state,omitempty"`
+}
+
+// File is a file to be scanned. Contents are base64 encoded when marshalled.
+type File struct {
+	Path     string `json:"path"`
+	Contents []byte `json:"contents"`
+}
+
+type Response struct {
+	// Error reports a failure which the plugin was able to describe
+	Error    string    `json:"error,omitempty"`
+	Manifest *Manifest `json:"manifest,omitempty"`
+	Results  []Result  `json:"results,omitempty"`
+}
+
+// Manifest describes a plugin, in response to a describe request
+type Manifest struct {
+	Name         string       `json:"name"`
+	Version      string       `json:"version"`
+	Capabilities []Capability `json:"capabilities"`
+	// FilePatterns select the files sent to scan-files plugins, using path.Match syntax against the base name of
+	// each file, e.g. "*.nomad". If empty, every file is sent.
+	FilePatterns []string `json:"file_patterns,omitempty"`
+	// Rules are the checks provided by the plugin. Every result must refer to one of them.
+	Rules []scan.Rule `json:"rules"`
+}
+
+// Result is a single finding reported by a plugin
+type Result struct {
+	// RuleID is the AVD ID or long ID of one of the rules in the plugin's manifest
+	RuleID string `json:"rule_id"`
+	// Passed is true when the check passed, rather than failed
+	Passed    bool   `json:"passed,omitempty"`
+	Message   string `json:"message"`
+	Filename  string `json:"filename,omitempty"`
+	StartLine int    `json:"start_line,omitempty"`
+	EndLine   int    `json:"end_line,omitempty"`
+	Resource  string `json:"resource,omitempty"`
+}
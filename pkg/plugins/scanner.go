@@ -0,0 +1,315 @@
+package plugins
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"io/fs"
+	"path"
+	"path/filepath"
+
+	"github.com/aquasecurity/defsec/pkg/debug"
+	"github.com/aquasecurity/defsec/pkg/framework"
+	"github.com/aquasecurity/defsec/pkg/scan"
+	"github.com/aquasecurity/defsec/pkg/scanners"
+	"github.com/aquasecurity/defsec/pkg/scanners/limits"
+	"github.com/aquasecurity/defsec/pkg/scanners/options"
+	"github.com/aquasecurity/defsec/pkg/severity"
+	"github.com/aquasecurity/defsec/pkg/state"
+	defsecTypes "github.com/aquasecurity/defsec/pkg/types"
+)
+
+var _ scanners.FSScanner = (*Scanner)(nil)
+var _ scanners.ReaderScanner = (*Scanner)(nil)
+var _ scanners.APIScanner = (*Scanner)(nil)
+var _ options.ConfigurableScanner = (*Scanner)(nil)
+var _ options.ResultProcessingScanner = (*Scanner)(nil)
+var _ options.StreamingScanner = (*Scanner)(nil)
+var _ options.FileLimitedScanner = (*Scanner)(nil)
+var _ options.PathFilteredScanner = (*Scanner)(nil)
+var _ options.SeverityFilteredScanner = (*Scanner)(nil)
+var _ options.RuleSelectingScanner = (*Scanner)(nil)
+var _ options.LoggingScanner = (*Scanner)(nil)
+
+// Scanner runs the checks provided by a plugin. It can be used alongside the in-tree scanners, either as an
+// FSScanner for plugins which scan files, or as an APIScanner for plugins which check the adapted cloud state.
+type Scanner struct {
+	plugin        *Plugin
+	debug         debug.Logger
+	collector     *collector
+	skipRequired  bool
+	minSeverity   severity.Severity
+	ruleSelection scan.RuleSelection
+	options.FileLimitHandler
+	options.Instrumentation
+	options.ResultHandler
+}
+
+// NewScanner creates a scanner which runs the given plugin. Options which do not apply to plugins, such as policy
+// directories, are ignored.
+func NewScanner(plugin *Plugin, opts ...options.ScannerOption) *Scanner {
+	s := &Scanner{
+		plugin: plugin,
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	s.collector = newCollector(
+		options.ParserWithSkipRequiredCheck(s.skipRequired),
+		s.FileLimitParserOption(),
+		s.PathFilterParserOption(),
+		options.ParserWithLogHandler(s.debug.Handler()),
+	)
+	return s
+}
+
+func (s *Scanner) Name() string {
+	return s.plugin.Name()
+}
+
+func (s *Scanner) SetDebugWriter(writer io.Writer) {
+	s.debug = debug.New(writer, "plugin", s.plugin.Name())
+}
+
+func (s *Scanner) SetLogHandler(handler debug.Handler) {
+	s.debug = debug.NewWithHandler(handler, "plugin", s.plugin.Name())
+}
+
+func (s *Scanner) SetSkipRequiredCheck(skip bool) {
+	s.skipRequired = skip
+}
+
+func (s *Scanner) SetMinimumSeverity(threshold severity.Severity) {
+	s.minSeverity = threshold
+}
+
+func (s *Scanner) SetRuleSelection(selection scan.RuleSelection) {
+	s.ruleSelection = selection
+}
+
+func (s *Scanner) SetTraceWriter(io.Writer)            {}
+func (s *Scanner) SetPerResultTracingEnabled(bool)     {}
+func (s *Scanner) SetPolicyDirs(...string)             {}
+func (s *Scanner) SetDataDirs(...string)               {}
+func (s *Scanner) SetPolicyNamespaces(...string)       {}
+func (s *Scanner) SetPolicyReaders([]io.Reader)        {}
+func (s *Scanner) SetPolicyFilesystem(fs.FS)           {}
+func (s *Scanner) SetDataFilesystem(fs.FS)             {}
+func (s *Scanner) SetUseEmbeddedPolicies(bool)         {}
+func (s *Scanner) SetFrameworks([]framework.Framework) {}
+func (s *Scanner) SetSpec(string)                      {}
+func (s *Scanner) SetRegoOnly(bool)                    {}
+
+// ScanReaders scans the given documents together, keyed by their paths, see scanners.ReaderScanner
+func (s *Scanner) ScanReaders(ctx context.Context, readers map[string]io.Reader) (scan.Results, error) {
+	return scanners.ScanReaders(ctx, s, readers)
+}
+
+// ScanFS sends the files matching the plugin's file patterns to the plugin, returning the results it reports
+func (s *Scanner) ScanFS(ctx context.Context, fsys fs.FS, dir string) (scan.Results, error) {
+	if !s.plugin.Can(CapabilityScanFiles) {
+		return nil, nil
+	}
+	rules := s.selectedRules()
+	if len(rules) == 0 {
+		return nil, nil
+	}
+
+	done := s.StartPhase(ctx, s.Name(), options.PhaseParse, "")
+	files, err := s.collector.collect(ctx, fsys, dir, s.plugin.manifest.FilePatterns)
+	done()
+	if err != nil {
+		return nil, err
+	}
+	s.RecordFiles(s.Name(), len(files))
+	if len(files) == 0 {
+		return nil, nil
+	}
+
+	s.debug.Log("Sending %d files to plugin...", len(files))
+	return s.run(ctx, fsys, rules, Request{
+		Command: CommandScanFiles,
+		Files:   files,
+	})
+}
+
+// Scan sends the adapted cloud state to the plugin, returning the results it reports
+func (s *Scanner) Scan(ctx context.Context, cloud *state.State) (scan.Results, error) {
+	if !s.plugin.Can(CapabilityCheckState) || cloud == nil {
+		return nil, nil
+	}
+	rules := s.selectedRules()
+	if len(rules) == 0 {
+		return nil, nil
+	}
+	return s.run(ctx, nil, rules, Request{
+		Command: CommandCheckState,
+		State:   cloud.ToRego(),
+	})
+}
+
+func (s *Scanner) run(ctx context.Context, fsys fs.FS, rules []scan.Rule, request Request) (scan.Results, error) {
+	for _, rule := range rules {
+		request.Rules = append(request.Rules, rule.AVDID)
+	}
+
+	done := s.StartPhase(ctx, s.Name(), options.PhaseEvaluate, "")
+	response, err := s.plugin.call(ctx, request)
+	done()
+	if err != nil {
+		return nil, err
+	}
+
+	results, err := s.convertResults(fsys, rules, response.Results)
+	if err != nil {
+		return nil, err
+	}
+	results = s.HandleResults(results)
+	s.RecordResults(s.Name(), results)
+	return results, nil
+}
+
+func (s *Scanner) selectedRules() []scan.Rule {
+	var rules []scan.Rule
+	for _, rule := range s.plugin.manifest.Rules {
+		if !rule.Severity.AtLeast(s.minSeverity) || !s.ruleSelection.Selects(rule) {
+			continue
+		}
+		rules = append(rules, rule)
+	}
+	return rules
+}
+
+func (s *Scanner) convertResults(fsys fs.FS, rules []scan.Rule, reported []Result) (scan.Results, error) {
+	var results scan.Results
+	for _, result := range reported {
+		rule, ok := findRule(rules, result.RuleID)
+		if !ok {
+			// the plugin may ignore the rules it was asked to evaluate
+			if _, known := findRule(s.plugin.manifest.Rules, result.RuleID); known {
+				continue
+			}
+			return nil, fmt.Errorf("plugin '%s' reported a result for unknown rule '%s'", s.plugin.Name(), result.RuleID)
+		}
+
+		var metadata defsecTypes.Metadata
+		switch {
+		case result.Filename != "":
+			metadata = defsecTypes.NewMetadata(
+				defsecTypes.NewRange(result.Filename, result.StartLine, result.EndLine, "", fsys),
+				result.Resource,
+			)
+		case result.Resource != "":
+			metadata = defsecTypes.NewRemoteMetadata(result.Resource)
+		default:
+			metadata = defsecTypes.NewUnmanagedMetadata()
+		}
+
+		if result.Passed {
+			results.AddPassed(metadata, result.Message)
+		} else {
+			results.Add(result.Message, metadata)
+		}
+		results[len(results)-1].SetRule(rule)
+	}
+	return results, nil
+}
+
+func findRule(rules []scan.Rule, id string) (scan.Rule, bool) {
+	for _, rule := range rules {
+		if rule.HasID(id) {
+			return rule, true
+		}
+	}
+	return scan.Rule{}, false
+}
+
+// collector gathers the files sent to a plugin, applying the same limits and path filters as the in-tree parsers
+type collector struct {
+	debug        debug.Logger
+	skipRequired bool
+	fileLimits   limits.FileLimits
+	summary      *limits.Summary
+	pathFilter   options.PathFilter
+}
+
+func newCollector(opts ...options.ParserOption) *collector {
+	c := &collector{}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+func (c *collector) SetDebugWriter(writer io.Writer) {
+	c.debug = debug.New(writer, "plugin", "collector")
+}
+
+func (c *collector) SetLogHandler(handler debug.Handler) {
+	c.debug = debug.NewWithHandler(handler, "plugin", "collector")
+}
+
+func (c *collector) SetSkipRequiredCheck(skip bool) {
+	c.skipRequired = skip
+}
+
+func (c *collector) SetFileLimits(fileLimits limits.FileLimits, summary *limits.Summary) {
+	c.fileLimits = fileLimits
+	c.summary = summary
+}
+
+func (c *collector) SetPathFilter(filter options.PathFilter) {
+	c.pathFilter = filter
+}
+
+func (c *collector) collect(ctx context.Context, fsys fs.FS, dir string, patterns []string) ([]File, error) {
+	tracker := limits.NewTracker(c.fileLimits, c.summary)
+	var files []File
+	if err := fs.WalkDir(fsys, filepath.ToSlash(dir), func(filePath string, entry fs.DirEntry, err error) error {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+		}
+		if err != nil {
+			return err
+		}
+		if entry.IsDir() {
+			if c.pathFilter.SkipsDir(dir, filePath) {
+				return fs.SkipDir
+			}
+			return nil
+		}
+		if !c.pathFilter.AllowsFile(dir, filePath) || !c.required(filePath, patterns) {
+			return nil
+		}
+		if !tracker.AllowSize(fsys, filePath) || !tracker.AllowFile(filePath) {
+			return nil
+		}
+		contents, err := fs.ReadFile(fsys, filePath)
+		if err != nil {
+			c.debug.Warn("Failed to read file", "path", filePath, "error", err)
+			return nil
+		}
+		files = append(files, File{
+			Path:     filePath,
+			Contents: contents,
+		})
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	return files, nil
+}
+
+func (c *collector) required(filePath string, patterns []string) bool {
+	if c.skipRequired || len(patterns) == 0 {
+		return true
+	}
+	for _, pattern := range patterns {
+		if ok, _ := path.Match(pattern, path.Base(filePath)); ok {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,202 @@
+package rego
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/open-policy-agent/opa/ast"
+	"github.com/open-policy-agent/opa/version"
+)
+
+// selectionCacheVersion is incremented whenever the format of the cache, or the way policies are selected, changes
+const selectionCacheVersion = 3
+
+// selectionCache records which of a set of policies apply to a source type. Compiled OPA policies cannot be
+// serialised, so we cache the outcome of the most expensive step instead - compiling every loaded policy
+// and querying its metadata to decide whether it applies to the inputs we will be scanning. The selected
+// policies are still compiled on every run, but only once, and without the policies which do not apply.
+type selectionCache struct {
+	Version    int      `json:"version"`
+	OPAVersion string   `json:"opa_version"`
+	Modules    []string `json:"modules"`
+	Combined   bool     `json:"combined"`
+}
+
+// SetPolicySelectionCacheDir enables caching of the policy selection in the given directory
+func (s *Scanner) SetPolicySelectionCacheDir(dir string) {
+	s.selectionDir = dir
+}
+
+// selectionCacheKey derives a key from everything which influences the selection of policies: the content of
+// every policy, the source type being scanned, the namespaces containing checks, the minimum severity, the rule
+// selection and the version of OPA used to evaluate them
+func (s *Scanner) selectionCacheKey() string {
+	if s.selectionDir == "" {
+		return ""
+	}
+	hash := sha256.New()
+	for _, part := range []string{
+		version.Version,
+		string(s.sourceType),
+		strings.Join(s.sortedNamespaces(), ","),
+		string(s.minSeverity),
+		fmt.Sprintf("%+v", s.ruleSelection),
+		digestModules(s.policies),
+	} {
+		_, _ = hash.Write([]byte(part))
+		_, _ = hash.Write([]byte{0})
+	}
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+// PolicyDigest returns a hash of the loaded policies, the options which influence their results and the
+// version of OPA used to evaluate them. It changes whenever a policy is added, removed or modified, so it can
+// be used to invalidate cached scan results.
+func (s *Scanner) PolicyDigest() string {
+	s.digestLock.Lock()
+	defer s.digestLock.Unlock()
+	if s.policyDigest != "" {
+		return s.policyDigest
+	}
+
+	var frameworks []string
+	for _, fw := range s.frameworks {
+		frameworks = append(frameworks, string(fw))
+	}
+	sort.Strings(frameworks)
+
+	hash := sha256.New()
+	for _, part := range []string{
+		version.Version,
+		strings.Join(s.sortedNamespaces(), ","),
+		strings.Join(frameworks, ","),
+		s.spec,
+		string(s.minSeverity),
+		fmt.Sprintf("%+v", s.ruleSelection),
+		digestModules(s.policies),
+	} {
+		_, _ = hash.Write([]byte(part))
+		_, _ = hash.Write([]byte{0})
+	}
+	s.policyDigest = hex.EncodeToString(hash.Sum(nil))
+	return s.policyDigest
+}
+
+// HasCombinedPolicies reports whether any loaded policy evaluates all inputs at once, rather than each input
+// individually. The results of such policies for one input can depend on the content of other inputs.
+func (s *Scanner) HasCombinedPolicies() bool {
+	return s.combined
+}
+
+func (s *Scanner) sortedNamespaces() []string {
+	var namespaces []string
+	for namespace := range s.ruleNamespaces {
+		namespaces = append(namespaces, namespace)
+	}
+	sort.Strings(namespaces)
+	return namespaces
+}
+
+func digestModules(modules map[string]*ast.Module) string {
+	var names []string
+	for name := range modules {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	hash := sha256.New()
+	for _, name := range names {
+		_, _ = hash.Write([]byte(name))
+		_, _ = hash.Write([]byte{0})
+		_, _ = hash.Write([]byte(modules[name].String()))
+		_, _ = hash.Write([]byte{0})
+	}
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+func (s *Scanner) selectionCachePath(key string) string {
+	return filepath.Join(s.selectionDir, "selection-"+key+".json")
+}
+
+func (s *Scanner) readSelectionCache(key string) (*selectionCache, bool) {
+	if key == "" {
+		return nil, false
+	}
+	data, err := os.ReadFile(s.selectionCachePath(key))
+	if err != nil {
+		return nil, false
+	}
+	var cache selectionCache
+	if err := json.Unmarshal(data, &cache); err != nil {
+		s.debug.Log("Ignoring invalid policy selection cache: %s", err)
+		return nil, false
+	}
+	if cache.Version != selectionCacheVersion || cache.OPAVersion != version.Version {
+		return nil, false
+	}
+	for _, name := range cache.Modules {
+		if _, ok := s.policies[name]; !ok {
+			return nil, false
+		}
+	}
+	return &cache, true
+}
+
+// writeSelectionCache records the currently selected policies. Failure to write the cache is not fatal.
+func (s *Scanner) writeSelectionCache(key string) {
+	if key == "" {
+		return
+	}
+	cache := selectionCache{
+		Version:    selectionCacheVersion,
+		OPAVersion: version.Version,
+		Modules:    []string{},
+		Combined:   s.combined,
+	}
+	for name := range s.policies {
+		cache.Modules = append(cache.Modules, name)
+	}
+	sort.Strings(cache.Modules)
+
+	data, err := json.Marshal(cache)
+	if err != nil {
+		return
+	}
+	if err := os.MkdirAll(s.selectionDir, 0o700); err != nil {
+		s.debug.Warn("Failed to create policy selection cache directory", "error", err)
+		return
+	}
+	// write to a temporary file first, so concurrent processes never read a partially written cache
+	tmp, err := os.CreateTemp(s.selectionDir, "selection-*.tmp")
+	if err != nil {
+		s.debug.Warn("Failed to write policy selection cache", "error", err)
+		return
+	}
+	if _, err := tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		_ = os.Remove(tmp.Name())
+		return
+	}
+	if err := tmp.Close(); err != nil {
+		_ = os.Remove(tmp.Name())
+		return
+	}
+	if err := os.Rename(tmp.Name(), s.selectionCachePath(key)); err != nil {
+		_ = os.Remove(tmp.Name())
+		s.debug.Warn("Failed to write policy selection cache", "error", err)
+	}
+}
+
+func (s *Scanner) selectModules(names []string) {
+	selected := make(map[string]*ast.Module, len(names))
+	for _, name := range names {
+		selected[name] = s.policies[name]
+	}
+	s.policies = selected
+}
@@ -0,0 +1,166 @@
+package rego
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/aquasecurity/defsec/pkg/scan"
+	"github.com/aquasecurity/defsec/pkg/scanners/options"
+	"github.com/aquasecurity/defsec/pkg/severity"
+	"github.com/aquasecurity/defsec/pkg/types"
+	"github.com/aquasecurity/defsec/test/testutil"
+	"github.com/open-policy-agent/opa/version"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_PolicySelectionCache(t *testing.T) {
+
+	srcFS := testutil.CreateFS(t, map[string]string{
+		"policies/json.rego": `
+package defsec.json
+
+__rego_input__ := {
+	"selector": [{"type": "json"}],
+}
+
+deny {
+    input.evil
+}
+`,
+		"policies/yaml.rego": `
+package defsec.yaml
+
+__rego_input__ := {
+	"selector": [{"type": "yaml"}],
+}
+
+deny {
+    input.evil
+}
+`,
+	})
+
+	cacheDir := t.TempDir()
+
+	run := func() int {
+		scanner := NewScanner(types.SourceJSON, options.ScannerWithPolicySelectionCache(cacheDir))
+		require.NoError(t, scanner.LoadPolicies(false, srcFS, []string{"policies"}, nil))
+		results, err := scanner.ScanInput(context.TODO(), Input{
+			Path: "/evil.lol",
+			Contents: map[string]interface{}{
+				"evil": true,
+			},
+			FS: srcFS,
+		})
+		require.NoError(t, err)
+		return len(results.GetFailed())
+	}
+
+	assert.Equal(t, 1, run())
+
+	entries, err := os.ReadDir(cacheDir)
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+
+	data, err := os.ReadFile(filepath.Join(cacheDir, entries[0].Name()))
+	require.NoError(t, err)
+	var cache selectionCache
+	require.NoError(t, json.Unmarshal(data, &cache))
+	assert.Equal(t, []string{"policies/json.rego"}, cache.Modules)
+
+	// the second scan uses the cached selection, and should produce the same results
+	assert.Equal(t, 1, run())
+}
+
+func Test_PolicySelectionCacheKeyedByNamespaces(t *testing.T) {
+
+	srcFS := testutil.CreateFS(t, map[string]string{
+		"policies/low.rego": `
+package custom.low
+
+__rego_metadata__ := {
+	"id": "AA001",
+	"severity": "LOW",
+}
+
+deny {
+    input.evil
+}
+`,
+	})
+
+	cacheDir := t.TempDir()
+
+	run := func(opts ...options.ScannerOption) int {
+		opts = append(opts, options.ScannerWithPolicySelectionCache(cacheDir), options.ScannerWithMinimumSeverity(severity.High))
+		scanner := NewScanner(types.SourceJSON, opts...)
+		require.NoError(t, scanner.LoadPolicies(false, srcFS, []string{"policies"}, nil))
+		results, err := scanner.ScanInput(context.TODO(), Input{
+			Path: "/evil.lol",
+			Contents: map[string]interface{}{
+				"evil": true,
+			},
+			FS: srcFS,
+		})
+		require.NoError(t, err)
+		return len(results.GetFailed())
+	}
+
+	// outside of the check namespaces, the module is kept as a library whatever its severity
+	assert.Equal(t, 0, run())
+
+	// as a check, it is below the minimum severity, and the selection cached above must not be reused
+	assert.Equal(t, 0, run(options.ScannerWithPolicyNamespaces("custom")))
+
+	entries, err := os.ReadDir(cacheDir)
+	require.NoError(t, err)
+	assert.Len(t, entries, 2)
+}
+
+func Test_PolicySelectionCacheRegistersAliases(t *testing.T) {
+
+	srcFS := testutil.CreateFS(t, map[string]string{
+		"policies/aliased.rego": `
+package defsec.aliased
+
+__rego_metadata__ := {
+	"id": "XX0468",
+	"avd_id": "AVD-XX-0468",
+	"severity": "HIGH",
+}
+
+__rego_input__ := {
+	"selector": [{"type": "json"}],
+}
+
+deny {
+    input.evil
+}
+`,
+	})
+
+	cacheDir := t.TempDir()
+	scanner := NewScanner(types.SourceJSON, options.ScannerWithPolicySelectionCache(cacheDir))
+
+	// warm the cache without loading the policies, as loading them registers their aliases
+	modules, err := scanner.loadPoliciesFromDirs(srcFS, []string{"policies"})
+	require.NoError(t, err)
+	scanner.policies = modules
+	data, err := json.Marshal(selectionCache{
+		Version:    selectionCacheVersion,
+		OPAVersion: version.Version,
+		Modules:    []string{"policies/aliased.rego"},
+	})
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(scanner.selectionCachePath(scanner.selectionCacheKey()), data, 0o600))
+	scanner.policies = nil
+
+	require.Equal(t, []string{"XX0468"}, scan.ResolveID("XX0468"))
+
+	require.NoError(t, scanner.LoadPolicies(false, srcFS, []string{"policies"}, nil))
+	assert.Equal(t, []string{"AVD-XX-0468"}, scan.ResolveID("XX0468"))
+}
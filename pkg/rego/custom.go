@@ -89,5 +89,8 @@ func updateMetadata(metadata map[string]*ast.Term, input *ast.Term) map[string]*
 	if term := input.Get(ast.StringTerm("resource")); term != nil {
 		metadata["resource"] = term
 	}
+	if term := input.Get(ast.StringTerm("anchor")); term != nil {
+		metadata["anchor"] = term
+	}
 	return metadata
 }
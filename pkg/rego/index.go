@@ -0,0 +1,66 @@
+package rego
+
+import (
+	"context"
+
+	"github.com/aquasecurity/defsec/pkg/providers"
+	"github.com/aquasecurity/defsec/pkg/state"
+	"github.com/aquasecurity/defsec/pkg/types"
+)
+
+// indexPolicies records the input options of each loaded policy, so the inputs a policy applies to can be
+// determined without evaluating it. It must be called whenever the compiler changes.
+func (s *Scanner) indexPolicies() {
+	s.inputOptions = make(map[string]InputOptions, len(s.policies))
+	for name, module := range s.policies {
+		s.inputOptions[name] = s.retriever.queryInputOptions(context.TODO(), module)
+	}
+}
+
+// applicableInputs returns the subset of inputs a policy should be evaluated against, based on the subtypes
+// in its input selectors. Policies which combine their inputs are evaluated against all of them, or none.
+func (s *Scanner) applicableInputs(options InputOptions, inputs []Input) []Input {
+	if !isPolicyWithSubtype(s.sourceType) {
+		return inputs
+	}
+	if options.Combined {
+		if isPolicyApplicable(options, inputs...) {
+			return inputs
+		}
+		return nil
+	}
+	var applicable []Input
+	for _, input := range inputs {
+		if isPolicyApplicable(options, input) {
+			applicable = append(applicable, input)
+		}
+	}
+	return applicable
+}
+
+// SelectServices adds the cloud services read by the loaded policies, according to the subtypes in their input
+// selectors, to the selection. It returns false if any policy may read from any part of the state, in which
+// case the whole state must be adapted.
+func (s *Scanner) SelectServices(selection *state.Selection) bool {
+	for name := range s.policies {
+		options := s.inputOptions[name]
+		if len(options.Selectors) == 0 {
+			return false
+		}
+		for _, selector := range options.Selectors {
+			if selector.Type != string(types.SourceCloud) {
+				continue
+			}
+			if len(selector.Subtypes) == 0 {
+				return false
+			}
+			for _, subtype := range selector.Subtypes {
+				if subtype.Provider == "" {
+					return false
+				}
+				selection.Add(providers.Provider(subtype.Provider), subtype.Service)
+			}
+		}
+	}
+	return true
+}
@@ -8,6 +8,9 @@ import (
 	"path/filepath"
 	"strings"
 
+	"github.com/aquasecurity/defsec/pkg/scan"
+	"github.com/aquasecurity/defsec/pkg/severity"
+
 	"github.com/open-policy-agent/opa/ast"
 	"github.com/open-policy-agent/opa/bundle"
 )
@@ -172,7 +175,6 @@ func (s *Scanner) LoadPolicies(loadEmbedded bool, srcFS fs.FS, paths []string, r
 }
 
 func (s *Scanner) compilePolicies(srcFS fs.FS, paths []string) error {
-	compiler := ast.NewCompiler()
 	schemaSet, custom, err := BuildSchemaSetFromPolicies(s.policies, paths, srcFS)
 	if err != nil {
 		return err
@@ -181,52 +183,124 @@ func (s *Scanner) compilePolicies(srcFS fs.FS, paths []string) error {
 		s.inputSchema = nil // discard auto detected input schema in favour of policy defined schema
 	}
 
+	// the set of policies which apply to our source type can only be determined by compiling all of them and
+	// querying their metadata, so we cache the outcome to skip this step when the same policies are loaded again
+	cacheKey := s.selectionCacheKey()
+	s.policyDigest = ""
+	if cached, ok := s.readSelectionCache(cacheKey); ok {
+		s.debug.Log("Using cached policy selection from %s.", s.selectionDir)
+		s.selectModules(cached.Modules)
+		s.combined = cached.Combined
+		compiler := ast.NewCompiler()
+		compiler.WithSchemas(schemaSet)
+		compiler.WithCapabilities(ast.CapabilitiesForThisVersion())
+		return s.finaliseCompiler(compiler)
+	}
+
+	compiler := ast.NewCompiler()
 	compiler.WithSchemas(schemaSet)
 	compiler.WithCapabilities(ast.CapabilitiesForThisVersion())
 	compiler.Compile(s.policies)
 	if compiler.Failed() {
 		return compiler.Errors
 	}
-	retriever := NewMetadataRetriever(compiler)
 
-	if err := s.filterModules(retriever); err != nil {
+	if err := s.filterModules(NewMetadataRetriever(compiler)); err != nil {
 		return err
 	}
+	s.writeSelectionCache(cacheKey)
+
+	if s.inputSchema == nil {
+		s.compiler = compiler
+		s.retriever = NewMetadataRetriever(compiler)
+		s.indexPolicies()
+		s.registerAliases()
+		return nil
+	}
+	return s.finaliseCompiler(compiler)
+}
+
+// finaliseCompiler compiles the selected policies, using the input schema for type checking where one is available
+func (s *Scanner) finaliseCompiler(compiler *ast.Compiler) error {
 	if s.inputSchema != nil {
 		schemaSet := ast.NewSchemaSet()
 		schemaSet.Put(ast.MustParseRef("schema.input"), s.inputSchema)
 		compiler.WithSchemas(schemaSet)
-		compiler.Compile(s.policies)
-		if compiler.Failed() {
-			return compiler.Errors
-		}
+	}
+	compiler.Compile(s.policies)
+	if compiler.Failed() {
+		return compiler.Errors
 	}
 	s.compiler = compiler
-	s.retriever = retriever
+	s.retriever = NewMetadataRetriever(compiler)
+	s.indexPolicies()
+	s.registerAliases()
 	return nil
 }
 
 func (s *Scanner) filterModules(retriever *MetadataRetriever) error {
 
 	filtered := make(map[string]*ast.Module)
+	combined := false
 	for name, module := range s.policies {
 		meta, err := retriever.RetrieveMetadata(context.TODO(), module)
 		if err != nil {
 			return err
 		}
+		if !s.selectsCheck(module, meta) {
+			continue
+		}
 		if len(meta.InputOptions.Selectors) == 0 {
-			s.debug.Log("WARNING: Module %s has no input selectors - it will be loaded for all inputs!", name)
+			s.debug.Warn("Module has no input selectors - it will be loaded for all inputs", "module", name)
 			filtered[name] = module
+			combined = combined || meta.InputOptions.Combined
 			continue
 		}
 		for _, selector := range meta.InputOptions.Selectors {
 			if selector.Type == string(s.sourceType) {
 				filtered[name] = module
+				combined = combined || meta.InputOptions.Combined
 				break
 			}
 		}
 	}
 
 	s.policies = filtered
+	s.combined = combined
 	return nil
 }
+
+// selectsCheck reports whether a module should be kept given the minimum severity and rule selection. Only
+// checks are filtered - library modules are always kept, as selected checks may depend on them.
+func (s *Scanner) selectsCheck(module *ast.Module, meta *StaticMetadata) bool {
+	topLevel := strings.Split(getModuleNamespace(module), ".")[0]
+	if _, ok := s.ruleNamespaces[topLevel]; !ok {
+		return true
+	}
+	if s.minSeverity == severity.None && s.ruleSelection.IsEmpty() {
+		return true
+	}
+	if !severity.StringToSeverity(meta.Severity).AtLeast(s.minSeverity) {
+		return false
+	}
+	return s.ruleSelection.Selects(meta.ToRule())
+}
+
+// registerAliases registers the long and legacy IDs of the loaded checks, so they are accepted wherever the IDs of
+// the checks are matched. It must be called whenever the selected policies change, including when the selection is
+// read from the policy selection cache.
+func (s *Scanner) registerAliases() {
+	for name, module := range s.policies {
+		topLevel := strings.Split(getModuleNamespace(module), ".")[0]
+		if _, ok := s.ruleNamespaces[topLevel]; !ok {
+			continue
+		}
+		meta, err := s.retriever.RetrieveMetadata(context.TODO(), module)
+		if err != nil {
+			s.debug.Log("Failed to retrieve metadata of %s to register its aliases: %s", name, err)
+			continue
+		}
+		rule := meta.ToRule()
+		scan.RegisterAliases(rule.AVDID, append([]string{rule.LongID()}, rule.Aliases...)...)
+	}
+}
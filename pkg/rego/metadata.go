@@ -37,6 +37,7 @@ type StaticMetadata struct {
 	Library            bool
 	CloudFormation     *scan.EngineMetadata
 	Terraform          *scan.EngineMetadata
+	Remediation        *scan.Remediation
 }
 
 type InputOptions struct {
@@ -87,6 +88,7 @@ func (m StaticMetadata) ToRule() scan.Rule {
 		Frameworks:     m.Frameworks,
 		CloudFormation: m.CloudFormation,
 		Terraform:      m.Terraform,
+		Remediation:    m.Remediation,
 	}
 }
 
@@ -251,6 +253,10 @@ func (m *MetadataRetriever) updateMetadata(meta map[string]interface{}, metadata
 		return err
 	}
 
+	if metadata.Remediation, err = m.getRemediation(meta); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -280,6 +286,59 @@ func (m *MetadataRetriever) getEngineMetadata(schema string, meta map[string]int
 	return &em, nil
 }
 
+func (m *MetadataRetriever) getRemediation(meta map[string]interface{}) (*scan.Remediation, error) {
+	raw, ok := meta["remediation"]
+	if !ok {
+		return nil, nil
+	}
+	rMap, ok := raw.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("failed to parse remediation metadata: not an object")
+	}
+
+	var remediation scan.Remediation
+	for _, format := range []scan.RemediationFormat{
+		scan.RemediationFormatKubernetes,
+		scan.RemediationFormatTerraform,
+		scan.RemediationFormatCloudFormation,
+	} {
+		if val, ok := rMap[string(format)].(string); ok {
+			remediation.Snippets = append(remediation.Snippets, scan.FixSnippet{
+				Format: format,
+				Code:   val,
+			})
+		}
+	}
+
+	if raw, ok := rMap["patches"]; ok {
+		patches, ok := raw.([]interface{})
+		if !ok {
+			return nil, fmt.Errorf("failed to parse remediation patches: not an array")
+		}
+		for _, rawPatch := range patches {
+			pMap, ok := rawPatch.(map[string]interface{})
+			if !ok {
+				return nil, fmt.Errorf("failed to parse remediation patch: not an object")
+			}
+			patch := scan.Patch{
+				Format:    scan.RemediationFormat(fmt.Sprintf("%s", pMap["format"])),
+				Attribute: fmt.Sprintf("%s", pMap["attribute"]),
+				Operation: scan.PatchOperationSet,
+				Value:     pMap["value"],
+			}
+			if op, ok := pMap["operation"].(string); ok {
+				patch.Operation = scan.PatchOperation(op)
+			}
+			if resourceType, ok := pMap["resource_type"].(string); ok {
+				patch.ResourceType = resourceType
+			}
+			remediation.Patches = append(remediation.Patches, patch)
+		}
+	}
+
+	return &remediation, nil
+}
+
 func (m *MetadataRetriever) fromAnnotation(metadata *StaticMetadata, annotation *ast.Annotations) error {
 	metadata.Title = annotation.Title
 	metadata.Description = annotation.Description
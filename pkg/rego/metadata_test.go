@@ -3,7 +3,9 @@ package rego
 import (
 	"testing"
 
+	"github.com/aquasecurity/defsec/pkg/scan"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func Test_getEngineMetadata(t *testing.T) {
@@ -77,3 +79,46 @@ Resources:
 		})
 	}
 }
+
+func Test_getRemediation(t *testing.T) {
+	meta := map[string]interface{}{
+		"remediation": map[string]interface{}{
+			"kubernetes": "securityContext:\n  privileged: false\n",
+			"patches": []interface{}{
+				map[string]interface{}{
+					"format":    "kubernetes",
+					"attribute": "securityContext.privileged",
+					"value":     false,
+				},
+			},
+		},
+	}
+
+	var m MetadataRetriever
+	remediation, err := m.getRemediation(meta)
+	require.NoError(t, err)
+	require.NotNil(t, remediation)
+
+	snippet, ok := remediation.SnippetFor(scan.RemediationFormatKubernetes)
+	require.True(t, ok)
+	assert.Equal(t, "securityContext:\n  privileged: false\n", snippet.Code)
+
+	assert.Equal(t, []scan.Patch{
+		{
+			Format:    scan.RemediationFormatKubernetes,
+			Attribute: "securityContext.privileged",
+			Operation: scan.PatchOperationSet,
+			Value:     false,
+		},
+	}, remediation.PatchesFor(scan.RemediationFormatKubernetes))
+
+	_, ok = remediation.SnippetFor(scan.RemediationFormatTerraform)
+	assert.False(t, ok)
+}
+
+func Test_getRemediationMissing(t *testing.T) {
+	var m MetadataRetriever
+	remediation, err := m.getRemediation(map[string]interface{}{})
+	require.NoError(t, err)
+	assert.Nil(t, remediation)
+}
@@ -22,6 +22,9 @@ type regoResult struct {
 	Managed   bool
 	FSKey     string
 	FS        fs.FS
+	// DefinedStartLine and DefinedEndLine are where the cause was defined, if it is a copy of a yaml anchor
+	DefinedStartLine int
+	DefinedEndLine   int
 }
 
 func (r regoResult) GetMetadata() defsecTypes.Metadata {
@@ -29,10 +32,16 @@ func (r regoResult) GetMetadata() defsecTypes.Metadata {
 		return defsecTypes.NewUnmanagedMetadata()
 	}
 	rng := defsecTypes.NewRangeWithFSKey(r.Filepath, r.StartLine, r.EndLine, "", r.FSKey, r.FS)
+	metadata := defsecTypes.NewMetadata(rng, r.Resource)
 	if r.Explicit {
-		return defsecTypes.NewExplicitMetadata(rng, r.Resource)
+		metadata = defsecTypes.NewExplicitMetadata(rng, r.Resource)
 	}
-	return defsecTypes.NewMetadata(rng, r.Resource)
+	if r.DefinedStartLine > 0 {
+		metadata = metadata.WithDefinition(
+			defsecTypes.NewRangeWithFSKey(r.Filepath, r.DefinedStartLine, r.DefinedEndLine, "", r.FSKey, r.FS),
+		)
+	}
+	return metadata
 }
 
 func (r regoResult) GetRawValue() interface{} {
@@ -95,6 +104,14 @@ func parseCause(cause map[string]interface{}) regoResult {
 			result.Managed = set
 		}
 	}
+	if anchor, ok := cause["anchor"].(map[string]interface{}); ok {
+		if start, ok := anchor["startline"]; ok {
+			result.DefinedStartLine = parseLineNumber(start)
+		}
+		if end, ok := anchor["endline"]; ok {
+			result.DefinedEndLine = parseLineNumber(end)
+		}
+	}
 	return result
 }
 
@@ -131,8 +148,7 @@ func (s *Scanner) convertResults(set rego.ResultSet, input Input, namespace stri
 				if regoResult.Message == "" {
 					regoResult.Message = fmt.Sprintf("Rego policy rule: %s.%s", namespace, rule)
 				}
-				regoResult.StartLine += offset
-				regoResult.EndLine += offset
+				regoResult.addOffset(offset)
 				results.AddRego(regoResult.Message, namespace, rule, traces, regoResult)
 				continue
 			}
@@ -146,8 +162,7 @@ func (s *Scanner) convertResults(set rego.ResultSet, input Input, namespace stri
 				if regoResult.Message == "" {
 					regoResult.Message = fmt.Sprintf("Rego policy rule: %s.%s", namespace, rule)
 				}
-				regoResult.StartLine += offset
-				regoResult.EndLine += offset
+				regoResult.addOffset(offset)
 				results.AddRego(regoResult.Message, namespace, rule, traces, regoResult)
 			}
 		}
@@ -155,6 +170,16 @@ func (s *Scanner) convertResults(set rego.ResultSet, input Input, namespace stri
 	return results
 }
 
+// addOffset moves the lines of a result in a document by the lines of the documents before it in the same file
+func (r *regoResult) addOffset(offset int) {
+	r.StartLine += offset
+	r.EndLine += offset
+	if r.DefinedStartLine > 0 {
+		r.DefinedStartLine += offset
+		r.DefinedEndLine += offset
+	}
+}
+
 func (s *Scanner) embellishResultsWithRuleMetadata(results scan.Results, metadata StaticMetadata) scan.Results {
 	results.SetRule(metadata.ToRule())
 	return results
@@ -8,11 +8,14 @@ import (
 	"io"
 	"io/fs"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/aquasecurity/defsec/pkg/rego/schemas"
 
 	"github.com/aquasecurity/defsec/pkg/types"
 
+	"github.com/aquasecurity/defsec/pkg/concurrency"
 	"github.com/aquasecurity/defsec/pkg/debug"
 	"github.com/aquasecurity/defsec/pkg/framework"
 
@@ -22,9 +25,16 @@ import (
 
 	"github.com/aquasecurity/defsec/pkg/scan"
 	"github.com/aquasecurity/defsec/pkg/scanners/options"
+	"github.com/aquasecurity/defsec/pkg/severity"
 )
 
 var _ options.ConfigurableScanner = (*Scanner)(nil)
+var _ options.ParallelScanner = (*Scanner)(nil)
+var _ options.PolicySelectionCachingScanner = (*Scanner)(nil)
+var _ options.SeverityFilteredScanner = (*Scanner)(nil)
+var _ options.RuleSelectingScanner = (*Scanner)(nil)
+var _ options.LoggingScanner = (*Scanner)(nil)
+var _ options.MetricsScanner = (*Scanner)(nil)
 
 type Scanner struct {
 	ruleNamespaces map[string]struct{}
@@ -43,6 +53,15 @@ type Scanner struct {
 	spec           string
 	inputSchema    interface{} // unmarshalled into this from a json schema document
 	sourceType     types.Source
+	parallelism    int
+	selectionDir   string
+	minSeverity    severity.Severity
+	ruleSelection  scan.RuleSelection
+	metrics        options.Metrics
+	policyDigest   string
+	digestLock     sync.Mutex
+	combined       bool
+	inputOptions   map[string]InputOptions
 }
 
 func (s *Scanner) SetSpec(spec string) {
@@ -85,6 +104,10 @@ func (s *Scanner) SetDebugWriter(writer io.Writer) {
 	s.debug = debug.New(writer, "rego", "scanner")
 }
 
+func (s *Scanner) SetLogHandler(handler debug.Handler) {
+	s.debug = debug.NewWithHandler(handler, "rego", "scanner")
+}
+
 func (s *Scanner) SetTraceWriter(writer io.Writer) {
 	s.traceWriter = writer
 }
@@ -93,6 +116,11 @@ func (s *Scanner) SetPerResultTracingEnabled(b bool) {
 	s.tracePerResult = b
 }
 
+// TracingEnabled reports whether evaluations are traced, either to the trace writer or for each result
+func (s *Scanner) TracingEnabled() bool {
+	return s.traceWriter != nil || s.tracePerResult
+}
+
 func (s *Scanner) SetPolicyDirs(_ ...string) {
 	// NOTE: Policy dirs option not applicable for rego, policies are loaded on-demand by other scanners.
 }
@@ -111,6 +139,35 @@ func (s *Scanner) SetSkipRequiredCheck(_ bool) {
 	// NOTE: Skip required option not applicable for rego.
 }
 
+// SetMinimumSeverity skips checks with a severity lower than threshold when policies are loaded
+func (s *Scanner) SetMinimumSeverity(threshold severity.Severity) {
+	s.minSeverity = threshold
+}
+
+// SetRuleSelection skips checks which are not chosen by the given selection when policies are loaded
+func (s *Scanner) SetRuleSelection(selection scan.RuleSelection) {
+	s.ruleSelection = selection
+}
+
+// SetMetrics reports the time taken to evaluate each policy to the given metrics implementation
+func (s *Scanner) SetMetrics(metrics options.Metrics) {
+	s.metrics = metrics
+}
+
+// SetParallelism sets the number of inputs which are evaluated concurrently against each rule
+func (s *Scanner) SetParallelism(parallelism int) {
+	s.parallelism = parallelism
+}
+
+// Workers returns the number of inputs to evaluate concurrently. Inputs are evaluated one at a time when a trace
+// is being written, as it would be unreadable if the traces of several inputs were interleaved.
+func (s *Scanner) Workers() int {
+	if s.traceWriter != nil {
+		return 1
+	}
+	return concurrency.Workers(s.parallelism)
+}
+
 type DynamicMetadata struct {
 	Warning   bool
 	Filepath  string
@@ -145,6 +202,7 @@ func NewScanner(source types.Source, options ...options.ScannerOption) *Scanner
 			"defsec":    {},
 		},
 		runtimeValues: addRuntimeValues(),
+		parallelism:   1,
 	}
 	for _, opt := range options {
 		opt(s)
@@ -191,6 +249,10 @@ func (s *Scanner) runQuery(ctx context.Context, query string, input interface{},
 	instance := rego.New(regoOptions...)
 	set, err := instance.Eval(ctx)
 	if err != nil {
+		// evaluation is halted when the context is cancelled - report that rather than the resulting halt error
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return nil, nil, ctxErr
+		}
 		return nil, nil, err
 	}
 
@@ -222,7 +284,7 @@ func (s *Scanner) ScanInput(ctx context.Context, inputs ...Input) (scan.Results,
 
 	var results scan.Results
 
-	for _, module := range s.policies {
+	for name, module := range s.policies {
 
 		select {
 		case <-ctx.Done():
@@ -236,22 +298,18 @@ func (s *Scanner) ScanInput(ctx context.Context, inputs ...Input) (scan.Results,
 			continue
 		}
 
-		staticMeta, err := s.retriever.RetrieveMetadata(ctx, module, inputs...)
-		if err != nil {
-			return nil, err
-		}
-
-		if isPolicyWithSubtype(s.sourceType) {
-			// skip if policy isn't relevant to what is being scanned
-			if !isPolicyApplicable(staticMeta, inputs...) {
-				continue
-			}
+		// skip the policy entirely - including retrieving its metadata - if it isn't relevant to any of the inputs
+		applicable := s.applicableInputs(s.inputOptions[name], inputs)
+		if len(applicable) == 0 {
+			continue
 		}
 
-		if len(inputs) == 0 {
-			continue
+		staticMeta, err := s.retriever.RetrieveMetadata(ctx, module, applicable...)
+		if err != nil {
+			return nil, err
 		}
 
+		start := time.Now()
 		usedRules := make(map[string]struct{})
 
 		// all rules
@@ -261,15 +319,20 @@ func (s *Scanner) ScanInput(ctx context.Context, inputs ...Input) (scan.Results,
 				continue
 			}
 			usedRules[ruleName] = struct{}{}
+			if err := ctx.Err(); err != nil {
+				return nil, err
+			}
 			if isEnforcedRule(ruleName) {
-				ruleResults, err := s.applyRule(ctx, namespace, ruleName, inputs, staticMeta.InputOptions.Combined)
+				ruleResults, err := s.applyRule(ctx, namespace, ruleName, applicable, staticMeta.InputOptions.Combined)
 				if err != nil {
 					return nil, err
 				}
 				results = append(results, s.embellishResultsWithRuleMetadata(ruleResults, *staticMeta)...)
 			}
 		}
-
+		if s.metrics != nil {
+			s.metrics.ObservePolicyEvaluation(namespace, time.Since(start))
+		}
 	}
 
 	return results, nil
@@ -309,7 +372,7 @@ func checkSubtype(ii map[string]interface{}, provider string, subTypes []SubType
 	return false
 }
 
-func isPolicyApplicable(staticMetadata *StaticMetadata, inputs ...Input) bool {
+func isPolicyApplicable(options InputOptions, inputs ...Input) bool {
 	for _, input := range inputs {
 		if ii, ok := input.Contents.(map[string]interface{}); ok {
 			for provider := range ii {
@@ -318,12 +381,12 @@ func isPolicyApplicable(staticMetadata *StaticMetadata, inputs ...Input) bool {
 					continue
 				}
 
-				if len(staticMetadata.InputOptions.Selectors) == 0 { // policy always applies if no selectors
+				if len(options.Selectors) == 0 { // policy always applies if no selectors
 					return true
 				}
 
 				// check metadata for subtype
-				for _, s := range staticMetadata.InputOptions.Selectors {
+				for _, s := range options.Selectors {
 					if checkSubtype(ii, provider, s.Subtypes) {
 						return true
 					}
@@ -342,40 +405,53 @@ func (s *Scanner) applyRule(ctx context.Context, namespace string, rule string,
 		return s.applyRuleCombined(ctx, namespace, rule, inputs)
 	}
 
-	var results scan.Results
+	// inputs are evaluated independently, so they can be evaluated concurrently
 	qualified := fmt.Sprintf("data.%s.%s", namespace, rule)
-	for _, input := range inputs {
-		s.trace("INPUT", input)
-		if ignored, err := s.isIgnored(ctx, namespace, rule, input.Contents); err != nil {
-			return nil, err
-		} else if ignored {
-			var result regoResult
-			result.FS = input.FS
-			result.Filepath = input.Path
-			result.Managed = true
-			results.AddIgnored(result)
-			continue
-		}
-		set, traces, err := s.runQuery(ctx, qualified, input.Contents, false)
-		if err != nil {
-			return nil, err
-		}
-		s.trace("RESULTSET", set)
-		ruleResults := s.convertResults(set, input, namespace, rule, traces)
-		if len(ruleResults) == 0 { // It passed because we didn't find anything wrong (NOT because it didn't exist)
-			var result regoResult
-			result.FS = input.FS
-			result.Filepath = input.Path
-			result.Managed = true
-			results.AddPassedRego(namespace, rule, traces, result)
-			continue
-		}
-		results = append(results, ruleResults...)
+	perInput, err := concurrency.Process(ctx, inputs, s.Workers(), func(ctx context.Context, input Input) (scan.Results, error) {
+		return s.applyRuleToInput(ctx, namespace, rule, qualified, input)
+	})
+	if err != nil {
+		return nil, err
 	}
 
+	// results are merged in input order, so the output is the same regardless of the number of workers
+	var results scan.Results
+	for _, inputResults := range perInput {
+		results = append(results, inputResults...)
+	}
 	return results, nil
 }
 
+func (s *Scanner) applyRuleToInput(ctx context.Context, namespace string, rule string, qualified string, input Input) (scan.Results, error) {
+	var results scan.Results
+	s.trace("INPUT", input)
+	if ignored, err := s.isIgnored(ctx, namespace, rule, input.Contents); err != nil {
+		return nil, err
+	} else if ignored {
+		var result regoResult
+		result.FS = input.FS
+		result.Filepath = input.Path
+		result.Managed = true
+		results.AddIgnored(result)
+		return results, nil
+	}
+	set, traces, err := s.runQuery(ctx, qualified, input.Contents, false)
+	if err != nil {
+		return nil, err
+	}
+	s.trace("RESULTSET", set)
+	ruleResults := s.convertResults(set, input, namespace, rule, traces)
+	if len(ruleResults) == 0 { // It passed because we didn't find anything wrong (NOT because it didn't exist)
+		var result regoResult
+		result.FS = input.FS
+		result.Filepath = input.Path
+		result.Managed = true
+		results.AddPassedRego(namespace, rule, traces, result)
+		return results, nil
+	}
+	return ruleResults, nil
+}
+
 func (s *Scanner) applyRuleCombined(ctx context.Context, namespace string, rule string, inputs []Input) (scan.Results, error) {
 	if len(inputs) == 0 {
 		return nil, nil
@@ -3,12 +3,14 @@ package rego
 import (
 	"bytes"
 	"context"
+	"fmt"
 	"os"
 	"path/filepath"
 	"testing"
 
 	"github.com/aquasecurity/defsec/pkg/types"
 
+	"github.com/aquasecurity/defsec/pkg/scan"
 	"github.com/aquasecurity/defsec/pkg/scanners/options"
 
 	"github.com/aquasecurity/defsec/pkg/severity"
@@ -884,3 +886,224 @@ deny {
 		"undefined ref: input.evil",
 	)
 }
+
+func Test_RegoScanning_WithParallelism(t *testing.T) {
+
+	srcFS := testutil.CreateFS(t, map[string]string{
+		"policies/test.rego": `
+package defsec.test
+
+deny {
+    input.evil
+}
+`,
+	})
+
+	var inputs []Input
+	for i := 0; i < 50; i++ {
+		inputs = append(inputs, Input{
+			Path: fmt.Sprintf("/file-%d.lol", i),
+			Contents: map[string]interface{}{
+				"evil": i%2 == 0,
+			},
+			FS: srcFS,
+		})
+	}
+
+	scanner := NewScanner(types.SourceJSON, options.ScannerWithParallelism(8))
+	require.NoError(
+		t,
+		scanner.LoadPolicies(false, srcFS, []string{"policies"}, nil),
+	)
+
+	results, err := scanner.ScanInput(context.TODO(), inputs...)
+	require.NoError(t, err)
+
+	require.Len(t, results, 50)
+	assert.Len(t, results.GetFailed(), 25)
+	assert.Len(t, results.GetPassed(), 25)
+	for i, result := range results {
+		assert.Equal(t, fmt.Sprintf("/file-%d.lol", i), result.Metadata().Range().GetFilename())
+	}
+}
+
+func Test_RegoScanning_OnlyApplicableInputsAreEvaluated(t *testing.T) {
+
+	srcFS := testutil.CreateFS(t, map[string]string{
+		"policies/test.rego": `
+package defsec.test
+
+__rego_input__ := {
+	"selector": [{"type": "cloud", "subtypes": [{"provider": "aws", "service": "s3"}]}],
+}
+
+deny {
+    true
+}
+`,
+	})
+
+	scanner := NewScanner(types.SourceCloud)
+	require.NoError(
+		t,
+		scanner.LoadPolicies(false, srcFS, []string{"policies"}, nil),
+	)
+
+	results, err := scanner.ScanInput(context.TODO(),
+		Input{
+			Path: "/s3.lol",
+			Contents: map[string]interface{}{
+				"aws": map[string]interface{}{
+					"s3": map[string]interface{}{},
+				},
+			},
+		},
+		Input{
+			Path: "/ec2.lol",
+			Contents: map[string]interface{}{
+				"aws": map[string]interface{}{
+					"ec2": map[string]interface{}{},
+				},
+			},
+		},
+	)
+	require.NoError(t, err)
+
+	require.Len(t, results.GetFailed(), 1)
+	assert.Equal(t, "/s3.lol", results.GetFailed()[0].Metadata().Range().GetFilename())
+}
+
+func Test_RegoScanning_WithCancelledContext(t *testing.T) {
+
+	srcFS := testutil.CreateFS(t, map[string]string{
+		"policies/test.rego": `
+package defsec.test
+
+deny {
+    input.evil
+}
+`,
+	})
+
+	scanner := NewScanner(types.SourceJSON)
+	require.NoError(
+		t,
+		scanner.LoadPolicies(false, srcFS, []string{"policies"}, nil),
+	)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := scanner.ScanInput(ctx, Input{
+		Path: "/evil.lol",
+		Contents: map[string]interface{}{
+			"evil": true,
+		},
+	})
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+func Test_RegoScanning_WithMinimumSeverity(t *testing.T) {
+	srcFS := testutil.CreateFS(t, map[string]string{
+		"policies/low.rego": `
+package defsec.low
+
+__rego_metadata__ := {
+	"id": "AA001",
+	"severity": "LOW",
+}
+
+deny {
+    input.evil
+}
+`,
+		"policies/high.rego": `
+package defsec.high
+
+import data.lib.evil
+
+__rego_metadata__ := {
+	"id": "AA002",
+	"severity": "HIGH",
+}
+
+deny {
+    evil.is_evil(input)
+}
+`,
+		"policies/lib.rego": `
+package lib.evil
+
+is_evil(x) {
+    x.evil
+}
+`,
+	})
+
+	scanner := NewScanner(types.SourceJSON, options.ScannerWithMinimumSeverity(severity.High))
+	require.NoError(
+		t,
+		scanner.LoadPolicies(false, srcFS, []string{"policies"}, nil),
+	)
+
+	results, err := scanner.ScanInput(context.TODO(), Input{
+		Path: "/evil.lol",
+		Contents: map[string]interface{}{
+			"evil": true,
+		},
+	})
+	require.NoError(t, err)
+
+	require.Equal(t, 1, len(results.GetFailed()))
+	assert.True(t, results.GetFailed()[0].Rule().HasID("AA002"))
+	assert.Equal(t, 0, len(results.GetPassed()))
+}
+
+func Test_RegoScanning_WithRuleSelection(t *testing.T) {
+	srcFS := testutil.CreateFS(t, map[string]string{
+		"policies/first.rego": `
+package defsec.first
+
+__rego_metadata__ := {
+	"id": "AA001",
+	"avd_id": "AVD-XX-0001",
+}
+
+deny {
+    input.evil
+}
+`,
+		"policies/second.rego": `
+package defsec.second
+
+__rego_metadata__ := {
+	"id": "AA002",
+	"avd_id": "AVD-XX-0002",
+}
+
+deny {
+    input.evil
+}
+`,
+	})
+
+	scanner := NewScanner(types.SourceJSON, options.ScannerWithRuleSelection(scan.RuleSelection{
+		Include: scan.RuleSet{Prefixes: []string{"AVD-XX-"}},
+		Exclude: scan.RuleSet{IDs: []string{"AA001"}},
+	}))
+	require.NoError(
+		t,
+		scanner.LoadPolicies(false, srcFS, []string{"policies"}, nil),
+	)
+
+	results, err := scanner.ScanInput(context.TODO(), Input{
+		Path: "/evil.lol",
+		Contents: map[string]interface{}{
+			"evil": true,
+		},
+	})
+	require.NoError(t, err)
+
+	require.Equal(t, 1, len(results.GetFailed()))
+	assert.Equal(t, "AVD-XX-0002", results.GetFailed()[0].Rule().AVDID)
+}
@@ -0,0 +1,82 @@
+package rules
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/aquasecurity/defsec/internal/rules"
+	"github.com/aquasecurity/defsec/pkg/framework"
+	"github.com/aquasecurity/defsec/pkg/scan"
+	"github.com/aquasecurity/defsec/pkg/severity"
+)
+
+// Query selects registered rules. A rule must match every field which is set, and matches a list field when it
+// matches any entry in the list. The zero value matches every rule in every framework.
+type Query struct {
+	// Providers are matched case-insensitively against the provider name, e.g. "aws"
+	Providers []string
+	// Services can be qualified with a provider, e.g. "aws/s3", or unqualified, e.g. "s3"
+	Services []string
+	// Severities match rules with exactly one of the given severities
+	Severities []severity.Severity
+	// MinimumSeverity matches rules whose severity is at least this
+	MinimumSeverity severity.Severity
+	// Frameworks match rules which belong to any of the given frameworks. All frameworks are searched when empty.
+	Frameworks []framework.Framework
+}
+
+// Find returns the full metadata of the registered rules matching the query, ordered by AVD ID
+func Find(query Query) []scan.Rule {
+	frameworks := query.Frameworks
+	if len(frameworks) == 0 {
+		frameworks = []framework.Framework{framework.ALL}
+	}
+	services := scan.RuleSet{Services: query.Services}
+
+	var found []scan.Rule
+	for _, registered := range rules.FilterByMinimumSeverity(rules.GetFrameworkRules(frameworks...), query.MinimumSeverity) {
+		rule := registered.Rule()
+		if len(query.Providers) > 0 && !containsFold(query.Providers, string(rule.Provider)) {
+			continue
+		}
+		if len(query.Services) > 0 && !services.Matches(rule) {
+			continue
+		}
+		if len(query.Severities) > 0 && !containsSeverity(query.Severities, rule.Severity) {
+			continue
+		}
+		found = append(found, rule)
+	}
+	sort.SliceStable(found, func(i, j int) bool {
+		return found[i].AVDID < found[j].AVDID
+	})
+	return found
+}
+
+// Lookup returns the registered rule with the given AVD ID, long ID or alias
+func Lookup(id string) (scan.Rule, bool) {
+	for _, registered := range rules.GetFrameworkRules(framework.ALL) {
+		if rule := registered.Rule(); rule.HasID(id) {
+			return rule, true
+		}
+	}
+	return scan.Rule{}, false
+}
+
+func containsFold(values []string, target string) bool {
+	for _, value := range values {
+		if strings.EqualFold(value, target) {
+			return true
+		}
+	}
+	return false
+}
+
+func containsSeverity(severities []severity.Severity, target severity.Severity) bool {
+	for _, sev := range severities {
+		if severity.StringToSeverity(string(sev)) == target {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,57 @@
+package rules
+
+import (
+	"testing"
+
+	"github.com/aquasecurity/defsec/pkg/framework"
+	"github.com/aquasecurity/defsec/pkg/severity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_FindRules(t *testing.T) {
+	all := Find(Query{})
+	require.NotEmpty(t, all)
+	for i := 1; i < len(all); i++ {
+		assert.LessOrEqual(t, all[i-1].AVDID, all[i].AVDID)
+	}
+
+	s3 := Find(Query{Services: []string{"aws/s3"}})
+	require.NotEmpty(t, s3)
+	for _, rule := range s3 {
+		assert.Equal(t, "aws", string(rule.Provider))
+		assert.Equal(t, "s3", rule.Service)
+		assert.NotEmpty(t, rule.Summary)
+	}
+
+	high := Find(Query{Providers: []string{"AWS"}, MinimumSeverity: severity.High})
+	require.NotEmpty(t, high)
+	assert.Less(t, len(high), len(all))
+	for _, rule := range high {
+		assert.True(t, rule.Severity.AtLeast(severity.High))
+	}
+
+	low := Find(Query{Severities: []severity.Severity{"low"}})
+	for _, rule := range low {
+		assert.Equal(t, severity.Low, rule.Severity)
+	}
+
+	cis := Find(Query{Frameworks: []framework.Framework{framework.CIS_AWS_1_4}})
+	require.NotEmpty(t, cis)
+	for _, rule := range cis {
+		assert.Contains(t, rule.Frameworks, framework.CIS_AWS_1_4)
+	}
+}
+
+func Test_LookupRule(t *testing.T) {
+	rule, ok := Lookup("aws-s3-enable-versioning")
+	require.True(t, ok)
+	assert.Equal(t, "enable-versioning", rule.ShortCode)
+
+	found, ok := Lookup(rule.AVDID)
+	require.True(t, ok)
+	assert.Equal(t, rule.LongID(), found.LongID())
+
+	_, ok = Lookup("AVD-NOPE-9999")
+	assert.False(t, ok)
+}
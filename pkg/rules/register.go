@@ -4,6 +4,8 @@ import (
 	"github.com/aquasecurity/defsec/internal/rules"
 	"github.com/aquasecurity/defsec/pkg/framework"
 	"github.com/aquasecurity/defsec/pkg/scan"
+	"github.com/aquasecurity/defsec/pkg/severity"
+	"github.com/aquasecurity/defsec/pkg/state"
 )
 
 func Register(rule scan.Rule, f scan.CheckFunc) rules.RegisteredRule {
@@ -13,3 +15,18 @@ func Register(rule scan.Rule, f scan.CheckFunc) rules.RegisteredRule {
 func GetRegistered(fw ...framework.Framework) (registered []rules.RegisteredRule) {
 	return rules.GetFrameworkRules(fw...)
 }
+
+// ServiceSelection returns the set of services which must be adapted into state to evaluate the given rules
+func ServiceSelection(registered []rules.RegisteredRule) state.Selection {
+	return rules.ServiceSelection(registered)
+}
+
+// FilterByMinimumSeverity returns the rules whose severity is at least threshold
+func FilterByMinimumSeverity(registered []rules.RegisteredRule, threshold severity.Severity) []rules.RegisteredRule {
+	return rules.FilterByMinimumSeverity(registered, threshold)
+}
+
+// FilterBySelection returns the rules chosen by the given selection
+func FilterBySelection(registered []rules.RegisteredRule, selection scan.RuleSelection) []rules.RegisteredRule {
+	return rules.FilterBySelection(registered, selection)
+}
@@ -0,0 +1,165 @@
+// Package ruletest helps rule authors measure how the cost of evaluating a rule grows with the size of the
+// infrastructure being scanned, so checks which scale badly (e.g. quadratically) are caught before they ship.
+package ruletest
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"runtime"
+	"time"
+
+	"github.com/aquasecurity/defsec/pkg/rego"
+	"github.com/aquasecurity/defsec/pkg/scan"
+	"github.com/aquasecurity/defsec/pkg/state"
+)
+
+// Generator builds state containing size instances of the resources read by the rule being benchmarked
+type Generator func(size int) *state.State
+
+// Report describes the cost of evaluating a rule against state of a given size
+type Report struct {
+	Size          int
+	Iterations    int
+	TimePerEval   time.Duration
+	AllocsPerEval int64
+	BytesPerEval  int64
+	// Results is the number of results produced by a single evaluation
+	Results int
+}
+
+func (r Report) String() string {
+	return fmt.Sprintf("size=%d\t%s/eval\t%d allocs/eval\t%d B/eval\t%d results", r.Size, r.TimePerEval, r.AllocsPerEval, r.BytesPerEval, r.Results)
+}
+
+type Reports []Report
+
+// GrowthExponent estimates k, where the time taken to evaluate the rule grows with size^k. A rule which does a
+// fixed amount of work per resource has an exponent close to 1, where one comparing every pair of resources is
+// close to 2. At least two distinct sizes are required - otherwise 0 is returned.
+func (r Reports) GrowthExponent() float64 {
+	var xs, ys []float64
+	for _, report := range r {
+		if report.Size <= 0 || report.TimePerEval <= 0 {
+			continue
+		}
+		xs = append(xs, math.Log(float64(report.Size)))
+		ys = append(ys, math.Log(float64(report.TimePerEval)))
+	}
+	if len(xs) < 2 {
+		return 0
+	}
+
+	// least squares fit of log(time) against log(size)
+	var meanX, meanY float64
+	for i := range xs {
+		meanX += xs[i]
+		meanY += ys[i]
+	}
+	meanX /= float64(len(xs))
+	meanY /= float64(len(ys))
+	var covariance, variance float64
+	for i := range xs {
+		covariance += (xs[i] - meanX) * (ys[i] - meanY)
+		variance += (xs[i] - meanX) * (xs[i] - meanX)
+	}
+	if variance == 0 {
+		return 0
+	}
+	return covariance / variance
+}
+
+// Rule benchmarks the check of a Go rule against state generated at each of the given sizes. State is generated
+// before timing starts, so only the evaluation of the check is measured.
+func Rule(rule scan.Rule, check scan.CheckFunc, generate Generator, sizes ...int) Reports {
+	var reports Reports
+	for _, size := range sizes {
+		s := generate(size)
+		report, _ := measure(size, func() (int, error) {
+			results := check(s)
+			results.SetRule(rule)
+			return len(results), nil
+		})
+		reports = append(reports, report)
+	}
+	return reports
+}
+
+// Rego benchmarks the policies loaded into a rego scanner against state generated at each of the given sizes. The
+// state is converted to rego input before timing starts, so only the evaluation of the policies is measured.
+func Rego(ctx context.Context, scanner *rego.Scanner, generate Generator, sizes ...int) (Reports, error) {
+	var reports Reports
+	for _, size := range sizes {
+		input := rego.Input{
+			Path:     "state",
+			Contents: generate(size).ToRego(),
+		}
+		report, err := measure(size, func() (int, error) {
+			results, err := scanner.ScanInput(ctx, input)
+			return len(results), err
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to evaluate policies against state of size %d: %w", size, err)
+		}
+		reports = append(reports, report)
+	}
+	return reports, nil
+}
+
+// minDuration is how long the evaluations at each size are timed for, so that fast evaluations are repeated enough
+// times to be measured reliably
+const minDuration = time.Second
+
+// maxIterations stops evaluations which take almost no time from being repeated indefinitely
+const maxIterations = 1_000_000_000
+
+// measure repeats eval, which returns the number of results it produced, until it has run for at least the minimum
+// duration, and reports the average cost of each evaluation
+func measure(size int, eval func() (int, error)) (Report, error) {
+	results, err := eval()
+	if err != nil {
+		return Report{}, err
+	}
+	var before, after runtime.MemStats
+	for iterations := 1; ; {
+		runtime.GC()
+		runtime.ReadMemStats(&before)
+		start := time.Now()
+		for i := 0; i < iterations; i++ {
+			if results, err = eval(); err != nil {
+				return Report{}, err
+			}
+		}
+		elapsed := time.Since(start)
+		runtime.ReadMemStats(&after)
+		if elapsed >= minDuration || iterations >= maxIterations {
+			return Report{
+				Size:          size,
+				Iterations:    iterations,
+				TimePerEval:   elapsed / time.Duration(iterations),
+				AllocsPerEval: int64(after.Mallocs-before.Mallocs) / int64(iterations),
+				BytesPerEval:  int64(after.TotalAlloc-before.TotalAlloc) / int64(iterations),
+				Results:       results,
+			}, nil
+		}
+		iterations = nextIterations(iterations, elapsed)
+	}
+}
+
+// nextIterations predicts how many iterations will run for the minimum duration, overshooting a little so it is
+// usually reached next time, and growing at most a hundredfold so a misleadingly fast run is not extrapolated too far
+func nextIterations(iterations int, elapsed time.Duration) int {
+	next := iterations * 100
+	if elapsed > 0 {
+		if predicted := int(int64(minDuration) * int64(iterations) / int64(elapsed) * 6 / 5); predicted < next {
+			next = predicted
+		}
+	}
+	if next <= iterations {
+		next = iterations + 1
+	}
+	if next > maxIterations {
+		next = maxIterations
+	}
+	return next
+}
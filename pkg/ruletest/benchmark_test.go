@@ -0,0 +1,72 @@
+package ruletest
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aquasecurity/defsec/pkg/providers"
+	"github.com/aquasecurity/defsec/pkg/providers/aws/s3"
+	"github.com/aquasecurity/defsec/pkg/scan"
+	"github.com/aquasecurity/defsec/pkg/severity"
+	"github.com/aquasecurity/defsec/pkg/state"
+	defsecTypes "github.com/aquasecurity/defsec/pkg/types"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func generateBuckets(size int) *state.State {
+	var s state.State
+	for i := 0; i < size; i++ {
+		s.AWS.S3.Buckets = append(s.AWS.S3.Buckets, s3.Bucket{
+			Metadata: defsecTypes.NewTestMetadata(),
+			Versioning: s3.Versioning{
+				Metadata: defsecTypes.NewTestMetadata(),
+				Enabled:  defsecTypes.Bool(i%2 == 0, defsecTypes.NewTestMetadata()),
+			},
+		})
+	}
+	return &s
+}
+
+func Test_RuleReportsResultsPerSize(t *testing.T) {
+	rule := scan.Rule{
+		AVDID:     "AVD-TEST-0001",
+		Provider:  providers.AWSProvider,
+		Service:   "s3",
+		ShortCode: "test",
+		Severity:  severity.Low,
+	}
+	check := func(s *state.State) (results scan.Results) {
+		for _, bucket := range s.AWS.S3.Buckets {
+			if bucket.Versioning.Enabled.IsFalse() {
+				results.Add("versioning disabled", bucket.Versioning.Enabled)
+			}
+		}
+		return results
+	}
+
+	reports := Rule(rule, check, generateBuckets, 10, 100)
+	assert.Len(t, reports, 2)
+	assert.Equal(t, 10, reports[0].Size)
+	assert.Equal(t, 5, reports[0].Results)
+	assert.Equal(t, 50, reports[1].Results)
+	assert.Greater(t, reports[1].Iterations, 0)
+	assert.Greater(t, reports[1].TimePerEval, time.Duration(0))
+}
+
+func Test_GrowthExponent(t *testing.T) {
+	linear := Reports{
+		{Size: 10, TimePerEval: 10 * time.Microsecond},
+		{Size: 100, TimePerEval: 100 * time.Microsecond},
+		{Size: 1000, TimePerEval: 1000 * time.Microsecond},
+	}
+	assert.InDelta(t, 1, linear.GrowthExponent(), 0.001)
+
+	quadratic := Reports{
+		{Size: 10, TimePerEval: 100 * time.Microsecond},
+		{Size: 100, TimePerEval: 10000 * time.Microsecond},
+	}
+	assert.InDelta(t, 2, quadratic.GrowthExponent(), 0.001)
+
+	assert.Equal(t, 0.0, Reports{{Size: 10, TimePerEval: time.Second}}.GrowthExponent())
+}
@@ -0,0 +1,105 @@
+package scan
+
+import (
+	"sort"
+	"strings"
+	"sync"
+)
+
+// aliasRegistry maps alternative rule IDs to the AVD IDs of the rules they refer to. An alias can refer to more
+// than one rule, e.g. when a rule was split in two.
+type aliasRegistry struct {
+	sync.RWMutex
+	rules   map[string]map[string]struct{}
+	aliases map[string]map[string]struct{}
+}
+
+var aliases = aliasRegistry{
+	rules:   make(map[string]map[string]struct{}),
+	aliases: make(map[string]map[string]struct{}),
+}
+
+// RegisterAliases records alternative IDs for the rule with the given AVD ID, such as the long ID of a rule before
+// it was renamed, or a legacy tfsec ID. Once registered, an alias is accepted anywhere a rule ID is matched (e.g.
+// ignores, severity overrides and rule selection), and is matched case-insensitively. Rules registered with defsec
+// have their long IDs and aliases registered automatically, so this is only needed for IDs defined elsewhere.
+func RegisterAliases(avdID string, ids ...string) {
+	if avdID == "" {
+		return
+	}
+	aliases.Lock()
+	defer aliases.Unlock()
+	for _, id := range ids {
+		if id == "" || strings.EqualFold(id, avdID) {
+			continue
+		}
+		key := strings.ToLower(id)
+		if _, ok := aliases.rules[key]; !ok {
+			aliases.rules[key] = make(map[string]struct{})
+		}
+		aliases.rules[key][avdID] = struct{}{}
+		if _, ok := aliases.aliases[avdID]; !ok {
+			aliases.aliases[avdID] = make(map[string]struct{})
+		}
+		aliases.aliases[avdID][id] = struct{}{}
+	}
+}
+
+// ResolveID returns the AVD IDs of the rules an ID refers to. An AVD ID is returned as it is, as are IDs which are
+// not registered aliases, as they may refer to rules which are not registered.
+func ResolveID(id string) []string {
+	aliases.RLock()
+	defer aliases.RUnlock()
+	avdIDs, ok := aliases.rules[strings.ToLower(id)]
+	if !ok {
+		return []string{id}
+	}
+	resolved := make([]string, 0, len(avdIDs))
+	for avdID := range avdIDs {
+		resolved = append(resolved, avdID)
+	}
+	sort.Strings(resolved)
+	return resolved
+}
+
+// resolvesTo reports whether id is a registered alias of the rule with the given AVD ID
+func resolvesTo(id string, avdID string) bool {
+	if avdID == "" {
+		return false
+	}
+	aliases.RLock()
+	defer aliases.RUnlock()
+	_, ok := aliases.rules[strings.ToLower(id)][avdID]
+	return ok
+}
+
+// AllAliases returns the rule's own aliases along with any registered for it, excluding its AVD ID and long ID
+func (r Rule) AllAliases() []string {
+	longID := r.LongID()
+	unique := make(map[string]struct{})
+	var all []string
+	add := func(id string) {
+		if id == "" || id == r.AVDID || id == longID {
+			return
+		}
+		if _, ok := unique[id]; ok {
+			return
+		}
+		unique[id] = struct{}{}
+		all = append(all, id)
+	}
+	for _, alias := range r.Aliases {
+		add(alias)
+	}
+	aliases.RLock()
+	var registered []string
+	for alias := range aliases.aliases[r.AVDID] {
+		registered = append(registered, alias)
+	}
+	aliases.RUnlock()
+	sort.Strings(registered)
+	for _, alias := range registered {
+		add(alias)
+	}
+	return all
+}
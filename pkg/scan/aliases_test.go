@@ -0,0 +1,46 @@
+package scan
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_RuleAliases(t *testing.T) {
+	rule := Rule{
+		AVDID:     "AVD-ALIAS-0001",
+		Aliases:   []string{"alias-old-name"},
+		Provider:  "alias",
+		Service:   "service",
+		ShortCode: "new-name",
+	}
+
+	assert.False(t, rule.HasID("ALIAS001"))
+
+	RegisterAliases(rule.AVDID, "ALIAS001", "alias-service-legacy-name", rule.LongID())
+
+	assert.True(t, rule.HasID("ALIAS001"))
+	assert.True(t, rule.HasID("alias001"))
+	assert.True(t, rule.HasID("alias-service-legacy-name"))
+	assert.True(t, rule.HasID("alias-old-name"))
+	assert.False(t, rule.HasID("ALIAS002"))
+
+	assert.Equal(t, []string{"AVD-ALIAS-0001"}, ResolveID("Alias001"))
+	assert.Equal(t, []string{"ALIAS002"}, ResolveID("ALIAS002"))
+
+	assert.Equal(t, []string{"alias-old-name", "ALIAS001", "alias-service-legacy-name"}, rule.AllAliases())
+
+	// aliases are also accepted by rule selection
+	selection := RuleSelection{Exclude: RuleSet{IDs: []string{"ALIAS001"}}}
+	assert.False(t, selection.Selects(rule))
+}
+
+func Test_AliasForSeveralRules(t *testing.T) {
+	RegisterAliases("AVD-ALIAS-0003", "ALIAS-SPLIT")
+	RegisterAliases("AVD-ALIAS-0002", "ALIAS-SPLIT")
+
+	assert.Equal(t, []string{"AVD-ALIAS-0002", "AVD-ALIAS-0003"}, ResolveID("ALIAS-SPLIT"))
+	assert.True(t, Rule{AVDID: "AVD-ALIAS-0002"}.HasID("alias-split"))
+	assert.True(t, Rule{AVDID: "AVD-ALIAS-0003"}.HasID("alias-split"))
+	assert.False(t, Rule{AVDID: "AVD-ALIAS-0004"}.HasID("alias-split"))
+}
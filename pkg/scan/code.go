@@ -6,6 +6,7 @@ import (
 	"path/filepath"
 	"strings"
 
+	"github.com/aquasecurity/defsec/pkg/scanners/charset"
 	defsecTypes "github.com/aquasecurity/defsec/pkg/types"
 )
 
@@ -47,6 +48,7 @@ type codeSettings struct {
 	allowTruncation    bool
 	maxLines           int
 	includeHighlighted bool
+	contextLines       int
 }
 
 var defaultCodeSettings = codeSettings{
@@ -54,6 +56,7 @@ var defaultCodeSettings = codeSettings{
 	allowTruncation:    true,
 	maxLines:           10,
 	includeHighlighted: true,
+	contextLines:       -1,
 }
 
 type CodeOption func(*codeSettings)
@@ -94,6 +97,14 @@ func OptionCodeWithHighlighted(include bool) CodeOption {
 	}
 }
 
+// OptionCodeWithContextLines shows the given number of lines either side of the cause, instead of the
+// enclosing block (e.g. the parent resource) which is shown by default.
+func OptionCodeWithContextLines(lines int) CodeOption {
+	return func(s *codeSettings) {
+		s.contextLines = lines
+	}
+}
+
 func validateRange(r defsecTypes.Range) error {
 	if r.GetStartLine() < 0 || r.GetStartLine() > r.GetEndLine() || r.GetEndLine() < 0 {
 		return fmt.Errorf("invalid range: %s", r.String())
@@ -101,6 +112,57 @@ func validateRange(r defsecTypes.Range) error {
 	return nil
 }
 
+// CauseRange returns the first and last line numbers of the cause, or zeroes if no cause lines are present.
+func (c *Code) CauseRange() (int, int) {
+	var start, end int
+	for _, line := range c.Lines {
+		if !line.IsCause {
+			continue
+		}
+		if start == 0 {
+			start = line.Number
+		}
+		end = line.Number
+	}
+	return start, end
+}
+
+// String renders the code as plain text with line numbers, prefixing cause lines with a marker.
+func (c *Code) String() string {
+	width := 1
+	if len(c.Lines) > 0 {
+		width = len(fmt.Sprintf("%d", c.Lines[len(c.Lines)-1].Number))
+	}
+	var sb strings.Builder
+	for _, line := range c.Lines {
+		marker := " "
+		if line.IsCause {
+			marker = ">"
+		}
+		if line.Truncated {
+			_, _ = fmt.Fprintf(&sb, "%s %*s | ...\n", marker, width, "")
+			continue
+		}
+		_, _ = fmt.Fprintf(&sb, "%s %*d | %s\n", marker, width, line.Number, line.Content)
+	}
+	return sb.String()
+}
+
+func withContext(r defsecTypes.Range, lines int, max int) defsecTypes.Range {
+	start := r.GetStartLine() - lines
+	if start < 1 {
+		start = 1
+	}
+	end := r.GetEndLine() + lines
+	if end > max {
+		end = max
+	}
+	if end < r.GetEndLine() {
+		end = r.GetEndLine()
+	}
+	return defsecTypes.NewRange(r.GetLocalFilename(), start, end, r.GetSourcePrefix(), r.GetFS())
+}
+
 // nolint
 func (r *Result) GetCode(opts ...CodeOption) (*Code, error) {
 
@@ -117,7 +179,7 @@ func (r *Result) GetCode(opts ...CodeOption) (*Code, error) {
 	innerRange := r.Range()
 	outerRange := innerRange
 	metadata := r.Metadata()
-	for {
+	for settings.contextLines < 0 {
 		if parent := metadata.Parent(); parent != nil &&
 			parent.Range().GetFilename() == metadata.Range().GetFilename() &&
 			parent.Range().GetStartLine() > 0 {
@@ -142,6 +204,7 @@ func (r *Result) GetCode(opts ...CodeOption) (*Code, error) {
 	if err != nil {
 		return nil, fmt.Errorf("failed to read file from result filesystem (%#v): %w", srcFS, err)
 	}
+	content = charset.Decode(content)
 
 	hasAnnotation := r.Annotation() != ""
 
@@ -151,6 +214,10 @@ func (r *Result) GetCode(opts ...CodeOption) (*Code, error) {
 
 	rawLines := strings.Split(string(content), "\n")
 
+	if settings.contextLines > 0 {
+		outerRange = withContext(innerRange, settings.contextLines, len(rawLines))
+	}
+
 	var highlightedLines []string
 	if settings.includeHighlighted {
 		highlightedLines = highlight(defsecTypes.CreateFSKey(innerRange.GetFS()), innerRange.GetLocalFilename(), content, settings.theme)
@@ -165,7 +232,7 @@ func (r *Result) GetCode(opts ...CodeOption) (*Code, error) {
 		return nil, fmt.Errorf("invalid line number")
 	}
 
-	shrink := settings.allowTruncation && outerRange.LineCount() > (innerRange.LineCount()+10)
+	shrink := settings.allowTruncation && settings.contextLines < 0 && outerRange.LineCount() > (innerRange.LineCount()+10)
 
 	if shrink {
 
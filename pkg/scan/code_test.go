@@ -264,3 +264,36 @@ func TestResult_GetCode(t *testing.T) {
 	}
 
 }
+
+func TestResult_GetCodeWithContextLines(t *testing.T) {
+	source := `resource "aws_s3_bucket" "something" {
+	bucket = "something"
+	acl    = "public-read"
+	tags   = {}
+}`
+	system := memoryfs.New()
+	require.NoError(t, system.WriteFile("main.tf", []byte(source), os.ModePerm))
+	result := &Result{
+		metadata: defsecTypes.NewMetadata(
+			defsecTypes.NewRange("main.tf", 3, 3, "", system),
+			"",
+		).WithParent(defsecTypes.NewMetadata(
+			defsecTypes.NewRange("main.tf", 1, 5, "", system),
+			"",
+		)),
+		fsPath: "main.tf",
+	}
+
+	code, err := result.GetCode(OptionCodeWithHighlighted(false), OptionCodeWithContextLines(1))
+	require.NoError(t, err)
+
+	start, end := code.CauseRange()
+	assert.Equal(t, 3, start)
+	assert.Equal(t, 3, end)
+
+	want := `  2 | 	bucket = "something"
+> 3 | 	acl    = "public-read"
+  4 | 	tags   = {}
+`
+	assert.Equal(t, want, code.String())
+}
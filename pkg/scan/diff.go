@@ -0,0 +1,49 @@
+package scan
+
+// ResultsDiff is the outcome of comparing two sets of results with Diff.
+type ResultsDiff struct {
+	// New contains failures in the new results which were not present in the old results
+	New Results
+	// Fixed contains failures in the old results which are no longer present in the new results
+	Fixed Results
+	// Unchanged contains failures present in both sets of results, as they appear in the new results
+	Unchanged Results
+}
+
+// Diff compares the failures in two sets of results using their fingerprints. Results which are
+// not failures (passed, ignored or baselined) are not considered.
+func Diff(old, new Results) ResultsDiff {
+	var diff ResultsDiff
+
+	oldFailures := make(map[string]struct{})
+	for _, res := range old {
+		if res.Status() == StatusFailed {
+			oldFailures[res.Fingerprint()] = struct{}{}
+		}
+	}
+
+	newFailures := make(map[string]struct{})
+	for _, res := range new {
+		if res.Status() != StatusFailed {
+			continue
+		}
+		fingerprint := res.Fingerprint()
+		newFailures[fingerprint] = struct{}{}
+		if _, ok := oldFailures[fingerprint]; ok {
+			diff.Unchanged = append(diff.Unchanged, res)
+		} else {
+			diff.New = append(diff.New, res)
+		}
+	}
+
+	for _, res := range old {
+		if res.Status() != StatusFailed {
+			continue
+		}
+		if _, ok := newFailures[res.Fingerprint()]; !ok {
+			diff.Fixed = append(diff.Fixed, res)
+		}
+	}
+
+	return diff
+}
@@ -0,0 +1,42 @@
+package scan
+
+import (
+	"testing"
+
+	defsecTypes "github.com/aquasecurity/defsec/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Diff(t *testing.T) {
+	old := diffResults("aws_s3_bucket.a", "aws_s3_bucket.b")
+	updated := diffResults("aws_s3_bucket.b", "aws_s3_bucket.c")
+
+	diff := Diff(old, updated)
+	require.Len(t, diff.New, 1)
+	require.Len(t, diff.Fixed, 1)
+	require.Len(t, diff.Unchanged, 1)
+	assert.Equal(t, "aws_s3_bucket.c", diff.New[0].Metadata().Reference())
+	assert.Equal(t, "aws_s3_bucket.a", diff.Fixed[0].Metadata().Reference())
+	assert.Equal(t, "aws_s3_bucket.b", diff.Unchanged[0].Metadata().Reference())
+}
+
+func Test_DiffIgnoresNonFailures(t *testing.T) {
+	old := diffResults("aws_s3_bucket.a")
+	updated := diffResults("aws_s3_bucket.a")
+	updated[0].OverrideStatus(StatusIgnored)
+
+	diff := Diff(old, updated)
+	assert.Len(t, diff.New, 0)
+	assert.Len(t, diff.Unchanged, 0)
+	assert.Len(t, diff.Fixed, 1)
+}
+
+func diffResults(refs ...string) Results {
+	var results Results
+	for _, ref := range refs {
+		results.Add("problem", defsecTypes.NewMetadata(defsecTypes.NewRange("main.tf", 1, 1, "", nil), ref))
+	}
+	results.SetRule(Rule{AVDID: "AVD-TEST-0001"})
+	return results
+}
@@ -0,0 +1,149 @@
+package scan
+
+import (
+	"strings"
+
+	"github.com/aquasecurity/defsec/pkg/extrafs"
+	"github.com/aquasecurity/defsec/pkg/severity"
+)
+
+// ResultFilter reports whether a result should be kept. Filters can be combined with AllOf, AnyOf and Not.
+type ResultFilter func(res Result) bool
+
+// Filter returns the results matching all of the given filters. The original results are not modified.
+func (r Results) Filter(filters ...ResultFilter) Results {
+	match := AllOf(filters...)
+	var filtered Results
+	for _, res := range r {
+		if match(res) {
+			filtered = append(filtered, res)
+		}
+	}
+	return filtered
+}
+
+// AllOf matches results which match every one of the given filters
+func AllOf(filters ...ResultFilter) ResultFilter {
+	return func(res Result) bool {
+		for _, filter := range filters {
+			if !filter(res) {
+				return false
+			}
+		}
+		return true
+	}
+}
+
+// AnyOf matches results which match at least one of the given filters
+func AnyOf(filters ...ResultFilter) ResultFilter {
+	return func(res Result) bool {
+		for _, filter := range filters {
+			if filter(res) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// Not matches results which do not match the given filter
+func Not(filter ResultFilter) ResultFilter {
+	return func(res Result) bool {
+		return !filter(res)
+	}
+}
+
+// FilterBySeverity matches results with one of the given severities
+func FilterBySeverity(severities ...severity.Severity) ResultFilter {
+	return func(res Result) bool {
+		for _, sev := range severities {
+			if res.Severity() == sev {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// FilterByMinimumSeverity matches results with a severity equal to or higher than threshold
+func FilterByMinimumSeverity(threshold severity.Severity) ResultFilter {
+	return func(res Result) bool {
+		return res.Severity().AtLeast(threshold)
+	}
+}
+
+// FilterByRuleIDs matches results for rules with one of the given IDs - AVD IDs, long IDs and aliases are all accepted
+func FilterByRuleIDs(ids ...string) ResultFilter {
+	return func(res Result) bool {
+		rule := res.Rule()
+		for _, id := range ids {
+			if rule.HasID(id) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// FilterByProviders matches results for rules belonging to one of the given providers, e.g. "aws"
+func FilterByProviders(providers ...string) ResultFilter {
+	return func(res Result) bool {
+		provider := string(res.Rule().Provider)
+		for _, p := range providers {
+			if strings.EqualFold(p, provider) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// FilterByServices matches results for rules belonging to one of the given services. Services can be
+// qualified with a provider, e.g. "aws/s3", or unqualified, e.g. "s3".
+func FilterByServices(services ...string) ResultFilter {
+	return func(res Result) bool {
+		rule := res.Rule()
+		for _, service := range services {
+			if provider, name, ok := strings.Cut(service, "/"); ok {
+				if strings.EqualFold(provider, string(rule.Provider)) && strings.EqualFold(name, rule.Service) {
+					return true
+				}
+				continue
+			}
+			if strings.EqualFold(service, rule.Service) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// FilterByPaths matches results found in a file matching one of the given glob patterns. Patterns are matched
+// by extrafs.MatchGlob, so "**" matches any number of directories and "vendor" any file inside a vendor directory.
+func FilterByPaths(patterns ...string) ResultFilter {
+	return func(res Result) bool {
+		rng := res.Range()
+		filename := strings.TrimPrefix(strings.ReplaceAll(rng.GetLocalFilename(), "\\", "/"), "/")
+		if filename == "" {
+			return false
+		}
+		for _, pattern := range patterns {
+			if extrafs.MatchGlob(pattern, filename) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// FilterByStatus matches results with one of the given statuses
+func FilterByStatus(statuses ...Status) ResultFilter {
+	return func(res Result) bool {
+		for _, status := range statuses {
+			if res.Status() == status {
+				return true
+			}
+		}
+		return false
+	}
+}
@@ -0,0 +1,67 @@
+package scan
+
+import (
+	"testing"
+
+	"github.com/aquasecurity/defsec/pkg/severity"
+	defsecTypes "github.com/aquasecurity/defsec/pkg/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Filter(t *testing.T) {
+	results := filterResults()
+
+	assert.Len(t, results.Filter(), 4)
+	assert.Len(t, results.Filter(FilterBySeverity(severity.High)), 1)
+	assert.Len(t, results.Filter(FilterByMinimumSeverity(severity.High)), 2)
+	assert.Len(t, results.Filter(FilterByRuleIDs("AVD-AWS-0001", "aws-ec2-rule")), 2)
+	assert.Len(t, results.Filter(FilterByProviders("AWS")), 3)
+	assert.Len(t, results.Filter(FilterByServices("aws/s3")), 2)
+	assert.Len(t, results.Filter(FilterByServices("s3")), 2)
+	assert.Len(t, results.Filter(FilterByStatus(StatusPassed)), 1)
+	assert.Len(t, results.Filter(FilterByProviders("aws"), Not(FilterByStatus(StatusPassed))), 2)
+	assert.Len(t, results.Filter(AnyOf(FilterByProviders("azure"), FilterBySeverity(severity.Low))), 2)
+}
+
+func Test_FilterByPaths(t *testing.T) {
+	results := filterResults()
+
+	assert.Len(t, results.Filter(FilterByPaths("modules/**/*.tf")), 2)
+	assert.Len(t, results.Filter(FilterByPaths("**/main.tf")), 2)
+	assert.Len(t, results.Filter(FilterByPaths("*.json")), 1)
+	assert.Len(t, results.Filter(FilterByPaths("other/**")), 0)
+	// a pattern without a slash matches any directory, as it does for path filters and ignore files
+	assert.Len(t, results.Filter(FilterByPaths("storage")), 1)
+}
+
+func filterResults() Results {
+	var results Results
+	for _, item := range []struct {
+		filename string
+		rule     Rule
+		status   Status
+	}{
+		{
+			filename: "main.tf",
+			rule:     Rule{AVDID: "AVD-AWS-0001", Provider: "aws", Service: "s3", ShortCode: "one", Severity: severity.Critical},
+		},
+		{
+			filename: "modules/storage/bucket.tf",
+			rule:     Rule{AVDID: "AVD-AWS-0002", Provider: "aws", Service: "s3", ShortCode: "two", Severity: severity.Low},
+			status:   StatusPassed,
+		},
+		{
+			filename: "modules/compute/nested/main.tf",
+			rule:     Rule{AVDID: "AVD-AWS-0003", Provider: "aws", Service: "ec2", ShortCode: "rule", Severity: severity.Medium},
+		},
+		{
+			filename: "template.json",
+			rule:     Rule{AVDID: "AVD-AZU-0001", Provider: "azure", Service: "storage", ShortCode: "three", Severity: severity.High},
+		},
+	} {
+		results.Add("problem", defsecTypes.NewMetadata(defsecTypes.NewRange(item.filename, 1, 1, "", nil), "resource"))
+		results[len(results)-1].SetRule(item.rule)
+		results[len(results)-1].OverrideStatus(item.status)
+	}
+	return results
+}
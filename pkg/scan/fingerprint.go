@@ -0,0 +1,66 @@
+package scan
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"path"
+	"strconv"
+	"strings"
+)
+
+// Fingerprint returns a deterministic identifier for the result, derived from the rule, the address of the
+// resource the result was raised against and the file it was found in. Line numbers are only used when the
+// result cannot be tied to a named resource, so the fingerprint is stable when unrelated lines are added or
+// removed elsewhere in the file.
+func (r Result) Fingerprint() string {
+
+	ruleID := r.ruleRef().AVDID
+	if ruleID == "" {
+		ruleID = r.ruleRef().LongID()
+	}
+
+	var references []string
+	metadata := r.metadata
+	for {
+		if ref := metadata.Reference(); ref != "" {
+			references = append(references, ref)
+		}
+		parent := metadata.Parent()
+		if parent == nil {
+			break
+		}
+		metadata = *parent
+	}
+
+	// parts are written straight to the hash rather than joined first, as a fingerprint is computed for every result
+	hash := sha256.New()
+	_, _ = io.WriteString(hash, ruleID)
+	_, _ = io.WriteString(hash, "\x00")
+	_, _ = io.WriteString(hash, normaliseFingerprintPath(r.fsPath))
+	_, _ = io.WriteString(hash, "\x00")
+	for i, ref := range references {
+		if i > 0 {
+			_, _ = io.WriteString(hash, "/")
+		}
+		_, _ = io.WriteString(hash, ref)
+	}
+
+	if len(references) == 0 {
+		rng := r.metadata.Range()
+		_, _ = io.WriteString(hash, "\x00")
+		_, _ = io.WriteString(hash, strconv.Itoa(rng.GetStartLine()))
+		_, _ = io.WriteString(hash, "\x00")
+		_, _ = io.WriteString(hash, strconv.Itoa(rng.GetEndLine()))
+	}
+
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+func normaliseFingerprintPath(p string) string {
+	if p == "" {
+		return ""
+	}
+	p = strings.ReplaceAll(p, "\\", "/")
+	return strings.TrimPrefix(path.Clean(p), "/")
+}
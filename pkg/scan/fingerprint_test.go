@@ -0,0 +1,47 @@
+package scan
+
+import (
+	"testing"
+
+	defsecTypes "github.com/aquasecurity/defsec/pkg/types"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func fingerprintTestResult(ruleID string, filename string, start, end int, ref string) Result {
+	var results Results
+	results.Add("something is wrong", defsecTypes.NewMetadata(
+		defsecTypes.NewRange(filename, start, end, "", nil),
+		ref,
+	).WithParent(defsecTypes.NewMetadata(
+		defsecTypes.NewRange(filename, start-1, end+1, "", nil),
+		"aws_s3_bucket.example",
+	)))
+	results.SetRule(Rule{AVDID: ruleID})
+	return results[0]
+}
+
+func TestResult_FingerprintIsStableWhenLinesShift(t *testing.T) {
+	original := fingerprintTestResult("AVD-AWS-0001", "main.tf", 10, 12, "aws_s3_bucket.example.acl")
+	shifted := fingerprintTestResult("AVD-AWS-0001", "main.tf", 20, 22, "aws_s3_bucket.example.acl")
+	assert.Equal(t, original.Fingerprint(), shifted.Fingerprint())
+	assert.Len(t, original.Fingerprint(), 64)
+}
+
+func TestResult_FingerprintDistinguishesResults(t *testing.T) {
+	original := fingerprintTestResult("AVD-AWS-0001", "main.tf", 10, 12, "aws_s3_bucket.example.acl")
+	for name, other := range map[string]Result{
+		"rule":      fingerprintTestResult("AVD-AWS-0002", "main.tf", 10, 12, "aws_s3_bucket.example.acl"),
+		"file":      fingerprintTestResult("AVD-AWS-0001", "other.tf", 10, 12, "aws_s3_bucket.example.acl"),
+		"attribute": fingerprintTestResult("AVD-AWS-0001", "main.tf", 10, 12, "aws_s3_bucket.example.policy"),
+	} {
+		assert.NotEqual(t, original.Fingerprint(), other.Fingerprint(), name)
+	}
+}
+
+func TestResult_FingerprintWithoutReferenceUsesLines(t *testing.T) {
+	var results Results
+	results.Add("first", defsecTypes.NewTestMetadata())
+	results.Add("second", defsecTypes.NewMetadata(defsecTypes.NewRange("test.test", 124, 124, "", nil), ""))
+	assert.NotEqual(t, results[0].Fingerprint(), results[1].Fingerprint())
+}
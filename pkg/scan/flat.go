@@ -19,6 +19,7 @@ type FlatResult struct {
 	Severity        severity.Severity  `json:"severity"`
 	Warning         bool               `json:"warning"`
 	Status          Status             `json:"status"`
+	IgnoreReason    string             `json:"ignore_reason,omitempty"`
 	Resource        string             `json:"resource"`
 	Location        FlatRange          `json:"location"`
 }
@@ -30,7 +31,10 @@ type FlatRange struct {
 }
 
 func (r Results) Flatten() []FlatResult {
-	var results []FlatResult
+	if len(r) == 0 {
+		return nil
+	}
+	results := make([]FlatResult, 0, len(r))
 	for _, original := range r {
 		results = append(results, original.Flatten())
 	}
@@ -39,6 +43,7 @@ func (r Results) Flatten() []FlatResult {
 
 func (r *Result) Flatten() FlatResult {
 	rng := r.metadata.Range()
+	rule := r.ruleRef()
 
 	resMetadata := r.metadata
 
@@ -47,18 +52,19 @@ func (r *Result) Flatten() FlatResult {
 	}
 
 	return FlatResult{
-		RuleID:          r.rule.AVDID,
-		LongID:          r.Rule().LongID(),
-		RuleSummary:     r.rule.Summary,
-		RuleProvider:    r.rule.Provider,
-		RuleService:     r.rule.Service,
-		Impact:          r.rule.Impact,
-		Resolution:      r.rule.Resolution,
-		Links:           r.rule.Links,
+		RuleID:          rule.AVDID,
+		LongID:          rule.LongID(),
+		RuleSummary:     rule.Summary,
+		RuleProvider:    rule.Provider,
+		RuleService:     rule.Service,
+		Impact:          rule.Impact,
+		Resolution:      rule.Resolution,
+		Links:           rule.Links,
 		Description:     r.Description(),
 		RangeAnnotation: r.Annotation(),
-		Severity:        r.rule.Severity,
+		Severity:        rule.Severity,
 		Status:          r.status,
+		IgnoreReason:    r.ignoreReason,
 		Resource:        resMetadata.Reference(),
 		Warning:         r.IsWarning(),
 		Location: FlatRange{
@@ -0,0 +1,99 @@
+package scan
+
+import (
+	"sort"
+
+	"github.com/aquasecurity/defsec/pkg/severity"
+)
+
+// ResultGroup is a set of results sharing the same key, as produced by Results.GroupBy.
+type ResultGroup struct {
+	Key     string
+	Results Results
+}
+
+// GroupKey derives the key used to group a result. Results with an empty key are grouped together.
+type GroupKey func(res Result) string
+
+// GroupByResource groups results by the address of the resource they were raised against
+func GroupByResource(res Result) string {
+	return res.Flatten().Resource
+}
+
+// GroupByService groups results by the provider and service of their rule, e.g. "aws/s3"
+func GroupByService(res Result) string {
+	rule := res.Rule()
+	return string(rule.Provider) + "/" + rule.Service
+}
+
+// GroupByRule groups results by the ID of their rule
+func GroupByRule(res Result) string {
+	rule := res.Rule()
+	if rule.AVDID != "" {
+		return rule.AVDID
+	}
+	return rule.LongID()
+}
+
+// GroupByFile groups results by the file they were found in
+func GroupByFile(res Result) string {
+	rng := res.Range()
+	return rng.GetFilename()
+}
+
+// GroupBy splits the results into groups using the given key. Groups are sorted by key, and
+// results within each group retain their original order.
+func (r Results) GroupBy(key GroupKey) []ResultGroup {
+	index := make(map[string]int)
+	var groups []ResultGroup
+	for _, res := range r {
+		k := key(res)
+		i, ok := index[k]
+		if !ok {
+			i = len(groups)
+			index[k] = i
+			groups = append(groups, ResultGroup{Key: k})
+		}
+		groups[i].Results = append(groups[i].Results, res)
+	}
+	sort.SliceStable(groups, func(i, j int) bool {
+		return groups[i].Key < groups[j].Key
+	})
+	return groups
+}
+
+// CountBy returns the number of failed results for each key
+func (r Results) CountBy(key GroupKey) map[string]int {
+	counts := make(map[string]int)
+	for _, res := range r {
+		if res.Status() == StatusFailed {
+			counts[key(res)]++
+		}
+	}
+	return counts
+}
+
+// WorstSeverity returns the highest severity of the failed results, or severity.None if there are no failures
+func (r Results) WorstSeverity() severity.Severity {
+	worst := severity.None
+	for _, res := range r {
+		if res.Status() != StatusFailed {
+			continue
+		}
+		if sev := res.Severity(); sev.AtLeast(worst) {
+			worst = sev
+		}
+	}
+	return worst
+}
+
+// WorstSeverityBy returns the highest severity of the failed results for each key. Keys with no failures are omitted.
+func (r Results) WorstSeverityBy(key GroupKey) map[string]severity.Severity {
+	worst := make(map[string]severity.Severity)
+	for _, group := range r.GroupBy(key) {
+		if sev := group.Results.WorstSeverity(); sev != severity.None {
+			worst[group.Key] = sev
+		}
+	}
+	return worst
+}
@@ -0,0 +1,51 @@
+package scan
+
+import (
+	"testing"
+
+	"github.com/aquasecurity/defsec/pkg/severity"
+	defsecTypes "github.com/aquasecurity/defsec/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_GroupBy(t *testing.T) {
+	results := groupResults()
+
+	groups := results.GroupBy(GroupByResource)
+	require.Len(t, groups, 2)
+	assert.Equal(t, "aws_s3_bucket.a", groups[0].Key)
+	assert.Len(t, groups[0].Results, 2)
+	assert.Equal(t, "aws_s3_bucket.b", groups[1].Key)
+	assert.Len(t, groups[1].Results, 1)
+
+	assert.Equal(t, map[string]int{"aws/s3": 2, "aws/ec2": 1}, results.CountBy(GroupByService))
+}
+
+func Test_WorstSeverityBy(t *testing.T) {
+	results := groupResults()
+
+	assert.Equal(t, severity.Critical, results.WorstSeverity())
+	assert.Equal(t, map[string]severity.Severity{
+		"aws_s3_bucket.a": severity.Critical,
+		"aws_s3_bucket.b": severity.Low,
+	}, results.WorstSeverityBy(GroupByResource))
+	assert.Equal(t, severity.None, Results(nil).WorstSeverity())
+}
+
+func groupResults() Results {
+	var results Results
+	for _, item := range []struct {
+		ref      string
+		service  string
+		severity severity.Severity
+	}{
+		{ref: "aws_s3_bucket.b", service: "s3", severity: severity.Low},
+		{ref: "aws_s3_bucket.a", service: "s3", severity: severity.Medium},
+		{ref: "aws_s3_bucket.a", service: "ec2", severity: severity.Critical},
+	} {
+		results.Add("problem", defsecTypes.NewMetadata(defsecTypes.NewRange("main.tf", 1, 1, "", nil), item.ref))
+		results[len(results)-1].SetRule(Rule{Provider: "aws", Service: item.service, Severity: item.severity})
+	}
+	return results
+}
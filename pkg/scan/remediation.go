@@ -0,0 +1,131 @@
+package scan
+
+import "strings"
+
+type RemediationFormat string
+
+const (
+	RemediationFormatTerraform      RemediationFormat = "terraform"
+	RemediationFormatCloudFormation RemediationFormat = "cloudformation"
+	RemediationFormatKubernetes     RemediationFormat = "kubernetes"
+)
+
+type PatchOperation string
+
+const (
+	PatchOperationSet    PatchOperation = "set"
+	PatchOperationRemove PatchOperation = "remove"
+)
+
+// Remediation holds structured guidance for fixing a rule violation, in addition to the free text Resolution.
+type Remediation struct {
+	Snippets []FixSnippet `json:"snippets,omitempty"`
+	Patches  []Patch      `json:"patches,omitempty"`
+}
+
+// FixSnippet is an example of compliant configuration for a particular IaC format.
+type FixSnippet struct {
+	Format      RemediationFormat `json:"format"`
+	Description string            `json:"description,omitempty"`
+	Code        string            `json:"code"`
+}
+
+// Patch is a machine-applicable change, relative to the resource block a result was raised against.
+// Attribute is a dot separated path within the resource e.g. "metadata_options.http_tokens" or
+// "securityContext.privileged". If ResourceType is set, the patch only applies to results raised against a
+// resource of that type e.g. "aws_s3_bucket_public_access_block".
+type Patch struct {
+	Format       RemediationFormat `json:"format"`
+	Attribute    string            `json:"attribute"`
+	Operation    PatchOperation    `json:"operation"`
+	Value        interface{}       `json:"value,omitempty"`
+	ResourceType string            `json:"resource_type,omitempty"`
+}
+
+// Fix is a Patch bound to the location of a particular result.
+type Fix struct {
+	Patch
+	Filename  string `json:"filename"`
+	StartLine int    `json:"start_line"`
+	EndLine   int    `json:"end_line"`
+	Resource  string `json:"resource,omitempty"`
+}
+
+func (r *Remediation) SnippetFor(format RemediationFormat) (FixSnippet, bool) {
+	if r == nil {
+		return FixSnippet{}, false
+	}
+	for _, snippet := range r.Snippets {
+		if snippet.Format == format {
+			return snippet, true
+		}
+	}
+	return FixSnippet{}, false
+}
+
+func (r *Remediation) PatchesFor(format RemediationFormat) []Patch {
+	if r == nil {
+		return nil
+	}
+	var patches []Patch
+	for _, patch := range r.Patches {
+		if patch.Format == format {
+			patches = append(patches, patch)
+		}
+	}
+	return patches
+}
+
+// Remediation returns the structured remediation for the rule which produced the result, if any.
+func (r Result) Remediation() *Remediation {
+	return r.ruleRef().Remediation
+}
+
+// Fixes returns the patches for the given format, bound to the resource the result was raised against.
+// Only failed results have fixes, and patches for a different type of resource are left out.
+func (r Result) Fixes(format RemediationFormat) []Fix {
+	if r.status != StatusFailed {
+		return nil
+	}
+	patches := r.ruleRef().Remediation.PatchesFor(format)
+	if len(patches) == 0 {
+		return nil
+	}
+
+	resource := r.metadata
+	for resource.Parent() != nil {
+		resource = *resource.Parent()
+	}
+	rng := resource.Range()
+
+	var fixes []Fix
+	for _, patch := range patches {
+		if patch.ResourceType != "" && resourceType(resource.Reference()) != patch.ResourceType {
+			continue
+		}
+		fixes = append(fixes, Fix{
+			Patch:     patch,
+			Filename:  r.fsPath,
+			StartLine: rng.GetStartLine(),
+			EndLine:   rng.GetEndLine(),
+			Resource:  resource.Reference(),
+		})
+	}
+	return fixes
+}
+
+// resourceType returns the type from a resource reference e.g. "aws_s3_bucket" from
+// "module.storage.aws_s3_bucket.example[0]".
+func resourceType(reference string) string {
+	for strings.HasPrefix(reference, "module.") {
+		parts := strings.SplitN(reference, ".", 3)
+		if len(parts) < 3 {
+			return ""
+		}
+		reference = parts[2]
+	}
+	if i := strings.Index(reference, "."); i >= 0 {
+		return reference[:i]
+	}
+	return reference
+}
@@ -0,0 +1,22 @@
+package scan
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ResourceType(t *testing.T) {
+	tests := map[string]string{
+		"aws_s3_bucket.example":                          "aws_s3_bucket",
+		"aws_s3_bucket.example[0]":                       "aws_s3_bucket",
+		"module.storage.aws_s3_bucket.example":           "aws_s3_bucket",
+		"module.a.module.b.aws_s3_bucket.example[\"x\"]": "aws_s3_bucket",
+		"MyBucket": "MyBucket",
+	}
+	for reference, expected := range tests {
+		t.Run(reference, func(t *testing.T) {
+			assert.Equal(t, expected, resourceType(reference))
+		})
+	}
+}
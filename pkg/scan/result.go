@@ -5,10 +5,13 @@ import (
 	"io/fs"
 	"path/filepath"
 	"reflect"
+	"strconv"
 	"strings"
+	"sync"
 
 	defsecTypes "github.com/aquasecurity/defsec/pkg/types"
 
+	"github.com/aquasecurity/defsec/pkg/extrafs"
 	"github.com/aquasecurity/defsec/pkg/severity"
 )
 
@@ -18,10 +21,13 @@ const (
 	StatusFailed Status = iota
 	StatusPassed
 	StatusIgnored
+	// StatusBaselined marks a failure which is suppressed because it was recorded in a baseline
+	StatusBaselined
 )
 
 type Result struct {
-	rule             Rule
+	// rule is shared between all results raised by the same rule, so must not be modified in place
+	rule             *Rule
 	description      string
 	annotation       string
 	status           Status
@@ -32,6 +38,7 @@ type Result struct {
 	warning          bool
 	traces           []string
 	fsPath           string
+	ignoreReason     string
 }
 
 func (r Result) RegoNamespace() string {
@@ -73,8 +80,13 @@ func (r *Result) OverrideAnnotation(annotation string) {
 	r.annotation = annotation
 }
 
+// OverrideIgnoreReason records why the result was ignored, e.g. the reason given in an ignore comment
+func (r *Result) OverrideIgnoreReason(reason string) {
+	r.ignoreReason = reason
+}
+
 func (r *Result) SetRule(ru Rule) {
-	r.rule = ru
+	r.rule = &ru
 }
 
 func (r Result) Status() Status {
@@ -82,9 +94,22 @@ func (r Result) Status() Status {
 }
 
 func (r Result) Rule() Rule {
+	if r.rule == nil {
+		return Rule{}
+	}
+	return *r.rule
+}
+
+// ruleRef avoids copying the rule for callers within the package which only read from it
+func (r *Result) ruleRef() *Rule {
+	if r.rule == nil {
+		return &noRule
+	}
 	return r.rule
 }
 
+var noRule Rule
+
 func (r Result) Description() string {
 	return r.description
 }
@@ -93,6 +118,10 @@ func (r Result) Annotation() string {
 	return r.annotation
 }
 
+func (r Result) IgnoreReason() string {
+	return r.ignoreReason
+}
+
 func (r Result) Metadata() defsecTypes.Metadata {
 	return r.metadata
 }
@@ -117,7 +146,8 @@ func (r *Result) AbsolutePath(fsRoot string, metadata defsecTypes.Metadata) stri
 	if rng.GetSourcePrefix() != "" && !strings.HasPrefix(rng.GetSourcePrefix(), ".") {
 		return rng.GetFilename()
 	}
-	return filepath.Join(fsRoot, rng.GetLocalFilename())
+	// results refer to files with slash separated paths on every platform
+	return filepath.ToSlash(filepath.Join(fsRoot, rng.GetLocalFilename()))
 }
 
 func (r *Result) RelativePathTo(fsRoot, to string, metadata defsecTypes.Metadata) string {
@@ -135,14 +165,14 @@ func (r *Result) RelativePathTo(fsRoot, to string, metadata defsecTypes.Metadata
 	if rng.GetSourcePrefix() != "" && !strings.HasPrefix(rng.GetSourcePrefix(), ".") {
 		return absolute
 	}
-	if !strings.HasPrefix(rng.GetLocalFilename(), strings.TrimSuffix(fsRoot, "/")) {
+	if _, inside := extrafs.RelPath(fsRoot, rng.GetLocalFilename()); !inside {
 		return absolute
 	}
-	relative, err := filepath.Rel(to, rng.GetLocalFilename())
+	relative, err := filepath.Rel(to, filepath.FromSlash(rng.GetLocalFilename()))
 	if err != nil {
 		return absolute
 	}
-	return relative
+	return filepath.ToSlash(relative)
 }
 
 type Results []Result
@@ -160,6 +190,10 @@ func (r *Results) GetIgnored() Results {
 	return r.filterStatus(StatusIgnored)
 }
 
+func (r *Results) GetBaselined() Results {
+	return r.filterStatus(StatusBaselined)
+}
+
 func (r *Results) GetFailed() Results {
 	return r.filterStatus(StatusFailed)
 }
@@ -227,10 +261,26 @@ func getMetadataFromSource(source interface{}) defsecTypes.Metadata {
 	if metaValue.Kind() == reflect.Ptr {
 		metaValue = metaValue.Elem()
 	}
-	metaVal := metaValue.FieldByName("Metadata")
+	metaVal := metaValue.FieldByIndex(metadataFieldIndex(metaValue.Type()))
 	return metaVal.Interface().(defsecTypes.Metadata)
 }
 
+// metadataFieldIndexes caches the location of the Metadata field for each source type, as looking fields up
+// by name is expensive when adding many results
+var metadataFieldIndexes sync.Map
+
+func metadataFieldIndex(t reflect.Type) []int {
+	if index, ok := metadataFieldIndexes.Load(t); ok {
+		return index.([]int)
+	}
+	field, ok := t.FieldByName("Metadata")
+	if !ok {
+		panic(fmt.Sprintf("%s has no Metadata field", t))
+	}
+	metadataFieldIndexes.Store(t, field.Index)
+	return field.Index
+}
+
 func getAnnotation(source interface{}) string {
 	if provider, ok := source.(MetadataProvider); ok {
 		return rawToString(provider.GetRawValue())
@@ -264,7 +314,7 @@ func (r *Results) AddIgnored(source interface{}, descriptions ...string) {
 
 func (r *Results) SetRule(rule Rule) {
 	for i := range *r {
-		(*r)[i].rule = rule
+		(*r)[i].rule = &rule
 	}
 }
 
@@ -297,38 +347,35 @@ func rawToString(raw interface{}) string {
 	}
 	switch t := raw.(type) {
 	case int:
-		return fmt.Sprintf("%d", t)
+		return strconv.Itoa(t)
 	case bool:
-		return fmt.Sprintf("%t", t)
+		return strconv.FormatBool(t)
 	case float64:
-		return fmt.Sprintf("%f", t)
+		return strconv.FormatFloat(t, 'f', 6, 64)
 	case string:
-		return fmt.Sprintf("%q", t)
+		return strconv.Quote(t)
 	case []string:
-		var items []string
-		for _, item := range t {
-			items = append(items, rawToString(item))
-		}
-		return fmt.Sprintf("[%s]", strings.Join(items, ", "))
+		return listToString(len(t), func(i int) string { return strconv.Quote(t[i]) })
 	case []int:
-		var items []string
-		for _, item := range t {
-			items = append(items, rawToString(item))
-		}
-		return fmt.Sprintf("[%s]", strings.Join(items, ", "))
+		return listToString(len(t), func(i int) string { return strconv.Itoa(t[i]) })
 	case []float64:
-		var items []string
-		for _, item := range t {
-			items = append(items, rawToString(item))
-		}
-		return fmt.Sprintf("[%s]", strings.Join(items, ", "))
+		return listToString(len(t), func(i int) string { return strconv.FormatFloat(t[i], 'f', 6, 64) })
 	case []bool:
-		var items []string
-		for _, item := range t {
-			items = append(items, rawToString(item))
-		}
-		return fmt.Sprintf("[%s]", strings.Join(items, ", "))
+		return listToString(len(t), func(i int) string { return strconv.FormatBool(t[i]) })
 	default:
 		return "?"
 	}
 }
+
+func listToString(length int, item func(i int) string) string {
+	var sb strings.Builder
+	sb.WriteByte('[')
+	for i := 0; i < length; i++ {
+		if i > 0 {
+			sb.WriteString(", ")
+		}
+		sb.WriteString(item(i))
+	}
+	sb.WriteByte(']')
+	return sb.String()
+}
@@ -0,0 +1,68 @@
+package scan
+
+import (
+	"testing"
+
+	defsecTypes "github.com/aquasecurity/defsec/pkg/types"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testSource struct {
+	Metadata defsecTypes.Metadata
+}
+
+func Test_RawToString(t *testing.T) {
+	tests := []struct {
+		raw  interface{}
+		want string
+	}{
+		{raw: nil, want: ""},
+		{raw: 12, want: "12"},
+		{raw: true, want: "true"},
+		{raw: 1.5, want: "1.500000"},
+		{raw: "a\"b", want: `"a\"b"`},
+		{raw: []string{"a", "b"}, want: `["a", "b"]`},
+		{raw: []int{1, 2}, want: "[1, 2]"},
+		{raw: []float64{}, want: "[]"},
+		{raw: []bool{true, false}, want: "[true, false]"},
+		{raw: struct{}{}, want: "?"},
+	}
+	for _, test := range tests {
+		assert.Equal(t, test.want, rawToString(test.raw))
+	}
+}
+
+func Test_ResultsWithoutRuleReturnEmptyRule(t *testing.T) {
+	var results Results
+	results.Add("something is wrong", testSource{Metadata: defsecTypes.NewTestMetadata()})
+	assert.Equal(t, Rule{}, results[0].Rule())
+	assert.Len(t, results[0].Fingerprint(), 64)
+}
+
+func Test_SetRuleDoesNotAffectOtherResults(t *testing.T) {
+	var results Results
+	results.Add("first", testSource{Metadata: defsecTypes.NewTestMetadata()})
+	results.Add("second", &testSource{Metadata: defsecTypes.NewTestMetadata()})
+	results.SetRule(Rule{AVDID: "AVD-TEST-0001"})
+
+	results[1].SetRule(Rule{AVDID: "AVD-TEST-0002"})
+	assert.Equal(t, "AVD-TEST-0001", results[0].Rule().AVDID)
+	assert.Equal(t, "AVD-TEST-0002", results[1].Rule().AVDID)
+}
+
+func Benchmark_ResultsAdd(b *testing.B) {
+	source := testSource{Metadata: defsecTypes.NewTestMetadata()}
+	rule := Rule{AVDID: "AVD-TEST-0001", Provider: "aws", Service: "s3", ShortCode: "test"}
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		results := make(Results, 0, 1000)
+		for j := 0; j < 1000; j++ {
+			results.Add("something is wrong", source)
+		}
+		results.SetRule(rule)
+		for _, result := range results {
+			_ = result.Fingerprint()
+		}
+	}
+}
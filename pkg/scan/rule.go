@@ -54,9 +54,12 @@ type Rule struct {
 	Severity       severity.Severity                `json:"severity"`
 	Terraform      *EngineMetadata                  `json:"terraform,omitempty"`
 	CloudFormation *EngineMetadata                  `json:"cloud_formation,omitempty"`
+	Remediation    *Remediation                     `json:"remediation,omitempty"`
 	CustomChecks   CustomChecks                     `json:"-"`
 	RegoPackage    string                           `json:"-"`
 	Frameworks     map[framework.Framework][]string `json:"frameworks"`
+	// RequiredServices lists the services of the provider read by the check, other than the rule's own Service
+	RequiredServices []string `json:"-"`
 }
 
 func (r Rule) HasID(id string) bool {
@@ -68,11 +71,11 @@ func (r Rule) HasID(id string) bool {
 			return true
 		}
 	}
-	return false
+	return resolvesTo(id, r.AVDID)
 }
 
 func (r Rule) LongID() string {
-	return strings.ToLower(fmt.Sprintf("%s-%s-%s", r.Provider, r.Service, r.ShortCode))
+	return strings.ToLower(string(r.Provider) + "-" + r.Service + "-" + r.ShortCode)
 }
 
 func (r Rule) ServiceDisplayName() string {
@@ -0,0 +1,72 @@
+package scan
+
+import (
+	"strings"
+)
+
+// RuleSet matches rules by ID, ID prefix or service. A rule matches the set if it matches any one of its entries.
+type RuleSet struct {
+	// IDs are matched exactly against a rule's AVD ID, long ID and aliases
+	IDs []string
+	// Prefixes are matched case-insensitively against the start of a rule's AVD ID, long ID and aliases, e.g.
+	// "AVD-AWS-" or "aws-s3-"
+	Prefixes []string
+	// Services can be qualified with a provider, e.g. "aws/s3", or unqualified, e.g. "s3"
+	Services []string
+}
+
+// IsEmpty reports whether the set has no entries, and so matches no rules
+func (s RuleSet) IsEmpty() bool {
+	return len(s.IDs) == 0 && len(s.Prefixes) == 0 && len(s.Services) == 0
+}
+
+// Matches reports whether the rule matches any entry in the set
+func (s RuleSet) Matches(rule Rule) bool {
+	for _, id := range s.IDs {
+		if rule.HasID(id) {
+			return true
+		}
+	}
+	if len(s.Prefixes) > 0 {
+		ids := append([]string{rule.AVDID, rule.LongID()}, rule.Aliases...)
+		for _, prefix := range s.Prefixes {
+			for _, id := range ids {
+				if id != "" && len(id) >= len(prefix) && strings.EqualFold(id[:len(prefix)], prefix) {
+					return true
+				}
+			}
+		}
+	}
+	for _, service := range s.Services {
+		if provider, name, ok := strings.Cut(service, "/"); ok {
+			if strings.EqualFold(provider, string(rule.Provider)) && strings.EqualFold(name, rule.Service) {
+				return true
+			}
+			continue
+		}
+		if strings.EqualFold(service, rule.Service) {
+			return true
+		}
+	}
+	return false
+}
+
+// RuleSelection decides which rules are evaluated by a scan. When Include is not empty, only rules matching it
+// are selected. Rules matching Exclude are never selected, even if they also match Include.
+type RuleSelection struct {
+	Include RuleSet
+	Exclude RuleSet
+}
+
+// IsEmpty reports whether the selection has no entries, and so selects every rule
+func (s RuleSelection) IsEmpty() bool {
+	return s.Include.IsEmpty() && s.Exclude.IsEmpty()
+}
+
+// Selects reports whether the rule should be evaluated
+func (s RuleSelection) Selects(rule Rule) bool {
+	if !s.Include.IsEmpty() && !s.Include.Matches(rule) {
+		return false
+	}
+	return !s.Exclude.Matches(rule)
+}
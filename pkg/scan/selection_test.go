@@ -0,0 +1,77 @@
+package scan
+
+import (
+	"testing"
+
+	"github.com/aquasecurity/defsec/pkg/providers"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_RuleSelection(t *testing.T) {
+	s3Rule := Rule{
+		AVDID:     "AVD-AWS-0086",
+		Aliases:   []string{"aws-s3-block-public-acls"},
+		ShortCode: "block-public-acls",
+		Provider:  providers.AWSProvider,
+		Service:   "s3",
+	}
+	ec2Rule := Rule{
+		AVDID:     "AVD-AWS-0028",
+		ShortCode: "enforce-http-token-imds",
+		Provider:  providers.AWSProvider,
+		Service:   "ec2",
+	}
+	azureRule := Rule{
+		AVDID:     "AVD-AZU-0038",
+		ShortCode: "enable-disk-encryption",
+		Provider:  providers.AzureProvider,
+		Service:   "compute",
+	}
+
+	tests := []struct {
+		name      string
+		selection RuleSelection
+		expected  []bool
+	}{
+		{
+			name:     "empty selection selects everything",
+			expected: []bool{true, true, true},
+		},
+		{
+			name:      "include by id",
+			selection: RuleSelection{Include: RuleSet{IDs: []string{"AVD-AWS-0028"}}},
+			expected:  []bool{false, true, false},
+		},
+		{
+			name:      "include by prefix",
+			selection: RuleSelection{Include: RuleSet{Prefixes: []string{"avd-aws-"}}},
+			expected:  []bool{true, true, false},
+		},
+		{
+			name:      "include by long id prefix",
+			selection: RuleSelection{Include: RuleSet{Prefixes: []string{"azure-compute-"}}},
+			expected:  []bool{false, false, true},
+		},
+		{
+			name:      "exclude by qualified service",
+			selection: RuleSelection{Exclude: RuleSet{Services: []string{"aws/s3"}}},
+			expected:  []bool{false, true, true},
+		},
+		{
+			name: "exclude takes precedence over include",
+			selection: RuleSelection{
+				Include: RuleSet{Prefixes: []string{"AVD-AWS-"}},
+				Exclude: RuleSet{IDs: []string{"aws-s3-block-public-acls"}},
+			},
+			expected: []bool{false, true, false},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			for i, rule := range []Rule{s3Rule, ec2Rule, azureRule} {
+				assert.Equal(t, test.expected[i], test.selection.Selects(rule), rule.AVDID)
+			}
+		})
+	}
+}
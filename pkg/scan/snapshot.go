@@ -0,0 +1,62 @@
+package scan
+
+import (
+	defsecTypes "github.com/aquasecurity/defsec/pkg/types"
+
+	"github.com/aquasecurity/defsec/pkg/severity"
+)
+
+// ResultSnapshot is a serialisable copy of a Result, used to persist results between scans. Filesystem
+// references are not preserved - use Results.SetSourceAndFilesystem after restoring results.
+type ResultSnapshot struct {
+	Rule             Rule                 `json:"rule"`
+	RegoPackage      string               `json:"rego_package,omitempty"`
+	Description      string               `json:"description"`
+	Annotation       string               `json:"annotation,omitempty"`
+	Status           Status               `json:"status"`
+	Metadata         defsecTypes.Metadata `json:"metadata"`
+	SeverityOverride *severity.Severity   `json:"severity_override,omitempty"`
+	RegoNamespace    string               `json:"rego_namespace,omitempty"`
+	RegoRule         string               `json:"rego_rule,omitempty"`
+	Warning          bool                 `json:"warning,omitempty"`
+	Traces           []string             `json:"traces,omitempty"`
+	FSPath           string               `json:"fs_path,omitempty"`
+	IgnoreReason     string               `json:"ignore_reason,omitempty"`
+}
+
+func (r Result) Snapshot() ResultSnapshot {
+	return ResultSnapshot{
+		Rule:             r.Rule(),
+		RegoPackage:      r.ruleRef().RegoPackage,
+		Description:      r.description,
+		Annotation:       r.annotation,
+		Status:           r.status,
+		Metadata:         r.metadata,
+		SeverityOverride: r.severityOverride,
+		RegoNamespace:    r.regoNamespace,
+		RegoRule:         r.regoRule,
+		Warning:          r.warning,
+		Traces:           r.traces,
+		FSPath:           r.fsPath,
+		IgnoreReason:     r.ignoreReason,
+	}
+}
+
+func (s ResultSnapshot) Restore() Result {
+	rule := s.Rule
+	rule.RegoPackage = s.RegoPackage
+	return Result{
+		rule:             &rule,
+		description:      s.Description,
+		annotation:       s.Annotation,
+		status:           s.Status,
+		metadata:         s.Metadata,
+		severityOverride: s.SeverityOverride,
+		regoNamespace:    s.RegoNamespace,
+		regoRule:         s.RegoRule,
+		warning:          s.Warning,
+		traces:           s.Traces,
+		fsPath:           s.FSPath,
+		ignoreReason:     s.IgnoreReason,
+	}
+}
@@ -0,0 +1,29 @@
+package scan
+
+import "github.com/aquasecurity/defsec/pkg/severity"
+
+// CountsBySeverity returns the number of failed results for each severity. Every valid severity is present in the map.
+func (r Results) CountsBySeverity() map[severity.Severity]int {
+	counts := make(map[severity.Severity]int, len(severity.ValidSeverity))
+	for _, sev := range severity.ValidSeverity {
+		counts[sev] = 0
+	}
+	for _, res := range r {
+		if res.Status() == StatusFailed {
+			counts[res.Severity()]++
+		}
+	}
+	return counts
+}
+
+// FailuresAbove returns the failed results with a severity equal to or higher than threshold,
+// e.g. FailuresAbove(severity.High) returns all HIGH and CRITICAL failures.
+func (r Results) FailuresAbove(threshold severity.Severity) Results {
+	var filtered Results
+	for _, res := range r {
+		if res.Status() == StatusFailed && res.Severity().AtLeast(threshold) {
+			filtered = append(filtered, res)
+		}
+	}
+	return filtered
+}
@@ -0,0 +1,40 @@
+package scan
+
+import (
+	"testing"
+
+	"github.com/aquasecurity/defsec/pkg/severity"
+	defsecTypes "github.com/aquasecurity/defsec/pkg/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_CountsBySeverity(t *testing.T) {
+	results := severityResults(severity.High, severity.Critical, severity.High, severity.Low)
+	results[3].OverrideStatus(StatusPassed)
+
+	counts := results.CountsBySeverity()
+	assert.Equal(t, map[severity.Severity]int{
+		severity.Critical: 1,
+		severity.High:     2,
+		severity.Medium:   0,
+		severity.Low:      0,
+	}, counts)
+}
+
+func Test_FailuresAbove(t *testing.T) {
+	results := severityResults(severity.Low, severity.Medium, severity.High, severity.Critical)
+
+	assert.Len(t, results.FailuresAbove(severity.High), 2)
+	assert.Len(t, results.FailuresAbove(severity.Low), 4)
+	assert.Len(t, results.FailuresAbove(severity.None), 4)
+	assert.Len(t, Results(nil).FailuresAbove(severity.Low), 0)
+}
+
+func severityResults(severities ...severity.Severity) Results {
+	var results Results
+	for _, sev := range severities {
+		results.Add("problem", defsecTypes.NewTestMetadata())
+		results[len(results)-1].SetRule(Rule{Severity: sev})
+	}
+	return results
+}
@@ -0,0 +1,226 @@
+package parser
+
+import (
+	"io/fs"
+	"net/url"
+	"path"
+	"regexp"
+	"strings"
+
+	"github.com/aquasecurity/defsec/pkg/scanners/azure"
+)
+
+// maxNestingDepth limits how deeply nested and linked templates are resolved into a deployment
+const maxNestingDepth = 10
+
+// stringLiteral matches the string literals of a template expression
+var stringLiteral = regexp.MustCompile(`'([^']*)'`)
+
+// nesting tracks the resolution of nested and linked templates into a single deployment
+type nesting struct {
+	// chain is the files of the templates currently being resolved, outermost first, so cycles of links are
+	// stopped
+	chain []string
+	// linked is the path of every linked template resolved
+	linked []string
+}
+
+// convertResources converts the resources of a template found in filename, substituting the given parameters.
+// Each deployment resource is followed by the resources of the template it deploys.
+func (p *Parser) convertResources(resources []Resource, filename string, parameters map[string]azure.Value, n *nesting) []azure.Resource {
+	var converted []azure.Resource
+	for _, resource := range resources {
+		resolved := p.convertResource(resource).SubstituteParameters(parameters)
+		converted = append(converted, resolved)
+		if strings.EqualFold(resource.Type.AsString(), deploymentType) {
+			converted = append(converted, p.deployedResources(resource.Template, resolved.Properties, filename, parameters, n)...)
+		}
+	}
+	return converted
+}
+
+// deployedResources returns the resources of the template deployed by a deployment resource in filename, whether
+// it is nested within the resource or linked from another file in the filesystem. Templates which cannot be found
+// are logged and skipped, as they may be deployed from elsewhere.
+func (p *Parser) deployedResources(nested *Template, properties azure.Value, filename string, parameters map[string]azure.Value, n *nesting) []azure.Resource {
+	if len(n.chain) > maxNestingDepth {
+		p.debug.Log("Not resolving templates deployed from %s, which are nested more than %d deep", filename, maxNestingDepth)
+		return nil
+	}
+
+	template, templateFile := nested, filename
+	if template != nil {
+		// nested templates are evaluated in the scope of the template containing them, unless the inner scope is set
+		scope := properties.GetMapValue("expressionEvaluationOptions").GetMapValue("scope").AsString()
+		if strings.EqualFold(scope, "inner") {
+			parameters = passedParameters(properties.GetMapValue("parameters"))
+		}
+	} else {
+		link := properties.GetMapValue("templateLink")
+		if link.Kind != azure.KindObject {
+			return nil
+		}
+		linked, ok := p.resolveLink(link, path.Dir(filename))
+		if !ok {
+			p.debug.Log("Could not find the template linked from %s in the filesystem", filename)
+			return nil
+		}
+		for _, file := range n.chain {
+			if file == linked {
+				p.debug.Log("Not resolving the template %s linked from %s, which links back to itself", linked, filename)
+				return nil
+			}
+		}
+		// linked templates are read from the same filesystem as the templates found by ParseFS, so they are subject
+		// to the same limits and path filters
+		if !p.pathFilter.AllowsFile(p.root, linked) || !p.tracker.AllowSize(p.targetFS, linked) {
+			p.debug.Log("Not resolving the template %s linked from %s, which is excluded from the scan", linked, filename)
+			return nil
+		}
+		data, err := fs.ReadFile(p.targetFS, linked)
+		if err != nil {
+			p.debug.Log("Failed to read the template %s linked from %s: %s", linked, filename, err)
+			return nil
+		}
+		parsed, err := p.parseTemplate(data, linked)
+		if err != nil {
+			p.debug.Log("Failed to parse the template %s linked from %s: %s", linked, filename, err)
+			return nil
+		}
+		n.linked = append(n.linked, linked)
+		template, templateFile = parsed, linked
+		// linked templates are always evaluated in their own scope, against their own parameters
+		parameters = linkedParameters(*parsed, properties.GetMapValue("parameters"))
+		p.newDeployment(*parsed, parameters)
+	}
+
+	n.chain = append(n.chain, templateFile)
+	defer func() { n.chain = n.chain[:len(n.chain)-1] }()
+	return p.convertResources(template.Resources, templateFile, parameters, n)
+}
+
+// linkedParameters returns the known values of the parameters of a linked template: the values passed to it by the
+// deployment, or otherwise the defaults declared by the template
+func linkedParameters(template Template, passed azure.Value) map[string]azure.Value {
+	parameters := make(map[string]azure.Value)
+	for name, parameter := range template.Parameters {
+		if known(parameter.DefaultValue) {
+			parameters[name] = parameter.DefaultValue
+		}
+	}
+	for name, value := range passedParameters(passed) {
+		parameters[name] = value
+	}
+	return parameters
+}
+
+// passedParameters returns the values of the parameters passed to a deployment which are known, i.e. not
+// expressions
+func passedParameters(parameters azure.Value) map[string]azure.Value {
+	passed := make(map[string]azure.Value)
+	for name, parameter := range parameters.AsMap() {
+		if value := parameter.GetMapValue("value"); known(value) {
+			passed[name] = value
+		}
+	}
+	return passed
+}
+
+// known reports whether a value is set and is not an expression, so it can be substituted for a parameter
+func known(value azure.Value) bool {
+	return value.Kind != "" && value.Kind != azure.KindExpression && value.Kind != azure.KindUnresolvable
+}
+
+// resolveLink returns the path of a linked template within the filesystem. A relative path is taken to be relative
+// to dir, the directory of the linking template. Links by uri are usually to wherever the templates are published,
+// so the path of the uri (or the last string in it which names a json file, if it is an expression) is looked for
+// within dir and the directories containing it, as is each shorter suffix of that path.
+func (p *Parser) resolveLink(link azure.Value, dir string) (string, bool) {
+	if relative := link.GetMapValue("relativePath"); relative.Kind == azure.KindString {
+		return p.findFile([]string{path.Join(dir, relative.AsString())})
+	}
+
+	var target string
+	uri := link.GetMapValue("uri")
+	switch uri.Kind {
+	case azure.KindString:
+		target = uri.AsString()
+		if parsed, err := url.Parse(target); err == nil {
+			target = parsed.Path
+		}
+	case azure.KindExpression:
+		expression, _ := uri.Raw().(string)
+		for _, match := range stringLiteral.FindAllStringSubmatch(expression, -1) {
+			if strings.Contains(match[1], ".json") {
+				target = match[1]
+			}
+		}
+	}
+	target = strings.Trim(strings.SplitN(target, "?", 2)[0], "/")
+	if target == "" {
+		return "", false
+	}
+
+	var candidates []string
+	segments := strings.Split(target, "/")
+	for i := range segments {
+		suffix := path.Join(segments[i:]...)
+		for current := dir; ; current = path.Dir(current) {
+			candidates = append(candidates, path.Join(current, suffix))
+			if current == "." || current == "/" {
+				break
+			}
+		}
+	}
+	return p.findFile(candidates)
+}
+
+// findFile returns the first of the paths which is a file within the filesystem
+func (p *Parser) findFile(candidates []string) (string, bool) {
+	for _, candidate := range candidates {
+		if candidate == ".." || strings.HasPrefix(candidate, "../") {
+			continue
+		}
+		if info, err := fs.Stat(p.targetFS, candidate); err == nil && !info.IsDir() {
+			return candidate, true
+		}
+	}
+	return "", false
+}
+
+// withoutLinked removes the deployments of templates which are linked from another template, as they are
+// evaluated as part of it with the parameters it passes. Templates which are only linked from each other, in a
+// cycle, are kept.
+func withoutLinked(deployments []azure.Deployment, paths []string, links map[string][]string) []azure.Deployment {
+	linkedTo := make(map[string]bool)
+	for _, linked := range links {
+		for _, file := range linked {
+			linkedTo[file] = true
+		}
+	}
+
+	// everything reachable from a template which is not linked to is evaluated as part of it
+	reachable := make(map[string]bool)
+	var visit func(file string)
+	visit = func(file string) {
+		for _, linked := range links[file] {
+			if !reachable[linked] {
+				reachable[linked] = true
+				visit(linked)
+			}
+		}
+	}
+	for _, file := range paths {
+		if !linkedTo[file] {
+			visit(file)
+		}
+	}
+
+	var kept []azure.Deployment
+	for i, deployment := range deployments {
+		if !linkedTo[paths[i]] || !reachable[paths[i]] {
+			kept = append(kept, deployment)
+		}
+	}
+	return kept
+}
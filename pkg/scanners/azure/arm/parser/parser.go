@@ -2,6 +2,7 @@ package parser
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"io/fs"
@@ -12,6 +13,8 @@ import (
 	"github.com/aquasecurity/defsec/pkg/scanners/azure"
 	"github.com/aquasecurity/defsec/pkg/scanners/azure/resolver"
 
+	"github.com/aquasecurity/defsec/pkg/scanners/charset"
+	"github.com/aquasecurity/defsec/pkg/scanners/limits"
 	"github.com/aquasecurity/defsec/pkg/scanners/options"
 
 	"github.com/aquasecurity/defsec/pkg/types"
@@ -23,16 +26,35 @@ type Parser struct {
 	targetFS     fs.FS
 	skipRequired bool
 	debug        debug.Logger
+	pathFilter   options.PathFilter
+	fileLimits   limits.FileLimits
+	summary      *limits.Summary
+	// tracker applies the file limits to the files read by the current call to ParseFS, whose directory is root
+	tracker *limits.Tracker
+	root    string
 }
 
 func (p *Parser) SetDebugWriter(writer io.Writer) {
 	p.debug = debug.New(writer, "azure", "arm")
 }
 
+func (p *Parser) SetLogHandler(handler debug.Handler) {
+	p.debug = debug.NewWithHandler(handler, "azure", "arm")
+}
+
 func (p *Parser) SetSkipRequiredCheck(b bool) {
 	p.skipRequired = b
 }
 
+func (p *Parser) SetPathFilter(filter options.PathFilter) {
+	p.pathFilter = filter
+}
+
+func (p *Parser) SetFileLimits(fileLimits limits.FileLimits, summary *limits.Summary) {
+	p.fileLimits = fileLimits
+	p.summary = summary
+}
+
 func New(targetFS fs.FS, opts ...options.ParserOption) *Parser {
 	p := &Parser{
 		targetFS: targetFS,
@@ -43,9 +65,16 @@ func New(targetFS fs.FS, opts ...options.ParserOption) *Parser {
 	return p
 }
 
+// ParseFS parses the templates found in dir into deployments. Nested templates, and templates linked from within
+// the filesystem, are resolved into the deployments which deploy them, so templates which are only ever linked from
+// others are not returned as deployments of their own.
 func (p *Parser) ParseFS(ctx context.Context, dir string) ([]azure.Deployment, error) {
 
 	var deployments []azure.Deployment
+	var paths []string
+	links := make(map[string][]string)
+	p.tracker = limits.NewTracker(p.fileLimits, p.summary)
+	p.root = dir
 
 	if err := fs.WalkDir(p.targetFS, dir, func(path string, entry fs.DirEntry, err error) error {
 		if err != nil {
@@ -57,27 +86,51 @@ func (p *Parser) ParseFS(ctx context.Context, dir string) ([]azure.Deployment, e
 		default:
 		}
 		if entry.IsDir() {
+			if p.pathFilter.SkipsDir(dir, path) {
+				return fs.SkipDir
+			}
 			return nil
 		}
-		if !p.Required(path) {
+		if !p.pathFilter.AllowsFile(dir, path) {
 			return nil
 		}
-		f, err := p.targetFS.Open(path)
-		if err != nil {
-			return err
+		if !p.tracker.AllowSize(p.targetFS, path) {
+			return nil
+		}
+		if !p.Required(path) || !p.tracker.AllowFile(path) {
+			return nil
+		}
+		type parsed struct {
+			deployment *azure.Deployment
+			linked     []string
 		}
-		defer f.Close()
-		deployment, err := p.parseFile(f, path)
+		result, err := limits.Parse(ctx, p.tracker, path, func(context.Context) (parsed, error) {
+			f, err := p.targetFS.Open(path)
+			if err != nil {
+				return parsed{}, err
+			}
+			defer f.Close()
+			deployment, linked, err := p.parseFile(f, path)
+			return parsed{deployment: deployment, linked: linked}, err
+		})
 		if err != nil {
+			if ctx.Err() == nil && errors.Is(err, context.DeadlineExceeded) {
+				// the file is recorded as skipped in the summary, and the rest of the files are still parsed
+				p.debug.Warn("Parsing timed out", "path", path)
+				return nil
+			}
 			return err
 		}
+		deployment, linked := result.deployment, result.linked
 		deployments = append(deployments, *deployment)
+		paths = append(paths, path)
+		links[path] = linked
 		return nil
 	}); err != nil {
 		return nil, err
 	}
 
-	return deployments, nil
+	return withoutLinked(deployments, paths, links), nil
 }
 
 func (p *Parser) Required(path string) bool {
@@ -91,13 +144,14 @@ func (p *Parser) Required(path string) bool {
 	if err != nil {
 		return false
 	}
+	data = charset.Decode(data)
 	var template Template
 	root := types.NewMetadata(
 		types.NewRange(filepath.Base(path), 0, 0, "", p.targetFS),
 		"",
 	)
 	if err := armjson.Unmarshal(data, &template, &root); err != nil {
-		p.debug.Log("Error scanning %s: %s", path, err)
+		p.debug.Warn("Failed to parse file", "path", path, "error", err)
 		return false
 	}
 
@@ -108,12 +162,23 @@ func (p *Parser) Required(path string) bool {
 	return strings.HasPrefix(template.Schema.AsString(), "https://schema.management.azure.com")
 }
 
-func (p *Parser) parseFile(r io.Reader, filename string) (*azure.Deployment, error) {
-	var template Template
+// parseFile parses a template into a deployment, returning the paths of the templates it links to as well
+func (p *Parser) parseFile(r io.Reader, filename string) (*azure.Deployment, []string, error) {
 	data, err := io.ReadAll(r)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
+	}
+	template, err := p.parseTemplate(data, filename)
+	if err != nil {
+		return nil, nil, err
 	}
+	n := &nesting{chain: []string{filename}}
+	return p.convertTemplate(*template, filename, n), n.linked, nil
+}
+
+func (p *Parser) parseTemplate(data []byte, filename string) (*Template, error) {
+	var template Template
+	data = charset.Decode(data)
 	root := types.NewMetadata(
 		types.NewRange(filename, 0, 0, "", p.targetFS),
 		"",
@@ -122,10 +187,18 @@ func (p *Parser) parseFile(r io.Reader, filename string) (*azure.Deployment, err
 	if err := armjson.Unmarshal(data, &template, &root); err != nil {
 		return nil, fmt.Errorf("failed to parse template: %w", err)
 	}
-	return p.convertTemplate(template), nil
+	return &template, nil
+}
+
+func (p *Parser) convertTemplate(template Template, filename string, n *nesting) *azure.Deployment {
+	deployment := p.newDeployment(template, nil)
+	deployment.Resources = p.convertResources(template.Resources, filename, nil, n)
+	return deployment
 }
 
-func (p *Parser) convertTemplate(template Template) *azure.Deployment {
+// newDeployment creates the deployment of a template, which the expressions in the template are resolved against.
+// Parameters take the values given, or otherwise their defaults.
+func (p *Parser) newDeployment(template Template, values map[string]azure.Value) *azure.Deployment {
 
 	deployment := azure.Deployment{
 		Metadata:    template.Metadata,
@@ -142,10 +215,14 @@ func (p *Parser) convertTemplate(template Template) *azure.Deployment {
 
 	// TODO: the references passed here should probably not be the name - maybe params.NAME.DefaultValue?
 	for name, param := range template.Parameters {
+		value := param.DefaultValue
+		if given, ok := values[name]; ok {
+			value = given
+		}
 		deployment.Parameters = append(deployment.Parameters, azure.Parameter{
 			Variable: azure.Variable{
 				Name:  name,
-				Value: param.DefaultValue,
+				Value: value,
 			},
 			Default:    param.DefaultValue,
 			Decorators: nil,
@@ -166,10 +243,6 @@ func (p *Parser) convertTemplate(template Template) *azure.Deployment {
 		})
 	}
 
-	for _, resource := range template.Resources {
-		deployment.Resources = append(deployment.Resources, p.convertResource(resource))
-	}
-
 	return &deployment
 }
 
@@ -2,12 +2,15 @@ package parser
 
 import (
 	"context"
+	"fmt"
 	"io/fs"
 	"os"
+	"strings"
 	"testing"
 
 	"github.com/aquasecurity/defsec/pkg/scanners/azure"
 	"github.com/aquasecurity/defsec/pkg/scanners/azure/resolver"
+	"github.com/aquasecurity/defsec/pkg/scanners/limits"
 	"github.com/aquasecurity/defsec/pkg/scanners/options"
 
 	"github.com/stretchr/testify/assert"
@@ -339,3 +342,350 @@ func Test_NestedResourceParsing(t *testing.T) {
 // 	assert.Equal(t, "myserver", name)
 //
 // }
+
+func Test_NestedTemplateParsing(t *testing.T) {
+
+	input := `
+{
+  "$schema": "https://schema.management.azure.com/schemas/2019-04-01/deploymentTemplate.json#",
+  "contentVersion": "1.0.0.0",
+  "resources": [
+    {
+      "type": "Microsoft.Resources/deployments",
+      "apiVersion": "2021-04-01",
+      "name": "nestedTemplate",
+      "properties": {
+        "mode": "Incremental",
+        "expressionEvaluationOptions": {
+          "scope": "inner"
+        },
+        "parameters": {
+          "allowSharedKeyAccess": {
+            "value": false
+          }
+        },
+        "template": {
+          "$schema": "https://schema.management.azure.com/schemas/2019-04-01/deploymentTemplate.json#",
+          "contentVersion": "1.0.0.0",
+          "parameters": {
+            "allowSharedKeyAccess": {
+              "type": "bool"
+            }
+          },
+          "resources": [
+            {
+              "type": "Microsoft.Storage/storageAccounts",
+              "apiVersion": "2022-05-01",
+              "name": "nestedStorage",
+              "properties": {
+                "allowSharedKeyAccess": "[parameters('allowSharedKeyAccess')]"
+              }
+            }
+          ]
+        }
+      }
+    }
+  ]
+}
+`
+
+	targetFS := memoryfs.New()
+
+	require.NoError(t, targetFS.WriteFile("nested.json", []byte(input), 0644))
+
+	p := New(targetFS, options.ParserWithDebug(os.Stderr))
+	got, err := p.ParseFS(context.Background(), ".")
+	require.NoError(t, err)
+	require.Len(t, got, 1)
+
+	deployment := got[0]
+
+	require.Len(t, deployment.Resources, 2)
+
+	assert.Equal(t, "Microsoft.Resources/deployments", deployment.Resources[0].Type.AsString())
+
+	storageAccount := deployment.Resources[1]
+	assert.Equal(t, "Microsoft.Storage/storageAccounts", storageAccount.Type.AsString())
+	assert.Equal(t, "nested.json", storageAccount.Metadata.Range().GetFilename())
+
+	allowSharedKeyAccess := storageAccount.Properties.GetMapValue("allowSharedKeyAccess")
+	assert.Equal(t, azure.KindBoolean, allowSharedKeyAccess.Kind)
+	assert.False(t, allowSharedKeyAccess.AsBool())
+}
+
+func Test_LinkedTemplateParsing(t *testing.T) {
+
+	main := `
+{
+  "$schema": "https://schema.management.azure.com/schemas/2019-04-01/deploymentTemplate.json#",
+  "contentVersion": "1.0.0.0",
+  "resources": [
+    {
+      "type": "Microsoft.Resources/deployments",
+      "apiVersion": "2021-04-01",
+      "name": "relativeLink",
+      "properties": {
+        "mode": "Incremental",
+        "templateLink": {
+          "relativePath": "linked/storage.json"
+        },
+        "parameters": {
+          "accountName": {
+            "value": "linkedStorage"
+          }
+        }
+      }
+    },
+    {
+      "type": "Microsoft.Resources/deployments",
+      "apiVersion": "2021-04-01",
+      "name": "uriLink",
+      "properties": {
+        "mode": "Incremental",
+        "templateLink": {
+          "uri": "[uri(deployment().properties.templateLink.uri, 'linked/storage.json')]"
+        },
+        "parameters": {
+          "accountName": {
+            "value": "[parameters('unknown')]"
+          }
+        }
+      }
+    }
+  ]
+}
+`
+
+	linked := `
+{
+  "$schema": "https://schema.management.azure.com/schemas/2019-04-01/deploymentTemplate.json#",
+  "contentVersion": "1.0.0.0",
+  "parameters": {
+    "accountName": {
+      "type": "string"
+    }
+  },
+  "resources": [
+    {
+      "type": "Microsoft.Storage/storageAccounts",
+      "apiVersion": "2022-05-01",
+      "name": "[parameters('accountName')]",
+      "properties": {
+        "allowSharedKeyAccess": false
+      }
+    }
+  ]
+}
+`
+
+	targetFS := memoryfs.New()
+
+	require.NoError(t, targetFS.MkdirAll("templates/linked", 0700))
+	require.NoError(t, targetFS.WriteFile("templates/main.json", []byte(main), 0644))
+	require.NoError(t, targetFS.WriteFile("templates/linked/storage.json", []byte(linked), 0644))
+
+	p := New(targetFS, options.ParserWithDebug(os.Stderr))
+	got, err := p.ParseFS(context.Background(), ".")
+	require.NoError(t, err)
+	require.Len(t, got, 1, "the linked template should only be parsed as part of the main template")
+
+	deployment := got[0]
+	assert.Equal(t, "templates/main.json", deployment.Metadata.Range().GetFilename())
+
+	require.Len(t, deployment.Resources, 4)
+
+	relative := deployment.Resources[1]
+	assert.Equal(t, "Microsoft.Storage/storageAccounts", relative.Type.AsString())
+	assert.Equal(t, "templates/linked/storage.json", relative.Metadata.Range().GetFilename())
+	assert.Equal(t, "linkedStorage", relative.Name.AsString())
+
+	uri := deployment.Resources[3]
+	assert.Equal(t, "Microsoft.Storage/storageAccounts", uri.Type.AsString())
+	assert.Equal(t, "templates/linked/storage.json", uri.Metadata.Range().GetFilename())
+	assert.Equal(t, azure.KindExpression, uri.Name.Kind)
+}
+
+func Test_LinkedTemplateCycle(t *testing.T) {
+
+	template := `
+{
+  "$schema": "https://schema.management.azure.com/schemas/2019-04-01/deploymentTemplate.json#",
+  "contentVersion": "1.0.0.0",
+  "resources": [
+    {
+      "type": "Microsoft.Resources/deployments",
+      "apiVersion": "2021-04-01",
+      "name": "link",
+      "properties": {
+        "mode": "Incremental",
+        "templateLink": {
+          "relativePath": "%s"
+        }
+      }
+    }
+  ]
+}
+`
+
+	targetFS := memoryfs.New()
+
+	require.NoError(t, targetFS.WriteFile("a.json", []byte(fmt.Sprintf(template, "b.json")), 0644))
+	require.NoError(t, targetFS.WriteFile("b.json", []byte(fmt.Sprintf(template, "a.json")), 0644))
+
+	p := New(targetFS, options.ParserWithDebug(os.Stderr))
+	got, err := p.ParseFS(context.Background(), ".")
+	require.NoError(t, err)
+	require.Len(t, got, 2)
+
+	for _, deployment := range got {
+		assert.Len(t, deployment.Resources, 2)
+	}
+}
+
+func Test_LinkedTemplateParameters(t *testing.T) {
+
+	main := `
+{
+  "$schema": "https://schema.management.azure.com/schemas/2019-04-01/deploymentTemplate.json#",
+  "contentVersion": "1.0.0.0",
+  "parameters": {
+    "tier": {
+      "type": "string",
+      "defaultValue": "Premium"
+    }
+  },
+  "variables": {
+    "prefix": "main"
+  },
+  "resources": [
+    {
+      "type": "Microsoft.Resources/deployments",
+      "apiVersion": "2021-04-01",
+      "name": "link",
+      "properties": {
+        "mode": "Incremental",
+        "templateLink": {
+          "relativePath": "linked.json"
+        },
+        "parameters": {
+          "tier": {
+            "value": "Cool"
+          }
+        }
+      }
+    }
+  ]
+}
+`
+
+	linked := `
+{
+  "$schema": "https://schema.management.azure.com/schemas/2019-04-01/deploymentTemplate.json#",
+  "contentVersion": "1.0.0.0",
+  "parameters": {
+    "sku": {
+      "type": "string",
+      "defaultValue": "Standard_LRS"
+    },
+    "tier": {
+      "type": "string",
+      "defaultValue": "Hot"
+    }
+  },
+  "variables": {
+    "prefix": "linked"
+  },
+  "resources": [
+    {
+      "type": "Microsoft.Storage/storageAccounts",
+      "apiVersion": "2022-05-01",
+      "name": "[variables('prefix')]",
+      "properties": {
+        "sku": "[parameters('sku')]",
+        "accessTier": "[parameters('tier')]"
+      }
+    }
+  ]
+}
+`
+
+	targetFS := memoryfs.New()
+	require.NoError(t, targetFS.WriteFile("main.json", []byte(main), 0644))
+	require.NoError(t, targetFS.WriteFile("linked.json", []byte(linked), 0644))
+
+	got, err := New(targetFS, options.ParserWithDebug(os.Stderr)).ParseFS(context.Background(), ".")
+	require.NoError(t, err)
+	require.Len(t, got, 1)
+	require.Len(t, got[0].Resources, 2)
+
+	storage := got[0].Resources[1]
+	assert.Equal(t, "linked.json", storage.Metadata.Range().GetFilename())
+	// parameters which are not passed take the defaults of the linked template, rather than the linking one
+	assert.Equal(t, "Standard_LRS", storage.Properties.GetMapValue("sku").AsString())
+	assert.Equal(t, "Cool", storage.Properties.GetMapValue("accessTier").AsString())
+
+	// expressions are resolved against the linked template's own deployment
+	r, ok := storage.Metadata.Internal().(resolver.Resolver)
+	require.True(t, ok)
+	assert.Equal(t, "linked", r.ResolveExpression(storage.Name).AsString())
+}
+
+func Test_LinkedTemplateLimits(t *testing.T) {
+
+	main := `
+{
+  "$schema": "https://schema.management.azure.com/schemas/2019-04-01/deploymentTemplate.json#",
+  "contentVersion": "1.0.0.0",
+  "resources": [
+    {
+      "type": "Microsoft.Resources/deployments",
+      "apiVersion": "2021-04-01",
+      "name": "link",
+      "properties": {
+        "mode": "Incremental",
+        "templateLink": {
+          "relativePath": "%s"
+        }
+      }
+    }
+  ]
+}
+`
+
+	linked := `
+{
+  "$schema": "https://schema.management.azure.com/schemas/2019-04-01/deploymentTemplate.json#",
+  "contentVersion": "1.0.0.0",
+  "resources": [
+    {
+      "type": "Microsoft.Storage/storageAccounts",
+      "apiVersion": "2022-05-01",
+      "name": "storage"
+    }
+  ]
+}
+`
+
+	targetFS := memoryfs.New()
+	require.NoError(t, targetFS.MkdirAll("templates/vendor", 0700))
+	require.NoError(t, targetFS.WriteFile("templates/filtered.json", []byte(fmt.Sprintf(main, "vendor/linked.json")), 0644))
+	require.NoError(t, targetFS.WriteFile("templates/large.json", []byte(fmt.Sprintf(main, "../linked.json")), 0644))
+	require.NoError(t, targetFS.WriteFile("templates/vendor/linked.json", []byte(linked), 0644))
+	require.NoError(t, targetFS.WriteFile("linked.json", []byte(linked+strings.Repeat(" ", 1024)), 0644))
+
+	summary := &limits.Summary{}
+	got, err := New(targetFS,
+		options.ParserWithPathFilter(options.PathFilter{Exclude: []string{"vendor"}}),
+		options.ParserWithFileLimits(limits.FileLimits{MaxFileSize: 1024}, summary),
+	).ParseFS(context.Background(), "templates")
+	require.NoError(t, err)
+
+	// linked templates which are excluded or too large are not resolved into the deployments which link them
+	require.Len(t, got, 2)
+	for _, deployment := range got {
+		assert.Len(t, deployment.Resources, 1)
+	}
+	assert.Equal(t, []limits.SkippedFile{
+		{Path: "linked.json", Reason: limits.ReasonFileTooLarge},
+	}, summary.Skipped())
+}
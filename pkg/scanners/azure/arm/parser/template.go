@@ -1,6 +1,8 @@
 package parser
 
 import (
+	"strings"
+
 	types2 "github.com/aquasecurity/defsec/pkg/scanners/azure"
 	"github.com/aquasecurity/defsec/pkg/scanners/azure/arm/parser/armjson"
 	"github.com/aquasecurity/defsec/pkg/types"
@@ -28,9 +30,14 @@ type Parameter struct {
 
 type Function struct{}
 
+// deploymentType is the type of resources which deploy another template, either nested within them or linked
+const deploymentType = "Microsoft.Resources/deployments"
+
 type Resource struct {
 	Metadata types.Metadata `json:"-"`
 	innerResource
+	// Template is the template nested within a deployment resource, if any
+	Template *Template `json:"-"`
 }
 
 func (t *Template) SetMetadata(m *types.Metadata) {
@@ -65,6 +72,16 @@ func (v *Resource) UnmarshalJSONWithMetadata(node armjson.Node) error {
 
 	v.Metadata = node.Metadata()
 
+	if strings.EqualFold(v.Type.AsString(), deploymentType) {
+		if template := objectField(objectField(node, "properties"), "template"); template != nil && template.Kind() == armjson.KindObject {
+			var nested Template
+			if err := template.Decode(&nested); err != nil {
+				return err
+			}
+			v.Template = &nested
+		}
+	}
+
 	for _, comment := range node.Comments() {
 		var str string
 		if err := comment.Decode(&str); err != nil {
@@ -76,3 +93,19 @@ func (v *Resource) UnmarshalJSONWithMetadata(node armjson.Node) error {
 
 	return nil
 }
+
+// objectField returns the value of a field of an object node, or nil if the node is not an object or has no such
+// field
+func objectField(node armjson.Node, name string) armjson.Node {
+	if node == nil || node.Kind() != armjson.KindObject {
+		return nil
+	}
+	content := node.Content()
+	for i := 0; i+1 < len(content); i += 2 {
+		var key string
+		if err := content[i].Decode(&key); err == nil && key == name {
+			return content[i+1]
+		}
+	}
+	return nil
+}
@@ -17,6 +17,7 @@ import (
 	"github.com/aquasecurity/defsec/pkg/debug"
 
 	"github.com/aquasecurity/defsec/pkg/scan"
+	"github.com/aquasecurity/defsec/pkg/severity"
 
 	"github.com/aquasecurity/defsec/pkg/framework"
 	"github.com/aquasecurity/defsec/pkg/scanners"
@@ -25,7 +26,17 @@ import (
 )
 
 var _ scanners.FSScanner = (*Scanner)(nil)
+var _ scanners.ReaderScanner = (*Scanner)(nil)
 var _ options.ConfigurableScanner = (*Scanner)(nil)
+var _ options.ResultProcessingScanner = (*Scanner)(nil)
+var _ options.StreamingScanner = (*Scanner)(nil)
+var _ options.SeverityFilteredScanner = (*Scanner)(nil)
+var _ options.RuleSelectingScanner = (*Scanner)(nil)
+var _ options.MetricsScanner = (*Scanner)(nil)
+var _ options.ProgressReportingScanner = (*Scanner)(nil)
+var _ options.PathFilteredScanner = (*Scanner)(nil)
+var _ options.SymlinkScanner = (*Scanner)(nil)
+var _ options.LoggingScanner = (*Scanner)(nil)
 
 type Scanner struct {
 	scannerOptions []options.ScannerOption
@@ -39,7 +50,13 @@ type Scanner struct {
 	policyReaders  []io.Reader
 	regoScanner    *rego.Scanner
 	spec           string
+	minSeverity    severity.Severity
+	ruleSelection  scan.RuleSelection
 	sync.Mutex
+	options.FileLimitHandler
+	options.SymlinkHandler
+	options.Instrumentation
+	options.ResultHandler
 }
 
 func (s *Scanner) SetSpec(spec string) {
@@ -50,6 +67,14 @@ func (s *Scanner) SetRegoOnly(regoOnly bool) {
 	s.regoOnly = regoOnly
 }
 
+func (s *Scanner) SetMinimumSeverity(threshold severity.Severity) {
+	s.minSeverity = threshold
+}
+
+func (s *Scanner) SetRuleSelection(selection scan.RuleSelection) {
+	s.ruleSelection = selection
+}
+
 func New(opts ...options.ScannerOption) *Scanner {
 	scanner := &Scanner{
 		scannerOptions: opts,
@@ -69,6 +94,11 @@ func (s *Scanner) SetDebugWriter(writer io.Writer) {
 	s.parserOptions = append(s.parserOptions, options.ParserWithDebug(writer))
 }
 
+func (s *Scanner) SetLogHandler(handler debug.Handler) {
+	s.debug = debug.NewWithHandler(handler, "azure", "arm")
+	s.parserOptions = append(s.parserOptions, options.ParserWithLogHandler(handler))
+}
+
 func (s *Scanner) SetPolicyDirs(dirs ...string) {
 	s.policyDirs = dirs
 }
@@ -115,30 +145,51 @@ func (s *Scanner) initRegoScanner(srcFS fs.FS) error {
 	return nil
 }
 
+// ScanReaders scans the given documents together, keyed by their paths, see scanners.ReaderScanner
+func (s *Scanner) ScanReaders(ctx context.Context, readers map[string]io.Reader) (scan.Results, error) {
+	return scanners.ScanReaders(ctx, s, readers)
+}
+
 func (s *Scanner) ScanFS(ctx context.Context, fs fs.FS, dir string) (scan.Results, error) {
-	p := parser.New(fs, s.parserOptions...)
+	fs = s.SymlinkFS(fs, s.debug, s.ScanSummary())
+	parserOptions := append([]options.ParserOption{
+		s.FileLimitParserOption(),
+		s.PathFilterParserOption(),
+	}, s.parserOptions...)
+	p := parser.New(fs, parserOptions...)
 	deployments, err := p.ParseFS(ctx, dir)
 	if err != nil {
 		return nil, err
 	}
+	s.RecordFiles(s.Name(), len(deployments))
 	if err := s.initRegoScanner(fs); err != nil {
 		return nil, err
 	}
 
-	return s.scanDeployments(ctx, deployments, fs)
+	results, err := s.scanDeployments(ctx, deployments, fs)
+	if err != nil {
+		return nil, err
+	}
+	s.RecordResults(s.Name(), results)
+	return results, nil
 }
 
 func (s *Scanner) scanDeployments(ctx context.Context, deployments []azure.Deployment, f fs.FS) (scan.Results, error) {
 
 	var results scan.Results
 
+	progress := s.TrackProgress(s.Name(), options.PhaseEvaluate)
+	progress.Discovered(len(deployments))
 	for _, deployment := range deployments {
 
+		path := deployment.Metadata.Range().GetFilename()
+		progress.Started(path)
 		result, err := s.scanDeployment(ctx, deployment, f)
 		if err != nil {
 			return nil, err
 		}
-		results = append(results, result...)
+		results = append(results, s.HandleResults(result)...)
+		progress.Completed(path)
 	}
 
 	return results, nil
@@ -146,9 +197,21 @@ func (s *Scanner) scanDeployments(ctx context.Context, deployments []azure.Deplo
 
 func (s *Scanner) scanDeployment(ctx context.Context, deployment azure.Deployment, fs fs.FS) (scan.Results, error) {
 	var results scan.Results
-	deploymentState := s.adaptDeployment(ctx, deployment)
+	registeredRules := rules.FilterBySelection(
+		rules.FilterByMinimumSeverity(rules.GetRegistered(s.frameworks...), s.minSeverity),
+		s.ruleSelection,
+	)
+	selection := state.NewSelection()
+	if !s.regoOnly {
+		selection = rules.ServiceSelection(registeredRules)
+	}
+	if !s.regoScanner.SelectServices(&selection) {
+		selection = state.Selection{}
+	}
+
+	deploymentState := s.adaptDeployment(ctx, deployment, selection)
 	if !s.regoOnly {
-		for _, rule := range rules.GetRegistered(s.frameworks...) {
+		for _, rule := range registeredRules {
 			select {
 			case <-ctx.Done():
 				return nil, ctx.Err()
@@ -177,6 +240,6 @@ func (s *Scanner) scanDeployment(ctx context.Context, deployment azure.Deploymen
 	return append(results, regoResults...), nil
 }
 
-func (s *Scanner) adaptDeployment(ctx context.Context, deployment azure.Deployment) *state.State {
-	return arm.Adapt(ctx, deployment)
+func (s *Scanner) adaptDeployment(ctx context.Context, deployment azure.Deployment, selection state.Selection) *state.State {
+	return arm.AdaptServices(ctx, deployment, selection)
 }
@@ -77,6 +77,32 @@ func (r *Resource) GetResourcesByType(t string) []Resource {
 	return resources
 }
 
+// SubstituteParameters returns a copy of the resource and its child resources with references to the given
+// parameters replaced by their values, see Value.SubstituteParameters
+func (r Resource) SubstituteParameters(parameters map[string]Value) Resource {
+	if len(parameters) == 0 {
+		return r
+	}
+	substituted := r
+	for _, value := range []*Value{
+		&substituted.APIVersion,
+		&substituted.Type,
+		&substituted.Kind,
+		&substituted.Name,
+		&substituted.Location,
+		&substituted.Tags,
+		&substituted.Sku,
+		&substituted.Properties,
+	} {
+		*value = value.SubstituteParameters(parameters)
+	}
+	substituted.Resources = nil
+	for _, child := range r.Resources {
+		substituted.Resources = append(substituted.Resources, child.SubstituteParameters(parameters))
+	}
+	return substituted
+}
+
 func (d *Deployment) GetParameter(parameterName string) interface{} {
 
 	for _, parameter := range d.Parameters {
@@ -1,6 +1,7 @@
 package azure
 
 import (
+	"regexp"
 	"strings"
 	"time"
 
@@ -314,6 +315,41 @@ func (v Value) Raw() interface{} {
 	}
 }
 
+// parameterReference matches an expression which is only a reference to a parameter, capturing its name
+var parameterReference = regexp.MustCompile(`^\s*parameters\(\s*'([^']+)'\s*\)\s*$`)
+
+// SubstituteParameters returns a copy of the value in which each expression which is only a reference to a
+// parameter, i.e. [parameters('name')], is replaced by the given value of that parameter, positioned where it is
+// referenced. References to parameters which are not given are left as they are.
+func (v Value) SubstituteParameters(parameters map[string]Value) Value {
+	if len(parameters) == 0 {
+		return v
+	}
+	switch v.Kind {
+	case KindExpression:
+		expression, _ := v.rLit.(string)
+		if match := parameterReference.FindStringSubmatch(expression); match != nil {
+			if value, ok := parameters[match[1]]; ok {
+				value.Metadata = v.Metadata
+				return value
+			}
+		}
+	case KindObject:
+		substituted := make(map[string]Value, len(v.rMap))
+		for key, val := range v.rMap {
+			substituted[key] = val.SubstituteParameters(parameters)
+		}
+		v.rMap = substituted
+	case KindArray:
+		substituted := make([]Value, len(v.rArr))
+		for i, val := range v.rArr {
+			substituted[i] = val.SubstituteParameters(parameters)
+		}
+		v.rArr = substituted
+	}
+	return v
+}
+
 func (v *Value) Resolve() {
 	if v.Kind != KindExpression {
 		return
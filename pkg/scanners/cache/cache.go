@@ -0,0 +1,303 @@
+package cache
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/aquasecurity/defsec/pkg/concurrency"
+	"github.com/aquasecurity/defsec/pkg/rego"
+	"github.com/aquasecurity/defsec/pkg/scan"
+	"github.com/aquasecurity/defsec/pkg/scanners/options"
+)
+
+const currentVersion = 1
+
+// Cache records the content hash of each scanned file alongside the results found in it. When a file is
+// scanned again with the same content and the same policies, the cached results are replayed instead of
+// evaluating the policies again.
+type Cache struct {
+	path    string
+	mu      sync.Mutex
+	scanner map[string]map[string]entry
+	dirty   bool
+}
+
+type index struct {
+	Version  int                         `json:"version"`
+	Scanners map[string]map[string]entry `json:"scanners"`
+}
+
+type entry struct {
+	Hash         string                `json:"hash"`
+	PolicyDigest string                `json:"policy_digest"`
+	Results      []scan.ResultSnapshot `json:"results"`
+}
+
+// CachingScanner is implemented by scanners which can replay results for unchanged files from a Cache
+type CachingScanner interface {
+	options.ConfigurableScanner
+	SetScanCache(c *Cache)
+}
+
+// ScannerWithCache enables incremental scanning using the given cache. Call Flush on the cache once scanning
+// is complete to persist it for subsequent scans.
+func ScannerWithCache(c *Cache) options.ScannerOption {
+	return func(s options.ConfigurableScanner) {
+		if cs, ok := s.(CachingScanner); ok {
+			cs.SetScanCache(c)
+		}
+	}
+}
+
+// New creates an empty cache which will be written to the given path when flushed.
+func New(path string) *Cache {
+	return &Cache{
+		path:    path,
+		scanner: make(map[string]map[string]entry),
+	}
+}
+
+// Open loads the cache stored at the given path. A missing file, or one written by an incompatible version,
+// results in an empty cache rather than an error.
+func Open(path string) (*Cache, error) {
+	c := New(path)
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return c, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var idx index
+	if err := json.Unmarshal(data, &idx); err != nil {
+		return nil, fmt.Errorf("failed to parse scan cache: %w", err)
+	}
+	if idx.Version != currentVersion {
+		return c, nil
+	}
+	for name, entries := range idx.Scanners {
+		c.scanner[name] = entries
+	}
+	return c, nil
+}
+
+// Flush writes the cache to disk if it has changed since it was opened.
+func (c *Cache) Flush() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if !c.dirty {
+		return nil
+	}
+	data, err := json.Marshal(index{
+		Version:  currentVersion,
+		Scanners: c.scanner,
+	})
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(c.path), 0o700); err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(c.path), filepath.Base(c.path)+".*")
+	if err != nil {
+		return err
+	}
+	defer func() { _ = os.Remove(tmp.Name()) }()
+	if _, err := tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := os.Rename(tmp.Name(), c.path); err != nil {
+		return err
+	}
+	c.dirty = false
+	return nil
+}
+
+// ScanInputs scans the given inputs with the rego scanner, replaying cached results for any file whose content
+// and policies are unchanged since it was last scanned. Results for the remaining files are added to the cache.
+// It is safe to call on a nil cache, in which case every input is scanned. Every input is also scanned when the
+// rego scanner is tracing, as replayed results would have no trace.
+func (c *Cache) ScanInputs(ctx context.Context, scanner string, regoScanner *rego.Scanner, fsys fs.FS, inputs []rego.Input) (scan.Results, error) {
+	// policies which evaluate all inputs at once can produce different results for a file when another changes
+	if c == nil || fsys == nil || len(inputs) == 0 || regoScanner.HasCombinedPolicies() || regoScanner.TracingEnabled() {
+		return regoScanner.ScanInput(ctx, inputs...)
+	}
+	policyDigest := regoScanner.PolicyDigest()
+
+	// a single file may produce several inputs (e.g. multi-document yaml), so the cache is checked per file
+	hashes := make(map[string]string)
+	replayed := make(map[string]bool)
+	var results scan.Results
+	var pending []rego.Input
+	for _, input := range inputs {
+		if replayed[input.Path] {
+			continue
+		}
+		hash, ok := hashes[input.Path]
+		if !ok {
+			hash = hashFile(fsys, input.Path)
+			hashes[input.Path] = hash
+			if cached, ok := c.lookup(scanner, input.Path, hash, policyDigest); ok {
+				replayed[input.Path] = true
+				results = append(results, cached...)
+				continue
+			}
+		}
+		pending = append(pending, input)
+	}
+
+	if len(pending) == 0 {
+		return results, nil
+	}
+
+	scanned, err := regoScanner.ScanInput(ctx, pending...)
+	if err != nil {
+		return nil, err
+	}
+	c.store(scanner, policyDigest, pending, hashes, scanned)
+	return append(results, scanned...), nil
+}
+
+// ScanFiles scans the inputs of each file separately, passing the results of each file to handle as soon as
+// they are available, so they can be streamed to callers rather than returned once every file has been scanned.
+// Files are scanned concurrently by as many workers as the rego scanner uses, but handle is only called for one
+// file at a time. The handled results of every file are returned, in the order the files' inputs were given. If
+// any loaded policy evaluates all inputs at once, every input is scanned together and handle is called once. Each
+// input is reported to progress as its file is scanned, and progress may be nil. It is safe to call on a nil cache,
+// in which case every input is scanned.
+func (c *Cache) ScanFiles(ctx context.Context, scanner string, regoScanner *rego.Scanner, fsys fs.FS, inputs []rego.Input, progress *options.ProgressTracker, handle func(path string, results scan.Results) scan.Results) (scan.Results, error) {
+	progress.Discovered(len(inputs))
+	if regoScanner.HasCombinedPolicies() {
+		results, err := c.ScanInputs(ctx, scanner, regoScanner, fsys, inputs)
+		if err != nil {
+			return nil, err
+		}
+		progress.Finished()
+		return handle("", results), nil
+	}
+
+	// a single file may produce several inputs (e.g. multi-document yaml), which are scanned together
+	var paths []string
+	byFile := make(map[string][]rego.Input)
+	for _, input := range inputs {
+		if _, ok := byFile[input.Path]; !ok {
+			paths = append(paths, input.Path)
+		}
+		byFile[input.Path] = append(byFile[input.Path], input)
+	}
+
+	var lock sync.Mutex
+	perFile, err := concurrency.Process(ctx, paths, regoScanner.Workers(), func(ctx context.Context, path string) (scan.Results, error) {
+		progress.Started(path)
+		results, err := c.ScanInputs(ctx, scanner, regoScanner, fsys, byFile[path])
+		if err != nil {
+			return nil, err
+		}
+		lock.Lock()
+		defer lock.Unlock()
+		results = handle(path, results)
+		for range byFile[path] {
+			progress.Completed(path)
+		}
+		return results, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var results scan.Results
+	for _, fileResults := range perFile {
+		results = append(results, fileResults...)
+	}
+	return results, nil
+}
+
+// lookup returns the cached results for a file, or false if the file must be scanned
+func (c *Cache) lookup(scanner, path, hash, policyDigest string) (scan.Results, bool) {
+	if hash == "" {
+		return nil, false
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	existing, ok := c.scanner[scanner][path]
+	if !ok || existing.Hash != hash || existing.PolicyDigest != policyDigest {
+		return nil, false
+	}
+	results := make(scan.Results, 0, len(existing.Results))
+	for _, snapshot := range existing.Results {
+		results = append(results, snapshot.Restore())
+	}
+	return results, true
+}
+
+// store records the results of scanning the given inputs. Results are attributed to files by their location,
+// so if any result cannot be attributed to one of the scanned files (e.g. a policy which reports against
+// another file) nothing is stored, and the files will be scanned again next time.
+func (c *Cache) store(scanner string, policyDigest string, inputs []rego.Input, hashes map[string]string, results scan.Results) {
+	byFile := make(map[string][]scan.ResultSnapshot)
+	for _, input := range inputs {
+		if hashes[input.Path] != "" {
+			byFile[input.Path] = []scan.ResultSnapshot{}
+		}
+	}
+	for _, result := range results {
+		filename := result.Range().GetLocalFilename()
+		snapshots, ok := byFile[filename]
+		if !ok {
+			return
+		}
+		byFile[filename] = append(snapshots, result.Snapshot())
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if _, ok := c.scanner[scanner]; !ok {
+		c.scanner[scanner] = make(map[string]entry)
+	}
+	for path, snapshots := range byFile {
+		c.scanner[scanner][path] = entry{
+			Hash:         hashes[path],
+			PolicyDigest: policyDigest,
+			Results:      snapshots,
+		}
+	}
+	c.dirty = true
+}
+
+// ParsingKey returns the name to cache a scanner's results under, given the options which change how its files
+// are parsed. Results are only replayed for files parsed the same way, as the options change the input the
+// policies are evaluated against. Preprocessed content is already hashed, so only the names custom parsers parse
+// files as are included.
+func ParsingKey(scanner string, lenient bool, parsers options.CustomParsers) string {
+	if !lenient && len(parsers) == 0 {
+		return scanner
+	}
+	hash := sha256.New()
+	_, _ = fmt.Fprintf(hash, "lenient=%t", lenient)
+	for _, parser := range parsers {
+		_, _ = fmt.Fprintf(hash, "\x00%s=%s", strings.ToLower(parser.Extension), parser.As)
+	}
+	return scanner + "@" + hex.EncodeToString(hash.Sum(nil))[:16]
+}
+
+func hashFile(fsys fs.FS, path string) string {
+	data, err := fs.ReadFile(fsys, strings.TrimPrefix(filepath.ToSlash(path), "/"))
+	if err != nil {
+		return ""
+	}
+	hash := sha256.Sum256(data)
+	return hex.EncodeToString(hash[:])
+}
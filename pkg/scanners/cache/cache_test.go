@@ -0,0 +1,201 @@
+package cache
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/aquasecurity/defsec/pkg/rego"
+	"github.com/aquasecurity/defsec/pkg/scan"
+	"github.com/aquasecurity/defsec/pkg/scanners/options"
+	"github.com/aquasecurity/defsec/pkg/types"
+	"github.com/aquasecurity/defsec/test/testutil"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testPolicy = `
+package defsec.test
+
+__rego_input__ := {
+	"combine": false,
+	"selector": [{"type": "json"}],
+}
+
+deny {
+    input.evil
+}
+`
+
+func scanWithCache(t *testing.T, c *Cache, files map[string]string, evil map[string]bool, configure ...func(*rego.Scanner)) (int, int) {
+	files["policies/test.rego"] = testPolicy
+	srcFS := testutil.CreateFS(t, files)
+
+	regoScanner := rego.NewScanner(types.SourceJSON)
+	for _, fn := range configure {
+		fn(regoScanner)
+	}
+	require.NoError(t, regoScanner.LoadPolicies(false, srcFS, []string{"policies"}, nil))
+
+	var inputs []rego.Input
+	for _, path := range []string{"a.json", "b.json"} {
+		inputs = append(inputs, rego.Input{
+			Path: path,
+			Contents: map[string]interface{}{
+				"evil": evil[path],
+			},
+			FS: srcFS,
+		})
+	}
+
+	results, err := c.ScanInputs(context.TODO(), "test", regoScanner, srcFS, inputs)
+	require.NoError(t, err)
+	return len(results.GetFailed()), len(results.GetPassed())
+}
+
+func Test_CacheReplaysResultsForUnchangedFiles(t *testing.T) {
+
+	path := filepath.Join(t.TempDir(), "cache.json")
+
+	c, err := Open(path)
+	require.NoError(t, err)
+
+	failed, passed := scanWithCache(t, c, map[string]string{
+		"a.json": `{"evil": true}`,
+		"b.json": `{"evil": false}`,
+	}, map[string]bool{"a.json": true})
+	assert.Equal(t, 1, failed)
+	assert.Equal(t, 1, passed)
+	require.NoError(t, c.Flush())
+
+	c, err = Open(path)
+	require.NoError(t, err)
+
+	// the parsed content no longer fails, but neither file has changed on disk, so cached results are replayed
+	failed, passed = scanWithCache(t, c, map[string]string{
+		"a.json": `{"evil": true}`,
+		"b.json": `{"evil": false}`,
+	}, map[string]bool{})
+	assert.Equal(t, 1, failed)
+	assert.Equal(t, 1, passed)
+
+	// a.json has changed, so it is scanned again
+	failed, passed = scanWithCache(t, c, map[string]string{
+		"a.json": `{"evil": false}`,
+		"b.json": `{"evil": false}`,
+	}, map[string]bool{})
+	assert.Equal(t, 0, failed)
+	assert.Equal(t, 2, passed)
+}
+
+func Test_CacheIsBypassedWhenTracing(t *testing.T) {
+
+	c, err := Open(filepath.Join(t.TempDir(), "cache.json"))
+	require.NoError(t, err)
+
+	files := map[string]string{
+		"a.json": `{"evil": true}`,
+		"b.json": `{"evil": false}`,
+	}
+	failed, passed := scanWithCache(t, c, files, map[string]bool{"a.json": true})
+	assert.Equal(t, 1, failed)
+	assert.Equal(t, 1, passed)
+
+	// cached results carry no trace, so both files are evaluated again
+	failed, passed = scanWithCache(t, c, files, map[string]bool{}, func(s *rego.Scanner) {
+		s.SetPerResultTracingEnabled(true)
+	})
+	assert.Equal(t, 0, failed)
+	assert.Equal(t, 2, passed)
+}
+
+func Test_NilCacheScansAllInputs(t *testing.T) {
+	var c *Cache
+	failed, passed := scanWithCache(t, c, map[string]string{
+		"a.json": `{"evil": true}`,
+		"b.json": `{"evil": false}`,
+	}, map[string]bool{"a.json": true})
+	assert.Equal(t, 1, failed)
+	assert.Equal(t, 1, passed)
+}
+
+func Test_ScanFilesHandlesEachFile(t *testing.T) {
+	srcFS := testutil.CreateFS(t, map[string]string{
+		"policies/test.rego": testPolicy,
+	})
+
+	regoScanner := rego.NewScanner(types.SourceJSON, options.ScannerWithParallelism(2))
+	require.NoError(t, regoScanner.LoadPolicies(false, srcFS, []string{"policies"}, nil))
+
+	var inputs []rego.Input
+	for _, path := range []string{"a.json", "b.json", "a.json"} {
+		inputs = append(inputs, rego.Input{
+			Path: path,
+			Contents: map[string]interface{}{
+				"evil": path == "a.json",
+			},
+		})
+	}
+
+	handled := make(map[string]int)
+	var c *Cache
+	results, err := c.ScanFiles(context.TODO(), "test", regoScanner, srcFS, inputs, nil, func(path string, results scan.Results) scan.Results {
+		handled[path] += len(results)
+		return results
+	})
+	require.NoError(t, err)
+
+	// both inputs of a.json are handled together, before the scan has completed
+	assert.Equal(t, map[string]int{"a.json": 2, "b.json": 1}, handled)
+	assert.Len(t, results.GetFailed(), 2)
+	assert.Len(t, results.GetPassed(), 1)
+}
+
+func Test_ScanFilesReportsProgress(t *testing.T) {
+	srcFS := testutil.CreateFS(t, map[string]string{
+		"policies/test.rego": testPolicy,
+	})
+
+	regoScanner := rego.NewScanner(types.SourceJSON)
+	require.NoError(t, regoScanner.LoadPolicies(false, srcFS, []string{"policies"}, nil))
+
+	var inputs []rego.Input
+	for _, path := range []string{"a.json", "b.json", "a.json"} {
+		inputs = append(inputs, rego.Input{
+			Path:     path,
+			Contents: map[string]interface{}{},
+		})
+	}
+
+	var progress []options.Progress
+	instrumentation := &options.Instrumentation{}
+	instrumentation.AddProgressCallbacks(func(p options.Progress) {
+		progress = append(progress, p)
+	})
+
+	var c *Cache
+	_, err := c.ScanFiles(context.TODO(), "test", regoScanner, srcFS, inputs, instrumentation.TrackProgress("test", options.PhaseEvaluate), func(_ string, results scan.Results) scan.Results {
+		return results
+	})
+	require.NoError(t, err)
+
+	// every input is discovered up front, then each file is started and its inputs completed once it is scanned
+	require.Len(t, progress, 6)
+	assert.Equal(t, options.Progress{Scanner: "test", Phase: options.PhaseEvaluate, Total: 3}, progress[0])
+	assert.Equal(t, options.Progress{Scanner: "test", Phase: options.PhaseEvaluate, Total: 3, Current: "a.json"}, progress[1])
+	assert.Equal(t, 2, progress[3].Completed)
+	assert.Equal(t, options.Progress{Scanner: "test", Phase: options.PhaseEvaluate, Total: 3, Completed: 3, Current: "b.json"}, progress[5])
+}
+
+func Test_ParsingKey(t *testing.T) {
+	assert.Equal(t, "yaml", ParsingKey("yaml", false, nil))
+
+	lenient := ParsingKey("yaml", true, nil)
+	custom := ParsingKey("yaml", false, options.CustomParsers{{Extension: ".yml.tpl", As: ".yaml"}})
+	assert.NotEqual(t, "yaml", lenient)
+	assert.NotEqual(t, "yaml", custom)
+	assert.NotEqual(t, lenient, custom)
+	assert.Equal(t, custom, ParsingKey("yaml", false, options.CustomParsers{{Extension: ".YML.TPL", As: ".yaml"}}))
+	assert.NotEqual(t, custom, ParsingKey("yaml", false, options.CustomParsers{{Extension: ".yml.tpl", As: ".json"}}))
+}
@@ -0,0 +1,159 @@
+package charset
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"io"
+	"unicode/utf16"
+	"unicode/utf8"
+)
+
+// Encoding is the character encoding of a text file
+type Encoding int
+
+const (
+	UTF8 Encoding = iota
+	UTF16LE
+	UTF16BE
+)
+
+// readSize is the number of bytes of UTF-16 decoded at a time
+const readSize = 4096
+
+var (
+	bomUTF8    = []byte{0xef, 0xbb, 0xbf}
+	bomUTF16LE = []byte{0xff, 0xfe}
+	bomUTF16BE = []byte{0xfe, 0xff}
+)
+
+// Detect returns the encoding of text beginning with prefix, which should be at least its first four bytes, and
+// the length of its byte order mark. Text without a byte order mark is taken to be UTF-16 if it begins with ASCII
+// characters encoded as UTF-16 (as a configuration file would), and UTF-8 otherwise.
+func Detect(prefix []byte) (Encoding, int) {
+	switch {
+	case bytes.HasPrefix(prefix, bomUTF8):
+		return UTF8, len(bomUTF8)
+	case bytes.HasPrefix(prefix, bomUTF16LE):
+		return UTF16LE, len(bomUTF16LE)
+	case bytes.HasPrefix(prefix, bomUTF16BE):
+		return UTF16BE, len(bomUTF16BE)
+	case isASCIIUnits(prefix, 0):
+		return UTF16LE, 0
+	case isASCIIUnits(prefix, 1):
+		return UTF16BE, 0
+	}
+	return UTF8, 0
+}
+
+// isASCIIUnits reports whether prefix begins with up to two ASCII characters encoded as UTF-16, with the
+// significant byte of each at offset within it
+func isASCIIUnits(prefix []byte, offset int) bool {
+	if len(prefix) < 2 {
+		return false
+	}
+	for i := 0; i+1 < len(prefix) && i < 4; i += 2 {
+		char, zero := prefix[i+offset], prefix[i+1-offset]
+		if char == 0 || char >= utf8.RuneSelf || zero != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// IsEncoded reports whether text beginning with prefix has a byte order mark or is UTF-16, and so needs to be
+// decoded before it is parsed
+func IsEncoded(prefix []byte) bool {
+	encoding, bom := Detect(prefix)
+	return encoding != UTF8 || bom > 0
+}
+
+// Decode returns text as UTF-8 without a byte order mark, as expected by parsers. Text which is already UTF-8
+// without a byte order mark is returned unchanged.
+func Decode(data []byte) []byte {
+	encoding, bom := Detect(data)
+	if encoding == UTF8 {
+		return data[bom:]
+	}
+	decoded, _ := io.ReadAll(newUTF16Reader(bytes.NewReader(data[bom:]), encoding))
+	return decoded
+}
+
+// NewReader returns a reader of the text read from r as UTF-8 without a byte order mark, as expected by parsers.
+// UTF-16 is decoded as it is read.
+func NewReader(r io.Reader) io.Reader {
+	buffered := bufio.NewReader(r)
+	prefix, _ := buffered.Peek(4)
+	encoding, bom := Detect(prefix)
+	_, _ = buffered.Discard(bom)
+	if encoding == UTF8 {
+		return buffered
+	}
+	return newUTF16Reader(buffered, encoding)
+}
+
+type utf16Reader struct {
+	r     io.Reader
+	order binary.ByteOrder
+	// carry is the part of a character read, but not yet decoded
+	carry []byte
+	// pending is decoded text, not yet returned
+	pending []byte
+	err     error
+}
+
+func newUTF16Reader(r io.Reader, encoding Encoding) *utf16Reader {
+	var order binary.ByteOrder = binary.LittleEndian
+	if encoding == UTF16BE {
+		order = binary.BigEndian
+	}
+	return &utf16Reader{
+		r:     r,
+		order: order,
+	}
+}
+
+func (u *utf16Reader) Read(p []byte) (int, error) {
+	for len(u.pending) == 0 {
+		if u.err != nil {
+			return 0, u.err
+		}
+		u.fill()
+	}
+	n := copy(p, u.pending)
+	u.pending = u.pending[n:]
+	return n, nil
+}
+
+// fill decodes the next chunk of input into pending. A trailing odd byte or high surrogate is carried over to be
+// decoded with the next chunk, or decoded as the replacement character at the end of the input.
+func (u *utf16Reader) fill() {
+	chunk := make([]byte, len(u.carry)+readSize)
+	copy(chunk, u.carry)
+	n, err := u.r.Read(chunk[len(u.carry):])
+	data := chunk[:len(u.carry)+n]
+	u.carry = nil
+	u.err = err
+
+	units := make([]uint16, len(data)/2)
+	for i := range units {
+		units[i] = u.order.Uint16(data[i*2:])
+	}
+	if err == nil {
+		if len(units) > 0 && isHighSurrogate(units[len(units)-1]) {
+			units = units[:len(units)-1]
+		}
+		u.carry = append(u.carry, data[len(units)*2:]...)
+	}
+
+	for _, r := range utf16.Decode(units) {
+		u.pending = utf8.AppendRune(u.pending, r)
+	}
+	if err != nil && len(data)%2 == 1 {
+		u.pending = utf8.AppendRune(u.pending, utf8.RuneError)
+	}
+}
+
+func isHighSurrogate(unit uint16) bool {
+	return unit >= 0xd800 && unit < 0xdc00
+}
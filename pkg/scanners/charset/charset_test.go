@@ -0,0 +1,84 @@
+package charset
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io"
+	"testing"
+	"testing/iotest"
+	"unicode/utf16"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const text = "AWSTemplateFormatVersion: 2010-09-09\nDescription: \"héllo wörld 🚀\"\n"
+
+func encodeUTF16(s string, order binary.ByteOrder, bom bool) []byte {
+	var buf bytes.Buffer
+	if bom {
+		_ = binary.Write(&buf, order, uint16(0xfeff))
+	}
+	for _, unit := range utf16.Encode([]rune(s)) {
+		_ = binary.Write(&buf, order, unit)
+	}
+	return buf.Bytes()
+}
+
+func Test_Decode(t *testing.T) {
+	tests := map[string]struct {
+		input    []byte
+		encoding Encoding
+	}{
+		"utf-8": {
+			input:    []byte(text),
+			encoding: UTF8,
+		},
+		"utf-8 with bom": {
+			input:    append([]byte{0xef, 0xbb, 0xbf}, text...),
+			encoding: UTF8,
+		},
+		"utf-16le with bom": {
+			input:    encodeUTF16(text, binary.LittleEndian, true),
+			encoding: UTF16LE,
+		},
+		"utf-16be with bom": {
+			input:    encodeUTF16(text, binary.BigEndian, true),
+			encoding: UTF16BE,
+		},
+		"utf-16le without bom": {
+			input:    encodeUTF16(text, binary.LittleEndian, false),
+			encoding: UTF16LE,
+		},
+		"utf-16be without bom": {
+			input:    encodeUTF16(text, binary.BigEndian, false),
+			encoding: UTF16BE,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			encoding, _ := Detect(test.input)
+			assert.Equal(t, test.encoding, encoding)
+			assert.Equal(t, text, string(Decode(test.input)))
+
+			// read a byte at a time, so characters are split between reads
+			decoded, err := io.ReadAll(NewReader(iotest.OneByteReader(bytes.NewReader(test.input))))
+			require.NoError(t, err)
+			assert.Equal(t, text, string(decoded))
+		})
+	}
+}
+
+func Test_DecodeLeavesUTF8Unchanged(t *testing.T) {
+	input := []byte(text)
+	assert.False(t, IsEncoded(input))
+	assert.Equal(t, &input[0], &Decode(input)[0])
+	assert.Empty(t, Decode(nil))
+}
+
+func Test_DecodeInvalidUTF16(t *testing.T) {
+	// an unpaired high surrogate, followed by an odd trailing byte
+	input := append(encodeUTF16("a", binary.LittleEndian, true), 0x3d, 0xd8, 0x62, 0x00, 0x63)
+	assert.Equal(t, "a�b�", string(Decode(input)))
+}
@@ -12,7 +12,15 @@ type ConfigurableAWSScanner interface {
 	SetAWSRegion(region string)
 	SetAWSEndpoint(endpoint string)
 	SetAWSServices(services []string)
+	SetAWSRegions(regions []string)
+	SetAWSSkipServices(services []string)
+	SetAWSSkipExpensive(skip bool)
+	SetAWSOrganization(enabled bool)
+	SetAWSOrganizationRole(role string)
+	SetAWSAccounts(accounts []string)
 	SetConcurrencyStrategy(strategy concurrency.Strategy)
+	SetRateLimit(rate float64)
+	SetMaxRetries(retries int)
 }
 
 func ScannerWithProgressTracker(t progress.Tracker) options.ScannerOption {
@@ -47,6 +55,64 @@ func ScannerWithAWSServices(services ...string) options.ScannerOption {
 	}
 }
 
+// ScannerWithAWSOrganization scans every account of the caller's organization rather than only the caller's account.
+// The caller must be in the management account, or be a delegated administrator of the organization.
+func ScannerWithAWSOrganization(enabled bool) options.ScannerOption {
+	return func(s options.ConfigurableScanner) {
+		if aws, ok := s.(ConfigurableAWSScanner); ok {
+			aws.SetAWSOrganization(enabled)
+		}
+	}
+}
+
+// ScannerWithAWSOrganizationRole sets the name of the role assumed in each member account of the organization. It
+// defaults to OrganizationAccountAccessRole.
+func ScannerWithAWSOrganizationRole(role string) options.ScannerOption {
+	return func(s options.ConfigurableScanner) {
+		if aws, ok := s.(ConfigurableAWSScanner); ok {
+			aws.SetAWSOrganizationRole(role)
+		}
+	}
+}
+
+// ScannerWithAWSAccounts restricts an organization scan to the accounts with the given IDs
+func ScannerWithAWSAccounts(accounts ...string) options.ScannerOption {
+	return func(s options.ConfigurableScanner) {
+		if aws, ok := s.(ConfigurableAWSScanner); ok {
+			aws.SetAWSAccounts(accounts)
+		}
+	}
+}
+
+// ScannerWithAWSRegions scans each of the given regions. Services which are not regional, such as iam, are scanned
+// once.
+func ScannerWithAWSRegions(regions ...string) options.ScannerOption {
+	return func(s options.ConfigurableScanner) {
+		if aws, ok := s.(ConfigurableAWSScanner); ok {
+			aws.SetAWSRegions(regions)
+		}
+	}
+}
+
+// ScannerWithAWSSkipServices excludes the given services from the scan
+func ScannerWithAWSSkipServices(services ...string) options.ScannerOption {
+	return func(s options.ConfigurableScanner) {
+		if aws, ok := s.(ConfigurableAWSScanner); ok {
+			aws.SetAWSSkipServices(services)
+		}
+	}
+}
+
+// ScannerWithAWSSkipExpensive excludes services which make requests for every resource they find, unless they are
+// explicitly selected
+func ScannerWithAWSSkipExpensive(skip bool) options.ScannerOption {
+	return func(s options.ConfigurableScanner) {
+		if aws, ok := s.(ConfigurableAWSScanner); ok {
+			aws.SetAWSSkipExpensive(skip)
+		}
+	}
+}
+
 func ScannerWithConcurrencyStrategy(strategy concurrency.Strategy) options.ScannerOption {
 	return func(s options.ConfigurableScanner) {
 		if aws, ok := s.(ConfigurableAWSScanner); ok {
@@ -54,3 +120,21 @@ func ScannerWithConcurrencyStrategy(strategy concurrency.Strategy) options.Scann
 		}
 	}
 }
+
+// ScannerWithRateLimit limits the requests made to each cloud service to the given number per second
+func ScannerWithRateLimit(rate float64) options.ScannerOption {
+	return func(s options.ConfigurableScanner) {
+		if aws, ok := s.(ConfigurableAWSScanner); ok {
+			aws.SetRateLimit(rate)
+		}
+	}
+}
+
+// ScannerWithMaxRetries sets how many times a throttled request is retried, with exponential backoff, before it fails
+func ScannerWithMaxRetries(retries int) options.ScannerOption {
+	return func(s options.ConfigurableScanner) {
+		if aws, ok := s.(ConfigurableAWSScanner); ok {
+			aws.SetMaxRetries(retries)
+		}
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"io/fs"
 	"os"
 	"runtime"
+	"sort"
 	"sync"
 
 	adapter "github.com/aquasecurity/defsec/internal/adapters/cloud"
@@ -27,10 +28,20 @@ import (
 	"github.com/aquasecurity/defsec/pkg/progress"
 	_ "github.com/aquasecurity/defsec/pkg/rules"
 	"github.com/aquasecurity/defsec/pkg/scan"
+	"github.com/aquasecurity/defsec/pkg/scanners/cloud/cache"
+	"github.com/aquasecurity/defsec/pkg/scanners/cloud/coverage"
 	"github.com/aquasecurity/defsec/pkg/scanners/options"
+	"github.com/aquasecurity/defsec/pkg/severity"
 )
 
 var _ ConfigurableAWSScanner = (*Scanner)(nil)
+var _ options.ResultProcessingScanner = (*Scanner)(nil)
+var _ options.StreamingScanner = (*Scanner)(nil)
+var _ options.SeverityFilteredScanner = (*Scanner)(nil)
+var _ options.RuleSelectingScanner = (*Scanner)(nil)
+var _ options.MetricsScanner = (*Scanner)(nil)
+var _ options.LoggingScanner = (*Scanner)(nil)
+var _ cache.CachingScanner = (*Scanner)(nil)
 
 type Scanner struct {
 	sync.Mutex
@@ -41,14 +52,28 @@ type Scanner struct {
 	region              string
 	endpoint            string
 	services            []string
+	organization        bool
+	organizationRole    string
+	accounts            []string
 	frameworks          []framework.Framework
 	spec                string
 	concurrencyStrategy concurrency.Strategy
+	stateCache          *cache.Cache
+	regions             []string
+	skipServices        []string
+	skipExpensive       bool
+	coverage            *coverage.Report
+	rateLimit           float64
+	maxRetries          int
 	policyDirs          []string
 	policyReaders       []io.Reader
 	policyFS            fs.FS
 	useEmbedded         bool
 	regoOnly            bool
+	minSeverity         severity.Severity
+	ruleSelection       scan.RuleSelection
+	options.Instrumentation
+	options.ResultHandler
 }
 
 func (s *Scanner) SetRegoOnly(value bool) {
@@ -63,6 +88,14 @@ func (s *Scanner) SetSpec(spec string) {
 	s.spec = spec
 }
 
+func (s *Scanner) SetMinimumSeverity(threshold severity.Severity) {
+	s.minSeverity = threshold
+}
+
+func (s *Scanner) SetRuleSelection(selection scan.RuleSelection) {
+	s.ruleSelection = selection
+}
+
 func (s *Scanner) Name() string {
 	return "AWS API"
 }
@@ -71,6 +104,10 @@ func (s *Scanner) SetDebugWriter(writer io.Writer) {
 	s.debug = debug.New(writer, "aws-api", "scanner")
 }
 
+func (s *Scanner) SetLogHandler(handler debug.Handler) {
+	s.debug = debug.NewWithHandler(handler, "aws-api", "scanner")
+}
+
 func (s *Scanner) SetProgressTracker(t progress.Tracker) {
 	s.progressTracker = t
 }
@@ -117,10 +154,46 @@ func (s *Scanner) SetAWSServices(services []string) {
 	s.services = services
 }
 
+func (s *Scanner) SetAWSOrganization(enabled bool) {
+	s.organization = enabled
+}
+
+func (s *Scanner) SetAWSOrganizationRole(role string) {
+	s.organizationRole = role
+}
+
+func (s *Scanner) SetAWSAccounts(accounts []string) {
+	s.accounts = accounts
+}
+
+func (s *Scanner) SetAWSRegions(regions []string) {
+	s.regions = regions
+}
+
+func (s *Scanner) SetAWSSkipServices(services []string) {
+	s.skipServices = services
+}
+
+func (s *Scanner) SetAWSSkipExpensive(skip bool) {
+	s.skipExpensive = skip
+}
+
 func (s *Scanner) SetConcurrencyStrategy(strategy concurrency.Strategy) {
 	s.concurrencyStrategy = strategy
 }
 
+func (s *Scanner) SetStateCache(c *cache.Cache) {
+	s.stateCache = c
+}
+
+func (s *Scanner) SetRateLimit(rate float64) {
+	s.rateLimit = rate
+}
+
+func (s *Scanner) SetMaxRetries(retries int) {
+	s.maxRetries = retries
+}
+
 func New(opts ...options.ScannerOption) *Scanner {
 
 	s := &Scanner{
@@ -134,13 +207,26 @@ func New(opts ...options.ScannerOption) *Scanner {
 	return s
 }
 
+// Coverage reports which services the last state created by the scanner covered, and which were skipped
+func (s *Scanner) Coverage() *coverage.Report {
+	return s.coverage
+}
+
 func (s *Scanner) CreateState(ctx context.Context) (*state.State, error) {
+	s.coverage = &coverage.Report{}
 	cloudState, err := adapter.Adapt(ctx, cloudoptions.Options{
 		ProgressTracker:     s.progressTracker,
 		Region:              s.region,
+		Regions:             s.regions,
 		Endpoint:            s.endpoint,
 		Services:            s.services,
+		SkipServices:        s.skipServices,
+		SkipExpensive:       s.skipExpensive,
+		Coverage:            s.coverage,
 		DebugWriter:         s.debug,
+		StateCache:          s.stateCache,
+		RateLimit:           s.rateLimit,
+		MaxRetries:          s.maxRetries,
 		ConcurrencyStrategy: s.concurrencyStrategy,
 	})
 	if err != nil {
@@ -154,7 +240,46 @@ func (s *Scanner) CreateState(ctx context.Context) (*state.State, error) {
 	return cloudState, nil
 }
 
+// CreateAccountStates reads the resources in each account of the caller's AWS organization, assuming the organization
+// role into each member account. The states are keyed by account ID.
+func (s *Scanner) CreateAccountStates(ctx context.Context) (map[string]*state.State, error) {
+	s.coverage = &coverage.Report{}
+	states, err := adapter.AdaptAWSOrganization(ctx, cloudoptions.Options{
+		ProgressTracker:     s.progressTracker,
+		Region:              s.region,
+		Regions:             s.regions,
+		Endpoint:            s.endpoint,
+		Services:            s.services,
+		SkipServices:        s.skipServices,
+		SkipExpensive:       s.skipExpensive,
+		Coverage:            s.coverage,
+		OrganizationRole:    s.organizationRole,
+		Accounts:            s.accounts,
+		DebugWriter:         s.debug,
+		StateCache:          s.stateCache,
+		RateLimit:           s.rateLimit,
+		MaxRetries:          s.maxRetries,
+		ConcurrencyStrategy: s.concurrencyStrategy,
+	})
+	if err != nil {
+		var adaptionError errs.AdapterError
+		if errors.As(err, &adaptionError) {
+			s.debug.Log("There were %d errors during adaption process: %s", len(adaptionError.Errors()), adaptionError)
+		} else {
+			return nil, err
+		}
+	}
+	return states, nil
+}
+
 func (s *Scanner) ScanWithStateRefresh(ctx context.Context) (results scan.Results, err error) {
+	if s.organization {
+		states, err := s.CreateAccountStates(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return s.ScanAccounts(ctx, states)
+	}
 	cloudState, err := s.CreateState(ctx)
 	if err != nil {
 		return nil, err
@@ -162,6 +287,27 @@ func (s *Scanner) ScanWithStateRefresh(ctx context.Context) (results scan.Result
 	return s.Scan(ctx, cloudState)
 }
 
+// ScanAccounts evaluates the rules against the state of each account separately, so that account-wide settings such as
+// the password policy are checked in every account. Results are identified by the ARNs of their resources, which
+// include the account ID.
+func (s *Scanner) ScanAccounts(ctx context.Context, states map[string]*state.State) (results scan.Results, err error) {
+	accountIDs := make([]string, 0, len(states))
+	for accountID := range states {
+		accountIDs = append(accountIDs, accountID)
+	}
+	sort.Strings(accountIDs)
+
+	for _, accountID := range accountIDs {
+		s.debug.Log("Evaluating rules for account %s...", accountID)
+		accountResults, err := s.Scan(ctx, states[accountID])
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan account %s: %w", accountID, err)
+		}
+		results = append(results, accountResults...)
+	}
+	return results, nil
+}
+
 func (s *Scanner) Scan(ctx context.Context, cloudState *state.State) (results scan.Results, err error) {
 
 	if cloudState == nil {
@@ -198,14 +344,19 @@ func (s *Scanner) Scan(ctx context.Context, cloudState *state.State) (results sc
 	if err != nil {
 		return nil, err
 	}
-	return append(results, regoResults...), nil
+	results = s.HandleResults(append(results, regoResults...))
+	s.RecordResults(s.Name(), results)
+	return results, nil
 }
 
 func (s *Scanner) getRegisteredRules() []rules.RegisteredRule {
+	var registered []rules.RegisteredRule
 	if len(s.frameworks) > 0 { // Only for maintaining backwards compat
-		return rules.GetFrameworkRules(s.frameworks...)
+		registered = rules.GetFrameworkRules(s.frameworks...)
+	} else {
+		registered = rules.GetSpecRules(s.spec)
 	}
-	return rules.GetSpecRules(s.spec)
+	return rules.FilterBySelection(rules.FilterByMinimumSeverity(registered, s.minSeverity), s.ruleSelection)
 }
 
 func (s *Scanner) initRegoScanner() (*rego.Scanner, error) {
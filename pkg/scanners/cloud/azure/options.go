@@ -0,0 +1,99 @@
+package azure
+
+import (
+	"github.com/aquasecurity/defsec/pkg/concurrency"
+	"github.com/aquasecurity/defsec/pkg/progress"
+	"github.com/aquasecurity/defsec/pkg/scanners/options"
+)
+
+type ConfigurableAzureScanner interface {
+	options.ConfigurableScanner
+	SetProgressTracker(t progress.Tracker)
+	SetAzureSubscription(subscription string)
+	SetAzureEndpoint(endpoint string)
+	SetAzureServices(services []string)
+	SetAzureSkipServices(services []string)
+	SetAzureSkipExpensive(skip bool)
+	SetConcurrencyStrategy(strategy concurrency.Strategy)
+	SetRateLimit(rate float64)
+	SetMaxRetries(retries int)
+}
+
+func ScannerWithProgressTracker(t progress.Tracker) options.ScannerOption {
+	return func(s options.ConfigurableScanner) {
+		if azure, ok := s.(ConfigurableAzureScanner); ok {
+			azure.SetProgressTracker(t)
+		}
+	}
+}
+
+// ScannerWithAzureSubscription sets the ID of the subscription to scan
+func ScannerWithAzureSubscription(subscription string) options.ScannerOption {
+	return func(s options.ConfigurableScanner) {
+		if azure, ok := s.(ConfigurableAzureScanner); ok {
+			azure.SetAzureSubscription(subscription)
+		}
+	}
+}
+
+// ScannerWithAzureEndpoint overrides the Azure Resource Manager endpoint, e.g. for sovereign clouds
+func ScannerWithAzureEndpoint(endpoint string) options.ScannerOption {
+	return func(s options.ConfigurableScanner) {
+		if azure, ok := s.(ConfigurableAzureScanner); ok {
+			azure.SetAzureEndpoint(endpoint)
+		}
+	}
+}
+
+func ScannerWithAzureServices(services ...string) options.ScannerOption {
+	return func(s options.ConfigurableScanner) {
+		if azure, ok := s.(ConfigurableAzureScanner); ok {
+			azure.SetAzureServices(services)
+		}
+	}
+}
+
+// ScannerWithAzureSkipServices excludes the given services from the scan
+func ScannerWithAzureSkipServices(services ...string) options.ScannerOption {
+	return func(s options.ConfigurableScanner) {
+		if azure, ok := s.(ConfigurableAzureScanner); ok {
+			azure.SetAzureSkipServices(services)
+		}
+	}
+}
+
+// ScannerWithAzureSkipExpensive excludes services which make requests for every resource they find, unless they are
+// explicitly selected
+func ScannerWithAzureSkipExpensive(skip bool) options.ScannerOption {
+	return func(s options.ConfigurableScanner) {
+		if azure, ok := s.(ConfigurableAzureScanner); ok {
+			azure.SetAzureSkipExpensive(skip)
+		}
+	}
+}
+
+func ScannerWithConcurrencyStrategy(strategy concurrency.Strategy) options.ScannerOption {
+	return func(s options.ConfigurableScanner) {
+		if azure, ok := s.(ConfigurableAzureScanner); ok {
+			azure.SetConcurrencyStrategy(strategy)
+		}
+	}
+}
+
+// ScannerWithRateLimit limits the requests made to each cloud service to the given number per second
+func ScannerWithRateLimit(rate float64) options.ScannerOption {
+	return func(s options.ConfigurableScanner) {
+		if azure, ok := s.(ConfigurableAzureScanner); ok {
+			azure.SetRateLimit(rate)
+		}
+	}
+}
+
+// ScannerWithMaxRetries sets how many times a throttled request is retried, with exponential backoff, before it fails
+func ScannerWithMaxRetries(retries int) options.ScannerOption {
+	return func(s options.ConfigurableScanner) {
+		if azure, ok := s.(ConfigurableAzureScanner); ok {
+			azure.SetMaxRetries(retries)
+		}
+	}
+}
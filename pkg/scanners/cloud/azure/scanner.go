@@ -0,0 +1,306 @@
+package azure
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"runtime"
+	"sync"
+
+	adapter "github.com/aquasecurity/defsec/internal/adapters/cloud"
+	cloudoptions "github.com/aquasecurity/defsec/internal/adapters/cloud/options"
+	"github.com/aquasecurity/defsec/pkg/errs"
+
+	"github.com/aquasecurity/defsec/pkg/state"
+
+	"github.com/aquasecurity/defsec/pkg/rego"
+	"github.com/aquasecurity/defsec/pkg/types"
+
+	"github.com/aquasecurity/defsec/internal/adapters/cloud/azure"
+	"github.com/aquasecurity/defsec/internal/rules"
+	"github.com/aquasecurity/defsec/pkg/concurrency"
+	"github.com/aquasecurity/defsec/pkg/debug"
+	"github.com/aquasecurity/defsec/pkg/framework"
+	"github.com/aquasecurity/defsec/pkg/progress"
+	_ "github.com/aquasecurity/defsec/pkg/rules"
+	"github.com/aquasecurity/defsec/pkg/scan"
+	"github.com/aquasecurity/defsec/pkg/scanners/cloud/cache"
+	"github.com/aquasecurity/defsec/pkg/scanners/cloud/coverage"
+	"github.com/aquasecurity/defsec/pkg/scanners/options"
+	"github.com/aquasecurity/defsec/pkg/severity"
+)
+
+var _ ConfigurableAzureScanner = (*Scanner)(nil)
+var _ options.ResultProcessingScanner = (*Scanner)(nil)
+var _ options.StreamingScanner = (*Scanner)(nil)
+var _ options.SeverityFilteredScanner = (*Scanner)(nil)
+var _ options.RuleSelectingScanner = (*Scanner)(nil)
+var _ options.MetricsScanner = (*Scanner)(nil)
+var _ options.LoggingScanner = (*Scanner)(nil)
+var _ cache.CachingScanner = (*Scanner)(nil)
+
+type Scanner struct {
+	sync.Mutex
+	regoScanner         *rego.Scanner
+	debug               debug.Logger
+	options             []options.ScannerOption
+	progressTracker     progress.Tracker
+	subscription        string
+	endpoint            string
+	services            []string
+	frameworks          []framework.Framework
+	spec                string
+	concurrencyStrategy concurrency.Strategy
+	stateCache          *cache.Cache
+	skipServices        []string
+	skipExpensive       bool
+	coverage            *coverage.Report
+	rateLimit           float64
+	maxRetries          int
+	policyDirs          []string
+	policyReaders       []io.Reader
+	policyFS            fs.FS
+	useEmbedded         bool
+	regoOnly            bool
+	minSeverity         severity.Severity
+	ruleSelection       scan.RuleSelection
+	options.Instrumentation
+	options.ResultHandler
+}
+
+func (s *Scanner) SetRegoOnly(value bool) {
+	s.regoOnly = value
+}
+
+func (s *Scanner) SetFrameworks(frameworks []framework.Framework) {
+	s.frameworks = frameworks
+}
+
+func (s *Scanner) SetSpec(spec string) {
+	s.spec = spec
+}
+
+func (s *Scanner) SetMinimumSeverity(threshold severity.Severity) {
+	s.minSeverity = threshold
+}
+
+func (s *Scanner) SetRuleSelection(selection scan.RuleSelection) {
+	s.ruleSelection = selection
+}
+
+func (s *Scanner) Name() string {
+	return "Azure API"
+}
+
+func (s *Scanner) SetDebugWriter(writer io.Writer) {
+	s.debug = debug.New(writer, "azure-api", "scanner")
+}
+
+func (s *Scanner) SetLogHandler(handler debug.Handler) {
+	s.debug = debug.NewWithHandler(handler, "azure-api", "scanner")
+}
+
+func (s *Scanner) SetProgressTracker(t progress.Tracker) {
+	s.progressTracker = t
+}
+
+func (s *Scanner) SetPolicyReaders(readers []io.Reader) {
+	s.policyReaders = readers
+}
+
+func (s *Scanner) SetPolicyDirs(dirs ...string) {
+	s.policyDirs = dirs
+}
+
+func (s *Scanner) SetPolicyFilesystem(fs fs.FS) {
+	s.policyFS = fs
+}
+
+func (s *Scanner) SetDataFilesystem(fs fs.FS) {
+	s.policyFS = fs
+}
+
+func (s *Scanner) SetUseEmbeddedPolicies(b bool) {
+	s.useEmbedded = b
+}
+
+func (s *Scanner) SetTraceWriter(writer io.Writer)   {}
+func (s *Scanner) SetPerResultTracingEnabled(b bool) {}
+func (s *Scanner) SetDataDirs(s2 ...string)          {}
+func (s *Scanner) SetPolicyNamespaces(s2 ...string)  {}
+func (s *Scanner) SetSkipRequiredCheck(b bool)       {}
+
+func AllSupportedServices() []string {
+	return azure.AllServices()
+}
+
+func (s *Scanner) SetAzureSubscription(subscription string) {
+	s.subscription = subscription
+}
+
+func (s *Scanner) SetAzureEndpoint(endpoint string) {
+	s.endpoint = endpoint
+}
+
+func (s *Scanner) SetAzureServices(services []string) {
+	s.services = services
+}
+
+func (s *Scanner) SetAzureSkipServices(services []string) {
+	s.skipServices = services
+}
+
+func (s *Scanner) SetAzureSkipExpensive(skip bool) {
+	s.skipExpensive = skip
+}
+
+func (s *Scanner) SetConcurrencyStrategy(strategy concurrency.Strategy) {
+	s.concurrencyStrategy = strategy
+}
+
+func (s *Scanner) SetStateCache(c *cache.Cache) {
+	s.stateCache = c
+}
+
+func (s *Scanner) SetRateLimit(rate float64) {
+	s.rateLimit = rate
+}
+
+func (s *Scanner) SetMaxRetries(retries int) {
+	s.maxRetries = retries
+}
+
+func New(opts ...options.ScannerOption) *Scanner {
+
+	s := &Scanner{
+		options:             opts,
+		progressTracker:     progress.NoProgress,
+		concurrencyStrategy: concurrency.DefaultStrategy,
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// Coverage reports which services the last state created by the scanner covered, and which were skipped
+func (s *Scanner) Coverage() *coverage.Report {
+	return s.coverage
+}
+
+// CreateState reads the resources in the configured Azure subscription, authenticating with AZURE_ACCESS_TOKEN or
+// the default Azure credential, e.g. a service principal, managed identity or the Azure CLI login. The subscription
+// defaults to AZURE_SUBSCRIPTION_ID.
+func (s *Scanner) CreateState(ctx context.Context) (*state.State, error) {
+	s.coverage = &coverage.Report{}
+	cloudState, err := adapter.AdaptAzure(ctx, cloudoptions.Options{
+		ProgressTracker:     s.progressTracker,
+		Subscription:        s.subscription,
+		Endpoint:            s.endpoint,
+		Services:            s.services,
+		SkipServices:        s.skipServices,
+		SkipExpensive:       s.skipExpensive,
+		Coverage:            s.coverage,
+		DebugWriter:         s.debug,
+		StateCache:          s.stateCache,
+		RateLimit:           s.rateLimit,
+		MaxRetries:          s.maxRetries,
+		ConcurrencyStrategy: s.concurrencyStrategy,
+	})
+	if err != nil {
+		var adaptionError errs.AdapterError
+		if errors.As(err, &adaptionError) {
+			s.debug.Log("There were %d errors during adaption process: %s", len(adaptionError.Errors()), adaptionError)
+		} else {
+			return nil, err
+		}
+	}
+	return cloudState, nil
+}
+
+func (s *Scanner) ScanWithStateRefresh(ctx context.Context) (results scan.Results, err error) {
+	cloudState, err := s.CreateState(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return s.Scan(ctx, cloudState)
+}
+
+func (s *Scanner) Scan(ctx context.Context, cloudState *state.State) (results scan.Results, err error) {
+
+	if cloudState == nil {
+		return nil, fmt.Errorf("cloud state is nil")
+	}
+
+	// evaluate go rules
+	if !s.regoOnly {
+		for _, rule := range s.getRegisteredRules() {
+			select {
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			default:
+			}
+			if rule.Rule().RegoPackage != "" {
+				continue
+			}
+			ruleResults := rule.Evaluate(cloudState)
+			if len(ruleResults) > 0 {
+				s.debug.Log("Found %d results for %s", len(ruleResults), rule.Rule().AVDID)
+				results = append(results, ruleResults...)
+			}
+		}
+	}
+
+	// evaluate rego rules
+	regoScanner, err := s.initRegoScanner()
+	if err != nil {
+		return nil, err
+	}
+	regoResults, err := regoScanner.ScanInput(ctx, rego.Input{
+		Contents: cloudState.ToRego(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	results = s.HandleResults(append(results, regoResults...))
+	s.RecordResults(s.Name(), results)
+	return results, nil
+}
+
+func (s *Scanner) getRegisteredRules() []rules.RegisteredRule {
+	var registered []rules.RegisteredRule
+	if len(s.frameworks) > 0 { // Only for maintaining backwards compat
+		registered = rules.GetFrameworkRules(s.frameworks...)
+	} else {
+		registered = rules.GetSpecRules(s.spec)
+	}
+	return rules.FilterBySelection(rules.FilterByMinimumSeverity(registered, s.minSeverity), s.ruleSelection)
+}
+
+func (s *Scanner) initRegoScanner() (*rego.Scanner, error) {
+	s.Lock()
+	defer s.Unlock()
+	if s.regoScanner != nil {
+		return s.regoScanner, nil
+	}
+
+	srcFS := s.policyFS
+	if srcFS == nil {
+		if runtime.GOOS == "windows" {
+			srcFS = os.DirFS("C:\\")
+		} else {
+			srcFS = os.DirFS("/")
+		}
+	}
+
+	regoScanner := rego.NewScanner(types.SourceCloud, s.options...)
+	regoScanner.SetParentDebugLogger(s.debug)
+	if err := regoScanner.LoadPolicies(s.useEmbedded, srcFS, s.policyDirs, s.policyReaders); err != nil {
+		return nil, err
+	}
+	s.regoScanner = regoScanner
+	return regoScanner, nil
+}
@@ -0,0 +1,173 @@
+package cache
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/aquasecurity/defsec/pkg/scanners/options"
+	"github.com/aquasecurity/defsec/pkg/state"
+)
+
+const currentVersion = 1
+
+// DefaultMaxAge is how long adapted state is reused for if no other age is given
+const DefaultMaxAge = time.Hour
+
+// Cache persists the state adapted from each cloud service to disk, so that scanning the same account again (e.g.
+// with other rules, or against another framework) reuses it rather than calling the cloud APIs again. Each service
+// of each account and region is stored in its own file, and expires independently.
+type Cache struct {
+	dir     string
+	maxAge  time.Duration
+	refresh bool
+	now     func() time.Time
+}
+
+// Key identifies the state adapted from a single service
+type Key struct {
+	Provider string
+	Account  string
+	Region   string
+	Service  string
+}
+
+type record struct {
+	Version  int          `json:"version"`
+	Key      Key          `json:"key"`
+	Created  time.Time    `json:"created"`
+	Contents *state.State `json:"contents"`
+}
+
+// CachingScanner is implemented by cloud scanners which can reuse adapted state from a Cache
+type CachingScanner interface {
+	options.ConfigurableScanner
+	SetStateCache(c *Cache)
+}
+
+// ScannerWithStateCache reuses state adapted within the max age of the cache, rather than calling the cloud APIs again
+func ScannerWithStateCache(c *Cache) options.ScannerOption {
+	return func(s options.ConfigurableScanner) {
+		if cs, ok := s.(CachingScanner); ok {
+			cs.SetStateCache(c)
+		}
+	}
+}
+
+// New creates a cache which stores state under the given directory, and reuses it for up to maxAge. A maxAge of
+// zero uses DefaultMaxAge.
+func New(dir string, maxAge time.Duration) *Cache {
+	if maxAge <= 0 {
+		maxAge = DefaultMaxAge
+	}
+	return &Cache{
+		dir:    dir,
+		maxAge: maxAge,
+		now:    time.Now,
+	}
+}
+
+// SetRefresh ignores any existing state, so that every service is adapted again. The new state is still stored.
+func (c *Cache) SetRefresh(refresh bool) {
+	c.refresh = refresh
+}
+
+// Clear removes all stored state
+func (c *Cache) Clear() error {
+	return os.RemoveAll(c.dir)
+}
+
+// Adapt combines the stored state of a service with target if it has not expired, and otherwise calls adapt to read
+// it from the cloud, storing the result. State is not stored if adapt fails, as it may be incomplete. It is safe to
+// call on a nil cache, in which case adapt is always called. As state is combined rather than replaced, the same
+// service can be adapted into target for several regions.
+func (c *Cache) Adapt(key Key, target *state.State, adapt func(*state.State) error) error {
+	if c != nil {
+		if cached, ok := c.Load(key); ok {
+			*target = *target.Combine(cached)
+			return nil
+		}
+	}
+
+	adapted := &state.State{}
+	adaptErr := adapt(adapted)
+	*target = *target.Combine(adapted)
+	if c != nil && adaptErr == nil {
+		if err := c.Store(key, adapted); err != nil {
+			return fmt.Errorf("failed to cache state for %s: %w", key.Service, err)
+		}
+	}
+	return adaptErr
+}
+
+// Load returns the stored state of a service, or false if there is none, it has expired, or a refresh was requested
+func (c *Cache) Load(key Key) (*state.State, bool) {
+	if c.refresh {
+		return nil, false
+	}
+	data, err := os.ReadFile(c.path(key))
+	if err != nil {
+		return nil, false
+	}
+	var r record
+	if err := json.Unmarshal(data, &r); err != nil {
+		return nil, false
+	}
+	if r.Version != currentVersion || r.Key != key || r.Contents == nil {
+		return nil, false
+	}
+	if c.now().Sub(r.Created) > c.maxAge {
+		return nil, false
+	}
+	return r.Contents, true
+}
+
+// Store records the state of a service
+func (c *Cache) Store(key Key, contents *state.State) error {
+	data, err := json.Marshal(record{
+		Version:  currentVersion,
+		Key:      key,
+		Created:  c.now(),
+		Contents: contents,
+	})
+	if err != nil {
+		return err
+	}
+	path := c.path(key)
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*")
+	if err != nil {
+		return err
+	}
+	defer func() { _ = os.Remove(tmp.Name()) }()
+	if _, err := tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// path returns the file the state of a service is stored in. Services which are not regional are stored as global.
+func (c *Cache) path(key Key) string {
+	region := key.Region
+	if region == "" {
+		region = "global"
+	}
+	return filepath.Join(c.dir, sanitise(key.Provider), sanitise(key.Account), sanitise(region), sanitise(key.Service)+".json")
+}
+
+// sanitise makes a key part safe to use as a single path element
+func sanitise(part string) string {
+	if part == "" {
+		return "_"
+	}
+	return strings.NewReplacer("/", "_", "\\", "_", ":", "_", "..", "_").Replace(part)
+}
@@ -0,0 +1,138 @@
+package cache
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/aquasecurity/defsec/pkg/providers/aws/iam"
+	"github.com/aquasecurity/defsec/pkg/providers/aws/s3"
+	"github.com/aquasecurity/defsec/pkg/state"
+	"github.com/aquasecurity/defsec/pkg/types"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func adaptBucket(name string, calls *int) func(*state.State) error {
+	return func(s *state.State) error {
+		*calls++
+		metadata := types.NewRemoteMetadata("arn:aws:s3:::" + name)
+		s.AWS.S3.Buckets = []s3.Bucket{
+			{
+				Metadata: metadata,
+				Name:     types.String(name, metadata),
+			},
+		}
+		return nil
+	}
+}
+
+func Test_StateIsReusedUntilItExpires(t *testing.T) {
+	c := New(t.TempDir(), time.Hour)
+	now := time.Now()
+	c.now = func() time.Time { return now }
+
+	key := Key{Provider: "aws", Account: "123456789012", Region: "eu-west-1", Service: "s3"}
+	var calls int
+
+	first := &state.State{}
+	require.NoError(t, c.Adapt(key, first, adaptBucket("first", &calls)))
+	assert.Equal(t, 1, calls)
+
+	second := &state.State{}
+	require.NoError(t, c.Adapt(key, second, adaptBucket("second", &calls)))
+	assert.Equal(t, 1, calls)
+	require.Len(t, second.AWS.S3.Buckets, 1)
+	assert.Equal(t, "first", second.AWS.S3.Buckets[0].Name.Value())
+	assert.Equal(t, "arn:aws:s3:::first", second.AWS.S3.Buckets[0].Metadata.Range().GetFilename())
+
+	now = now.Add(2 * time.Hour)
+	expired := &state.State{}
+	require.NoError(t, c.Adapt(key, expired, adaptBucket("expired", &calls)))
+	assert.Equal(t, 2, calls)
+	assert.Equal(t, "expired", expired.AWS.S3.Buckets[0].Name.Value())
+}
+
+func Test_StateIsKeyedByAccountAndRegion(t *testing.T) {
+	c := New(t.TempDir(), time.Hour)
+	var calls int
+
+	for _, key := range []Key{
+		{Provider: "aws", Account: "123456789012", Region: "eu-west-1", Service: "s3"},
+		{Provider: "aws", Account: "123456789012", Region: "us-east-1", Service: "s3"},
+		{Provider: "aws", Account: "210987654321", Region: "eu-west-1", Service: "s3"},
+	} {
+		require.NoError(t, c.Adapt(key, &state.State{}, adaptBucket(key.Region, &calls)))
+	}
+	assert.Equal(t, 3, calls)
+}
+
+func Test_RefreshIgnoresStoredState(t *testing.T) {
+	dir := t.TempDir()
+	key := Key{Provider: "aws", Account: "123456789012", Region: "eu-west-1", Service: "s3"}
+	var calls int
+
+	require.NoError(t, New(dir, time.Hour).Adapt(key, &state.State{}, adaptBucket("old", &calls)))
+
+	refreshing := New(dir, time.Hour)
+	refreshing.SetRefresh(true)
+	require.NoError(t, refreshing.Adapt(key, &state.State{}, adaptBucket("new", &calls)))
+	assert.Equal(t, 2, calls)
+
+	cached := &state.State{}
+	require.NoError(t, New(dir, time.Hour).Adapt(key, cached, adaptBucket("unused", &calls)))
+	assert.Equal(t, 2, calls)
+	assert.Equal(t, "new", cached.AWS.S3.Buckets[0].Name.Value())
+}
+
+func Test_FailedAdaptionIsNotStored(t *testing.T) {
+	c := New(t.TempDir(), time.Hour)
+	key := Key{Provider: "aws", Account: "123456789012", Region: "eu-west-1", Service: "s3"}
+
+	err := c.Adapt(key, &state.State{}, func(*state.State) error {
+		return errors.New("throttled")
+	})
+	require.Error(t, err)
+
+	_, ok := c.Load(key)
+	assert.False(t, ok)
+}
+
+func Test_CachedStateIsMergedWithOtherServices(t *testing.T) {
+	c := New(t.TempDir(), time.Hour)
+	var calls int
+
+	target := &state.State{}
+	metadata := types.NewRemoteMetadata("arn:aws:iam::123456789012:user/admin")
+	target.AWS.IAM.Users = []iam.User{{Metadata: metadata, Name: types.String("admin", metadata)}}
+
+	key := Key{Provider: "aws", Account: "123456789012", Region: "eu-west-1", Service: "s3"}
+	require.NoError(t, c.Adapt(key, target, adaptBucket("bucket", &calls)))
+	assert.Len(t, target.AWS.IAM.Users, 1)
+	assert.Len(t, target.AWS.S3.Buckets, 1)
+}
+
+func Test_NilCacheAlwaysAdapts(t *testing.T) {
+	var c *Cache
+	var calls int
+	target := &state.State{}
+	key := Key{Provider: "aws", Service: "s3"}
+	require.NoError(t, c.Adapt(key, target, adaptBucket("bucket", &calls)))
+	require.NoError(t, c.Adapt(key, target, adaptBucket("bucket", &calls)))
+	assert.Equal(t, 2, calls)
+}
+
+func Test_StateOfSeveralRegionsIsCombined(t *testing.T) {
+	c := New(t.TempDir(), time.Hour)
+	var calls int
+
+	target := &state.State{}
+	for _, region := range []string{"eu-west-1", "us-east-1"} {
+		key := Key{Provider: "aws", Account: "123456789012", Region: region, Service: "s3"}
+		require.NoError(t, c.Adapt(key, target, adaptBucket(region, &calls)))
+	}
+	require.Len(t, target.AWS.S3.Buckets, 2)
+	assert.Equal(t, "eu-west-1", target.AWS.S3.Buckets[0].Name.Value())
+	assert.Equal(t, "us-east-1", target.AWS.S3.Buckets[1].Name.Value())
+}
@@ -0,0 +1,145 @@
+package coverage
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"sync"
+)
+
+type Status string
+
+const (
+	// Covered services were read from the cloud, or from the state cache
+	Covered Status = "covered"
+	// Skipped services were not read, e.g. because they were not selected
+	Skipped Status = "skipped"
+	// Failed services could not be read, so their resources were not checked
+	Failed Status = "failed"
+	// Unsupported services cannot be read from the cloud yet, so their resources were not checked
+	Unsupported Status = "unsupported"
+)
+
+// Service records whether a service was read during a scan. Region and Account are empty for services which are not
+// scanned per region or account.
+type Service struct {
+	Provider string
+	Name     string
+	Account  string
+	Region   string
+	Status   Status
+	Reason   string
+}
+
+// Report records which services a cloud scan covered, and which were skipped or could not be read
+type Report struct {
+	lock     sync.Mutex
+	services []Service
+}
+
+// Add records a service. It is safe to call on a nil report, which records nothing.
+func (r *Report) Add(service Service) {
+	if r == nil {
+		return
+	}
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.services = append(r.services, service)
+}
+
+// Services returns the recorded services, sorted by name then account and region
+func (r *Report) Services() []Service {
+	if r == nil {
+		return nil
+	}
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	services := make([]Service, len(r.services))
+	copy(services, r.services)
+	sort.SliceStable(services, func(i, j int) bool {
+		if services[i].Name != services[j].Name {
+			return services[i].Name < services[j].Name
+		}
+		if services[i].Account != services[j].Account {
+			return services[i].Account < services[j].Account
+		}
+		return services[i].Region < services[j].Region
+	})
+	return services
+}
+
+// WithStatus returns the recorded services with the given status
+func (r *Report) WithStatus(status Status) []Service {
+	var services []Service
+	for _, service := range r.Services() {
+		if service.Status == status {
+			services = append(services, service)
+		}
+	}
+	return services
+}
+
+// Write writes a summary of the covered, skipped, failed and unsupported services, e.g.
+//
+//	Covered services: ec2 (eu-west-1, us-east-1), iam
+//	Skipped services: s3 (expensive)
+func (r *Report) Write(w io.Writer) error {
+	for _, heading := range []struct {
+		status Status
+		label  string
+	}{
+		{Covered, "Covered"},
+		{Skipped, "Skipped"},
+		{Failed, "Failed"},
+		{Unsupported, "Unsupported"},
+	} {
+		services := r.WithStatus(heading.status)
+		if len(services) == 0 {
+			continue
+		}
+		if _, err := fmt.Fprintf(w, "%s services: %s\n", heading.label, summarise(services)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// summarise lists each service once, followed by where it was read and why it was skipped or failed
+func summarise(services []Service) string {
+	var names []string
+	details := make(map[string][]string)
+	for _, service := range services {
+		if _, ok := details[service.Name]; !ok {
+			names = append(names, service.Name)
+			details[service.Name] = nil
+		}
+		var detail []string
+		for _, part := range []string{service.Account, service.Region, service.Reason} {
+			if part != "" {
+				detail = append(detail, part)
+			}
+		}
+		if len(detail) > 0 && !contains(details[service.Name], strings.Join(detail, " ")) {
+			details[service.Name] = append(details[service.Name], strings.Join(detail, " "))
+		}
+	}
+	parts := make([]string, 0, len(names))
+	for _, name := range names {
+		if len(details[name]) == 0 {
+			parts = append(parts, name)
+			continue
+		}
+		parts = append(parts, fmt.Sprintf("%s (%s)", name, strings.Join(details[name], ", ")))
+	}
+	return strings.Join(parts, ", ")
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
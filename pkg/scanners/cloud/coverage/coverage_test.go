@@ -0,0 +1,39 @@
+package coverage
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ReportSummarisesServicesByStatus(t *testing.T) {
+	report := &Report{}
+	report.Add(Service{Provider: "aws", Name: "ec2", Region: "us-east-1", Status: Covered})
+	report.Add(Service{Provider: "aws", Name: "iam", Status: Covered})
+	report.Add(Service{Provider: "aws", Name: "ec2", Region: "eu-west-1", Status: Covered})
+	report.Add(Service{Provider: "aws", Name: "s3", Status: Skipped, Reason: "expensive"})
+	report.Add(Service{Provider: "aws", Name: "kms", Region: "eu-west-1", Status: Failed})
+	report.Add(Service{Provider: "aws", Name: "waf", Status: Unsupported})
+
+	assert.Len(t, report.WithStatus(Covered), 3)
+
+	var buffer bytes.Buffer
+	require.NoError(t, report.Write(&buffer))
+	assert.Equal(t, `Covered services: ec2 (eu-west-1, us-east-1), iam
+Skipped services: s3 (expensive)
+Failed services: kms (eu-west-1)
+Unsupported services: waf
+`, buffer.String())
+}
+
+func Test_NilReportRecordsNothing(t *testing.T) {
+	var report *Report
+	report.Add(Service{Name: "ec2", Status: Covered})
+	assert.Empty(t, report.Services())
+
+	var buffer bytes.Buffer
+	require.NoError(t, report.Write(&buffer))
+	assert.Empty(t, buffer.String())
+}
@@ -0,0 +1,119 @@
+package google
+
+import (
+	"github.com/aquasecurity/defsec/pkg/concurrency"
+	"github.com/aquasecurity/defsec/pkg/progress"
+	"github.com/aquasecurity/defsec/pkg/scanners/options"
+)
+
+type ConfigurableGoogleScanner interface {
+	options.ConfigurableScanner
+	SetProgressTracker(t progress.Tracker)
+	SetGoogleProject(project string)
+	SetGoogleFolder(folder string)
+	SetGoogleOrganization(organization string)
+	SetGoogleEndpoint(endpoint string)
+	SetGoogleServices(services []string)
+	SetGoogleSkipServices(services []string)
+	SetGoogleSkipExpensive(skip bool)
+	SetConcurrencyStrategy(strategy concurrency.Strategy)
+	SetRateLimit(rate float64)
+	SetMaxRetries(retries int)
+}
+
+func ScannerWithProgressTracker(t progress.Tracker) options.ScannerOption {
+	return func(s options.ConfigurableScanner) {
+		if google, ok := s.(ConfigurableGoogleScanner); ok {
+			google.SetProgressTracker(t)
+		}
+	}
+}
+
+// ScannerWithGoogleProject sets the ID of a single project to scan
+func ScannerWithGoogleProject(project string) options.ScannerOption {
+	return func(s options.ConfigurableScanner) {
+		if google, ok := s.(ConfigurableGoogleScanner); ok {
+			google.SetGoogleProject(project)
+		}
+	}
+}
+
+// ScannerWithGoogleFolder scans every project below a folder, given by its numeric ID
+func ScannerWithGoogleFolder(folder string) options.ScannerOption {
+	return func(s options.ConfigurableScanner) {
+		if google, ok := s.(ConfigurableGoogleScanner); ok {
+			google.SetGoogleFolder(folder)
+		}
+	}
+}
+
+// ScannerWithGoogleOrganization scans every project in an organization, given by its numeric ID
+func ScannerWithGoogleOrganization(organization string) options.ScannerOption {
+	return func(s options.ConfigurableScanner) {
+		if google, ok := s.(ConfigurableGoogleScanner); ok {
+			google.SetGoogleOrganization(organization)
+		}
+	}
+}
+
+// ScannerWithGoogleEndpoint sends the requests for every Google API to a single endpoint, e.g. an emulator
+func ScannerWithGoogleEndpoint(endpoint string) options.ScannerOption {
+	return func(s options.ConfigurableScanner) {
+		if google, ok := s.(ConfigurableGoogleScanner); ok {
+			google.SetGoogleEndpoint(endpoint)
+		}
+	}
+}
+
+func ScannerWithGoogleServices(services ...string) options.ScannerOption {
+	return func(s options.ConfigurableScanner) {
+		if google, ok := s.(ConfigurableGoogleScanner); ok {
+			google.SetGoogleServices(services)
+		}
+	}
+}
+
+// ScannerWithGoogleSkipServices excludes the given services from the scan
+func ScannerWithGoogleSkipServices(services ...string) options.ScannerOption {
+	return func(s options.ConfigurableScanner) {
+		if google, ok := s.(ConfigurableGoogleScanner); ok {
+			google.SetGoogleSkipServices(services)
+		}
+	}
+}
+
+// ScannerWithGoogleSkipExpensive excludes services which make requests for every resource they find, unless they are
+// explicitly selected
+func ScannerWithGoogleSkipExpensive(skip bool) options.ScannerOption {
+	return func(s options.ConfigurableScanner) {
+		if google, ok := s.(ConfigurableGoogleScanner); ok {
+			google.SetGoogleSkipExpensive(skip)
+		}
+	}
+}
+
+func ScannerWithConcurrencyStrategy(strategy concurrency.Strategy) options.ScannerOption {
+	return func(s options.ConfigurableScanner) {
+		if google, ok := s.(ConfigurableGoogleScanner); ok {
+			google.SetConcurrencyStrategy(strategy)
+		}
+	}
+}
+
+// ScannerWithRateLimit limits the requests made to each cloud service to the given number per second
+func ScannerWithRateLimit(rate float64) options.ScannerOption {
+	return func(s options.ConfigurableScanner) {
+		if google, ok := s.(ConfigurableGoogleScanner); ok {
+			google.SetRateLimit(rate)
+		}
+	}
+}
+
+// ScannerWithMaxRetries sets how many times a throttled request is retried, with exponential backoff, before it fails
+func ScannerWithMaxRetries(retries int) options.ScannerOption {
+	return func(s options.ConfigurableScanner) {
+		if google, ok := s.(ConfigurableGoogleScanner); ok {
+			google.SetMaxRetries(retries)
+		}
+	}
+}
@@ -3,16 +3,22 @@ package parser
 import (
 	"bytes"
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"io/fs"
 	"path/filepath"
 	"strings"
 
+	"github.com/aquasecurity/defsec/pkg/concurrency"
 	"github.com/aquasecurity/defsec/pkg/debug"
 
 	"github.com/aquasecurity/defsec/pkg/detection"
+	"github.com/aquasecurity/defsec/pkg/scanners/charset"
+	"github.com/aquasecurity/defsec/pkg/scanners/lenient"
+	"github.com/aquasecurity/defsec/pkg/scanners/limits"
 	"github.com/aquasecurity/defsec/pkg/scanners/options"
+	"github.com/aquasecurity/defsec/pkg/scanners/yaml/anchors"
 
 	"github.com/liamg/jfather"
 	"gopkg.in/yaml.v3"
@@ -21,20 +27,65 @@ import (
 var _ options.ConfigurableParser = (*Parser)(nil)
 
 type Parser struct {
-	debug        debug.Logger
-	skipRequired bool
+	debug           debug.Logger
+	skipRequired    bool
+	parallelism     int
+	maxDocumentSize int64
+	lenient         bool
+	fileLimits      limits.FileLimits
+	summary         *limits.Summary
+	pathFilter      options.PathFilter
+	customParsers   options.CustomParsers
+	instrumentation *options.Instrumentation
+	scannerName     string
 }
 
 func (p *Parser) SetDebugWriter(writer io.Writer) {
 	p.debug = debug.New(writer, "cloudformation", "parser")
 }
 
+func (p *Parser) SetLogHandler(handler debug.Handler) {
+	p.debug = debug.NewWithHandler(handler, "cloudformation", "parser")
+}
+
 func (p *Parser) SetSkipRequiredCheck(b bool) {
 	p.skipRequired = b
 }
 
+func (p *Parser) SetParallelism(parallelism int) {
+	p.parallelism = parallelism
+}
+
+func (p *Parser) SetMaxDocumentSize(size int64) {
+	p.maxDocumentSize = size
+}
+
+func (p *Parser) SetLenientParsing(lenient bool) {
+	p.lenient = lenient
+}
+
+func (p *Parser) SetFileLimits(fileLimits limits.FileLimits, summary *limits.Summary) {
+	p.fileLimits = fileLimits
+	p.summary = summary
+}
+
+func (p *Parser) SetPathFilter(filter options.PathFilter) {
+	p.pathFilter = filter
+}
+
+func (p *Parser) SetCustomParsers(parsers options.CustomParsers) {
+	p.customParsers = parsers
+}
+
+func (p *Parser) SetInstrumentation(instrumentation *options.Instrumentation, scanner string) {
+	p.instrumentation = instrumentation
+	p.scannerName = scanner
+}
+
 func New(options ...options.ParserOption) *Parser {
-	p := &Parser{}
+	p := &Parser{
+		parallelism: 1,
+	}
 	for _, option := range options {
 		option(p)
 	}
@@ -42,7 +93,31 @@ func New(options ...options.ParserOption) *Parser {
 }
 
 func (p *Parser) ParseFS(ctx context.Context, target fs.FS, dir string) (FileContexts, error) {
-	var contexts FileContexts
+	return parseFiles(ctx, p, target, dir, nil, func(_ context.Context, c *FileContext) (*FileContext, error) {
+		return c, nil
+	})
+}
+
+// ParseFSEach parses the CloudFormation files in dir, calling fn with each file as soon as it is parsed, so files
+// can be parsed and scanned by the same worker and released once scanned, rather than all being held in memory.
+// fn is called concurrently when parallelism is enabled. Files which fail to parse are logged and skipped.
+// Every selected file is reported to progress before any is parsed, and files which are skipped are reported as
+// completed, so fn only needs to report the progress of the files it is given. progress may be nil.
+func (p *Parser) ParseFSEach(ctx context.Context, target fs.FS, dir string, progress *options.ProgressTracker, fn func(ctx context.Context, c *FileContext) error) error {
+	_, err := parseFiles(ctx, p, target, dir, progress, func(ctx context.Context, c *FileContext) (struct{}, error) {
+		return struct{}{}, fn(ctx, c)
+	})
+	return err
+}
+
+// parseFiles parses each CloudFormation file in dir and passes it to fn, using as many workers as the parallelism
+// allows. The outputs of fn for the files which were parsed are returned in walk order. consumed tracks the
+// progress of fn: every selected file is added to its total up front, and files which are not passed to fn are
+// completed there. It may be nil.
+func parseFiles[T any](ctx context.Context, p *Parser, target fs.FS, dir string, consumed *options.ProgressTracker, fn func(ctx context.Context, c *FileContext) (T, error)) ([]T, error) {
+	tracker := limits.NewTracker(p.fileLimits, p.summary)
+	progress := p.instrumentation.TrackProgress(p.scannerName, options.PhaseParse)
+	var paths []string
 	if err := fs.WalkDir(target, filepath.ToSlash(dir), func(path string, entry fs.DirEntry, err error) error {
 		select {
 		case <-ctx.Done():
@@ -53,25 +128,87 @@ func (p *Parser) ParseFS(ctx context.Context, target fs.FS, dir string) (FileCon
 			return err
 		}
 		if entry.IsDir() {
+			if p.pathFilter.SkipsDir(dir, path) {
+				return fs.SkipDir
+			}
 			return nil
 		}
+		if !p.pathFilter.AllowsFile(dir, path) {
+			return nil
+		}
+		if !tracker.AllowSize(target, path) {
+			return nil
+		}
+		paths = append(paths, path)
+		return nil
+	}); err != nil {
+		return nil, err
+	}
 
+	// files are only counted once they are known to be relevant, in walk order, so the same files are skipped each time
+	required, err := concurrency.Process(ctx, paths, concurrency.Workers(p.parallelism), func(ctx context.Context, path string) (bool, error) {
 		if !p.Required(target, path) {
 			p.debug.Log("not a CloudFormation file, skipping %s", path)
-			return nil
+			return false, nil
+		}
+		return true, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	var selected []string
+	for i, path := range paths {
+		if required[i] && tracker.AllowFile(path) {
+			selected = append(selected, path)
 		}
+	}
 
-		c, err := p.ParseFile(ctx, target, path)
+	type output struct {
+		value  T
+		parsed bool
+	}
+	progress.Discovered(len(selected))
+	consumed.Discovered(len(selected))
+	outputs, err := concurrency.Process(ctx, selected, concurrency.Workers(p.parallelism), func(ctx context.Context, path string) (output, error) {
+		progress.Started(path)
+		done := p.instrumentation.StartPhase(ctx, p.scannerName, options.PhaseParse, path)
+		c, err := limits.Parse(ctx, tracker, path, func(ctx context.Context) (*FileContext, error) {
+			return p.ParseFile(ctx, target, path)
+		})
+		done()
+		progress.Completed(path)
+		if errors.Is(err, limits.ErrDocumentTooLarge) {
+			// a template is a single document, so the whole file is skipped
+			p.summary.Record(path, limits.ReasonDocumentTooLarge)
+			consumed.Completed(path)
+			return output{}, nil
+		}
 		if err != nil {
-			p.debug.Log("Error parsing file '%s': %s", path, err)
-			return nil
+			p.debug.Warn("Failed to parse file", "path", path, "error", err)
+			consumed.Completed(path)
+			return output{}, nil
 		}
-		contexts = append(contexts, c)
-		return nil
-	}); err != nil {
+		if c == nil {
+			consumed.Completed(path)
+			return output{}, nil
+		}
+		value, err := fn(ctx, c)
+		if err != nil {
+			return output{}, err
+		}
+		return output{value: value, parsed: true}, nil
+	})
+	if err != nil {
 		return nil, err
 	}
-	return contexts, nil
+
+	var values []T
+	for _, out := range outputs {
+		if out.parsed {
+			values = append(values, out.value)
+		}
+	}
+	return values, nil
 }
 
 func (p *Parser) Required(fs fs.FS, path string) bool {
@@ -85,7 +222,12 @@ func (p *Parser) Required(fs fs.FS, path string) bool {
 	}
 	defer func() { _ = f.Close() }()
 	if data, err := io.ReadAll(f); err == nil {
-		return detection.IsType(path, bytes.NewReader(data), detection.FileTypeCloudFormation)
+		data = charset.Decode(data)
+		name := p.customParsers.ParsedAs(path)
+		if p.lenient && strings.EqualFold(filepath.Ext(name), ".json") {
+			data = lenient.StripJSON(data)
+		}
+		return detection.IsType(name, bytes.NewReader(data), detection.FileTypeCloudFormation)
 	}
 	return false
 
@@ -106,7 +248,7 @@ func (p *Parser) ParseFile(ctx context.Context, fs fs.FS, path string) (context
 	}
 
 	sourceFmt := YamlSourceFormat
-	if strings.HasSuffix(strings.ToLower(path), ".json") {
+	if strings.HasSuffix(strings.ToLower(p.customParsers.ParsedAs(path)), ".json") {
 		sourceFmt = JsonSourceFormat
 	}
 
@@ -116,10 +258,11 @@ func (p *Parser) ParseFile(ctx context.Context, fs fs.FS, path string) (context
 	}
 	defer func() { _ = f.Close() }()
 
-	content, err := io.ReadAll(f)
+	content, err := io.ReadAll(limits.NewDocumentReader(f, p.maxDocumentSize))
 	if err != nil {
 		return nil, err
 	}
+	content = charset.Decode(content)
 
 	lines := strings.Split(string(content), "\n")
 
@@ -129,14 +272,30 @@ func (p *Parser) ParseFile(ctx context.Context, fs fs.FS, path string) (context
 		SourceFormat: sourceFmt,
 	}
 
-	if strings.HasSuffix(strings.ToLower(path), ".json") {
+	var definitions map[*yaml.Node]anchors.Anchor
+	if strings.HasSuffix(strings.ToLower(p.customParsers.ParsedAs(path)), ".json") {
+		if p.lenient {
+			content = lenient.StripJSON(content)
+		}
 		if err := jfather.Unmarshal(content, context); err != nil {
 			return nil, NewErrInvalidContent(path, err)
 		}
 	} else {
-		if err := yaml.Unmarshal(content, context); err != nil {
+		var root yaml.Node
+		if err := yaml.Unmarshal(content, &root); err != nil {
 			return nil, NewErrInvalidContent(path, err)
 		}
+		// aliases and merge keys are expanded so that every property is read, and reported where it is used, along
+		// with where it was defined
+		definitions = anchors.Expand(&root)
+		if p.lenient {
+			lenient.NormaliseYAML(&root)
+		}
+		if root.Kind != 0 {
+			if err := root.Decode(context); err != nil {
+				return nil, NewErrInvalidContent(path, err)
+			}
+		}
 	}
 
 	context.lines = lines
@@ -146,8 +305,18 @@ func (p *Parser) ParseFile(ctx context.Context, fs fs.FS, path string) (context
 	p.debug.Log("Context loaded from source %s", path)
 
 	for name, r := range context.Resources {
+		r.setDefinitions(definitions)
 		r.ConfigureResource(name, fs, path, context)
 	}
+	for _, r := range context.Globals {
+		if r != nil {
+			r.setDefinitions(definitions)
+		}
+	}
+	for name, condition := range context.Conditions {
+		condition.setDefinitions(definitions)
+		context.Conditions[name] = condition
+	}
 
 	return context, nil
 }
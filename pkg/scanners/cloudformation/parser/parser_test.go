@@ -2,9 +2,11 @@ package parser
 
 import (
 	"context"
+	"encoding/binary"
 	"os"
 	"path/filepath"
 	"testing"
+	"unicode/utf16"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -170,6 +172,79 @@ Resources:
 	assert.Equal(t, "somebucket", refProp.AsString())
 }
 
+func Test_parse_yaml_with_merged_anchor(t *testing.T) {
+	source := `---
+Resources:
+  FirstBucket:
+    Type: 'AWS::S3::Bucket'
+    Properties: &properties
+      BucketName: first
+      VersioningConfiguration:
+        Status: Enabled
+  SecondBucket:
+    Type: 'AWS::S3::Bucket'
+    Properties:
+      <<: *properties
+      BucketName: second
+`
+
+	files, err := parseFile(t, source, "cf.yaml")
+	require.NoError(t, err)
+	require.Len(t, files, 1)
+	ctx := files[0]
+
+	first := ctx.GetResourceByLogicalID("FirstBucket")
+	require.NotNil(t, first)
+	assert.Equal(t, "first", first.GetProperty("BucketName").AsString())
+	assert.Equal(t, 8, first.GetProperty("VersioningConfiguration.Status").Range().GetStartLine())
+
+	second := ctx.GetResourceByLogicalID("SecondBucket")
+	require.NotNil(t, second)
+	assert.Equal(t, "second", second.GetProperty("BucketName").AsString())
+	assert.True(t, second.GetProperty("<<").IsNil())
+
+	status := second.GetProperty("VersioningConfiguration.Status")
+	require.False(t, status.IsNil())
+	assert.Equal(t, "Enabled", status.AsString())
+	assert.Equal(t, 12, status.Range().GetStartLine())
+	assert.Equal(t, 13, second.Range().GetEndLine())
+}
+
+func Test_parse_encoded_files(t *testing.T) {
+	source := `{
+  "Resources": {
+    "Bucket": {
+      "Type": "AWS::S3::Bucket",
+      "Properties": {
+        "BucketName": "encoded-bucket"
+      }
+    }
+  }
+}`
+	utf16le := []byte{0xff, 0xfe}
+	for _, unit := range utf16.Encode([]rune(source)) {
+		utf16le = binary.LittleEndian.AppendUint16(utf16le, unit)
+	}
+
+	tests := map[string][]byte{
+		"utf-8 with bom": append([]byte{0xef, 0xbb, 0xbf}, source...),
+		"utf-16le":       utf16le,
+	}
+	for name, encoded := range tests {
+		t.Run(name, func(t *testing.T) {
+			files, err := parseFile(t, string(encoded), "cf.json")
+			require.NoError(t, err)
+			require.Len(t, files, 1)
+
+			bucket := files[0].GetResourceByLogicalID("Bucket")
+			require.NotNil(t, bucket)
+			name := bucket.GetProperty("BucketName")
+			assert.Equal(t, "encoded-bucket", name.AsString())
+			assert.Equal(t, 6, name.Range().GetStartLine())
+		})
+	}
+}
+
 func createTestFileContext(t *testing.T, source string) *FileContext {
 	contexts, err := parseFile(t, source, "main.yaml")
 	require.NoError(t, err)
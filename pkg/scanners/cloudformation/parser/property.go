@@ -9,6 +9,7 @@ import (
 	defsecTypes "github.com/aquasecurity/defsec/pkg/types"
 
 	"github.com/aquasecurity/defsec/pkg/scanners/cloudformation/cftypes"
+	"github.com/aquasecurity/defsec/pkg/scanners/yaml/anchors"
 
 	"github.com/liamg/jfather"
 	"gopkg.in/yaml.v3"
@@ -29,6 +30,10 @@ type Property struct {
 	Inner       PropertyInner
 	logicalId   string
 	unresolved  bool
+	// node is the yaml the property was decoded from, kept only until its definition has been looked up
+	node *yaml.Node
+	// definition is where the property was defined, if it was copied from an anchor by an alias or a merge key
+	definition *anchors.Anchor
 }
 
 type PropertyInner struct {
@@ -71,6 +76,31 @@ func (p *Property) setContext(ctx *FileContext) {
 	}
 }
 
+// setDefinitions records where the property and its children were defined, if they were copied from an anchor
+func (p *Property) setDefinitions(definitions map[*yaml.Node]anchors.Anchor) {
+	if definition, ok := definitions[p.node]; ok {
+		p.definition = &definition
+	}
+	p.node = nil
+
+	switch p.Type() {
+	case cftypes.Map:
+		for _, subProp := range p.AsMap() {
+			if subProp == nil {
+				continue
+			}
+			subProp.setDefinitions(definitions)
+		}
+	case cftypes.List:
+		for _, subProp := range p.AsList() {
+			if subProp == nil {
+				continue
+			}
+			subProp.setDefinitions(definitions)
+		}
+	}
+}
+
 func (p *Property) setFileAndParentRange(target fs.FS, filepath string, parentRange defsecTypes.Range) {
 	p.rng = defsecTypes.NewRange(filepath, p.rng.GetStartLine(), p.rng.GetEndLine(), p.rng.GetSourcePrefix(), target)
 	p.parentRange = parentRange
@@ -95,6 +125,7 @@ func (p *Property) setFileAndParentRange(target fs.FS, filepath string, parentRa
 
 func (p *Property) UnmarshalYAML(node *yaml.Node) error {
 	p.rng = defsecTypes.NewRange("", node.Line, calculateEndLine(node), "", nil)
+	p.node = node
 
 	p.comment = node.LineComment
 	return setPropertyValueFromYaml(node, &p.Inner)
@@ -121,12 +152,26 @@ func (p *Property) Metadata() defsecTypes.Metadata {
 		}
 	}
 	ref := NewCFReferenceWithValue(p.parentRange, *base, p.logicalId)
-	return defsecTypes.NewMetadata(p.Range(), ref.String())
+	return p.withDefinition(defsecTypes.NewMetadata(p.Range(), ref.String()))
 }
 
 func (p *Property) MetadataWithValue(resolvedValue *Property) defsecTypes.Metadata {
 	ref := NewCFReferenceWithValue(p.parentRange, *resolvedValue, p.logicalId)
-	return defsecTypes.NewMetadata(p.Range(), ref.String())
+	return p.withDefinition(defsecTypes.NewMetadata(p.Range(), ref.String()))
+}
+
+// withDefinition adds where the property was defined to its metadata, if it was copied from an anchor
+func (p *Property) withDefinition(metadata defsecTypes.Metadata) defsecTypes.Metadata {
+	if p.definition == nil {
+		return metadata
+	}
+	return metadata.WithDefinition(defsecTypes.NewRange(
+		p.rng.GetLocalFilename(),
+		p.definition.StartLine,
+		p.definition.EndLine,
+		p.rng.GetSourcePrefix(),
+		p.rng.GetFS(),
+	))
 }
 
 func (p *Property) isFunction() bool {
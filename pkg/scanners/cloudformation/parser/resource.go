@@ -4,6 +4,7 @@ import (
 	"io/fs"
 	"strings"
 
+	"github.com/aquasecurity/defsec/pkg/scanners/yaml/anchors"
 	defsecTypes "github.com/aquasecurity/defsec/pkg/types"
 
 	"github.com/liamg/jfather"
@@ -37,6 +38,15 @@ func (r *Resource) setId(id string) {
 	}
 }
 
+func (r *Resource) setDefinitions(definitions map[*yaml.Node]anchors.Anchor) {
+	for _, p := range r.Inner.Properties {
+		if p == nil {
+			continue
+		}
+		p.setDefinitions(definitions)
+	}
+}
+
 func (r *Resource) setFile(target fs.FS, filepath string) {
 	r.rng = defsecTypes.NewRange(filepath, r.rng.GetStartLine(), r.rng.GetEndLine(), r.rng.GetSourcePrefix(), target)
 
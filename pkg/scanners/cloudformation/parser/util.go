@@ -130,10 +130,12 @@ func createNode(node *yaml.Node, newContent []*yaml.Node) []*yaml.Node {
 }
 
 func calculateEndLine(node *yaml.Node) int {
-	if node.Content == nil {
-		return node.Line
+	// merged keys are moved to the end of their mapping, so the last node is not necessarily the last line
+	end := node.Line
+	for _, child := range node.Content {
+		if line := calculateEndLine(child); line > end {
+			end = line
+		}
 	}
-
-	return calculateEndLine(node.Content[len(node.Content)-1])
-
+	return end
 }
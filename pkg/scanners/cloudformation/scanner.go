@@ -17,35 +17,79 @@ import (
 	"github.com/aquasecurity/defsec/pkg/scanners/cloudformation/parser"
 
 	"github.com/aquasecurity/defsec/pkg/scan"
+	"github.com/aquasecurity/defsec/pkg/severity"
 
 	adapter "github.com/aquasecurity/defsec/internal/adapters/cloudformation"
 	"github.com/aquasecurity/defsec/internal/rules"
+	"github.com/aquasecurity/defsec/pkg/ignore"
 	"github.com/aquasecurity/defsec/pkg/rego"
 	_ "github.com/aquasecurity/defsec/pkg/rules"
 	"github.com/aquasecurity/defsec/pkg/scanners"
+	"github.com/aquasecurity/defsec/pkg/state"
 )
 
 var _ scanners.FSScanner = (*Scanner)(nil)
+var _ scanners.ReaderScanner = (*Scanner)(nil)
+var _ options.ResultProcessingScanner = (*Scanner)(nil)
+var _ options.StreamingScanner = (*Scanner)(nil)
+var _ options.InstrumentedScanner = (*Scanner)(nil)
+var _ options.MetricsScanner = (*Scanner)(nil)
+var _ options.ProgressReportingScanner = (*Scanner)(nil)
+var _ options.PathFilteredScanner = (*Scanner)(nil)
+var _ options.SymlinkScanner = (*Scanner)(nil)
+var _ options.CustomParsingScanner = (*Scanner)(nil)
+var _ options.ParallelScanner = (*Scanner)(nil)
+var _ options.DocumentSizeLimitedScanner = (*Scanner)(nil)
+var _ options.LenientScanner = (*Scanner)(nil)
+var _ options.SeverityFilteredScanner = (*Scanner)(nil)
+var _ options.RuleSelectingScanner = (*Scanner)(nil)
+var _ options.LoggingScanner = (*Scanner)(nil)
 
 type Scanner struct {
-	debug         debug.Logger
-	policyDirs    []string
-	policyReaders []io.Reader
-	parser        *parser.Parser
-	regoScanner   *rego.Scanner
-	skipRequired  bool
-	regoOnly      bool
-	loadEmbedded  bool
-	options       []options.ScannerOption
-	frameworks    []framework.Framework
-	spec          string
+	debug           debug.Logger
+	policyDirs      []string
+	policyReaders   []io.Reader
+	parser          *parser.Parser
+	regoScanner     *rego.Scanner
+	skipRequired    bool
+	regoOnly        bool
+	loadEmbedded    bool
+	options         []options.ScannerOption
+	frameworks      []framework.Framework
+	spec            string
+	parallelism     int
+	maxDocumentSize int64
+	lenient         bool
+	minSeverity     severity.Severity
+	ruleSelection   scan.RuleSelection
 	sync.Mutex
+	options.FileLimitHandler
+	options.SymlinkHandler
+	options.CustomParserHandler
+	options.Instrumentation
+	options.ResultHandler
 }
 
 func (s *Scanner) SetFrameworks(frameworks []framework.Framework) {
 	s.frameworks = frameworks
 }
 
+func (s *Scanner) SetMinimumSeverity(threshold severity.Severity) {
+	s.minSeverity = threshold
+}
+
+func (s *Scanner) SetRuleSelection(selection scan.RuleSelection) {
+	s.ruleSelection = selection
+}
+
+func (s *Scanner) SetMaxDocumentSize(size int64) {
+	s.maxDocumentSize = size
+}
+
+func (s *Scanner) SetLenientParsing(lenient bool) {
+	s.lenient = lenient
+}
+
 func (s *Scanner) SetSpec(spec string) {
 	s.spec = spec
 }
@@ -70,10 +114,18 @@ func (s *Scanner) SetSkipRequiredCheck(skip bool) {
 	s.skipRequired = skip
 }
 
+func (s *Scanner) SetParallelism(parallelism int) {
+	s.parallelism = parallelism
+}
+
 func (s *Scanner) SetDebugWriter(writer io.Writer) {
 	s.debug = debug.New(writer, "cloudformation", "scanner")
 }
 
+func (s *Scanner) SetLogHandler(handler debug.Handler) {
+	s.debug = debug.NewWithHandler(handler, "cloudformation", "scanner")
+}
+
 func (s *Scanner) SetPolicyDirs(dirs ...string) {
 	s.policyDirs = dirs
 }
@@ -94,12 +146,23 @@ func (s *Scanner) SetPolicyNamespaces(_ ...string)   {}
 // New creates a new Scanner
 func New(opts ...options.ScannerOption) *Scanner {
 	s := &Scanner{
-		options: opts,
+		options:     opts,
+		parallelism: 1,
 	}
 	for _, opt := range opts {
 		opt(s)
 	}
-	s.parser = parser.New(options.ParserWithSkipRequiredCheck(s.skipRequired))
+	s.parser = parser.New(
+		options.ParserWithSkipRequiredCheck(s.skipRequired),
+		options.ParserWithParallelism(s.parallelism),
+		options.ParserWithMaxDocumentSize(s.maxDocumentSize),
+		options.ParserWithLenientParsing(s.lenient),
+		s.FileLimitParserOption(),
+		s.PathFilterParserOption(),
+		s.CustomParserOption(),
+		s.InstrumentationParserOption(s.Name()),
+		options.ParserWithLogHandler(s.debug.Handler()),
+	)
 	return s
 }
 
@@ -118,44 +181,62 @@ func (s *Scanner) initRegoScanner(srcFS fs.FS) (*rego.Scanner, error) {
 	return regoScanner, nil
 }
 
+// ScanReaders scans the given documents together, keyed by their paths, see scanners.ReaderScanner
+func (s *Scanner) ScanReaders(ctx context.Context, readers map[string]io.Reader) (scan.Results, error) {
+	return scanners.ScanReaders(ctx, s, readers)
+}
+
 func (s *Scanner) ScanFS(ctx context.Context, fs fs.FS, dir string) (results scan.Results, err error) {
+	fs = s.PreprocessFS(ctx, s.SymlinkFS(fs, s.debug, s.ScanSummary()))
+
+	// each file is scanned by the worker which parsed it, as soon as it is parsed, so files are parsed and scanned
+	// concurrently when parallelism is enabled, and are released once scanned
+	var lock sync.Mutex
+	var files int
+	progress := s.TrackProgress(s.Name(), options.PhaseEvaluate)
+	if err := s.parser.ParseFSEach(ctx, fs, dir, progress, func(ctx context.Context, cfCtx *parser.FileContext) error {
+		regoScanner, err := s.initRegoScanner(fs)
+		if err != nil {
+			return err
+		}
+		path := cfCtx.Metadata().Range().GetFilename()
+		progress.Started(path)
+		fileResults, err := s.scanFileContext(ctx, regoScanner, cfCtx, fs)
+		if err != nil {
+			return err
+		}
+		fileResults = s.HandleResults(ignore.ApplyInline(fs, fileResults))
+		progress.Completed(path)
 
-	contexts, err := s.parser.ParseFS(ctx, fs, dir)
-	if err != nil {
+		lock.Lock()
+		defer lock.Unlock()
+		files++
+		results = append(results, fileResults...)
+		return nil
+	}); err != nil {
 		return nil, err
 	}
 
-	if len(contexts) == 0 {
+	s.RecordFiles(s.Name(), files)
+	if files == 0 {
 		return nil, nil
 	}
 
-	regoScanner, err := s.initRegoScanner(fs)
-	if err != nil {
-		return nil, err
-	}
-
-	for _, cfCtx := range contexts {
-		if cfCtx == nil {
-			continue
-		}
-		fileResults, err := s.scanFileContext(ctx, regoScanner, cfCtx, fs)
-		if err != nil {
-			return nil, err
-		}
-		results = append(results, fileResults...)
-	}
 	sort.Slice(results, func(i, j int) bool {
 		return results[i].Rule().AVDID < results[j].Rule().AVDID
 	})
+	s.RecordResults(s.Name(), results)
 	return results, nil
 }
 
 func (s *Scanner) ScanFile(ctx context.Context, fs fs.FS, path string) (scan.Results, error) {
+	fs = s.PreprocessFS(ctx, s.SymlinkFS(fs, s.debug, s.ScanSummary()))
 
 	cfCtx, err := s.parser.ParseFile(ctx, fs, path)
 	if err != nil {
 		return nil, err
 	}
+	s.RecordFiles(s.Name(), 1)
 
 	regoScanner, err := s.initRegoScanner(fs)
 	if err != nil {
@@ -167,20 +248,40 @@ func (s *Scanner) ScanFile(ctx context.Context, fs fs.FS, path string) (scan.Res
 		return nil, err
 	}
 	results.SetSourceAndFilesystem("", fs, false)
+	results = s.HandleResults(ignore.ApplyInline(fs, results))
 
 	sort.Slice(results, func(i, j int) bool {
 		return results[i].Rule().AVDID < results[j].Rule().AVDID
 	})
+	s.RecordResults(s.Name(), results)
 	return results, nil
 }
 
 func (s *Scanner) scanFileContext(ctx context.Context, regoScanner *rego.Scanner, cfCtx *parser.FileContext, fs fs.FS) (results scan.Results, err error) {
-	state := adapter.Adapt(*cfCtx)
-	if state == nil {
+	registeredRules := rules.FilterBySelection(
+		rules.FilterByMinimumSeverity(rules.GetFrameworkRules(s.frameworks...), s.minSeverity),
+		s.ruleSelection,
+	)
+	selection := state.NewSelection()
+	if !s.regoOnly {
+		selection = rules.ServiceSelection(registeredRules)
+	}
+	if !regoScanner.SelectServices(&selection) {
+		selection = state.Selection{}
+	}
+
+	path := cfCtx.Metadata().Range().GetFilename()
+	done := s.StartPhase(ctx, s.Name(), options.PhaseAdapt, path)
+	cfState := adapter.AdaptServices(*cfCtx, selection)
+	done()
+	if cfState == nil {
 		return nil, nil
 	}
+
+	done = s.StartPhase(ctx, s.Name(), options.PhaseEvaluate, path)
+	defer done()
 	if !s.regoOnly {
-		for _, rule := range rules.GetFrameworkRules(s.frameworks...) {
+		for _, rule := range registeredRules {
 			select {
 			case <-ctx.Done():
 				return nil, ctx.Err()
@@ -189,7 +290,7 @@ func (s *Scanner) scanFileContext(ctx context.Context, regoScanner *rego.Scanner
 			if rule.Rule().RegoPackage != "" {
 				continue
 			}
-			evalResult := rule.Evaluate(state)
+			evalResult := rule.Evaluate(cfState)
 			if len(evalResult) > 0 {
 				s.debug.Log("Found %d results for %s", len(evalResult), rule.Rule().AVDID)
 				for _, scanResult := range evalResult {
@@ -208,9 +309,9 @@ func (s *Scanner) scanFileContext(ctx context.Context, regoScanner *rego.Scanner
 		}
 	}
 	regoResults, err := regoScanner.ScanInput(ctx, rego.Input{
-		Path:     cfCtx.Metadata().Range().GetFilename(),
+		Path:     path,
 		FS:       fs,
-		Contents: state.ToRego(),
+		Contents: cfState.ToRego(),
 	})
 	if err != nil {
 		return nil, fmt.Errorf("rego scan error: %w", err)
@@ -2,9 +2,13 @@ package cloudformation
 
 import (
 	"context"
+	"fmt"
+	"strings"
+	"sync"
 	"testing"
 
 	"github.com/aquasecurity/defsec/pkg/framework"
+	"github.com/aquasecurity/defsec/pkg/scanners/limits"
 
 	"github.com/aquasecurity/defsec/pkg/scanners/options"
 
@@ -105,3 +109,224 @@ deny[res] {
 		},
 	}, actualCode.Lines)
 }
+
+func Test_ScanFSWithParallelism(t *testing.T) {
+
+	files := map[string]string{
+		"/rules/rule.rego": `package builtin.test.TEST001
+
+__rego_metadata__ := {
+	"id": "TEST001",
+	"avd_id": "AVD-TEST-0001",
+	"severity": "HIGH",
+}
+
+__rego_input__ := {
+	"combine": false,
+	"selector": [{"type": "defsec", "subtypes": [{"service": "s3", "provider": "aws"}]}],
+}
+
+deny[msg] {
+	input.aws.s3.buckets[_]
+	msg := "bucket found"
+}
+`,
+	}
+	for i := 0; i < 8; i++ {
+		files[fmt.Sprintf("/code/bucket%d.yaml", i)] = `---
+Resources:
+  S3Bucket:
+    Type: 'AWS::S3::Bucket'
+    Properties:
+      BucketName: bucket
+`
+	}
+	fs := testutil.CreateFS(t, files)
+
+	var streamed int
+	scanner := New(
+		options.ScannerWithPolicyDirs("rules"),
+		options.ScannerWithRegoOnly(true),
+		options.ScannerWithParallelism(4),
+		options.ScannerWithResultCallback(func(result scan.Result) {
+			streamed++
+		}),
+	)
+
+	results, err := scanner.ScanFS(context.TODO(), fs, "code")
+	require.NoError(t, err)
+
+	assert.Len(t, results.GetFailed(), 8)
+	assert.Equal(t, len(results), streamed)
+}
+
+func Test_ScanFSWithMaxDocumentSize(t *testing.T) {
+
+	bucket := `---
+Resources:
+  S3Bucket:
+    Type: 'AWS::S3::Bucket'
+    Properties:
+      BucketName: bucket
+`
+	fs := testutil.CreateFS(t, map[string]string{
+		"/rules/rule.rego": `package builtin.test.TEST001
+
+__rego_metadata__ := {
+	"id": "TEST001",
+	"avd_id": "AVD-TEST-0001",
+	"severity": "HIGH",
+}
+
+__rego_input__ := {
+	"combine": false,
+	"selector": [{"type": "defsec", "subtypes": [{"service": "s3", "provider": "aws"}]}],
+}
+
+deny[msg] {
+	input.aws.s3.buckets[_]
+	msg := "bucket found"
+}
+`,
+		"/code/small.yaml": bucket,
+		"/code/large.yaml": bucket + "Description: " + strings.Repeat("a", 1024) + "\n",
+	})
+
+	summary := &limits.Summary{}
+	scanner := New(
+		options.ScannerWithPolicyDirs("rules"),
+		options.ScannerWithRegoOnly(true),
+		options.ScannerWithMaxDocumentSize(512),
+		options.ScannerWithScanSummary(summary),
+	)
+
+	results, err := scanner.ScanFS(context.TODO(), fs, "code")
+	require.NoError(t, err)
+
+	require.Len(t, results.GetFailed(), 1)
+	assert.Equal(t, "code/small.yaml", results.GetFailed()[0].Range().GetFilename())
+	assert.Equal(t, []limits.SkippedFile{
+		{Path: "code/large.yaml", Reason: limits.ReasonDocumentTooLarge},
+	}, summary.Skipped())
+}
+
+func Test_ScanReportsWhereMergedPropertiesAreDefined(t *testing.T) {
+
+	fs := testutil.CreateFS(t, map[string]string{
+		"/rules/rule.rego": `package builtin.test.TEST001
+
+__rego_metadata__ := {
+	"id": "TEST001",
+	"avd_id": "AVD-TEST-0001",
+	"severity": "HIGH",
+}
+
+__rego_input__ := {
+	"combine": false,
+	"selector": [{"type": "defsec", "subtypes": [{"service": "s3", "provider": "aws"}]}],
+}
+
+deny[res] {
+	bucket := input.aws.s3.buckets[_]
+	bucket.publicaccessblock.blockpublicacls.value == false
+	res := result.new("public ACLs are not blocked", bucket.publicaccessblock.blockpublicacls)
+}
+`,
+		"/code/main.yaml": `---
+Resources:
+  FirstBucket:
+    Type: 'AWS::S3::Bucket'
+    Properties: &properties
+      BucketName: first
+      PublicAccessBlockConfiguration:
+        BlockPublicAcls: false
+  SecondBucket:
+    Type: 'AWS::S3::Bucket'
+    Properties:
+      <<: *properties
+      BucketName: second
+`,
+	})
+
+	scanner := New(
+		options.ScannerWithPolicyDirs("rules"),
+		options.ScannerWithRegoOnly(true),
+	)
+
+	results, err := scanner.ScanFS(context.TODO(), fs, "code")
+	require.NoError(t, err)
+	require.Len(t, results.GetFailed(), 2)
+
+	for _, failure := range results.GetFailed() {
+		definition := failure.Metadata().Definition()
+		switch failure.Range().GetStartLine() {
+		case 8:
+			// the anchor itself is not a copy
+			assert.Nil(t, definition)
+		case 12:
+			// the merged property is reported where it is merged, and where it was defined
+			require.NotNil(t, definition)
+			assert.Equal(t, 8, definition.GetStartLine())
+			assert.Equal(t, 8, definition.GetEndLine())
+		default:
+			t.Errorf("unexpected failure on line %d", failure.Range().GetStartLine())
+		}
+	}
+}
+
+func Test_ScanFSWithProgress(t *testing.T) {
+
+	bucket := `---
+Resources:
+  S3Bucket:
+    Type: 'AWS::S3::Bucket'
+    Properties:
+      BucketName: bucket
+`
+	fs := testutil.CreateFS(t, map[string]string{
+		"/rules/rule.rego": `package builtin.test.TEST001
+
+__rego_metadata__ := {
+	"id": "TEST001",
+	"avd_id": "AVD-TEST-0001",
+	"severity": "HIGH",
+}
+
+__rego_input__ := {
+	"combine": false,
+	"selector": [{"type": "defsec", "subtypes": [{"service": "s3", "provider": "aws"}]}],
+}
+
+deny[msg] {
+	input.aws.s3.buckets[_]
+	msg := "bucket found"
+}
+`,
+		"/code/a.yaml":     bucket,
+		"/code/b.yaml":     bucket,
+		"/code/large.yaml": bucket + "Description: " + strings.Repeat("a", 1024) + "\n",
+	})
+
+	var lock sync.Mutex
+	final := make(map[options.Phase]options.Progress)
+	scanner := New(
+		options.ScannerWithPolicyDirs("rules"),
+		options.ScannerWithRegoOnly(true),
+		options.ScannerWithMaxDocumentSize(512),
+		options.ScannerWithProgress(func(progress options.Progress) {
+			lock.Lock()
+			defer lock.Unlock()
+			assert.LessOrEqual(t, progress.Completed, progress.Total)
+			// every file is discovered before the first is started
+			assert.Equal(t, 3, progress.Total)
+			final[progress.Phase] = progress
+		}),
+	)
+
+	_, err := scanner.ScanFS(context.TODO(), fs, "code")
+	require.NoError(t, err)
+
+	// the oversized file is completed without being evaluated
+	assert.Equal(t, 3, final[options.PhaseEvaluate].Total)
+	assert.Equal(t, 3, final[options.PhaseEvaluate].Completed)
+}
@@ -8,10 +8,13 @@ import (
 	"path/filepath"
 	"strings"
 
+	"github.com/aquasecurity/defsec/pkg/concurrency"
 	"github.com/aquasecurity/defsec/pkg/debug"
 
 	"github.com/aquasecurity/defsec/pkg/detection"
 	"github.com/aquasecurity/defsec/pkg/providers/dockerfile"
+	"github.com/aquasecurity/defsec/pkg/scanners/charset"
+	"github.com/aquasecurity/defsec/pkg/scanners/limits"
 	"github.com/aquasecurity/defsec/pkg/scanners/options"
 	"github.com/moby/buildkit/frontend/dockerfile/instructions"
 	"github.com/moby/buildkit/frontend/dockerfile/parser"
@@ -20,21 +23,56 @@ import (
 var _ options.ConfigurableParser = (*Parser)(nil)
 
 type Parser struct {
-	debug        debug.Logger
-	skipRequired bool
+	debug           debug.Logger
+	skipRequired    bool
+	parallelism     int
+	fileLimits      limits.FileLimits
+	summary         *limits.Summary
+	pathFilter      options.PathFilter
+	customParsers   options.CustomParsers
+	instrumentation *options.Instrumentation
+	scannerName     string
 }
 
 func (p *Parser) SetDebugWriter(writer io.Writer) {
 	p.debug = debug.New(writer, "dockerfile", "parser")
 }
 
+func (p *Parser) SetLogHandler(handler debug.Handler) {
+	p.debug = debug.NewWithHandler(handler, "dockerfile", "parser")
+}
+
 func (p *Parser) SetSkipRequiredCheck(b bool) {
 	p.skipRequired = b
 }
 
+func (p *Parser) SetParallelism(parallelism int) {
+	p.parallelism = parallelism
+}
+
+func (p *Parser) SetFileLimits(fileLimits limits.FileLimits, summary *limits.Summary) {
+	p.fileLimits = fileLimits
+	p.summary = summary
+}
+
+func (p *Parser) SetPathFilter(filter options.PathFilter) {
+	p.pathFilter = filter
+}
+
+func (p *Parser) SetCustomParsers(parsers options.CustomParsers) {
+	p.customParsers = parsers
+}
+
+func (p *Parser) SetInstrumentation(instrumentation *options.Instrumentation, scanner string) {
+	p.instrumentation = instrumentation
+	p.scannerName = scanner
+}
+
 // New creates a new Dockerfile parser
 func New(options ...options.ParserOption) *Parser {
-	p := &Parser{}
+	p := &Parser{
+		parallelism: 1,
+	}
 	for _, option := range options {
 		option(p)
 	}
@@ -43,7 +81,10 @@ func New(options ...options.ParserOption) *Parser {
 
 func (p *Parser) ParseFS(ctx context.Context, target fs.FS, path string) (map[string]*dockerfile.Dockerfile, error) {
 
-	files := make(map[string]*dockerfile.Dockerfile)
+	tracker := limits.NewTracker(p.fileLimits, p.summary)
+	progress := p.instrumentation.TrackProgress(p.scannerName, options.PhaseParse)
+	var paths []string
+	root := path
 	if err := fs.WalkDir(target, filepath.ToSlash(path), func(path string, entry fs.DirEntry, err error) error {
 		select {
 		case <-ctx.Done():
@@ -54,21 +95,51 @@ func (p *Parser) ParseFS(ctx context.Context, target fs.FS, path string) (map[st
 			return err
 		}
 		if entry.IsDir() {
+			if p.pathFilter.SkipsDir(root, path) {
+				return fs.SkipDir
+			}
+			return nil
+		}
+		if !p.pathFilter.AllowsFile(root, path) {
 			return nil
 		}
 		if !p.Required(path) {
 			return nil
 		}
-		df, err := p.ParseFile(ctx, target, path)
-		if err != nil {
-			// TODO add debug for parse errors
+		if !tracker.AllowSize(target, path) || !tracker.AllowFile(path) {
 			return nil
 		}
-		files[path] = df
+		paths = append(paths, path)
 		return nil
 	}); err != nil {
 		return nil, err
 	}
+
+	progress.Discovered(len(paths))
+	parsed, err := concurrency.Process(ctx, paths, concurrency.Workers(p.parallelism), func(ctx context.Context, path string) (*dockerfile.Dockerfile, error) {
+		progress.Started(path)
+		done := p.instrumentation.StartPhase(ctx, p.scannerName, options.PhaseParse, path)
+		df, err := limits.Parse(ctx, tracker, path, func(ctx context.Context) (*dockerfile.Dockerfile, error) {
+			return p.ParseFile(ctx, target, path)
+		})
+		done()
+		progress.Completed(path)
+		if err != nil {
+			// TODO add debug for parse errors
+			return nil, nil
+		}
+		return df, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	files := make(map[string]*dockerfile.Dockerfile)
+	for i, df := range parsed {
+		if df != nil {
+			files[paths[i]] = df
+		}
+	}
 	return files, nil
 }
 
@@ -86,11 +157,11 @@ func (p *Parser) Required(path string) bool {
 	if p.skipRequired {
 		return true
 	}
-	return detection.IsType(path, nil, detection.FileTypeDockerfile)
+	return detection.IsType(p.customParsers.ParsedAs(path), nil, detection.FileTypeDockerfile)
 }
 
 func (p *Parser) parse(path string, r io.Reader) (*dockerfile.Dockerfile, error) {
-	parsed, err := parser.Parse(r)
+	parsed, err := parser.Parse(charset.NewReader(r))
 	if err != nil {
 		return nil, fmt.Errorf("dockerfile parse error: %w", err)
 	}
@@ -24,6 +24,8 @@ import (
 	"helm.sh/helm/v3/pkg/releaseutil"
 
 	"github.com/aquasecurity/defsec/pkg/detection"
+	"github.com/aquasecurity/defsec/pkg/scanners/charset"
+	"github.com/aquasecurity/defsec/pkg/scanners/limits"
 	"github.com/aquasecurity/defsec/pkg/scanners/options"
 )
 
@@ -41,6 +43,7 @@ type Parser struct {
 	values       []string
 	fileValues   []string
 	stringValues []string
+	tracker      *limits.Tracker
 }
 
 type ChartFile struct {
@@ -52,10 +55,20 @@ func (p *Parser) SetDebugWriter(writer io.Writer) {
 	p.debug = debug.New(writer, "helm", "parser")
 }
 
+func (p *Parser) SetLogHandler(handler debug.Handler) {
+	p.debug = debug.NewWithHandler(handler, "helm", "parser")
+}
+
 func (p *Parser) SetSkipRequiredCheck(b bool) {
 	p.skipRequired = b
 }
 
+// SetFileLimits skips files in a chart which are larger than the maximum file size. The other limits are applied to
+// each chart as a whole by the scanner.
+func (p *Parser) SetFileLimits(fileLimits limits.FileLimits, summary *limits.Summary) {
+	p.tracker = limits.NewTracker(limits.FileLimits{MaxFileSize: fileLimits.MaxFileSize}, summary)
+}
+
 func (p *Parser) SetValuesFile(s ...string) {
 	p.valuesFiles = s
 }
@@ -106,6 +119,10 @@ func (p *Parser) ParseFS(ctx context.Context, target fs.FS, path string) error {
 			return nil
 		}
 
+		if !p.tracker.AllowSize(p.workingFS, path) {
+			return nil
+		}
+
 		if !p.required(path, p.workingFS) {
 			return nil
 		}
@@ -285,6 +302,8 @@ func (p *Parser) writeBuildFiles(tempFs string) error {
 		if err != nil {
 			return err
 		}
+		// helm requires templates and values to be UTF-8
+		content = charset.Decode(content)
 		workingPath := strings.TrimPrefix(path, p.rootPath)
 		workingPath = filepath.Join(tempFs, workingPath)
 		if err := os.MkdirAll(filepath.Dir(workingPath), os.ModePerm); err != nil {
@@ -2,6 +2,7 @@ package helm
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"io/fs"
@@ -21,13 +22,24 @@ import (
 	"github.com/aquasecurity/defsec/pkg/scan"
 	"github.com/aquasecurity/defsec/pkg/scanners/helm/parser"
 	kparser "github.com/aquasecurity/defsec/pkg/scanners/kubernetes/parser"
+	"github.com/aquasecurity/defsec/pkg/scanners/limits"
 	"github.com/aquasecurity/defsec/pkg/scanners/options"
 
+	"github.com/aquasecurity/defsec/pkg/ignore"
 	"github.com/aquasecurity/defsec/pkg/rego"
 )
 
 var _ scanners.FSScanner = (*Scanner)(nil)
+var _ scanners.ReaderScanner = (*Scanner)(nil)
 var _ options.ConfigurableScanner = (*Scanner)(nil)
+var _ options.ResultProcessingScanner = (*Scanner)(nil)
+var _ options.StreamingScanner = (*Scanner)(nil)
+var _ options.LoggingScanner = (*Scanner)(nil)
+var _ options.MetricsScanner = (*Scanner)(nil)
+var _ options.ProgressReportingScanner = (*Scanner)(nil)
+var _ options.PathFilteredScanner = (*Scanner)(nil)
+var _ options.SymlinkScanner = (*Scanner)(nil)
+var _ options.LenientScanner = (*Scanner)(nil)
 
 type Scanner struct {
 	policyDirs    []string
@@ -41,6 +53,11 @@ type Scanner struct {
 	skipRequired  bool
 	frameworks    []framework.Framework
 	spec          string
+	lenient       bool
+	options.FileLimitHandler
+	options.SymlinkHandler
+	options.Instrumentation
+	options.ResultHandler
 }
 
 func (s *Scanner) SetSpec(spec string) {
@@ -50,6 +67,10 @@ func (s *Scanner) SetSpec(spec string) {
 func (s *Scanner) SetRegoOnly(bool) {
 }
 
+func (s *Scanner) SetLenientParsing(lenient bool) {
+	s.lenient = lenient
+}
+
 func (s *Scanner) SetFrameworks(frameworks []framework.Framework) {
 	s.frameworks = frameworks
 }
@@ -90,6 +111,11 @@ func (s *Scanner) SetDebugWriter(writer io.Writer) {
 	s.debug = debug.New(writer, "helm", "scanner")
 }
 
+func (s *Scanner) SetLogHandler(handler debug.Handler) {
+	s.debug = debug.NewWithHandler(handler, "helm", "scanner")
+	s.parserOptions = append(s.parserOptions, options.ParserWithLogHandler(handler))
+}
+
 func (s *Scanner) SetTraceWriter(_ io.Writer) {
 	// handled by rego later - nothing to do for now...
 }
@@ -116,9 +142,21 @@ func (s *Scanner) SetPolicyFilesystem(policyFS fs.FS) {
 
 func (s *Scanner) SetDataFilesystem(_ fs.FS) {}
 
+// ScanReaders scans the given documents together, keyed by their paths, see scanners.ReaderScanner
+func (s *Scanner) ScanReaders(ctx context.Context, readers map[string]io.Reader) (scan.Results, error) {
+	return scanners.ScanReaders(ctx, s, readers)
+}
+
 func (s *Scanner) ScanFS(ctx context.Context, target fs.FS, path string) (scan.Results, error) {
 
+	target = s.SymlinkFS(target, s.debug, s.ScanSummary())
 	var results []scan.Result
+	progress := s.TrackProgress(s.Name(), options.PhaseEvaluate)
+	root := path
+	pathFilter := s.PathFilter()
+	// each chart counts as a single file towards the maximum number of files, and is abandoned as a whole if
+	// rendering it exceeds the parse timeout
+	tracker := s.FileLimitTracker()
 	if err := fs.WalkDir(target, path, func(path string, d fs.DirEntry, err error) error {
 		select {
 		case <-ctx.Done():
@@ -131,23 +169,44 @@ func (s *Scanner) ScanFS(ctx context.Context, target fs.FS, path string) (scan.R
 		}
 
 		if d.IsDir() {
+			if pathFilter.SkipsDir(root, path) {
+				return fs.SkipDir
+			}
+			return nil
+		}
+
+		// charts are scanned as a whole, so only the chart file or archive itself is filtered
+		if !pathFilter.AllowsFile(root, path) {
 			return nil
 		}
 
 		if detection.IsArchive(path) {
-			if scanResults, err := s.getScanResults(path, ctx, target); err != nil {
+			if !tracker.AllowSize(target, path) || !tracker.AllowFile(path) {
+				return nil
+			}
+			progress.Discovered(1)
+			progress.Started(path)
+			if scanResults, err := s.getScanResults(path, ctx, target, tracker); err != nil {
 				return err
 			} else {
-				results = append(results, scanResults...)
+				results = append(results, s.HandleResults(scanResults)...)
 			}
+			progress.Completed(path)
 		}
 
 		if strings.HasSuffix(path, "Chart.yaml") {
-			if scanResults, err := s.getScanResults(filepath.Dir(path), ctx, target); err != nil {
+			chartDir := filepath.Dir(path)
+			if !tracker.AllowFile(chartDir) {
+				return nil
+			}
+			progress.Discovered(1)
+			progress.Started(chartDir)
+			if scanResults, err := s.getScanResults(chartDir, ctx, target, tracker); err != nil {
 				return err
 			} else {
-				results = append(results, scanResults...)
+				results = append(results, s.HandleResults(scanResults)...)
 			}
+			progress.Completed(chartDir)
 		}
 
 		return nil
@@ -155,21 +214,41 @@ func (s *Scanner) ScanFS(ctx context.Context, target fs.FS, path string) (scan.R
 		return nil, err
 	}
 
+	s.RecordResults(s.Name(), results)
 	return results, nil
 
 }
 
-func (s *Scanner) getScanResults(path string, ctx context.Context, target fs.FS) (results []scan.Result, err error) {
-	helmParser := parser.New(path, s.parserOptions...)
+func (s *Scanner) getScanResults(path string, ctx context.Context, target fs.FS, tracker *limits.Tracker) (results []scan.Result, err error) {
+	parserOptions := append([]options.ParserOption{s.FileLimitParserOption()}, s.parserOptions...)
+	helmParser := parser.New(path, parserOptions...)
 
-	if err := helmParser.ParseFS(ctx, target, path); err != nil {
+	type rendered struct {
+		files []parser.ChartFile
+		valid bool
+	}
+	chart, err := limits.Parse(ctx, tracker, path, func(ctx context.Context) (rendered, error) {
+		if err := helmParser.ParseFS(ctx, target, path); err != nil {
+			return rendered{}, err
+		}
+		chartFiles, err := helmParser.RenderedChartFiles()
+		if err != nil { // not valid helm, maybe some other yaml etc., abort
+			return rendered{}, nil
+		}
+		return rendered{files: chartFiles, valid: true}, nil
+	})
+	if err != nil {
+		if ctx.Err() == nil && errors.Is(err, context.DeadlineExceeded) {
+			s.debug.Warn("Rendering the chart timed out", "path", path)
+			return nil, nil
+		}
 		return nil, err
 	}
-
-	chartFiles, err := helmParser.RenderedChartFiles()
-	if err != nil { // not valid helm, maybe some other yaml etc., abort
+	if !chart.valid {
 		return nil, nil
 	}
+	chartFiles := chart.files
+	s.RecordFiles(s.Name(), len(chartFiles))
 
 	regoScanner := rego.NewScanner(types.SourceKubernetes, s.options...)
 	policyFS := target
@@ -182,7 +261,7 @@ func (s *Scanner) getScanResults(path string, ctx context.Context, target fs.FS)
 	for _, file := range chartFiles {
 		s.debug.Log("Processing rendered chart file: %s", file.TemplateFilePath)
 
-		manifests, err := kparser.New().Parse(strings.NewReader(file.ManifestContent), file.TemplateFilePath)
+		manifests, err := kparser.New(options.ParserWithLenientParsing(s.lenient)).Parse(strings.NewReader(file.ManifestContent), file.TemplateFilePath)
 		if err != nil {
 			return nil, fmt.Errorf("unmarshal yaml: %w", err)
 		}
@@ -207,6 +286,7 @@ func (s *Scanner) getScanResults(path string, ctx context.Context, target fs.FS)
 					return nil, err
 				}
 				fileResults.SetSourceAndFilesystem(helmParser.ChartSource, renderedFS, detection.IsArchive(helmParser.ChartSource))
+				fileResults = ignore.ApplyInline(nil, fileResults)
 			}
 
 			results = append(results, fileResults...)
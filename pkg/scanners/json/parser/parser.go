@@ -3,6 +3,7 @@ package parser
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"io"
 	"io/fs"
 	"path/filepath"
@@ -10,24 +11,65 @@ import (
 	"github.com/aquasecurity/defsec/pkg/debug"
 
 	"github.com/aquasecurity/defsec/pkg/detection"
+	"github.com/aquasecurity/defsec/pkg/scanners/charset"
+	"github.com/aquasecurity/defsec/pkg/scanners/lenient"
+	"github.com/aquasecurity/defsec/pkg/scanners/limits"
 	"github.com/aquasecurity/defsec/pkg/scanners/options"
 )
 
 var _ options.ConfigurableParser = (*Parser)(nil)
 
 type Parser struct {
-	debug        debug.Logger
-	skipRequired bool
+	debug           debug.Logger
+	skipRequired    bool
+	maxDocumentSize int64
+	lenient         bool
+	fileLimits      limits.FileLimits
+	summary         *limits.Summary
+	pathFilter      options.PathFilter
+	customParsers   options.CustomParsers
+	instrumentation *options.Instrumentation
+	scannerName     string
 }
 
 func (p *Parser) SetDebugWriter(writer io.Writer) {
 	p.debug = debug.New(writer, "json", "parser")
 }
 
+func (p *Parser) SetLogHandler(handler debug.Handler) {
+	p.debug = debug.NewWithHandler(handler, "json", "parser")
+}
+
 func (p *Parser) SetSkipRequiredCheck(b bool) {
 	p.skipRequired = b
 }
 
+func (p *Parser) SetFileLimits(fileLimits limits.FileLimits, summary *limits.Summary) {
+	p.fileLimits = fileLimits
+	p.summary = summary
+}
+
+func (p *Parser) SetPathFilter(filter options.PathFilter) {
+	p.pathFilter = filter
+}
+
+func (p *Parser) SetCustomParsers(parsers options.CustomParsers) {
+	p.customParsers = parsers
+}
+
+func (p *Parser) SetInstrumentation(instrumentation *options.Instrumentation, scanner string) {
+	p.instrumentation = instrumentation
+	p.scannerName = scanner
+}
+
+func (p *Parser) SetMaxDocumentSize(size int64) {
+	p.maxDocumentSize = size
+}
+
+func (p *Parser) SetLenientParsing(lenient bool) {
+	p.lenient = lenient
+}
+
 // New creates a new parser
 func New(opts ...options.ParserOption) *Parser {
 	p := &Parser{}
@@ -39,7 +81,11 @@ func New(opts ...options.ParserOption) *Parser {
 
 func (p *Parser) ParseFS(ctx context.Context, target fs.FS, path string) (map[string]interface{}, error) {
 
+	tracker := limits.NewTracker(p.fileLimits, p.summary)
+	progress := p.instrumentation.TrackProgress(p.scannerName, options.PhaseParse)
+	var paths []string
 	files := make(map[string]interface{})
+	root := path
 	if err := fs.WalkDir(target, filepath.ToSlash(path), func(path string, entry fs.DirEntry, err error) error {
 		select {
 		case <-ctx.Done():
@@ -50,21 +96,52 @@ func (p *Parser) ParseFS(ctx context.Context, target fs.FS, path string) (map[st
 			return err
 		}
 		if entry.IsDir() {
+			if p.pathFilter.SkipsDir(root, path) {
+				return fs.SkipDir
+			}
+			return nil
+		}
+		if !p.pathFilter.AllowsFile(root, path) {
 			return nil
 		}
 		if !p.Required(path) {
 			return nil
 		}
-		df, err := p.ParseFile(ctx, target, path)
-		if err != nil {
-			p.debug.Log("Parse error in '%s': %s", path, err)
+		if !tracker.AllowSize(target, path) || !tracker.AllowFile(path) {
 			return nil
 		}
-		files[path] = df
+		paths = append(paths, path)
 		return nil
 	}); err != nil {
 		return nil, err
 	}
+
+	// every file is discovered before any is parsed, so the total is known from the start
+	progress.Discovered(len(paths))
+	for _, path := range paths {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		default:
+		}
+		progress.Started(path)
+		done := p.instrumentation.StartPhase(ctx, p.scannerName, options.PhaseParse, path)
+		df, err := limits.Parse(ctx, tracker, path, func(ctx context.Context) (interface{}, error) {
+			return p.ParseFile(ctx, target, path)
+		})
+		done()
+		progress.Completed(path)
+		if errors.Is(err, limits.ErrDocumentTooLarge) {
+			// a JSON file holds a single document, so the whole file is skipped
+			p.summary.Record(path, limits.ReasonDocumentTooLarge)
+			continue
+		}
+		if err != nil {
+			p.debug.Warn("Failed to parse file", "path", path, "error", err)
+			continue
+		}
+		files[path] = df
+	}
 	return files, nil
 }
 
@@ -75,8 +152,19 @@ func (p *Parser) ParseFile(_ context.Context, fs fs.FS, path string) (interface{
 		return nil, err
 	}
 	defer func() { _ = f.Close() }()
+	reader := limits.NewDocumentReader(charset.NewReader(f), p.maxDocumentSize)
 	var target interface{}
-	if err := json.NewDecoder(f).Decode(&target); err != nil {
+	if p.lenient {
+		data, err := io.ReadAll(reader)
+		if err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(lenient.StripJSON(data), &target); err != nil {
+			return nil, err
+		}
+		return target, nil
+	}
+	if err := json.NewDecoder(reader).Decode(&target); err != nil {
 		return nil, err
 	}
 	return target, nil
@@ -86,5 +174,5 @@ func (p *Parser) Required(path string) bool {
 	if p.skipRequired {
 		return true
 	}
-	return detection.IsType(path, nil, detection.FileTypeJSON)
+	return detection.IsType(p.customParsers.ParsedAs(path), nil, detection.FileTypeJSON)
 }
@@ -2,10 +2,14 @@ package parser
 
 import (
 	"context"
+	"strings"
 	"testing"
 
 	"github.com/liamg/memoryfs"
 
+	"github.com/aquasecurity/defsec/pkg/scanners/limits"
+	"github.com/aquasecurity/defsec/pkg/scanners/options"
+
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -50,3 +54,65 @@ func Test_Parser(t *testing.T) {
 	assert.Equal(t, "c", z[2])
 
 }
+
+func Test_Parser_WithMaxDocumentSize(t *testing.T) {
+	input := `{ "x": "` + strings.Repeat("a", 4096) + `"}`
+
+	memfs := memoryfs.New()
+	err := memfs.WriteFile("something.json", []byte(input), 0644)
+	require.NoError(t, err)
+
+	_, err = New(options.ParserWithMaxDocumentSize(1024)).ParseFile(context.TODO(), memfs, "something.json")
+	assert.ErrorIs(t, err, limits.ErrDocumentTooLarge)
+
+	summary := &limits.Summary{}
+	files, err := New(
+		options.ParserWithMaxDocumentSize(1024),
+		options.ParserWithFileLimits(limits.FileLimits{}, summary),
+	).ParseFS(context.TODO(), memfs, ".")
+	require.NoError(t, err)
+	assert.Empty(t, files)
+	assert.Equal(t, []limits.SkippedFile{
+		{Path: "something.json", Reason: limits.ReasonDocumentTooLarge},
+	}, summary.Skipped())
+}
+
+func Test_Parser_WithFileLimits(t *testing.T) {
+	memfs := memoryfs.New()
+	require.NoError(t, memfs.WriteFile("a.json", []byte(`{"x": 1}`), 0644))
+	require.NoError(t, memfs.WriteFile("b.json", []byte(`{"x": "`+strings.Repeat("a", 4096)+`"}`), 0644))
+	require.NoError(t, memfs.WriteFile("c.json", []byte(`{"x": 2}`), 0644))
+	require.NoError(t, memfs.WriteFile("d.json", []byte(`{"x": 3}`), 0644))
+
+	summary := &limits.Summary{}
+	files, err := New(options.ParserWithFileLimits(limits.FileLimits{
+		MaxFileSize: 1024,
+		MaxFiles:    2,
+	}, summary)).ParseFS(context.TODO(), memfs, ".")
+	require.NoError(t, err)
+
+	assert.Len(t, files, 2)
+	assert.Contains(t, files, "a.json")
+	assert.Contains(t, files, "c.json")
+	assert.Equal(t, []limits.SkippedFile{
+		{Path: "b.json", Reason: limits.ReasonFileTooLarge},
+		{Path: "d.json", Reason: limits.ReasonTooManyFiles},
+	}, summary.Skipped())
+}
+
+func Test_Parser_WithLenientParsing(t *testing.T) {
+	input := `{
+  // comments and trailing commas are allowed in JSONC
+  "x": [1, 2,],
+}`
+
+	memfs := memoryfs.New()
+	require.NoError(t, memfs.WriteFile("something.json", []byte(input), 0644))
+
+	_, err := New().ParseFile(context.TODO(), memfs, "something.json")
+	require.Error(t, err)
+
+	data, err := New(options.ParserWithLenientParsing(true)).ParseFile(context.TODO(), memfs, "something.json")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"x": []interface{}{float64(1), float64(2)}}, data)
+}
@@ -2,12 +2,17 @@ package json
 
 import (
 	"context"
+	"fmt"
+	"sync"
 	"testing"
+	"time"
 
+	"github.com/aquasecurity/defsec/pkg/debug"
 	"github.com/aquasecurity/defsec/pkg/framework"
 	"github.com/aquasecurity/defsec/pkg/scanners/options"
 
 	"github.com/aquasecurity/defsec/pkg/scan"
+	"github.com/aquasecurity/defsec/pkg/severity"
 
 	"github.com/aquasecurity/defsec/test/testutil"
 
@@ -79,3 +84,333 @@ deny[res] {
 		Frameworks:  map[framework.Framework][]string{},
 	}, results.GetFailed()[0].Rule())
 }
+
+func Test_ScanWithResultCallback(t *testing.T) {
+
+	fs := testutil.CreateFS(t, map[string]string{
+		"/code/data.json": `{ "x": { "y": 123 }}`,
+		"/rules/rule.rego": `package builtin.json.lol
+
+__rego_metadata__ := {
+	"id": "ABC123",
+	"avd_id": "AVD-AB-0123",
+	"title": "title",
+	"short_code": "short",
+	"severity": "CRITICAL",
+	"type": "JSON Check",
+	"description": "description",
+	"recommended_actions": "actions",
+	"url": "https://example.com",
+}
+
+__rego_input__ := {
+	"combine": false,
+	"selector": [{"type": "json"}],
+}
+
+deny[res] {
+	input.x.y == 123
+	res := {
+		"msg": "oh no",
+		"startline": 1,
+		"endline": 1,
+	}
+}
+
+`,
+	})
+
+	var streamed scan.Results
+	scanner := NewScanner(
+		options.ScannerWithPolicyDirs("rules"),
+		options.ScannerWithResultCallback(func(result scan.Result) {
+			streamed = append(streamed, result)
+		}),
+		options.ScannerWithResultProcessors(func(results scan.Results) scan.Results {
+			for i := range results {
+				results[i].OverrideDescription("processed")
+			}
+			return results
+		}),
+	)
+
+	results, err := scanner.ScanFS(context.TODO(), fs, "code")
+	require.NoError(t, err)
+
+	require.Len(t, results.GetFailed(), 1)
+	require.Len(t, streamed, 1)
+	assert.Equal(t, "processed", streamed[0].Description())
+	assert.Equal(t, results.GetFailed()[0].Fingerprint(), streamed[0].Fingerprint())
+}
+
+func Test_ScanWithMinimumReportingSeverity(t *testing.T) {
+	policy := `package builtin.json.%s
+
+__rego_metadata__ := {
+	"id": "%s",
+	"avd_id": "AVD-AB-%s",
+	"severity": "%s",
+}
+
+__rego_input__ := {
+	"combine": false,
+	"selector": [{"type": "json"}],
+}
+
+deny[res] {
+	input.x.y == 123
+	res := "oh no"
+}
+`
+	fs := testutil.CreateFS(t, map[string]string{
+		"/code/data.json":  `{ "x": { "y": 123 }}`,
+		"/rules/low.rego":  fmt.Sprintf(policy, "low", "LOW001", "0001", "LOW"),
+		"/rules/high.rego": fmt.Sprintf(policy, "high", "HIGH001", "0002", "HIGH"),
+	})
+
+	var streamed scan.Results
+	scanner := NewScanner(
+		options.ScannerWithPolicyDirs("rules"),
+		options.ScannerWithMinimumReportingSeverity(severity.Medium),
+		options.ScannerWithResultCallback(func(result scan.Result) {
+			streamed = append(streamed, result)
+		}),
+	)
+
+	results, err := scanner.ScanFS(context.TODO(), fs, "code")
+	require.NoError(t, err)
+
+	// the low severity result is dropped before it is returned or streamed
+	require.Len(t, results, 1)
+	assert.Equal(t, severity.High, results[0].Severity())
+	require.Len(t, streamed, 1)
+	assert.Equal(t, severity.High, streamed[0].Severity())
+}
+
+func Test_ScanWithInstrumentation(t *testing.T) {
+	fs := testutil.CreateFS(t, map[string]string{
+		"/code/a.json": `{ "x": { "y": 123 }}`,
+		"/code/b.json": `{ "x": { "y": 456 }}`,
+		"/rules/rule.rego": `package builtin.json.lol
+
+__rego_input__ := {
+	"combine": false,
+	"selector": [{"type": "json"}],
+}
+
+deny[res] {
+	input.x.y == 123
+	res := "oh no"
+}
+`,
+	})
+
+	var lock sync.Mutex
+	var timings []options.Timing
+	scanner := NewScanner(
+		options.ScannerWithPolicyDirs("rules"),
+		options.ScannerWithProfilerLabels(true),
+		options.ScannerWithInstrumentation(func(timing options.Timing) {
+			lock.Lock()
+			defer lock.Unlock()
+			timings = append(timings, timing)
+		}),
+	)
+
+	_, err := scanner.ScanFS(context.TODO(), fs, "code")
+	require.NoError(t, err)
+
+	var phases []string
+	for _, timing := range timings {
+		assert.Equal(t, "JSON", timing.Scanner)
+		phases = append(phases, string(timing.Phase)+":"+timing.Path)
+	}
+	assert.Equal(t, []string{"parse:code/a.json", "parse:code/b.json", "parse:", "evaluate:"}, phases)
+}
+
+type recordingLogHandler struct {
+	sync.Mutex
+	records []debug.Record
+}
+
+func (h *recordingLogHandler) Enabled(level debug.Level) bool {
+	return level >= debug.LevelWarn
+}
+
+func (h *recordingLogHandler) Handle(record debug.Record) {
+	h.Lock()
+	defer h.Unlock()
+	h.records = append(h.records, record)
+}
+
+func Test_ScanWithLogHandler(t *testing.T) {
+	fs := testutil.CreateFS(t, map[string]string{
+		"/code/good.json": `{ "x": { "y": 123 }}`,
+		"/code/bad.json":  `{ "x": `,
+		"/rules/rule.rego": `package builtin.json.lol
+
+deny[res] {
+	input.x.y == 123
+	res := "oh no"
+}
+`,
+	})
+
+	handler := &recordingLogHandler{}
+	scanner := NewScanner(
+		options.ScannerWithPolicyDirs("rules"),
+		options.ScannerWithLogHandler(handler),
+	)
+
+	_, err := scanner.ScanFS(context.TODO(), fs, "code")
+	require.NoError(t, err)
+
+	var parseFailures []debug.Record
+	for _, record := range handler.records {
+		assert.GreaterOrEqual(t, record.Level, debug.LevelWarn)
+		if record.Component == "json.parser" {
+			parseFailures = append(parseFailures, record)
+		}
+	}
+	require.Len(t, parseFailures, 1)
+	assert.Equal(t, []interface{}{"path", "code/bad.json"}, parseFailures[0].Fields[:2])
+}
+
+type recordingMetrics struct {
+	sync.Mutex
+	files    int
+	results  map[string]int
+	policies []string
+}
+
+func (m *recordingMetrics) AddFilesScanned(_ string, count int) {
+	m.Lock()
+	defer m.Unlock()
+	m.files += count
+}
+
+func (m *recordingMetrics) AddResults(_ string, sev severity.Severity, status scan.Status, count int) {
+	m.Lock()
+	defer m.Unlock()
+	if status == scan.StatusFailed {
+		m.results[string(sev)] += count
+	}
+}
+
+func (m *recordingMetrics) ObservePolicyEvaluation(namespace string, _ time.Duration) {
+	m.Lock()
+	defer m.Unlock()
+	m.policies = append(m.policies, namespace)
+}
+
+func Test_ScanWithMetrics(t *testing.T) {
+	fs := testutil.CreateFS(t, map[string]string{
+		"/code/a.json": `{ "x": { "y": 123 }}`,
+		"/code/b.json": `{ "x": { "y": 123 }}`,
+		"/code/c.json": `{ "x": { "y": 456 }}`,
+		"/rules/rule.rego": `package builtin.json.lol
+
+__rego_metadata__ := {
+	"id": "ABC123",
+	"severity": "HIGH",
+}
+
+__rego_input__ := {
+	"combine": false,
+	"selector": [{"type": "json"}],
+}
+
+deny[res] {
+	input.x.y == 123
+	res := "oh no"
+}
+`,
+	})
+
+	metrics := &recordingMetrics{results: make(map[string]int)}
+	scanner := NewScanner(
+		options.ScannerWithPolicyDirs("rules"),
+		options.ScannerWithMetrics(metrics),
+	)
+
+	_, err := scanner.ScanFS(context.TODO(), fs, "code")
+	require.NoError(t, err)
+
+	assert.Equal(t, 3, metrics.files)
+	assert.Equal(t, map[string]int{"HIGH": 2}, metrics.results)
+	assert.Equal(t, []string{"builtin.json.lol"}, metrics.policies)
+}
+
+func Test_ScanWithProgress(t *testing.T) {
+	fs := testutil.CreateFS(t, map[string]string{
+		"/code/a.json": `{ "x": { "y": 123 }}`,
+		"/code/b.json": `{ "x": { "y": 456 }}`,
+		"/rules/rule.rego": `package builtin.json.lol
+
+deny[res] {
+	input.x.y == 123
+	res := "oh no"
+}
+`,
+	})
+
+	var lock sync.Mutex
+	final := make(map[options.Phase]options.Progress)
+	current := make(map[options.Phase][]string)
+	scanner := NewScanner(
+		options.ScannerWithPolicyDirs("rules"),
+		options.ScannerWithProgress(func(progress options.Progress) {
+			lock.Lock()
+			defer lock.Unlock()
+			assert.Equal(t, "JSON", progress.Scanner)
+			assert.LessOrEqual(t, progress.Completed, progress.Total)
+			// every file is discovered before the first is started
+			assert.Equal(t, 2, progress.Total)
+			final[progress.Phase] = progress
+			if progress.Current != "" {
+				current[progress.Phase] = append(current[progress.Phase], progress.Current)
+			}
+		}),
+	)
+
+	_, err := scanner.ScanFS(context.TODO(), fs, "code")
+	require.NoError(t, err)
+
+	assert.Equal(t, 2, final[options.PhaseParse].Total)
+	assert.Equal(t, 2, final[options.PhaseParse].Completed)
+	assert.Contains(t, current[options.PhaseParse], "code/a.json")
+	assert.Contains(t, current[options.PhaseParse], "code/b.json")
+	assert.Equal(t, 2, final[options.PhaseEvaluate].Total)
+	assert.Equal(t, 2, final[options.PhaseEvaluate].Completed)
+	assert.Contains(t, current[options.PhaseEvaluate], "code/a.json")
+	assert.Contains(t, current[options.PhaseEvaluate], "code/b.json")
+}
+
+func Test_ScanWithPathFilter(t *testing.T) {
+	fs := testutil.CreateFS(t, map[string]string{
+		"/code/app.json":                  `{ "x": { "y": 123 }}`,
+		"/code/vendor/lib.json":           `{ "x": { "y": 123 }}`,
+		"/code/testdata/fixture.json":     `{ "x": { "y": 123 }}`,
+		"/code/config/app.generated.json": `{ "x": { "y": 123 }}`,
+		"/rules/rule.rego": `package builtin.json.lol
+
+deny[res] {
+	input.x.y == 123
+	res := "oh no"
+}
+`,
+	})
+
+	scanner := NewScanner(
+		options.ScannerWithPolicyDirs("rules"),
+		options.ScannerWithExcludePaths("vendor", "testdata/**"),
+		options.ScannerWithIncludePaths("**/app*.json"),
+		options.ScannerWithExcludePaths("*.generated.json"),
+	)
+
+	results, err := scanner.ScanFS(context.TODO(), fs, "code")
+	require.NoError(t, err)
+
+	require.Len(t, results.GetFailed(), 1)
+	assert.Equal(t, "code/app.json", results.GetFailed()[0].Range().GetFilename())
+}
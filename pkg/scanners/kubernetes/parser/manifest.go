@@ -4,6 +4,8 @@ import (
 	"fmt"
 
 	"gopkg.in/yaml.v3"
+
+	"github.com/aquasecurity/defsec/pkg/scanners/yaml/anchors"
 )
 
 type Manifest struct {
@@ -17,7 +19,9 @@ func (m *Manifest) UnmarshalYAML(value *yaml.Node) error {
 	case "!!map":
 		node := new(ManifestNode)
 		node.Path = m.Path
-		if err := value.Decode(node); err != nil {
+		// aliases and merge keys are expanded so that rules see every field, reported where it is used
+		definitions := anchors.Expand(value)
+		if err := node.decode(value, definitions); err != nil {
 			return err
 		}
 		m.Content = node
@@ -5,6 +5,8 @@ import (
 	"strconv"
 
 	"gopkg.in/yaml.v3"
+
+	"github.com/aquasecurity/defsec/pkg/scanners/yaml/anchors"
 )
 
 type TagType string
@@ -26,6 +28,8 @@ type ManifestNode struct {
 	Value     interface{}
 	Type      TagType
 	Path      string
+	// Anchor is where the node was defined, if it was copied from an anchor by an alias or a merge key
+	Anchor *anchors.Anchor
 }
 
 func (r *ManifestNode) ToRego() interface{} {
@@ -33,7 +37,7 @@ func (r *ManifestNode) ToRego() interface{} {
 		return nil
 	}
 	switch r.Type {
-	case TagBool, TagInt, TagString, TagStr:
+	case TagBool, TagInt, TagFloat, TagString, TagStr:
 		return r.Value
 	case TagSlice:
 		var output []interface{}
@@ -43,12 +47,20 @@ func (r *ManifestNode) ToRego() interface{} {
 		return output
 	case TagMap:
 		output := make(map[string]interface{})
-		output["__defsec_metadata"] = map[string]interface{}{
+		metadata := map[string]interface{}{
 			"startline": r.StartLine,
 			"endline":   r.EndLine,
 			"filepath":  r.Path,
 			"offset":    r.Offset,
 		}
+		if r.Anchor != nil {
+			metadata["anchor"] = map[string]interface{}{
+				"name":      r.Anchor.Name,
+				"startline": r.Anchor.StartLine,
+				"endline":   r.Anchor.EndLine,
+			}
+		}
+		output["__defsec_metadata"] = metadata
 		for key, node := range r.Value.(map[string]ManifestNode) {
 			output[key] = node.ToRego()
 		}
@@ -58,10 +70,19 @@ func (r *ManifestNode) ToRego() interface{} {
 }
 
 func (r *ManifestNode) UnmarshalYAML(node *yaml.Node) error {
+	return r.decode(node, nil)
+}
+
+// decode reads a node whose aliases and merge keys have been expanded, referring each node copied from an anchor
+// to its definition
+func (r *ManifestNode) decode(node *yaml.Node, definitions map[*yaml.Node]anchors.Anchor) error {
 
 	r.StartLine = node.Line
 	r.EndLine = node.Line
 	r.Type = TagType(node.Tag)
+	if definition, ok := definitions[node]; ok {
+		r.Anchor = &definition
+	}
 
 	switch TagType(node.Tag) {
 	case TagString, TagStr:
@@ -86,9 +107,9 @@ func (r *ManifestNode) UnmarshalYAML(node *yaml.Node) error {
 		}
 		r.Value = val
 	case TagMap:
-		return r.handleMapTag(node)
+		return r.handleMapTag(node, definitions)
 	case TagSlice:
-		return r.handleSliceTag(node)
+		return r.handleSliceTag(node, definitions)
 
 	default:
 		return fmt.Errorf("node tag is not supported %s", node.Tag)
@@ -96,13 +117,13 @@ func (r *ManifestNode) UnmarshalYAML(node *yaml.Node) error {
 	return nil
 }
 
-func (r *ManifestNode) handleSliceTag(node *yaml.Node) error {
+func (r *ManifestNode) handleSliceTag(node *yaml.Node, definitions map[*yaml.Node]anchors.Anchor) error {
 	var nodes []ManifestNode
 	max := node.Line
 	for _, contentNode := range node.Content {
 		newNode := new(ManifestNode)
 		newNode.Path = r.Path
-		if err := contentNode.Decode(newNode); err != nil {
+		if err := newNode.decode(contentNode, definitions); err != nil {
 			return err
 		}
 		if newNode.EndLine > max {
@@ -115,7 +136,7 @@ func (r *ManifestNode) handleSliceTag(node *yaml.Node) error {
 	return nil
 }
 
-func (r *ManifestNode) handleMapTag(node *yaml.Node) error {
+func (r *ManifestNode) handleMapTag(node *yaml.Node, definitions map[*yaml.Node]anchors.Anchor) error {
 	output := make(map[string]ManifestNode)
 	var key string
 	max := node.Line
@@ -125,7 +146,7 @@ func (r *ManifestNode) handleMapTag(node *yaml.Node) error {
 		} else {
 			newNode := new(ManifestNode)
 			newNode.Path = r.Path
-			if err := contentNode.Decode(newNode); err != nil {
+			if err := newNode.decode(contentNode, definitions); err != nil {
 				return err
 			}
 			output[key] = *newNode
@@ -1,42 +1,93 @@
 package parser
 
 import (
+	"bufio"
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"io/fs"
 	"path/filepath"
-	"regexp"
 	"strings"
+	"unicode"
 
+	"github.com/aquasecurity/defsec/pkg/concurrency"
 	"github.com/aquasecurity/defsec/pkg/debug"
 
 	"gopkg.in/yaml.v3"
 
 	"github.com/aquasecurity/defsec/pkg/detection"
+	"github.com/aquasecurity/defsec/pkg/scanners/charset"
+	"github.com/aquasecurity/defsec/pkg/scanners/lenient"
+	"github.com/aquasecurity/defsec/pkg/scanners/limits"
 	"github.com/aquasecurity/defsec/pkg/scanners/options"
 )
 
 var _ options.ConfigurableParser = (*Parser)(nil)
 
 type Parser struct {
-	debug        debug.Logger
-	skipRequired bool
+	debug           debug.Logger
+	skipRequired    bool
+	parallelism     int
+	maxDocumentSize int64
+	lenient         bool
+	fileLimits      limits.FileLimits
+	summary         *limits.Summary
+	pathFilter      options.PathFilter
+	customParsers   options.CustomParsers
+	instrumentation *options.Instrumentation
+	scannerName     string
 }
 
 func (p *Parser) SetDebugWriter(writer io.Writer) {
 	p.debug = debug.New(writer, "kubernetes", "parser")
 }
 
+func (p *Parser) SetLogHandler(handler debug.Handler) {
+	p.debug = debug.NewWithHandler(handler, "kubernetes", "parser")
+}
+
 func (p *Parser) SetSkipRequiredCheck(b bool) {
 	p.skipRequired = b
 }
 
+func (p *Parser) SetParallelism(parallelism int) {
+	p.parallelism = parallelism
+}
+
+func (p *Parser) SetMaxDocumentSize(size int64) {
+	p.maxDocumentSize = size
+}
+
+func (p *Parser) SetLenientParsing(lenient bool) {
+	p.lenient = lenient
+}
+
+func (p *Parser) SetFileLimits(fileLimits limits.FileLimits, summary *limits.Summary) {
+	p.fileLimits = fileLimits
+	p.summary = summary
+}
+
+func (p *Parser) SetPathFilter(filter options.PathFilter) {
+	p.pathFilter = filter
+}
+
+func (p *Parser) SetCustomParsers(parsers options.CustomParsers) {
+	p.customParsers = parsers
+}
+
+func (p *Parser) SetInstrumentation(instrumentation *options.Instrumentation, scanner string) {
+	p.instrumentation = instrumentation
+	p.scannerName = scanner
+}
+
 // New creates a new K8s parser
 func New(options ...options.ParserOption) *Parser {
-	p := &Parser{}
+	p := &Parser{
+		parallelism: 1,
+	}
 	for _, option := range options {
 		option(p)
 	}
@@ -44,7 +95,10 @@ func New(options ...options.ParserOption) *Parser {
 }
 
 func (p *Parser) ParseFS(ctx context.Context, target fs.FS, path string) (map[string][]interface{}, error) {
-	files := make(map[string][]interface{})
+	tracker := limits.NewTracker(p.fileLimits, p.summary)
+	progress := p.instrumentation.TrackProgress(p.scannerName, options.PhaseParse)
+	var paths []string
+	root := path
 	if err := fs.WalkDir(target, filepath.ToSlash(path), func(path string, entry fs.DirEntry, err error) error {
 		select {
 		case <-ctx.Done():
@@ -55,21 +109,62 @@ func (p *Parser) ParseFS(ctx context.Context, target fs.FS, path string) (map[st
 			return err
 		}
 		if entry.IsDir() {
+			if p.pathFilter.SkipsDir(root, path) {
+				return fs.SkipDir
+			}
 			return nil
 		}
-		if !p.required(target, path) {
+		if !p.pathFilter.AllowsFile(root, path) {
 			return nil
 		}
-		parsed, err := p.ParseFile(ctx, target, path)
-		if err != nil {
-			p.debug.Log("Parse error in '%s': %s", path, err)
+		if !tracker.AllowSize(target, path) {
 			return nil
 		}
-		files[path] = parsed
+		paths = append(paths, path)
 		return nil
 	}); err != nil {
 		return nil, err
 	}
+
+	// files are only counted once they are known to be relevant, in walk order, so the same files are skipped each time
+	required, err := concurrency.Process(ctx, paths, concurrency.Workers(p.parallelism), func(ctx context.Context, path string) (bool, error) {
+		return p.required(target, path), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	var selected []string
+	for i, path := range paths {
+		if required[i] && tracker.AllowFile(path) {
+			selected = append(selected, path)
+		}
+	}
+
+	progress.Discovered(len(selected))
+	parsed, err := concurrency.Process(ctx, selected, concurrency.Workers(p.parallelism), func(ctx context.Context, path string) ([]interface{}, error) {
+		progress.Started(path)
+		done := p.instrumentation.StartPhase(ctx, p.scannerName, options.PhaseParse, path)
+		contents, err := limits.Parse(ctx, tracker, path, func(ctx context.Context) ([]interface{}, error) {
+			return p.ParseFile(ctx, target, path)
+		})
+		done()
+		progress.Completed(path)
+		if err != nil {
+			p.debug.Log("Parse error in '%s': %s", path, err)
+			return nil, nil
+		}
+		return contents, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	files := make(map[string][]interface{})
+	for i, contents := range parsed {
+		if contents != nil {
+			files[selected[i]] = contents
+		}
+	}
 	return files, nil
 }
 
@@ -93,46 +188,111 @@ func (p *Parser) required(fs fs.FS, path string) bool {
 	}
 	defer func() { _ = f.Close() }()
 	if data, err := io.ReadAll(f); err == nil {
-		return detection.IsType(path, bytes.NewReader(data), detection.FileTypeKubernetes)
+		data = charset.Decode(data)
+		name := p.customParsers.ParsedAs(path)
+		if p.lenient && strings.EqualFold(filepath.Ext(name), ".json") {
+			data = lenient.StripJSON(data)
+		}
+		return detection.IsType(name, bytes.NewReader(data), detection.FileTypeKubernetes)
 	}
 	return false
 }
 
 func (p *Parser) Parse(r io.Reader, path string) ([]interface{}, error) {
 
-	contents, err := io.ReadAll(r)
+	// documents are read one at a time, so only the parsed documents are held in memory rather than the whole
+	// file, and an oversized document can be skipped without skipping the rest of the file
+	reader := bufio.NewReader(charset.NewReader(r))
+	first, err := firstNonSpace(reader)
+	if errors.Is(err, io.EOF) {
+		return nil, nil
+	}
 	if err != nil {
 		return nil, err
 	}
 
-	if len(contents) == 0 {
-		return nil, nil
-	}
-
-	if strings.TrimSpace(string(contents))[0] == '{' {
-		var target interface{}
-		if err := json.Unmarshal(contents, &target); err != nil {
+	if first == '{' {
+		target, err := p.decodeJSON(limits.NewDocumentReader(reader, p.maxDocumentSize))
+		if errors.Is(err, limits.ErrDocumentTooLarge) {
+			p.debug.Log("Skipping oversized document in %s", path)
+			p.summary.Record(path, limits.ReasonDocumentTooLarge)
+			return nil, nil
+		}
+		if err != nil {
 			return nil, err
 		}
 		return []interface{}{target}, nil
 	}
 
 	var results []interface{}
-
-	re := regexp.MustCompile(`(?m:^---\r?\n)`)
-	pos := 0
-	for _, partial := range re.Split(string(contents), -1) {
+	documents := limits.NewYAMLDocuments(reader, p.maxDocumentSize)
+	for {
+		document, err := documents.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if errors.Is(err, limits.ErrDocumentTooLarge) {
+			p.debug.Log("Skipping oversized document in %s", path)
+			p.summary.Record(path, limits.ReasonDocumentTooLarge)
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
 		var result Manifest
 		result.Path = path
-		if err := yaml.Unmarshal([]byte(partial), &result); err != nil {
+		if err := p.unmarshal(document, &result); err != nil {
 			return nil, fmt.Errorf("unmarshal yaml: %w", err)
 		}
 		if result.Content != nil {
-			result.Content.Offset = pos
+			result.Content.Offset = documents.Offset()
 			results = append(results, result.ToRego())
 		}
-		pos += len(strings.Split(partial, "\n"))
 	}
 
 	return results, nil
 }
+
+// firstNonSpace returns the first character of a reader which is not whitespace, without consuming it
+func firstNonSpace(reader *bufio.Reader) (byte, error) {
+	for n := 1; ; n++ {
+		peeked, err := reader.Peek(n)
+		if len(peeked) == n && !unicode.IsSpace(rune(peeked[n-1])) {
+			return peeked[n-1], nil
+		}
+		if err != nil {
+			return 0, err
+		}
+	}
+}
+
+// decodeJSON decodes a json manifest, stripping comments and trailing commas first if parsing is lenient
+func (p *Parser) decodeJSON(r io.Reader) (interface{}, error) {
+	var target interface{}
+	if !p.lenient {
+		err := json.NewDecoder(r).Decode(&target)
+		return target, err
+	}
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	err = json.Unmarshal(lenient.StripJSON(data), &target)
+	return target, err
+}
+
+// unmarshal decodes a yaml document, normalising its values first if parsing is lenient
+func (p *Parser) unmarshal(data []byte, target interface{}) error {
+	if !p.lenient {
+		return yaml.Unmarshal(data, target)
+	}
+	var node yaml.Node
+	if err := yaml.Unmarshal(data, &node); err != nil {
+		return err
+	}
+	if node.IsZero() {
+		return nil
+	}
+	lenient.NormaliseYAML(&node)
+	return node.Decode(target)
+}
@@ -4,7 +4,6 @@ import (
 	"context"
 	"io"
 	"io/fs"
-	"path/filepath"
 	"sync"
 
 	"github.com/aquasecurity/defsec/pkg/types"
@@ -13,10 +12,10 @@ import (
 
 	"github.com/aquasecurity/defsec/pkg/debug"
 
+	"github.com/aquasecurity/defsec/pkg/scanners/cache"
 	"github.com/aquasecurity/defsec/pkg/scanners/options"
 
-	"github.com/liamg/memoryfs"
-
+	"github.com/aquasecurity/defsec/pkg/ignore"
 	"github.com/aquasecurity/defsec/pkg/rego"
 	"github.com/aquasecurity/defsec/pkg/scanners/kubernetes/parser"
 
@@ -26,7 +25,21 @@ import (
 )
 
 var _ scanners.FSScanner = (*Scanner)(nil)
+var _ scanners.ReaderScanner = (*Scanner)(nil)
 var _ options.ConfigurableScanner = (*Scanner)(nil)
+var _ options.ResultProcessingScanner = (*Scanner)(nil)
+var _ options.StreamingScanner = (*Scanner)(nil)
+var _ options.InstrumentedScanner = (*Scanner)(nil)
+var _ options.MetricsScanner = (*Scanner)(nil)
+var _ options.ProgressReportingScanner = (*Scanner)(nil)
+var _ options.PathFilteredScanner = (*Scanner)(nil)
+var _ options.SymlinkScanner = (*Scanner)(nil)
+var _ options.CustomParsingScanner = (*Scanner)(nil)
+var _ cache.CachingScanner = (*Scanner)(nil)
+var _ options.ParallelScanner = (*Scanner)(nil)
+var _ options.DocumentSizeLimitedScanner = (*Scanner)(nil)
+var _ options.LenientScanner = (*Scanner)(nil)
+var _ options.LoggingScanner = (*Scanner)(nil)
 
 type Scanner struct {
 	debug         debug.Logger
@@ -37,9 +50,20 @@ type Scanner struct {
 	parser        *parser.Parser
 	skipRequired  bool
 	sync.Mutex
-	loadEmbedded bool
-	frameworks   []framework.Framework
-	spec         string
+	loadEmbedded    bool
+	frameworks      []framework.Framework
+	spec            string
+	parallelism     int
+	maxDocumentSize int64
+	lenient         bool
+	cache           *cache.Cache
+	traceWriter     io.Writer
+	tracePerResult  bool
+	options.FileLimitHandler
+	options.SymlinkHandler
+	options.CustomParserHandler
+	options.Instrumentation
+	options.ResultHandler
 }
 
 func (s *Scanner) SetSpec(spec string) {
@@ -64,14 +88,24 @@ func (s *Scanner) SetSkipRequiredCheck(skip bool) {
 	s.skipRequired = skip
 }
 
+func (s *Scanner) SetParallelism(parallelism int) {
+	s.parallelism = parallelism
+}
+
 func (s *Scanner) SetDebugWriter(writer io.Writer) {
 	s.debug = debug.New(writer, "kubernetes", "scanner")
 }
 
-func (s *Scanner) SetTraceWriter(_ io.Writer) {
+func (s *Scanner) SetLogHandler(handler debug.Handler) {
+	s.debug = debug.NewWithHandler(handler, "kubernetes", "scanner")
+}
+
+func (s *Scanner) SetTraceWriter(writer io.Writer) {
+	s.traceWriter = writer
 }
 
-func (s *Scanner) SetPerResultTracingEnabled(_ bool) {
+func (s *Scanner) SetPerResultTracingEnabled(enabled bool) {
+	s.tracePerResult = enabled
 }
 
 func (s *Scanner) SetPolicyDirs(dirs ...string) {
@@ -92,15 +126,38 @@ func (s *Scanner) SetDataFilesystem(_ fs.FS) {
 
 func NewScanner(opts ...options.ScannerOption) *Scanner {
 	s := &Scanner{
-		options: opts,
+		options:     opts,
+		parallelism: 1,
 	}
 	for _, opt := range opts {
 		opt(s)
 	}
-	s.parser = parser.New(options.ParserWithSkipRequiredCheck(s.skipRequired))
+	s.parser = parser.New(
+		options.ParserWithSkipRequiredCheck(s.skipRequired),
+		options.ParserWithParallelism(s.parallelism),
+		options.ParserWithMaxDocumentSize(s.maxDocumentSize),
+		options.ParserWithLenientParsing(s.lenient),
+		s.FileLimitParserOption(),
+		s.PathFilterParserOption(),
+		s.CustomParserOption(),
+		s.InstrumentationParserOption(s.Name()),
+		options.ParserWithLogHandler(s.debug.Handler()),
+	)
 	return s
 }
 
+func (s *Scanner) SetMaxDocumentSize(size int64) {
+	s.maxDocumentSize = size
+}
+
+func (s *Scanner) SetLenientParsing(lenient bool) {
+	s.lenient = lenient
+}
+
+func (s *Scanner) SetScanCache(c *cache.Cache) {
+	s.cache = c
+}
+
 func (s *Scanner) Name() string {
 	return "Kubernetes"
 }
@@ -113,6 +170,8 @@ func (s *Scanner) initRegoScanner(srcFS fs.FS) (*rego.Scanner, error) {
 	}
 	regoScanner := rego.NewScanner(types.SourceKubernetes, s.options...)
 	regoScanner.SetParentDebugLogger(s.debug)
+	regoScanner.SetTraceWriter(s.traceWriter)
+	regoScanner.SetPerResultTracingEnabled(s.tracePerResult)
 	if err := regoScanner.LoadPolicies(s.loadEmbedded, srcFS, s.policyDirs, s.policyReaders); err != nil {
 		return nil, err
 	}
@@ -121,27 +180,25 @@ func (s *Scanner) initRegoScanner(srcFS fs.FS) (*rego.Scanner, error) {
 }
 
 func (s *Scanner) ScanReader(ctx context.Context, filename string, reader io.Reader) (scan.Results, error) {
-	memfs := memoryfs.New()
-	if err := memfs.MkdirAll(filepath.Base(filename), 0o700); err != nil {
-		return nil, err
-	}
-	data, err := io.ReadAll(reader)
-	if err != nil {
-		return nil, err
-	}
-	if err := memfs.WriteFile(filename, data, 0o644); err != nil {
-		return nil, err
-	}
-	return s.ScanFS(ctx, memfs, ".")
+	return s.ScanReaders(ctx, map[string]io.Reader{filename: reader})
+}
+
+// ScanReaders scans the given documents together, keyed by their paths, see scanners.ReaderScanner
+func (s *Scanner) ScanReaders(ctx context.Context, readers map[string]io.Reader) (scan.Results, error) {
+	return scanners.ScanReaders(ctx, s, readers)
 }
 
 func (s *Scanner) ScanFS(ctx context.Context, target fs.FS, dir string) (scan.Results, error) {
+	target = s.PreprocessFS(ctx, s.SymlinkFS(target, s.debug, s.ScanSummary()))
 
+	done := s.StartPhase(ctx, s.Name(), options.PhaseParse, "")
 	k8sFilesets, err := s.parser.ParseFS(ctx, target, dir)
+	done()
 	if err != nil {
 		return nil, err
 	}
 
+	s.RecordFiles(s.Name(), len(k8sFilesets))
 	if len(k8sFilesets) == 0 {
 		return nil, nil
 	}
@@ -163,10 +220,17 @@ func (s *Scanner) ScanFS(ctx context.Context, target fs.FS, dir string) (scan.Re
 	}
 
 	s.debug.Log("Scanning %d files...", len(inputs))
-	results, err := regoScanner.ScanInput(ctx, inputs...)
+	progress := s.TrackProgress(s.Name(), options.PhaseEvaluate)
+	done = s.StartPhase(ctx, s.Name(), options.PhaseEvaluate, "")
+	// results are handled a file at a time, so they are streamed to any callbacks as each file is scanned
+	results, err := s.cache.ScanFiles(ctx, cache.ParsingKey(s.Name(), s.lenient, s.CustomParsers()), regoScanner, target, inputs, progress, func(_ string, results scan.Results) scan.Results {
+		results.SetSourceAndFilesystem("", target, false)
+		return s.HandleResults(ignore.ApplyInline(target, results))
+	})
+	done()
 	if err != nil {
 		return nil, err
 	}
-	results.SetSourceAndFilesystem("", target, false)
+	s.RecordResults(s.Name(), results)
 	return results, nil
 }
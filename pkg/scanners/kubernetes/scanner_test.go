@@ -1,12 +1,14 @@
 package kubernetes
 
 import (
+	"bytes"
 	"context"
 	"os"
 	"strings"
 	"testing"
 
 	"github.com/aquasecurity/defsec/pkg/framework"
+	"github.com/aquasecurity/defsec/pkg/scanners/limits"
 	"github.com/aquasecurity/defsec/pkg/scanners/options"
 
 	"github.com/aquasecurity/defsec/pkg/scan"
@@ -441,6 +443,36 @@ spec:
 	assert.Equal(t, 1, len(results.GetFailed()))
 }
 
+func Test_FileScanWithTracing(t *testing.T) {
+
+	traceBuffer := bytes.NewBuffer([]byte{})
+	results, err := NewScanner(
+		options.ScannerWithTrace(traceBuffer),
+		options.ScannerWithPerResultTracing(true),
+		options.ScannerWithPolicyReader(strings.NewReader(`package defsec
+
+deny[msg] {
+  input.kind == "Pod"
+  msg = "fail"
+}
+`))).ScanReader(context.TODO(), "k8s.yaml", strings.NewReader(`
+apiVersion: v1
+kind: Pod
+metadata: 
+  name: hello-cpu-limit
+spec: 
+  containers: 
+  - command: ["sh", "-c", "echo 'Hello' && sleep 1h"]
+    image: busybox
+    name: hello
+`))
+	require.NoError(t, err)
+
+	require.Len(t, results.GetFailed(), 1)
+	assert.NotEmpty(t, results.GetFailed()[0].Traces())
+	assert.Greater(t, traceBuffer.Len(), 0)
+}
+
 func Test_FileScanJSON(t *testing.T) {
 
 	results, err := NewScanner(options.ScannerWithPolicyReader(strings.NewReader(`package defsec
@@ -615,6 +647,100 @@ spec:
 	assert.Equal(t, "k8s.yaml", firstResult.Metadata().Range().GetFilename())
 }
 
+func Test_FileScanWithMergedAnchor(t *testing.T) {
+
+	results, err := NewScanner(
+		options.ScannerWithPolicyReader(strings.NewReader(`package defsec
+
+deny[res] {
+  container := input.spec.containers[_]
+  container.securityContext.privileged
+  res := result.new(sprintf("Container '%s' is privileged", [container.name]), container.securityContext)
+}
+`))).ScanReader(
+		context.TODO(),
+		"k8s.yaml",
+		strings.NewReader(`
+apiVersion: v1
+kind: Pod
+metadata:
+  name: hello
+spec:
+  containers:
+  - &base
+    name: first
+    image: busybox
+    securityContext:
+      privileged: true
+  - <<: *base
+    name: second
+`))
+	require.NoError(t, err)
+
+	failed := results.GetFailed()
+	require.Len(t, failed, 2)
+
+	for _, result := range failed {
+		metadata := result.Metadata()
+		switch result.Description() {
+		case "Container 'first' is privileged":
+			assert.Equal(t, 12, metadata.Range().GetStartLine())
+			assert.Nil(t, metadata.Definition())
+		case "Container 'second' is privileged":
+			assert.Equal(t, 13, metadata.Range().GetStartLine())
+			assert.Equal(t, 13, metadata.Range().GetEndLine())
+			require.NotNil(t, metadata.Definition())
+			assert.Equal(t, 12, metadata.Definition().GetStartLine())
+			assert.Equal(t, "k8s.yaml", metadata.Definition().GetFilename())
+		default:
+			t.Errorf("unexpected result: %s", result.Description())
+		}
+	}
+}
+
+func Test_FileScanWithLenientParsing(t *testing.T) {
+
+	policy := `package defsec
+
+deny[res] {
+  container := input.spec.containers[_]
+  container.securityContext.privileged == true
+  res := result.new("privileged", container.securityContext)
+}
+`
+	manifest := `
+apiVersion: v1
+kind: Pod
+metadata:
+  name: hello
+spec:
+  containers:
+  - name: hello
+    image: busybox
+    securityContext:
+      privileged: yes
+  volumes:
+  - name: config
+    configMap:
+      name: config
+      defaultMode: 0o644
+`
+
+	results, err := NewScanner(
+		options.ScannerWithPolicyReader(strings.NewReader(policy)),
+	).ScanReader(context.TODO(), "k8s.yaml", strings.NewReader(manifest))
+	require.NoError(t, err)
+	assert.Empty(t, results.GetFailed())
+
+	results, err = NewScanner(
+		options.ScannerWithPolicyReader(strings.NewReader(policy)),
+		options.ScannerWithLenientParsing(true),
+	).ScanReader(context.TODO(), "k8s.yaml", strings.NewReader(manifest))
+	require.NoError(t, err)
+	require.Len(t, results.GetFailed(), 1)
+	assert.Equal(t, 11, results.GetFailed()[0].Metadata().Range().GetStartLine())
+}
+
 // TODO(simar): Uncomment once all k8s policies have subtype selector added
 /*
 func Test_checkPolicyIsApplicable(t *testing.T) {
@@ -735,3 +861,82 @@ spec:
 	assert.Equal(t, "Process can elevate its own privileges", failure.Summary)
 }
 */
+
+func Test_FileScanWithMaxDocumentSize(t *testing.T) {
+	file := `apiVersion: v1
+kind: Pod
+metadata:
+  name: hello1-cpu-limit
+spec:
+  containers:
+  - command: ["sh", "-c", "echo 'Hello1' && sleep 1h"]
+    image: busybox
+    name: hello1
+---
+apiVersion: v1
+kind: Pod
+metadata:
+  name: hello2-cpu-limit
+  annotations:
+    padding: ` + strings.Repeat("a", 1024) + `
+spec:
+  containers:
+  - command: ["sh", "-c", "echo 'Hello2' && sleep 1h"]
+    image: busybox
+    name: hello2
+`
+
+	summary := &limits.Summary{}
+	results, err := NewScanner(
+		options.ScannerWithEmbeddedPolicies(true),
+		options.ScannerWithMaxDocumentSize(512),
+		options.ScannerWithScanSummary(summary),
+	).ScanReader(context.TODO(), "k8s.yaml", strings.NewReader(file))
+	require.NoError(t, err)
+
+	// only the first manifest, which ends on line 9, is scanned
+	require.Greater(t, len(results.GetFailed()), 0)
+	for _, failure := range results.GetFailed() {
+		assert.LessOrEqual(t, failure.Range().GetEndLine(), 9)
+	}
+	assert.Equal(t, []limits.SkippedFile{
+		{Path: "k8s.yaml", Reason: limits.ReasonDocumentTooLarge},
+	}, summary.Skipped())
+}
+
+func Test_FileScanLocatesManifestsAfterOversizedDocument(t *testing.T) {
+	file := `apiVersion: v1
+kind: Pod
+metadata:
+  name: hello1-cpu-limit
+  annotations:
+    padding: ` + strings.Repeat("a", 1024) + `
+spec:
+  containers:
+  - command: ["sh", "-c", "echo 'Hello1' && sleep 1h"]
+    image: busybox
+    name: hello1
+---
+apiVersion: v1
+kind: Pod
+metadata:
+  name: hello2-cpu-limit
+spec:
+  containers:
+  - command: ["sh", "-c", "echo 'Hello2' && sleep 1h"]
+    image: busybox
+    name: hello2
+`
+
+	results, err := NewScanner(
+		options.ScannerWithEmbeddedPolicies(true),
+		options.ScannerWithMaxDocumentSize(512),
+	).ScanReader(context.TODO(), "k8s.yaml", strings.NewReader(file))
+	require.NoError(t, err)
+
+	// the second manifest starts on line 12, after the lines of the skipped manifest
+	require.Greater(t, len(results.GetFailed()), 0)
+	for _, failure := range results.GetFailed() {
+		assert.GreaterOrEqual(t, failure.Range().GetStartLine(), 12)
+	}
+}
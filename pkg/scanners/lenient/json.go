@@ -0,0 +1,76 @@
+package lenient
+
+// StripJSON removes the comments and trailing commas which JSON with comments (JSONC) allows, e.g. in tsconfig.json
+// or the configuration of a CDK app, so that it can be read by a strict JSON parser. They are replaced by
+// whitespace, so the lines and columns of everything else are unchanged. Input which is already valid JSON is
+// returned unchanged.
+func StripJSON(data []byte) []byte {
+	out := make([]byte, len(data))
+	copy(out, data)
+
+	var inString, escaped bool
+	// comma is the position of the last comma, until something other than whitespace or a comment follows it
+	comma := -1
+
+	for i := 0; i < len(out); i++ {
+		c := out[i]
+		if inString {
+			switch {
+			case escaped:
+				escaped = false
+			case c == '\\':
+				escaped = true
+			case c == '"':
+				inString = false
+			}
+			continue
+		}
+
+		switch c {
+		case '"':
+			inString = true
+			comma = -1
+		case ',':
+			comma = i
+		case '}', ']':
+			if comma >= 0 {
+				out[comma] = ' '
+			}
+			comma = -1
+		case ' ', '\t', '\r', '\n':
+		case '/':
+			if i+1 < len(out) && out[i+1] == '/' {
+				i = blank(out, i, "\n") - 1
+				continue
+			}
+			if i+1 < len(out) && out[i+1] == '*' {
+				i = blank(out, i, "*/") - 1
+				continue
+			}
+			comma = -1
+		default:
+			comma = -1
+		}
+	}
+	return out
+}
+
+// blank replaces a comment starting at start with spaces, up to and including the given terminator. Line breaks
+// are kept, and the terminator is kept if it is a line break. It returns the position after the comment.
+func blank(data []byte, start int, terminator string) int {
+	for i := start; i < len(data); i++ {
+		if data[i] == terminator[0] && i+len(terminator) <= len(data) && string(data[i:i+len(terminator)]) == terminator {
+			if terminator == "\n" {
+				return i
+			}
+			for j := i; j < i+len(terminator); j++ {
+				data[j] = ' '
+			}
+			return i + len(terminator)
+		}
+		if data[i] != '\n' && data[i] != '\r' {
+			data[i] = ' '
+		}
+	}
+	return len(data)
+}
@@ -0,0 +1,82 @@
+package lenient
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_StripJSON(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{
+			name:     "valid json is unchanged",
+			input:    `{"a": [1, 2], "b": "c"}`,
+			expected: `{"a": [1, 2], "b": "c"}`,
+		},
+		{
+			name:     "line comments",
+			input:    "{\n  // comment\n  \"a\": 1 // trailing\n}",
+			expected: "{\n            \n  \"a\": 1            \n}",
+		},
+		{
+			name:     "block comments keep their lines",
+			input:    "{/* one\ntwo */\"a\": 1}",
+			expected: "{      \n      \"a\": 1}",
+		},
+		{
+			name:     "trailing commas",
+			input:    "{\"a\": [1, 2,], \"b\": 3,\n}",
+			expected: "{\"a\": [1, 2 ], \"b\": 3 \n}",
+		},
+		{
+			name:     "trailing comma before a comment",
+			input:    "[1, // last\n]",
+			expected: "[1         \n]",
+		},
+		{
+			name:     "comments and commas in strings are kept",
+			input:    `{"url": "https://example.com/*x*/", "list": "a,]"}`,
+			expected: `{"url": "https://example.com/*x*/", "list": "a,]"}`,
+		},
+		{
+			name:     "escaped quotes",
+			input:    `{"a": "\"// not a comment", "b": 1,}`,
+			expected: `{"a": "\"// not a comment", "b": 1 }`,
+		},
+		{
+			name:     "unterminated block comment",
+			input:    "{} /* comment",
+			expected: "{}           ",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, string(StripJSON([]byte(test.input))))
+		})
+	}
+}
+
+func Test_StripJSONCanBeDecoded(t *testing.T) {
+	input := `{
+  // compiler options
+  "compilerOptions": {
+    "strict": true, /* always */
+    "lib": ["es2020",],
+  },
+}`
+	var target map[string]interface{}
+	require.NoError(t, json.Unmarshal(StripJSON([]byte(input)), &target))
+	assert.Equal(t, map[string]interface{}{
+		"compilerOptions": map[string]interface{}{
+			"strict": true,
+			"lib":    []interface{}{"es2020"},
+		},
+	}, target)
+}
@@ -0,0 +1,70 @@
+package lenient
+
+import (
+	"math"
+	"strconv"
+
+	"gopkg.in/yaml.v3"
+)
+
+// yaml11Booleans are the plain scalars which YAML 1.1 reads as booleans, but YAML 1.2 reads as strings
+var yaml11Booleans = map[string]bool{
+	"y": true, "Y": true, "yes": true, "Yes": true, "YES": true, "on": true, "On": true, "ON": true,
+	"n": false, "N": false, "no": false, "No": false, "NO": false, "off": false, "Off": false, "OFF": false,
+}
+
+// NormaliseYAML rewrites the plain scalar values of a parsed document so that they are read the way tools which
+// consume yaml (including Kubernetes) read them, whatever parses them afterwards:
+//
+//   - yes/no/on/off and their variants are booleans, as in YAML 1.1
+//   - integers in any notation (e.g. 0644, 0o644, 0x1a4 or 1_000) are given in decimal, with a leading zero
+//     meaning octal as in YAML 1.1
+//   - finite floats in any notation (e.g. .5 or 1_000.0) are given in the notation Go parses
+//   - timestamps are strings
+//
+// Mapping keys and quoted or explicitly tagged values are left as they are, so e.g. the `on` key of a GitHub
+// workflow is still a string.
+func NormaliseYAML(node *yaml.Node) {
+	switch node.Kind {
+	case yaml.DocumentNode, yaml.SequenceNode:
+		for _, child := range node.Content {
+			NormaliseYAML(child)
+		}
+	case yaml.MappingNode:
+		for i := 1; i < len(node.Content); i += 2 {
+			NormaliseYAML(node.Content[i])
+		}
+	case yaml.ScalarNode:
+		if node.Style == 0 {
+			normaliseScalar(node)
+		}
+	}
+}
+
+func normaliseScalar(node *yaml.Node) {
+	switch node.Tag {
+	case "!!str":
+		if value, ok := yaml11Booleans[node.Value]; ok {
+			node.Tag = "!!bool"
+			node.Value = strconv.FormatBool(value)
+		}
+	case "!!int":
+		var value int64
+		if err := node.Decode(&value); err == nil {
+			node.Value = strconv.FormatInt(value, 10)
+			return
+		}
+		var unsigned uint64
+		if err := node.Decode(&unsigned); err == nil {
+			node.Value = strconv.FormatUint(unsigned, 10)
+		}
+	case "!!float":
+		// infinity and NaN are left in yaml notation, as the yaml decoder cannot read them in Go's
+		var value float64
+		if err := node.Decode(&value); err == nil && !math.IsNaN(value) && !math.IsInf(value, 0) {
+			node.Value = strconv.FormatFloat(value, 'g', -1, 64)
+		}
+	case "!!timestamp":
+		node.Tag = "!!str"
+	}
+}
@@ -0,0 +1,56 @@
+package lenient
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+func Test_NormaliseYAML(t *testing.T) {
+	source := `
+on: push
+enabled: yes
+disabled: Off
+quoted: "yes"
+tagged: !!str no
+mode: 0644
+octal: 0o644
+hex: 0x1A
+large: 1_000
+float: 1_000.5
+infinite: .inf
+date: 2023-01-02
+list: [on, "off", 7]
+`
+	var root yaml.Node
+	require.NoError(t, yaml.Unmarshal([]byte(source), &root))
+	NormaliseYAML(&root)
+
+	var target map[string]interface{}
+	require.NoError(t, root.Decode(&target))
+
+	assert.Equal(t, "push", target["on"])
+	assert.Equal(t, true, target["enabled"])
+	assert.Equal(t, false, target["disabled"])
+	assert.Equal(t, "yes", target["quoted"])
+	assert.Equal(t, "no", target["tagged"])
+	assert.Equal(t, 420, target["mode"])
+	assert.Equal(t, 420, target["octal"])
+	assert.Equal(t, 26, target["hex"])
+	assert.Equal(t, 1000, target["large"])
+	assert.Equal(t, 1000.5, target["float"])
+	assert.Equal(t, "2023-01-02", target["date"])
+	assert.Equal(t, []interface{}{true, "off", 7}, target["list"])
+
+	values := make(map[string]string)
+	mapping := root.Content[0]
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		values[mapping.Content[i].Value] = mapping.Content[i+1].Value
+	}
+	assert.Equal(t, "420", values["mode"])
+	assert.Equal(t, "26", values["hex"])
+	assert.Equal(t, "1000.5", values["float"])
+	assert.Equal(t, ".inf", values["infinite"])
+}
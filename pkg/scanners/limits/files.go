@@ -0,0 +1,149 @@
+package limits
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"path/filepath"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// FileLimits restrict the files read by a scan, to protect long-running services from pathological inputs.
+// A zero value for any limit means it is not applied.
+type FileLimits struct {
+	// MaxFileSize is the size in bytes above which files are skipped
+	MaxFileSize int64
+	// MaxFiles is the number of files after which any further files are skipped
+	MaxFiles int
+	// ParseTimeout is the time after which parsing of a single file is abandoned
+	ParseTimeout time.Duration
+}
+
+type SkipReason string
+
+const (
+	ReasonFileTooLarge     SkipReason = "file exceeds the maximum size"
+	ReasonDocumentTooLarge SkipReason = "document exceeds the maximum size"
+	ReasonTooManyFiles     SkipReason = "maximum number of files reached"
+	ReasonParseTimeout     SkipReason = "parsing timed out"
+	ReasonSymlinkLoop      SkipReason = "symlink loops back to a directory containing it"
+	ReasonOutsideRoot      SkipReason = "symlink points outside the scanned directory"
+)
+
+// SkippedFile is a file which was not scanned because it exceeded one of the limits, or was excluded by the symlink
+// options
+type SkippedFile struct {
+	Path   string
+	Reason SkipReason
+}
+
+// Summary records the files skipped because they exceeded a limit, or were excluded by the symlink options. It is safe for concurrent use, and can be
+// shared between several scans.
+type Summary struct {
+	mu      sync.Mutex
+	skipped []SkippedFile
+}
+
+// Skipped returns the files skipped so far, ordered by path
+func (s *Summary) Skipped() []SkippedFile {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	skipped := make([]SkippedFile, len(s.skipped))
+	copy(skipped, s.skipped)
+	sort.SliceStable(skipped, func(i, j int) bool {
+		return skipped[i].Path < skipped[j].Path
+	})
+	return skipped
+}
+
+// Record records a file as skipped. It does nothing if the summary is nil.
+func (s *Summary) Record(path string, reason SkipReason) {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.skipped = append(s.skipped, SkippedFile{
+		Path:   path,
+		Reason: reason,
+	})
+}
+
+// Tracker applies FileLimits to the files of a single scan. A nil Tracker applies no limits.
+type Tracker struct {
+	limits  FileLimits
+	summary *Summary
+	files   int64
+}
+
+// NewTracker creates a tracker which records skipped files in the given summary, which may be nil
+func NewTracker(limits FileLimits, summary *Summary) *Tracker {
+	return &Tracker{
+		limits:  limits,
+		summary: summary,
+	}
+}
+
+// AllowSize reports whether a file is within the maximum file size, recording it as skipped if not. It should be
+// called before the file is read.
+func (t *Tracker) AllowSize(fsys fs.FS, path string) bool {
+	if t == nil || t.limits.MaxFileSize <= 0 {
+		return true
+	}
+	info, err := fs.Stat(fsys, filepath.ToSlash(path))
+	if err != nil || info.Size() <= t.limits.MaxFileSize {
+		return true
+	}
+	t.summary.Record(path, ReasonFileTooLarge)
+	return false
+}
+
+// AllowFile counts a file towards the maximum number of files, reporting whether it is within the limit and
+// recording it as skipped if not. It should only be called for files which would otherwise be parsed, in a
+// consistent order, so the same files are skipped on each scan.
+func (t *Tracker) AllowFile(path string) bool {
+	if t == nil || t.limits.MaxFiles <= 0 {
+		return true
+	}
+	if atomic.AddInt64(&t.files, 1) <= int64(t.limits.MaxFiles) {
+		return true
+	}
+	t.summary.Record(path, ReasonTooManyFiles)
+	return false
+}
+
+// Parse runs the parse function for a file, abandoning it and recording the file as skipped if it does not
+// complete within the parse timeout. Parsers are not required to observe the context they are given, in which
+// case an abandoned parse continues in the background until it completes.
+func Parse[T any](ctx context.Context, t *Tracker, path string, parse func(ctx context.Context) (T, error)) (T, error) {
+	if t == nil || t.limits.ParseTimeout <= 0 {
+		return parse(ctx)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, t.limits.ParseTimeout)
+	defer cancel()
+
+	type outcome struct {
+		value T
+		err   error
+	}
+	done := make(chan outcome, 1)
+	go func() {
+		value, err := parse(ctx)
+		done <- outcome{value: value, err: err}
+	}()
+
+	select {
+	case result := <-done:
+		return result.value, result.err
+	case <-ctx.Done():
+		var zero T
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			t.summary.Record(path, ReasonParseTimeout)
+		}
+		return zero, ctx.Err()
+	}
+}
@@ -0,0 +1,72 @@
+package limits
+
+import (
+	"context"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_TrackerSkipsLargeFiles(t *testing.T) {
+	fsys := fstest.MapFS{
+		"small.json": {Data: []byte("{}")},
+		"large.json": {Data: []byte(`{"a": "0123456789"}`)},
+	}
+	summary := &Summary{}
+	tracker := NewTracker(FileLimits{MaxFileSize: 10}, summary)
+
+	assert.True(t, tracker.AllowSize(fsys, "small.json"))
+	assert.False(t, tracker.AllowSize(fsys, "large.json"))
+	assert.Equal(t, []SkippedFile{
+		{Path: "large.json", Reason: ReasonFileTooLarge},
+	}, summary.Skipped())
+}
+
+func Test_TrackerSkipsFilesOverCount(t *testing.T) {
+	summary := &Summary{}
+	tracker := NewTracker(FileLimits{MaxFiles: 2}, summary)
+
+	assert.True(t, tracker.AllowFile("a.json"))
+	assert.True(t, tracker.AllowFile("b.json"))
+	assert.False(t, tracker.AllowFile("d.json"))
+	assert.False(t, tracker.AllowFile("c.json"))
+	assert.Equal(t, []SkippedFile{
+		{Path: "c.json", Reason: ReasonTooManyFiles},
+		{Path: "d.json", Reason: ReasonTooManyFiles},
+	}, summary.Skipped())
+}
+
+func Test_TrackerAbandonsSlowParse(t *testing.T) {
+	summary := &Summary{}
+	tracker := NewTracker(FileLimits{ParseTimeout: time.Millisecond * 10}, summary)
+
+	_, err := Parse(context.TODO(), tracker, "slow.json", func(ctx context.Context) (int, error) {
+		<-ctx.Done()
+		return 0, nil
+	})
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+
+	value, err := Parse(context.TODO(), tracker, "fast.json", func(ctx context.Context) (int, error) {
+		return 1, nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 1, value)
+
+	assert.Equal(t, []SkippedFile{
+		{Path: "slow.json", Reason: ReasonParseTimeout},
+	}, summary.Skipped())
+}
+
+func Test_NilTrackerAppliesNoLimits(t *testing.T) {
+	var tracker *Tracker
+	assert.True(t, tracker.AllowSize(fstest.MapFS{}, "a.json"))
+	assert.True(t, tracker.AllowFile("a.json"))
+	value, err := Parse(context.TODO(), tracker, "a.json", func(ctx context.Context) (int, error) {
+		return 1, nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 1, value)
+}
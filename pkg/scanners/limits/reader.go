@@ -0,0 +1,148 @@
+package limits
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"io"
+	"strings"
+)
+
+// ErrDocumentTooLarge is returned when a document exceeds the configured maximum size
+var ErrDocumentTooLarge = errors.New("document exceeds the maximum allowed size")
+
+// DocumentReader wraps a reader containing one or more documents, failing with ErrDocumentTooLarge once more
+// than the maximum number of bytes have been read for the current document. This allows streaming decoders to
+// give up on an oversized document before it has been buffered in memory.
+//
+// Decoders read ahead of the document they are decoding, so the limit is approximate - bytes belonging to the
+// next document may be counted against the current one.
+type DocumentReader struct {
+	r    io.Reader
+	max  int64
+	read int64
+}
+
+// NewDocumentReader creates a DocumentReader. A maximum size below 1 means documents are not limited.
+func NewDocumentReader(r io.Reader, max int64) *DocumentReader {
+	return &DocumentReader{
+		r:   r,
+		max: max,
+	}
+}
+
+func (d *DocumentReader) Read(p []byte) (int, error) {
+	if d.max <= 0 {
+		return d.r.Read(p)
+	}
+	if d.read > d.max {
+		return 0, ErrDocumentTooLarge
+	}
+	// read at most one byte beyond the limit, so we can tell a document of exactly the maximum size apart
+	if remaining := d.max - d.read + 1; int64(len(p)) > remaining {
+		p = p[:remaining]
+	}
+	n, err := d.r.Read(p)
+	d.read += int64(n)
+	if d.read > d.max {
+		return n, ErrDocumentTooLarge
+	}
+	return n, err
+}
+
+// NextDocument resets the byte count, and should be called before decoding each document
+func (d *DocumentReader) NextDocument() {
+	d.read = 0
+}
+
+// YAMLDocuments splits a stream of yaml documents at each "---" separator line, so that an oversized document can
+// be skipped without giving up on the documents which follow it. Only the current document is held in memory, and
+// the bytes of an oversized document are discarded as they are read.
+type YAMLDocuments struct {
+	r         *bufio.Reader
+	max       int64
+	pending   []byte
+	lineStart bool
+	eof       bool
+	lines     int
+	offset    int
+}
+
+// NewYAMLDocuments creates a YAMLDocuments. A maximum size below 1 means documents are not limited.
+func NewYAMLDocuments(r io.Reader, max int64) *YAMLDocuments {
+	return &YAMLDocuments{
+		r:         bufio.NewReader(r),
+		max:       max,
+		lineStart: true,
+	}
+}
+
+// Next returns the next document, including the separator line which starts it. ErrDocumentTooLarge is returned
+// for a document larger than the maximum size, after which the following documents can still be read. io.EOF is
+// returned once there are no documents left.
+func (d *YAMLDocuments) Next() ([]byte, error) {
+	if d.eof && d.pending == nil {
+		return nil, io.EOF
+	}
+	var document []byte
+	var size int64
+	var started, tooLarge bool
+	d.offset = d.lines
+	add := func(chunk []byte) {
+		started = true
+		d.lines += bytes.Count(chunk, []byte("\n"))
+		if tooLarge {
+			return
+		}
+		size += int64(len(chunk))
+		if d.max > 0 && size > d.max {
+			tooLarge = true
+			document = nil
+			return
+		}
+		document = append(document, chunk...)
+	}
+	if d.pending != nil {
+		add(d.pending)
+		d.pending = nil
+	}
+	for !d.eof {
+		chunk, err := d.r.ReadSlice('\n')
+		switch {
+		case err == io.EOF:
+			d.eof = true
+		case err != nil && !errors.Is(err, bufio.ErrBufferFull):
+			return nil, err
+		}
+		if started && d.lineStart && isDocumentSeparator(chunk) {
+			d.pending = append([]byte{}, chunk...)
+			d.lineStart = err == nil
+			break
+		}
+		if len(chunk) > 0 {
+			add(chunk)
+		}
+		d.lineStart = err == nil
+	}
+	if !started {
+		return nil, io.EOF
+	}
+	if tooLarge {
+		return nil, ErrDocumentTooLarge
+	}
+	return document, nil
+}
+
+// Offset returns the number of lines before the document last returned by Next, including the lines of any
+// oversized documents which were skipped, so lines within the document can be located within the stream
+func (d *YAMLDocuments) Offset() int {
+	return d.offset
+}
+
+// isDocumentSeparator reports whether a line starts a new yaml document, e.g. "---" or "--- !tag"
+func isDocumentSeparator(line []byte) bool {
+	if !bytes.HasPrefix(line, []byte("---")) {
+		return false
+	}
+	return len(line) == 3 || strings.ContainsRune(" \t\r\n", rune(line[3]))
+}
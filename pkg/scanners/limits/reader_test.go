@@ -0,0 +1,83 @@
+package limits
+
+import (
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_DocumentReaderWithinLimit(t *testing.T) {
+	reader := NewDocumentReader(strings.NewReader("0123456789"), 10)
+	data, err := io.ReadAll(reader)
+	require.NoError(t, err)
+	assert.Equal(t, "0123456789", string(data))
+}
+
+func Test_DocumentReaderExceedsLimit(t *testing.T) {
+	reader := NewDocumentReader(strings.NewReader("0123456789"), 9)
+	_, err := io.ReadAll(reader)
+	assert.ErrorIs(t, err, ErrDocumentTooLarge)
+}
+
+func Test_DocumentReaderResetsPerDocument(t *testing.T) {
+	reader := NewDocumentReader(strings.NewReader("01234567"), 5)
+	buffer := make([]byte, 4)
+
+	n, err := io.ReadFull(reader, buffer)
+	require.NoError(t, err)
+	assert.Equal(t, 4, n)
+
+	reader.NextDocument()
+
+	n, err = io.ReadFull(reader, buffer)
+	require.NoError(t, err)
+	assert.Equal(t, "4567", string(buffer[:n]))
+}
+
+func Test_DocumentReaderUnlimited(t *testing.T) {
+	reader := NewDocumentReader(strings.NewReader(strings.Repeat("x", 4096)), 0)
+	data, err := io.ReadAll(reader)
+	require.NoError(t, err)
+	assert.Len(t, data, 4096)
+}
+
+func Test_YAMLDocumentsSkipsOversizedDocument(t *testing.T) {
+	input := "x: 1\n---\nx: " + strings.Repeat("a", 64) + "\n--- # third\nx: 3\n"
+	documents := NewYAMLDocuments(strings.NewReader(input), 32)
+
+	document, err := documents.Next()
+	require.NoError(t, err)
+	assert.Equal(t, "x: 1\n", string(document))
+	assert.Equal(t, 0, documents.Offset())
+
+	_, err = documents.Next()
+	assert.ErrorIs(t, err, ErrDocumentTooLarge)
+
+	// the lines of the skipped document are still counted
+	document, err = documents.Next()
+	require.NoError(t, err)
+	assert.Equal(t, "--- # third\nx: 3\n", string(document))
+	assert.Equal(t, 3, documents.Offset())
+
+	_, err = documents.Next()
+	assert.ErrorIs(t, err, io.EOF)
+}
+
+func Test_YAMLDocumentsUnlimited(t *testing.T) {
+	input := "---\nx: 1\n---\r\nx: 2\n---x: not a separator"
+	documents := NewYAMLDocuments(strings.NewReader(input), 0)
+
+	document, err := documents.Next()
+	require.NoError(t, err)
+	assert.Equal(t, "---\nx: 1\n", string(document))
+
+	document, err = documents.Next()
+	require.NoError(t, err)
+	assert.Equal(t, "---\r\nx: 2\n---x: not a separator", string(document))
+
+	_, err = documents.Next()
+	assert.ErrorIs(t, err, io.EOF)
+}
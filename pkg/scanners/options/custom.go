@@ -0,0 +1,208 @@
+package options
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"io/fs"
+	"strings"
+	"sync"
+)
+
+// Preprocessor converts the content of a file which the parsers cannot read directly, such as a template, into a
+// form which they can
+type Preprocessor func(ctx context.Context, path string, content []byte) ([]byte, error)
+
+// CustomParser handles files with an additional extension, by preprocessing them and then parsing the result as if
+// the file had a built-in extension
+type CustomParser struct {
+	// Extension is the suffix of the names of the files handled, e.g. ".yml.tpl", and is matched case-insensitively
+	Extension string
+	// As replaces Extension to give the name the file is parsed as, e.g. ".yaml"
+	As string
+	// Preprocess produces the content which is parsed
+	Preprocess Preprocessor
+}
+
+// CustomParsers are the custom parsers registered with a scanner. When several handle a file, the one with the
+// longest extension is used.
+type CustomParsers []CustomParser
+
+func (c CustomParsers) match(path string) (CustomParser, bool) {
+	var matched CustomParser
+	var found bool
+	for _, parser := range c {
+		if parser.Extension == "" || len(path) < len(parser.Extension) {
+			continue
+		}
+		if !strings.EqualFold(path[len(path)-len(parser.Extension):], parser.Extension) {
+			continue
+		}
+		if !found || len(parser.Extension) > len(matched.Extension) {
+			matched, found = parser, true
+		}
+	}
+	return matched, found
+}
+
+// ParsedAs returns the name the file at path is parsed as, which is only different to path when a custom parser
+// handles it
+func (c CustomParsers) ParsedAs(path string) string {
+	parser, ok := c.match(path)
+	if !ok {
+		return path
+	}
+	return path[:len(path)-len(parser.Extension)] + parser.As
+}
+
+// FS returns a filesystem which serves the preprocessed content of files handled by a custom parser, and everything
+// else unchanged. File names are not changed, so results refer to the original files.
+func (c CustomParsers) FS(ctx context.Context, fsys fs.FS) fs.FS {
+	if len(c) == 0 || fsys == nil {
+		return fsys
+	}
+	if pfs, ok := fsys.(*preprocessedFS); ok {
+		fsys = pfs.FS
+	}
+	return &preprocessedFS{
+		FS:       fsys,
+		ctx:      ctx,
+		parsers:  c,
+		rendered: make(map[string][]byte),
+	}
+}
+
+type preprocessedFS struct {
+	fs.FS
+	ctx      context.Context
+	parsers  CustomParsers
+	lock     sync.Mutex
+	rendered map[string][]byte
+}
+
+func (p *preprocessedFS) Open(name string) (fs.File, error) {
+	f, err := p.FS.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	parser, ok := p.parsers.match(name)
+	if !ok {
+		return f, nil
+	}
+	info, err := f.Stat()
+	if err != nil || info.IsDir() {
+		return f, err
+	}
+	defer func() { _ = f.Close() }()
+
+	content, err := p.render(name, f, parser)
+	if err != nil {
+		return nil, &fs.PathError{Op: "preprocess", Path: name, Err: err}
+	}
+	return &preprocessedFile{
+		Reader: bytes.NewReader(content),
+		info:   preprocessedInfo{FileInfo: info, size: int64(len(content))},
+	}, nil
+}
+
+// render preprocesses a file once, as the scanners open files again to show the code behind results
+func (p *preprocessedFS) render(name string, f io.Reader, parser CustomParser) ([]byte, error) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	if content, ok := p.rendered[name]; ok {
+		return content, nil
+	}
+	raw, err := io.ReadAll(f)
+	if err != nil {
+		return nil, err
+	}
+	if parser.Preprocess == nil {
+		return nil, fmt.Errorf("no preprocessor for extension '%s'", parser.Extension)
+	}
+	content, err := parser.Preprocess(p.ctx, name, raw)
+	if err != nil {
+		return nil, err
+	}
+	p.rendered[name] = content
+	return content, nil
+}
+
+type preprocessedFile struct {
+	*bytes.Reader
+	info fs.FileInfo
+}
+
+func (f *preprocessedFile) Stat() (fs.FileInfo, error) {
+	return f.info, nil
+}
+
+func (f *preprocessedFile) Close() error {
+	return nil
+}
+
+type preprocessedInfo struct {
+	fs.FileInfo
+	size int64
+}
+
+func (i preprocessedInfo) Size() int64 {
+	return i.size
+}
+
+type CustomParsingParser interface {
+	SetCustomParsers(CustomParsers)
+}
+
+// ParserWithCustomParsers makes the parser read files handled by the given custom parsers, as the names they are
+// parsed as. The filesystem given to the parser must serve their preprocessed content, see CustomParsers.FS.
+func ParserWithCustomParsers(parsers CustomParsers) ParserOption {
+	return func(s ConfigurableParser) {
+		if cp, ok := s.(CustomParsingParser); ok {
+			cp.SetCustomParsers(parsers)
+		}
+	}
+}
+
+type CustomParsingScanner interface {
+	AddCustomParsers(...CustomParser)
+}
+
+// ScannerWithCustomParser scans files whose names end with extension by preprocessing them, then parsing the result
+// as if the extension were replaced with as, e.g. ScannerWithCustomParser(".yml.tpl", ".yaml", render). It applies
+// to the json, yaml, toml, dockerfile, kubernetes and cloudformation scanners.
+func ScannerWithCustomParser(extension string, as string, preprocess Preprocessor) ScannerOption {
+	return func(s ConfigurableScanner) {
+		if cs, ok := s.(CustomParsingScanner); ok {
+			cs.AddCustomParsers(CustomParser{
+				Extension:  extension,
+				As:         as,
+				Preprocess: preprocess,
+			})
+		}
+	}
+}
+
+// CustomParserHandler is embedded by scanners to implement CustomParsingScanner
+type CustomParserHandler struct {
+	customParsers CustomParsers
+}
+
+func (h *CustomParserHandler) AddCustomParsers(parsers ...CustomParser) {
+	h.customParsers = append(h.customParsers, parsers...)
+}
+
+// CustomParsers returns the registered custom parsers
+func (h *CustomParserHandler) CustomParsers() CustomParsers {
+	return h.customParsers
+}
+
+// CustomParserOption passes the registered custom parsers on to the scanner's parser
+func (h *CustomParserHandler) CustomParserOption() ParserOption {
+	return ParserWithCustomParsers(h.customParsers)
+}
+
+// PreprocessFS wraps a filesystem being scanned so that the registered custom parsers are applied to it
+func (h *CustomParserHandler) PreprocessFS(ctx context.Context, fsys fs.FS) fs.FS {
+	return h.customParsers.FS(ctx, fsys)
+}
@@ -0,0 +1,81 @@
+package options
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_CustomParsersParsedAs(t *testing.T) {
+	parsers := CustomParsers{
+		{Extension: ".tpl", As: ""},
+		{Extension: ".yml.tpl", As: ".yaml"},
+		{Extension: ".json.j2", As: ".json"},
+	}
+
+	tests := []struct {
+		path     string
+		expected string
+	}{
+		{path: "deploy/app.yml.tpl", expected: "deploy/app.yaml"},
+		{path: "deploy/APP.YML.TPL", expected: "deploy/APP.yaml"},
+		{path: "deploy/config.json.j2", expected: "deploy/config.json"},
+		{path: "deploy/Dockerfile.tpl", expected: "deploy/Dockerfile"},
+		{path: "deploy/app.yaml", expected: "deploy/app.yaml"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.path, func(t *testing.T) {
+			assert.Equal(t, test.expected, parsers.ParsedAs(test.path))
+		})
+	}
+}
+
+func Test_CustomParsersFS(t *testing.T) {
+	calls := 0
+	parsers := CustomParsers{
+		{
+			Extension: ".yml.tpl",
+			As:        ".yaml",
+			Preprocess: func(_ context.Context, path string, content []byte) ([]byte, error) {
+				calls++
+				if path == "broken.yml.tpl" {
+					return nil, errors.New("template error")
+				}
+				return []byte(strings.ToUpper(string(content))), nil
+			},
+		},
+	}
+
+	fsys := parsers.FS(context.TODO(), fstest.MapFS{
+		"app.yml.tpl":    {Data: []byte("name: example")},
+		"broken.yml.tpl": {Data: []byte("{{")},
+		"other.yaml":     {Data: []byte("name: other")},
+	})
+
+	content, err := fs.ReadFile(fsys, "app.yml.tpl")
+	require.NoError(t, err)
+	assert.Equal(t, "NAME: EXAMPLE", string(content))
+
+	info, err := fs.Stat(fsys, "app.yml.tpl")
+	require.NoError(t, err)
+	assert.Equal(t, int64(len("NAME: EXAMPLE")), info.Size())
+	assert.Equal(t, 1, calls, "content should only be preprocessed once")
+
+	content, err = fs.ReadFile(fsys, "other.yaml")
+	require.NoError(t, err)
+	assert.Equal(t, "name: other", string(content))
+
+	_, err = fs.ReadFile(fsys, "broken.yml.tpl")
+	assert.ErrorContains(t, err, "template error")
+
+	entries, err := fs.ReadDir(fsys, ".")
+	require.NoError(t, err)
+	assert.Len(t, entries, 3)
+}
@@ -0,0 +1,128 @@
+package options
+
+import (
+	"context"
+	"runtime/pprof"
+	"time"
+)
+
+// Phase is a stage of a scan which is timed by instrumentation hooks
+type Phase string
+
+const (
+	PhaseParse    Phase = "parse"
+	PhaseAdapt    Phase = "adapt"
+	PhaseEvaluate Phase = "evaluate"
+)
+
+// Timing is the time taken by a phase of a scan
+type Timing struct {
+	Scanner string
+	Phase   Phase
+	// Path is the file or directory the timing relates to, or empty if it covers everything scanned by the call
+	Path     string
+	Duration time.Duration
+}
+
+// InstrumentationHook is invoked with the duration of each phase of a scan as it completes
+type InstrumentationHook func(timing Timing)
+
+type InstrumentedScanner interface {
+	AddInstrumentationHooks(hooks ...InstrumentationHook)
+	SetProfilerLabelsEnabled(enabled bool)
+}
+
+// ScannerWithInstrumentation registers hooks which receive the duration of each phase of a scan (parse, adapt
+// and evaluate), along with the time taken to parse each file. Hooks may be called concurrently.
+func ScannerWithInstrumentation(hooks ...InstrumentationHook) ScannerOption {
+	return func(s ConfigurableScanner) {
+		if is, ok := s.(InstrumentedScanner); ok {
+			is.AddInstrumentationHooks(hooks...)
+		}
+	}
+}
+
+// ScannerWithProfilerLabels labels the goroutines running each phase of a scan with the scanner, phase and path,
+// so CPU profiles taken with runtime/pprof can be broken down by them
+func ScannerWithProfilerLabels(enabled bool) ScannerOption {
+	return func(s ConfigurableScanner) {
+		if is, ok := s.(InstrumentedScanner); ok {
+			is.SetProfilerLabelsEnabled(enabled)
+		}
+	}
+}
+
+type InstrumentedParser interface {
+	SetInstrumentation(instrumentation *Instrumentation, scanner string)
+}
+
+// ParserWithInstrumentation times the parsing of each file using the given instrumentation, reporting timings
+// against the named scanner
+func ParserWithInstrumentation(instrumentation *Instrumentation, scanner string) ParserOption {
+	return func(s ConfigurableParser) {
+		if ip, ok := s.(InstrumentedParser); ok {
+			ip.SetInstrumentation(instrumentation, scanner)
+		}
+	}
+}
+
+// Instrumentation is embedded by scanners to implement InstrumentedScanner, MetricsScanner and
+// ProgressReportingScanner. It is safe to use a nil Instrumentation, in which case nothing is recorded.
+type Instrumentation struct {
+	hooks             []InstrumentationHook
+	profilerLabels    bool
+	metrics           Metrics
+	progressCallbacks []ProgressCallback
+}
+
+func (i *Instrumentation) AddInstrumentationHooks(hooks ...InstrumentationHook) {
+	i.hooks = append(i.hooks, hooks...)
+}
+
+func (i *Instrumentation) SetProfilerLabelsEnabled(enabled bool) {
+	i.profilerLabels = enabled
+}
+
+// StartPhase marks the start of a phase, returning a function which must be called when the phase completes. If
+// profiler labels are enabled, the calling goroutine (and any goroutines it starts) is labelled until then, after
+// which it reverts to the labels carried by ctx.
+func (i *Instrumentation) StartPhase(ctx context.Context, scanner string, phase Phase, path string) func() {
+	if i == nil || (len(i.hooks) == 0 && !i.profilerLabels) {
+		return func() {}
+	}
+	if i.profilerLabels {
+		labels := pprof.Labels("defsec_scanner", scanner, "defsec_phase", string(phase))
+		if path != "" {
+			labels = pprof.Labels("defsec_scanner", scanner, "defsec_phase", string(phase), "defsec_path", path)
+		}
+		pprof.SetGoroutineLabels(pprof.WithLabels(ctx, labels))
+	}
+	start := time.Now()
+	return func() {
+		if i.profilerLabels {
+			pprof.SetGoroutineLabels(ctx)
+		}
+		i.Record(scanner, phase, path, time.Since(start))
+	}
+}
+
+// Record reports the duration of a phase which was timed elsewhere
+func (i *Instrumentation) Record(scanner string, phase Phase, path string, duration time.Duration) {
+	if i == nil {
+		return
+	}
+	timing := Timing{
+		Scanner:  scanner,
+		Phase:    phase,
+		Path:     path,
+		Duration: duration,
+	}
+	for _, hook := range i.hooks {
+		hook(timing)
+	}
+}
+
+// InstrumentationParserOption passes the instrumentation on to the scanner's parser, so it can time each file
+func (i *Instrumentation) InstrumentationParserOption(scanner string) ParserOption {
+	return ParserWithInstrumentation(i, scanner)
+}
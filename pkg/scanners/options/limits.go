@@ -0,0 +1,121 @@
+package options
+
+import (
+	"time"
+
+	"github.com/aquasecurity/defsec/pkg/scanners/limits"
+)
+
+type FileLimitedParser interface {
+	SetFileLimits(limits.FileLimits, *limits.Summary)
+}
+
+// ParserWithFileLimits restricts the size and number of files parsed, and the time spent parsing each of them.
+// Skipped files are recorded in summary, which may be nil.
+func ParserWithFileLimits(fileLimits limits.FileLimits, summary *limits.Summary) ParserOption {
+	return func(s ConfigurableParser) {
+		if fp, ok := s.(FileLimitedParser); ok {
+			fp.SetFileLimits(fileLimits, summary)
+		}
+	}
+}
+
+type FileLimitedScanner interface {
+	SetMaxFileSize(int64)
+	SetMaxFiles(int)
+	SetParseTimeout(time.Duration)
+	SetScanSummary(*limits.Summary)
+}
+
+// ScannerWithMaxFileSize skips files larger than size bytes - values below 1 mean no limit
+func ScannerWithMaxFileSize(size int64) ScannerOption {
+	return func(s ConfigurableScanner) {
+		if fs, ok := s.(FileLimitedScanner); ok {
+			fs.SetMaxFileSize(size)
+		}
+	}
+}
+
+// ScannerWithMaxFiles skips any files found after the first max files in each scan - values below 1 mean no limit
+func ScannerWithMaxFiles(max int) ScannerOption {
+	return func(s ConfigurableScanner) {
+		if fs, ok := s.(FileLimitedScanner); ok {
+			fs.SetMaxFiles(max)
+		}
+	}
+}
+
+// ScannerWithParseTimeout abandons parsing of any file which takes longer than timeout - values below 1 mean no limit
+func ScannerWithParseTimeout(timeout time.Duration) ScannerOption {
+	return func(s ConfigurableScanner) {
+		if fs, ok := s.(FileLimitedScanner); ok {
+			fs.SetParseTimeout(timeout)
+		}
+	}
+}
+
+// ScannerWithScanSummary records files skipped due to the file limits in summary, so callers can report them
+func ScannerWithScanSummary(summary *limits.Summary) ScannerOption {
+	return func(s ConfigurableScanner) {
+		if fs, ok := s.(FileLimitedScanner); ok {
+			fs.SetScanSummary(summary)
+		}
+	}
+}
+
+// FileLimitHandler is embedded by scanners to implement FileLimitedScanner and PathFilteredScanner
+type FileLimitHandler struct {
+	fileLimits  limits.FileLimits
+	scanSummary *limits.Summary
+	pathFilter  PathFilter
+}
+
+func (h *FileLimitHandler) SetMaxFileSize(size int64) {
+	h.fileLimits.MaxFileSize = size
+}
+
+func (h *FileLimitHandler) SetMaxFiles(max int) {
+	h.fileLimits.MaxFiles = max
+}
+
+func (h *FileLimitHandler) SetParseTimeout(timeout time.Duration) {
+	h.fileLimits.ParseTimeout = timeout
+}
+
+func (h *FileLimitHandler) SetScanSummary(summary *limits.Summary) {
+	h.scanSummary = summary
+}
+
+// FileLimitParserOption passes the configured limits on to the scanner's parser
+func (h *FileLimitHandler) FileLimitParserOption() ParserOption {
+	return ParserWithFileLimits(h.fileLimits, h.scanSummary)
+}
+
+func (h *FileLimitHandler) SetIncludePaths(patterns ...string) {
+	h.pathFilter.Include = append(h.pathFilter.Include, patterns...)
+}
+
+func (h *FileLimitHandler) SetExcludePaths(patterns ...string) {
+	h.pathFilter.Exclude = append(h.pathFilter.Exclude, patterns...)
+}
+
+// PathFilterParserOption passes the configured include and exclude patterns on to the scanner's parser
+func (h *FileLimitHandler) PathFilterParserOption() ParserOption {
+	return ParserWithPathFilter(h.pathFilter)
+}
+
+// PathFilter returns the configured include and exclude patterns, for scanners which walk the filesystem themselves
+func (h *FileLimitHandler) PathFilter() PathFilter {
+	return h.pathFilter
+}
+
+// FileLimitTracker creates a tracker which applies the configured limits to the files of a single scan, for
+// scanners which read files themselves, or share the limits between several parsers
+func (h *FileLimitHandler) FileLimitTracker() *limits.Tracker {
+	return limits.NewTracker(h.fileLimits, h.scanSummary)
+}
+
+// ScanSummary returns the summary skipped files are recorded in, which may be nil
+func (h *FileLimitHandler) ScanSummary() *limits.Summary {
+	return h.scanSummary
+}
@@ -0,0 +1,68 @@
+package options
+
+import (
+	"time"
+
+	"github.com/aquasecurity/defsec/pkg/scan"
+	"github.com/aquasecurity/defsec/pkg/severity"
+)
+
+// Metrics receives measurements taken during scans, so they can be exported to a monitoring system such as
+// Prometheus or OpenTelemetry. Implementations must be safe for concurrent use.
+type Metrics interface {
+	// AddFilesScanned is called with the number of files parsed by each scan
+	AddFilesScanned(scanner string, count int)
+	// AddResults is called with the number of results of each severity and status returned by each scan
+	AddResults(scanner string, sev severity.Severity, status scan.Status, count int)
+	// ObservePolicyEvaluation is called with the time taken to evaluate a rego policy against the inputs of a scan
+	ObservePolicyEvaluation(namespace string, duration time.Duration)
+}
+
+type MetricsScanner interface {
+	SetMetrics(metrics Metrics)
+}
+
+// ScannerWithMetrics reports the number of files scanned, the results found and the time taken to evaluate each
+// rego policy to the given metrics implementation
+func ScannerWithMetrics(metrics Metrics) ScannerOption {
+	return func(s ConfigurableScanner) {
+		if ms, ok := s.(MetricsScanner); ok {
+			ms.SetMetrics(metrics)
+		}
+	}
+}
+
+func (i *Instrumentation) SetMetrics(metrics Metrics) {
+	i.metrics = metrics
+}
+
+// RecordFiles reports the number of files parsed by a scan
+func (i *Instrumentation) RecordFiles(scanner string, count int) {
+	if i == nil || i.metrics == nil || count == 0 {
+		return
+	}
+	i.metrics.AddFilesScanned(scanner, count)
+}
+
+// RecordResults reports the number of results of each severity and status returned by a scan
+func (i *Instrumentation) RecordResults(scanner string, results scan.Results) {
+	if i == nil || i.metrics == nil || len(results) == 0 {
+		return
+	}
+	type key struct {
+		severity severity.Severity
+		status   scan.Status
+	}
+	counts := make(map[key]int)
+	var order []key
+	for _, result := range results {
+		k := key{severity: result.Severity(), status: result.Status()}
+		if counts[k] == 0 {
+			order = append(order, k)
+		}
+		counts[k]++
+	}
+	for _, k := range order {
+		i.metrics.AddResults(scanner, k.severity, k.status, counts[k])
+	}
+}
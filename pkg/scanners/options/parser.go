@@ -1,6 +1,10 @@
 package options
 
-import "io"
+import (
+	"io"
+
+	"github.com/aquasecurity/defsec/pkg/debug"
+)
 
 type ConfigurableParser interface {
 	SetDebugWriter(io.Writer)
@@ -21,3 +25,56 @@ func ParserWithDebug(w io.Writer) ParserOption {
 		s.SetDebugWriter(w)
 	}
 }
+
+type LoggingParser interface {
+	SetLogHandler(debug.Handler)
+}
+
+// ParserWithLogHandler passes structured, levelled logs to the given handler - if not set, they are discarded
+func ParserWithLogHandler(handler debug.Handler) ParserOption {
+	return func(s ConfigurableParser) {
+		if lp, ok := s.(LoggingParser); ok {
+			lp.SetLogHandler(handler)
+		}
+	}
+}
+
+type ParallelParser interface {
+	SetParallelism(int)
+}
+
+// ParserWithParallelism sets the number of files which are parsed concurrently - values below 1 mean one per CPU
+func ParserWithParallelism(parallelism int) ParserOption {
+	return func(s ConfigurableParser) {
+		if pp, ok := s.(ParallelParser); ok {
+			pp.SetParallelism(parallelism)
+		}
+	}
+}
+
+type DocumentSizeLimitedParser interface {
+	SetMaxDocumentSize(int64)
+}
+
+// ParserWithMaxDocumentSize sets the maximum size in bytes of a single document - values below 1 mean no limit
+func ParserWithMaxDocumentSize(size int64) ParserOption {
+	return func(s ConfigurableParser) {
+		if dp, ok := s.(DocumentSizeLimitedParser); ok {
+			dp.SetMaxDocumentSize(size)
+		}
+	}
+}
+
+type LenientParser interface {
+	SetLenientParsing(bool)
+}
+
+// ParserWithLenientParsing reads JSON with comments and trailing commas, and reads ambiguous yaml values the way
+// YAML 1.1 does - see lenient.NormaliseYAML
+func ParserWithLenientParsing(lenient bool) ParserOption {
+	return func(s ConfigurableParser) {
+		if lp, ok := s.(LenientParser); ok {
+			lp.SetLenientParsing(lenient)
+		}
+	}
+}
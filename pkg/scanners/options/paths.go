@@ -0,0 +1,99 @@
+package options
+
+import "github.com/aquasecurity/defsec/pkg/extrafs"
+
+// PathFilter selects the files walked by ScanFS using glob patterns, before they are parsed. Patterns are matched
+// by extrafs.MatchGlob against paths relative to the directory being scanned, so "vendor" matches every file inside
+// any directory named vendor, and "**/testdata/**" every file beneath a testdata directory.
+type PathFilter struct {
+	// Include limits the files scanned to those matching at least one pattern, unless it is empty
+	Include []string
+	// Exclude skips files matching any pattern, even if they also match Include
+	Exclude []string
+}
+
+// IsEmpty reports whether the filter has no patterns, and so allows every path
+func (f PathFilter) IsEmpty() bool {
+	return len(f.Include) == 0 && len(f.Exclude) == 0
+}
+
+// AllowsFile reports whether a file found while scanning root should be parsed
+func (f PathFilter) AllowsFile(root string, filePath string) bool {
+	if f.IsEmpty() {
+		return true
+	}
+	rel := relativePath(root, filePath)
+	if rel == "" {
+		return true
+	}
+	if len(f.Include) > 0 && !matchesAny(f.Include, rel) {
+		return false
+	}
+	return !matchesAny(f.Exclude, rel)
+}
+
+// SkipsDir reports whether a directory found while scanning root is excluded, so nothing inside it needs to be
+// walked. The root itself is never skipped.
+func (f PathFilter) SkipsDir(root string, dirPath string) bool {
+	if len(f.Exclude) == 0 {
+		return false
+	}
+	rel := relativePath(root, dirPath)
+	if rel == "" {
+		return false
+	}
+	return matchesAny(f.Exclude, rel)
+}
+
+func relativePath(root string, target string) string {
+	rel, _ := extrafs.RelPath(root, target)
+	return rel
+}
+
+func matchesAny(patterns []string, rel string) bool {
+	for _, pattern := range patterns {
+		if extrafs.MatchGlob(pattern, rel) {
+			return true
+		}
+	}
+	return false
+}
+
+type PathFilteredParser interface {
+	SetPathFilter(PathFilter)
+}
+
+// ParserWithPathFilter restricts the files parsed when walking a filesystem to those allowed by the filter
+func ParserWithPathFilter(filter PathFilter) ParserOption {
+	return func(s ConfigurableParser) {
+		if pp, ok := s.(PathFilteredParser); ok {
+			pp.SetPathFilter(filter)
+		}
+	}
+}
+
+type PathFilteredScanner interface {
+	SetIncludePaths(patterns ...string)
+	SetExcludePaths(patterns ...string)
+}
+
+// ScannerWithIncludePaths limits the files scanned by ScanFS to those matching at least one of the glob patterns.
+// See PathFilter for how patterns are matched.
+func ScannerWithIncludePaths(patterns ...string) ScannerOption {
+	return func(s ConfigurableScanner) {
+		if ps, ok := s.(PathFilteredScanner); ok {
+			ps.SetIncludePaths(patterns...)
+		}
+	}
+}
+
+// ScannerWithExcludePaths skips files and directories matching any of the glob patterns when walking the
+// filesystem in ScanFS, e.g. vendored modules, test fixtures or generated code. See PathFilter for how patterns
+// are matched.
+func ScannerWithExcludePaths(patterns ...string) ScannerOption {
+	return func(s ConfigurableScanner) {
+		if ps, ok := s.(PathFilteredScanner); ok {
+			ps.SetExcludePaths(patterns...)
+		}
+	}
+}
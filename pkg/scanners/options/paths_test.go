@@ -0,0 +1,105 @@
+package options
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_PathFilter(t *testing.T) {
+	tests := []struct {
+		name    string
+		filter  PathFilter
+		root    string
+		path    string
+		allowed bool
+	}{
+		{
+			name:    "empty filter",
+			root:    ".",
+			path:    "vendor/main.tf",
+			allowed: true,
+		},
+		{
+			name:    "excluded directory name",
+			filter:  PathFilter{Exclude: []string{"vendor"}},
+			root:    ".",
+			path:    "modules/vendor/main.tf",
+			allowed: false,
+		},
+		{
+			name:    "excluded glob relative to root",
+			filter:  PathFilter{Exclude: []string{"test/**"}},
+			root:    "code",
+			path:    "code/test/fixtures/bad.json",
+			allowed: false,
+		},
+		{
+			name:    "excluded glob relative to windows root",
+			filter:  PathFilter{Exclude: []string{"test/**"}},
+			root:    `C:\Code`,
+			path:    `c:\code\test\fixtures\bad.json`,
+			allowed: false,
+		},
+		{
+			name:    "anchored exclude does not match nested directory",
+			filter:  PathFilter{Exclude: []string{"test/fixtures"}},
+			root:    "code",
+			path:    "code/src/test/fixtures/bad.json",
+			allowed: true,
+		},
+		{
+			name:    "excluded file extension",
+			filter:  PathFilter{Exclude: []string{"*.generated.json"}},
+			root:    ".",
+			path:    "config/app.generated.json",
+			allowed: false,
+		},
+		{
+			name:    "included by pattern",
+			filter:  PathFilter{Include: []string{"deploy/**/*.yaml"}},
+			root:    "/",
+			path:    "/deploy/prod/app.yaml",
+			allowed: true,
+		},
+		{
+			name:    "not included",
+			filter:  PathFilter{Include: []string{"deploy/**/*.yaml"}},
+			root:    ".",
+			path:    "charts/app.yaml",
+			allowed: false,
+		},
+		{
+			name:    "exclude takes precedence over include",
+			filter:  PathFilter{Include: []string{"deploy"}, Exclude: []string{"**/testdata/**"}},
+			root:    ".",
+			path:    "deploy/testdata/app.yaml",
+			allowed: false,
+		},
+		{
+			name:    "invalid pattern matches nothing",
+			filter:  PathFilter{Exclude: []string{"[a-"}},
+			root:    ".",
+			path:    "a/main.tf",
+			allowed: true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.allowed, test.filter.AllowsFile(test.root, test.path))
+		})
+	}
+}
+
+func Test_PathFilterSkipsDir(t *testing.T) {
+	filter := PathFilter{
+		Include: []string{"*.json"},
+		Exclude: []string{"node_modules", "build/generated"},
+	}
+	assert.False(t, filter.SkipsDir("code", "code"))
+	assert.True(t, filter.SkipsDir("code", "code/web/node_modules"))
+	assert.True(t, filter.SkipsDir("code", "code/build/generated"))
+	assert.False(t, filter.SkipsDir("code", "code/build"))
+	// include patterns never skip directories, as files beneath them may still match
+	assert.False(t, filter.SkipsDir("code", "code/src"))
+}
@@ -0,0 +1,112 @@
+package options
+
+import (
+	"sync"
+)
+
+// Progress describes how far through a phase of a scan a scanner is
+type Progress struct {
+	Scanner string
+	Phase   Phase
+	// Total is the number of inputs discovered so far. It can grow while the phase is running, e.g. when files are
+	// parsed as they are found.
+	Total     int
+	Completed int
+	// Current is the input most recently started, or empty if it is not known
+	Current string
+}
+
+// ProgressCallback is invoked whenever the progress of a scan changes
+type ProgressCallback func(progress Progress)
+
+type ProgressReportingScanner interface {
+	AddProgressCallbacks(callbacks ...ProgressCallback)
+}
+
+// ScannerWithProgress registers callbacks which are invoked as inputs are discovered and completed during each phase
+// of a scan, so callers can display progress. Callbacks are invoked one at a time, in order, for each phase.
+func ScannerWithProgress(callbacks ...ProgressCallback) ScannerOption {
+	return func(s ConfigurableScanner) {
+		if ps, ok := s.(ProgressReportingScanner); ok {
+			ps.AddProgressCallbacks(callbacks...)
+		}
+	}
+}
+
+func (i *Instrumentation) AddProgressCallbacks(callbacks ...ProgressCallback) {
+	i.progressCallbacks = append(i.progressCallbacks, callbacks...)
+}
+
+// TrackProgress starts tracking the progress of a phase of a scan. It returns nil if no progress callbacks are
+// registered, which is safe to use.
+func (i *Instrumentation) TrackProgress(scanner string, phase Phase) *ProgressTracker {
+	if i == nil || len(i.progressCallbacks) == 0 {
+		return nil
+	}
+	return &ProgressTracker{
+		callbacks: i.progressCallbacks,
+		progress: Progress{
+			Scanner: scanner,
+			Phase:   phase,
+		},
+	}
+}
+
+// ProgressTracker counts the inputs of a single phase of a scan, reporting each change to the progress callbacks.
+// It is safe for concurrent use, and a nil tracker does nothing.
+type ProgressTracker struct {
+	sync.Mutex
+	callbacks []ProgressCallback
+	progress  Progress
+}
+
+// Discovered adds inputs to the total
+func (t *ProgressTracker) Discovered(count int) {
+	if t == nil || count == 0 {
+		return
+	}
+	t.update(func(p *Progress) {
+		p.Total += count
+	})
+}
+
+// Started records that work on an input has begun
+func (t *ProgressTracker) Started(path string) {
+	if t == nil {
+		return
+	}
+	t.update(func(p *Progress) {
+		p.Current = path
+	})
+}
+
+// Completed records that an input has been processed
+func (t *ProgressTracker) Completed(path string) {
+	if t == nil {
+		return
+	}
+	t.update(func(p *Progress) {
+		p.Current = path
+		p.Completed++
+	})
+}
+
+// Finished records that every input discovered has been processed, for phases which process inputs in a batch
+func (t *ProgressTracker) Finished() {
+	if t == nil {
+		return
+	}
+	t.update(func(p *Progress) {
+		p.Current = ""
+		p.Completed = p.Total
+	})
+}
+
+func (t *ProgressTracker) update(f func(p *Progress)) {
+	t.Lock()
+	defer t.Unlock()
+	f(&t.progress)
+	for _, callback := range t.callbacks {
+		callback(t.progress)
+	}
+}
@@ -0,0 +1,81 @@
+package options
+
+import (
+	"sync"
+
+	"github.com/aquasecurity/defsec/pkg/scan"
+	"github.com/aquasecurity/defsec/pkg/severity"
+)
+
+// ResultProcessor is applied to the results of a scan before they are returned to the caller
+type ResultProcessor func(results scan.Results) scan.Results
+
+type ResultProcessingScanner interface {
+	AddResultProcessors(processors ...ResultProcessor)
+}
+
+// ScannerWithResultProcessors registers processors which are applied, in order, to the results of every scan
+func ScannerWithResultProcessors(processors ...ResultProcessor) ScannerOption {
+	return func(s ConfigurableScanner) {
+		if rs, ok := s.(ResultProcessingScanner); ok {
+			rs.AddResultProcessors(processors...)
+		}
+	}
+}
+
+// ScannerWithMinimumReportingSeverity drops results with a severity lower than threshold from the results of every scan
+func ScannerWithMinimumReportingSeverity(threshold severity.Severity) ScannerOption {
+	return ScannerWithResultProcessors(func(results scan.Results) scan.Results {
+		return results.Filter(scan.FilterByMinimumSeverity(threshold))
+	})
+}
+
+// ResultCallback is invoked for each result as soon as it is available, rather than when the scan completes
+type ResultCallback func(result scan.Result)
+
+type StreamingScanner interface {
+	AddResultCallbacks(callbacks ...ResultCallback)
+}
+
+// ScannerWithResultCallback registers callbacks which are invoked for each result as it is produced. Results
+// are passed to callbacks after any result processors have been applied, and are still returned in full when
+// the scan completes.
+func ScannerWithResultCallback(callbacks ...ResultCallback) ScannerOption {
+	return func(s ConfigurableScanner) {
+		if ss, ok := s.(StreamingScanner); ok {
+			ss.AddResultCallbacks(callbacks...)
+		}
+	}
+}
+
+// ResultHandler is embedded by scanners to implement ResultProcessingScanner and StreamingScanner
+type ResultHandler struct {
+	processors []ResultProcessor
+	callbacks  []ResultCallback
+	lock       sync.Mutex
+}
+
+func (h *ResultHandler) AddResultProcessors(processors ...ResultProcessor) {
+	h.processors = append(h.processors, processors...)
+}
+
+func (h *ResultHandler) AddResultCallbacks(callbacks ...ResultCallback) {
+	h.callbacks = append(h.callbacks, callbacks...)
+}
+
+// HandleResults applies the registered processors to a batch of results, in order, and then passes
+// each remaining result to the registered callbacks. It is safe to call concurrently, e.g. for files scanned in
+// parallel, but handles one batch at a time, so processors and callbacks are never invoked concurrently.
+func (h *ResultHandler) HandleResults(results scan.Results) scan.Results {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+	for _, processor := range h.processors {
+		results = processor(results)
+	}
+	for _, result := range results {
+		for _, callback := range h.callbacks {
+			callback(result)
+		}
+	}
+	return results
+}
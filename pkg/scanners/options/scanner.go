@@ -4,7 +4,10 @@ import (
 	"io"
 	"io/fs"
 
+	"github.com/aquasecurity/defsec/pkg/debug"
 	"github.com/aquasecurity/defsec/pkg/framework"
+	"github.com/aquasecurity/defsec/pkg/scan"
+	"github.com/aquasecurity/defsec/pkg/severity"
 )
 
 type ConfigurableScanner interface {
@@ -51,6 +54,20 @@ func ScannerWithDebug(w io.Writer) ScannerOption {
 	}
 }
 
+type LoggingScanner interface {
+	SetLogHandler(debug.Handler)
+}
+
+// ScannerWithLogHandler passes structured, levelled logs from the scanner, its parser and the rego engine to the
+// given handler. It replaces any writer set with ScannerWithDebug, and vice versa.
+func ScannerWithLogHandler(handler debug.Handler) ScannerOption {
+	return func(s ConfigurableScanner) {
+		if ls, ok := s.(LoggingScanner); ok {
+			ls.SetLogHandler(handler)
+		}
+	}
+}
+
 func ScannerWithEmbeddedPolicies(embedded bool) ScannerOption {
 	return func(s ConfigurableScanner) {
 		s.SetUseEmbeddedPolicies(embedded)
@@ -112,3 +129,92 @@ func ScannerWithRegoOnly(regoOnly bool) ScannerOption {
 		s.SetRegoOnly(regoOnly)
 	}
 }
+
+type ParallelScanner interface {
+	SetParallelism(int)
+}
+
+// ScannerWithParallelism sets the number of files (or modules) which are processed concurrently, and the number of
+// inputs evaluated concurrently against each rego policy. Scanners process one at a time unless this option is set -
+// values below 1 mean one per CPU.
+func ScannerWithParallelism(parallelism int) ScannerOption {
+	return func(s ConfigurableScanner) {
+		if ps, ok := s.(ParallelScanner); ok {
+			ps.SetParallelism(parallelism)
+		}
+	}
+}
+
+type DocumentSizeLimitedScanner interface {
+	SetMaxDocumentSize(int64)
+}
+
+// ScannerWithMaxDocumentSize sets the maximum size in bytes of a single document (e.g. one document in a
+// multi-document YAML file). Larger documents are skipped, and recorded in the scan summary, while the other documents
+// in the same file are still scanned. A JSON file or CloudFormation template is a single document, so the whole file is
+// skipped. Values below 1 mean no limit.
+func ScannerWithMaxDocumentSize(size int64) ScannerOption {
+	return func(s ConfigurableScanner) {
+		if ds, ok := s.(DocumentSizeLimitedScanner); ok {
+			ds.SetMaxDocumentSize(size)
+		}
+	}
+}
+
+type LenientScanner interface {
+	SetLenientParsing(bool)
+}
+
+// ScannerWithLenientParsing tolerates common deviations from the JSON and yaml specifications, so that files which
+// other tools accept are scanned rather than failing to parse: comments and trailing commas in JSON, and yaml values
+// such as yes/no and 0644 which are read differently by YAML 1.1 and 1.2 are read the way YAML 1.1 reads them.
+func ScannerWithLenientParsing(lenient bool) ScannerOption {
+	return func(s ConfigurableScanner) {
+		if ls, ok := s.(LenientScanner); ok {
+			ls.SetLenientParsing(lenient)
+		}
+	}
+}
+
+type PolicySelectionCachingScanner interface {
+	SetPolicySelectionCacheDir(string)
+}
+
+// ScannerWithPolicySelectionCache caches which of the loaded rego policies apply to a scan in the given directory,
+// so subsequent scans using the same policies and options skip compiling every policy to select them. Compiled
+// policies cannot be cached, so the policies which do apply are still compiled on every scan.
+func ScannerWithPolicySelectionCache(dir string) ScannerOption {
+	return func(s ConfigurableScanner) {
+		if pc, ok := s.(PolicySelectionCachingScanner); ok {
+			pc.SetPolicySelectionCacheDir(dir)
+		}
+	}
+}
+
+type SeverityFilteredScanner interface {
+	SetMinimumSeverity(severity.Severity)
+}
+
+// ScannerWithMinimumSeverity skips checks with a severity lower than threshold, so they are never evaluated.
+// Unlike ScannerWithMinimumReportingSeverity, this also avoids the cost of running the skipped checks.
+func ScannerWithMinimumSeverity(threshold severity.Severity) ScannerOption {
+	return func(s ConfigurableScanner) {
+		if sf, ok := s.(SeverityFilteredScanner); ok {
+			sf.SetMinimumSeverity(threshold)
+		}
+	}
+}
+
+type RuleSelectingScanner interface {
+	SetRuleSelection(scan.RuleSelection)
+}
+
+// ScannerWithRuleSelection restricts the checks evaluated by a scan to those chosen by the given selection.
+// Checks which are not selected are never run, rather than having their results removed afterwards.
+func ScannerWithRuleSelection(selection scan.RuleSelection) ScannerOption {
+	return func(s ConfigurableScanner) {
+		if rs, ok := s.(RuleSelectingScanner); ok {
+			rs.SetRuleSelection(selection)
+		}
+	}
+}
@@ -0,0 +1,64 @@
+package options
+
+import (
+	"errors"
+	"io/fs"
+
+	"github.com/aquasecurity/defsec/pkg/debug"
+	"github.com/aquasecurity/defsec/pkg/extrafs"
+	"github.com/aquasecurity/defsec/pkg/scanners/limits"
+)
+
+type SymlinkScanner interface {
+	SetFollowSymlinks(bool)
+	SetStayWithinRoot(bool)
+}
+
+// ScannerWithFollowSymlinks walks into symlinked directories when scanning a directory on disk (see
+// extrafs.OSDir), e.g. for module layouts which link in shared modules. Symlinks which loop back to a directory
+// containing them are skipped and reported.
+func ScannerWithFollowSymlinks(follow bool) ScannerOption {
+	return func(s ConfigurableScanner) {
+		if ss, ok := s.(SymlinkScanner); ok {
+			ss.SetFollowSymlinks(follow)
+		}
+	}
+}
+
+// ScannerWithStayWithinRoot skips and reports symlinks which point outside the directory being scanned, when it
+// is on disk (see extrafs.OSDir), so nothing outside it is read
+func ScannerWithStayWithinRoot(within bool) ScannerOption {
+	return func(s ConfigurableScanner) {
+		if ss, ok := s.(SymlinkScanner); ok {
+			ss.SetStayWithinRoot(within)
+		}
+	}
+}
+
+// SymlinkHandler is embedded by scanners to implement SymlinkScanner
+type SymlinkHandler struct {
+	symlinks extrafs.SymlinkOptions
+}
+
+func (h *SymlinkHandler) SetFollowSymlinks(follow bool) {
+	h.symlinks.Follow = follow
+}
+
+func (h *SymlinkHandler) SetStayWithinRoot(within bool) {
+	h.symlinks.WithinRoot = within
+}
+
+// SymlinkFS wraps a filesystem being scanned so that symlinks are handled as configured. Skipped symlinks are
+// logged, and recorded in summary, which may be nil.
+func (h *SymlinkHandler) SymlinkFS(fsys fs.FS, logger debug.Logger, summary *limits.Summary) fs.FS {
+	opts := h.symlinks
+	opts.Skipped = func(path string, err error) {
+		logger.Log("Skipping symlink %s: %s", path, err)
+		reason := limits.ReasonOutsideRoot
+		if errors.Is(err, extrafs.ErrSymlinkLoop) {
+			reason = limits.ReasonSymlinkLoop
+		}
+		summary.Record(path, reason)
+	}
+	return extrafs.WithSymlinks(fsys, opts)
+}
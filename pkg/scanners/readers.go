@@ -0,0 +1,74 @@
+package scanners
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"io/fs"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/liamg/memoryfs"
+
+	"github.com/aquasecurity/defsec/pkg/scan"
+)
+
+// ReaderScanner is implemented by scanners which can scan documents held in memory, e.g. from an API request,
+// without writing them to disk first.
+type ReaderScanner interface {
+	// ScanReaders scans the given documents together, keyed by their paths, so that references between them
+	// (e.g. terraform modules or cloudformation nested stacks) are resolved as they would be on disk.
+	ScanReaders(ctx context.Context, readers map[string]io.Reader) (scan.Results, error)
+}
+
+// ReadersFS builds an in-memory filesystem containing the given documents, keyed by their paths. Paths are
+// cleaned and made relative to the root of the filesystem, and may not refer outside of it.
+func ReadersFS(readers map[string]io.Reader) (fs.FS, error) {
+	names := make([]string, 0, len(readers))
+	for name := range readers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	memfs := memoryfs.New()
+	seen := make(map[string]string)
+	for _, name := range names {
+		cleaned := strings.TrimPrefix(path.Clean("/"+filepath.ToSlash(name)), "/")
+		if cleaned == "" || !fs.ValidPath(cleaned) {
+			return nil, fmt.Errorf("invalid document path '%s'", name)
+		}
+		if other, ok := seen[cleaned]; ok {
+			return nil, fmt.Errorf("document paths '%s' and '%s' refer to the same file", other, name)
+		}
+		seen[cleaned] = name
+
+		if dir := path.Dir(cleaned); dir != "." {
+			if err := memfs.MkdirAll(dir, 0o700); err != nil {
+				return nil, err
+			}
+		}
+		data, err := io.ReadAll(readers[name])
+		if err != nil {
+			return nil, fmt.Errorf("failed to read document '%s': %w", name, err)
+		}
+		if err := memfs.WriteFile(cleaned, data, 0o644); err != nil {
+			return nil, err
+		}
+	}
+	return memfs, nil
+}
+
+// ScanReaders scans the given documents together by building a filesystem containing them and scanning it in full.
+// Scanners use it to implement ReaderScanner.
+func ScanReaders(ctx context.Context, scanner FSScanner, readers map[string]io.Reader) (scan.Results, error) {
+	if len(readers) == 0 {
+		return nil, nil
+	}
+	memfs, err := ReadersFS(readers)
+	if err != nil {
+		return nil, err
+	}
+	return scanner.ScanFS(ctx, memfs, ".")
+}
@@ -1,6 +1,7 @@
 package executor
 
 import (
+	"context"
 	"runtime"
 	"sort"
 	"time"
@@ -22,6 +23,7 @@ import (
 	"github.com/aquasecurity/defsec/pkg/rego"
 
 	adapter "github.com/aquasecurity/defsec/internal/adapters/terraform"
+	rules3 "github.com/aquasecurity/defsec/internal/rules"
 )
 
 // Executor scans HCL blocks by running all registered rules against them
@@ -41,6 +43,8 @@ type Executor struct {
 	regoOnly                  bool
 	stateFuncs                []func(*state.State)
 	frameworks                []framework.Framework
+	minSeverity               severity.Severity
+	ruleSelection             scan.RuleSelection
 }
 
 type Metrics struct {
@@ -87,13 +91,40 @@ func checkInList(id string, altIDs []string, list []string) bool {
 	return false
 }
 
+// serviceSelection determines which services must be adapted into state for the rules and policies being
+// evaluated, so adaptation can be skipped for the rest
+func (e *Executor) serviceSelection(registeredRules []rules3.RegisteredRule) state.Selection {
+	// state modifier functions may read from any part of the state
+	if len(e.stateFuncs) > 0 {
+		return state.Selection{}
+	}
+	selection := state.NewSelection()
+	if !e.regoOnly {
+		selection = rules.ServiceSelection(registeredRules)
+	}
+	if e.regoScanner != nil && !e.regoScanner.SelectServices(&selection) {
+		return state.Selection{}
+	}
+	return selection
+}
+
 func (e *Executor) Execute(modules terraform.Modules) (scan.Results, Metrics, error) {
+	return e.ExecuteContext(context.Background(), modules)
+}
+
+// ExecuteContext runs all rules against the modules, aborting as soon as possible if the context is cancelled
+func (e *Executor) ExecuteContext(ctx context.Context, modules terraform.Modules) (scan.Results, Metrics, error) {
 
 	var metrics Metrics
 
+	registeredRules := rules.FilterBySelection(
+		rules.FilterByMinimumSeverity(rules.GetRegistered(e.frameworks...), e.minSeverity),
+		e.ruleSelection,
+	)
+
 	e.debug.Log("Adapting modules...")
 	adaptationTime := time.Now()
-	infra := adapter.Adapt(modules)
+	infra := adapter.AdaptServices(modules, e.serviceSelection(registeredRules))
 	metrics.Timings.Adaptation = time.Since(adaptationTime)
 	e.debug.Log("Adapted %d module(s) into defsec state data.", len(modules))
 
@@ -112,12 +143,11 @@ func (e *Executor) Execute(modules terraform.Modules) (scan.Results, Metrics, er
 	}
 
 	checksTime := time.Now()
-	registeredRules := rules.GetRegistered(e.frameworks...)
 	e.debug.Log("Initialised %d rule(s).", len(registeredRules))
 
 	pool := NewPool(threads, registeredRules, modules, infra, e.ignoreCheckErrors, e.regoScanner, e.regoOnly)
 	e.debug.Log("Created pool with %d worker(s) to apply rules.", threads)
-	results, err := pool.Run()
+	results, err := pool.Run(ctx)
 	if err != nil {
 		return nil, metrics, err
 	}
@@ -139,19 +169,20 @@ func (e *Executor) Execute(modules terraform.Modules) (scan.Results, Metrics, er
 				result.Rule().AVDID,
 				result.Rule().ShortCode,
 			}
-			allIDs = append(allIDs, result.Rule().Aliases...)
+			allIDs = append(allIDs, result.Rule().AllAliases()...)
 
 			if e.alternativeIDProviderFunc != nil {
 				allIDs = append(allIDs, e.alternativeIDProviderFunc(result.Rule().LongID())...)
 			}
-			if ignores.Covering(
+			if ignore := ignores.Covering(
 				modules,
 				result.Metadata(),
 				e.workspaceName,
 				allIDs...,
-			) != nil {
+			); ignore != nil {
 				e.debug.Log("Ignored '%s' at '%s'.", result.Rule().LongID(), result.Range())
 				results[i].OverrideStatus(scan.StatusIgnored)
+				results[i].OverrideIgnoreReason(ignore.Reason)
 			}
 		}
 	} else {
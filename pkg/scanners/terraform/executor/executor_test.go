@@ -127,3 +127,26 @@ resource "problem" "this" {
 	_, _, err = New(OptionStopOnErrors(false)).Execute(modules)
 	assert.Error(t, err)
 }
+
+func Test_ExecuteWithCancelledContext(t *testing.T) {
+
+	fs := testutil.CreateFS(t, map[string]string{
+		"project/main.tf": `
+resource "problem" "this" {
+	panic = false
+}
+`,
+	})
+
+	p := parser.New(fs, "", parser.OptionStopOnHCLError(true))
+	err := p.ParseFS(context.TODO(), "project")
+	require.NoError(t, err)
+	modules, _, err := p.EvaluateAll(context.TODO())
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, _, err = New().ExecuteContext(ctx, modules)
+	assert.ErrorIs(t, err, context.Canceled)
+}
@@ -12,6 +12,8 @@ import (
 	"github.com/aquasecurity/defsec/pkg/scan"
 
 	"github.com/aquasecurity/defsec/pkg/rego"
+
+	"github.com/aquasecurity/defsec/pkg/severity"
 )
 
 type Option func(s *Executor)
@@ -46,6 +48,13 @@ func OptionWithDebugWriter(w io.Writer) Option {
 	}
 }
 
+// OptionWithLogHandler passes structured, levelled logs to the given handler
+func OptionWithLogHandler(handler debug.Handler) Option {
+	return func(s *Executor) {
+		s.debug = debug.NewWithHandler(handler, "terraform", "executor")
+	}
+}
+
 func OptionNoIgnores() Option {
 	return func(s *Executor) {
 		s.enableIgnores = false
@@ -105,3 +114,17 @@ func OptionWithRegoOnly(regoOnly bool) Option {
 		e.regoOnly = regoOnly
 	}
 }
+
+// OptionWithMinimumSeverity skips rules with a severity lower than threshold
+func OptionWithMinimumSeverity(threshold severity.Severity) Option {
+	return func(e *Executor) {
+		e.minSeverity = threshold
+	}
+}
+
+// OptionWithRuleSelection skips rules which are not chosen by the given selection
+func OptionWithRuleSelection(selection scan.RuleSelection) Option {
+	return func(e *Executor) {
+		e.ruleSelection = selection
+	}
+}
@@ -42,18 +42,42 @@ func NewPool(size int, rules []rules3.RegisteredRule, modules terraform.Modules,
 	}
 }
 
-// Run runs the job in the pool - this will only return an error if a job panics
-func (p *Pool) Run() (scan.Results, error) {
+// Run runs the job in the pool - this will only return an error if a job panics, or the context is cancelled
+func (p *Pool) Run(ctx context.Context) (scan.Results, error) {
 
 	outgoing := make(chan Job, p.size*2)
 
 	var workers []*Worker
 	for i := 0; i < p.size; i++ {
 		worker := NewWorker(outgoing)
-		go worker.Start()
+		go worker.Start(ctx)
 		workers = append(workers, worker)
 	}
 
+	p.dispatch(ctx, outgoing)
+
+	var results scan.Results
+	for _, worker := range workers {
+		results = append(results, worker.Wait()...)
+		if err := worker.Error(); err != nil {
+			if ctx.Err() != nil {
+				return nil, ctx.Err()
+			}
+			return nil, err
+		}
+	}
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	return results, nil
+}
+
+// dispatch sends a job for each rule to the workers, stopping early if the context is cancelled
+func (p *Pool) dispatch(ctx context.Context, outgoing chan<- Job) {
+	defer close(outgoing)
+
 	if p.rs != nil {
 		var basePath string
 		if len(p.modules) > 0 {
@@ -68,6 +92,9 @@ func (p *Pool) Run() (scan.Results, error) {
 
 	if !p.regoOnly {
 		for _, r := range p.rules {
+			if ctx.Err() != nil {
+				return
+			}
 			if r.Rule().CustomChecks.Terraform != nil && r.Rule().CustomChecks.Terraform.Check != nil {
 				// run local hcl rule
 				for _, module := range p.modules {
@@ -88,22 +115,10 @@ func (p *Pool) Run() (scan.Results, error) {
 			}
 		}
 	}
-
-	close(outgoing)
-
-	var results scan.Results
-	for _, worker := range workers {
-		results = append(results, worker.Wait()...)
-		if err := worker.Error(); err != nil {
-			return nil, err
-		}
-	}
-
-	return results, nil
 }
 
 type Job interface {
-	Run() (scan.Results, error)
+	Run(ctx context.Context) (scan.Results, error)
 }
 
 type infraRuleJob struct {
@@ -125,7 +140,7 @@ type regoJob struct {
 	basePath string
 }
 
-func (h *infraRuleJob) Run() (_ scan.Results, err error) {
+func (h *infraRuleJob) Run(_ context.Context) (_ scan.Results, err error) {
 	if h.ignoreErrors {
 		defer func() {
 			if panicErr := recover(); panicErr != nil {
@@ -136,7 +151,7 @@ func (h *infraRuleJob) Run() (_ scan.Results, err error) {
 	return h.rule.Evaluate(h.state), err
 }
 
-func (h *hclModuleRuleJob) Run() (results scan.Results, err error) {
+func (h *hclModuleRuleJob) Run(ctx context.Context) (results scan.Results, err error) {
 	if h.ignoreErrors {
 		defer func() {
 			if panicErr := recover(); panicErr != nil {
@@ -146,6 +161,9 @@ func (h *hclModuleRuleJob) Run() (results scan.Results, err error) {
 	}
 	customCheck := h.rule.Rule().CustomChecks.Terraform
 	for _, block := range h.module.GetBlocks() {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
 		if !isCustomCheckRequiredForBlock(customCheck, block) {
 			continue
 		}
@@ -155,8 +173,8 @@ func (h *hclModuleRuleJob) Run() (results scan.Results, err error) {
 	return
 }
 
-func (h *regoJob) Run() (results scan.Results, err error) {
-	regoResults, err := h.scanner.ScanInput(context.TODO(), rego.Input{
+func (h *regoJob) Run(ctx context.Context) (results scan.Results, err error) {
+	regoResults, err := h.scanner.ScanInput(ctx, rego.Input{
 		Contents: h.state.ToRego(),
 		Path:     h.basePath,
 	})
@@ -275,12 +293,16 @@ func NewWorker(incoming <-chan Job) *Worker {
 	return w
 }
 
-func (w *Worker) Start() {
+func (w *Worker) Start(ctx context.Context) {
 	defer w.mu.Unlock()
 	w.results = nil
 	for job := range w.incoming {
+		// keep draining jobs once cancelled, so the dispatcher is never blocked
+		if ctx.Err() != nil {
+			continue
+		}
 		func() {
-			results, err := job.Run()
+			results, err := job.Run(ctx)
 			if err != nil {
 				w.panic = err
 			}
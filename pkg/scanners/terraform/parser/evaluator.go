@@ -156,7 +156,7 @@ func (e *evaluator) EvaluateAll(ctx context.Context) (terraform.Modules, map[str
 	for _, definition := range e.loadModules(ctx) {
 		submodules, outputs, err := definition.Parser.EvaluateAll(ctx)
 		if err != nil {
-			e.debug.Log("Failed to evaluate submodule '%s': %s.", definition.Name, err)
+			e.debug.Warn("Failed to evaluate submodule", "submodule", definition.Name, "error", err)
 			continue
 		}
 		// export module outputs
@@ -63,7 +63,7 @@ func (e *evaluator) loadModules(ctx context.Context) []*ModuleDefinition {
 				}
 				continue
 			}
-			e.debug.Log("Failed to load module '%s'. Maybe try 'terraform init'?", err)
+			e.debug.Warn("Failed to load module. Maybe try 'terraform init'?", "error", err)
 			continue
 		}
 		e.debug.Log("Loaded module '%s' from '%s'.", moduleDefinition.Name, moduleDefinition.Path)
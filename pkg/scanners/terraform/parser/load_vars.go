@@ -11,6 +11,8 @@ import (
 	"github.com/hashicorp/hcl/v2/hclsyntax"
 	hcljson "github.com/hashicorp/hcl/v2/json"
 	"github.com/zclconf/go-cty/cty"
+
+	"github.com/aquasecurity/defsec/pkg/scanners/charset"
 )
 
 func loadTFVars(srcFS fs.FS, filenames []string) (map[string]cty.Value, error) {
@@ -54,6 +56,7 @@ func loadTFVarsFile(srcFS fs.FS, filename string) (map[string]cty.Value, error)
 	if err != nil {
 		return nil, err
 	}
+	src = charset.Decode(src)
 
 	var attrs hcl.Attributes
 	if strings.HasSuffix(filename, ".json") {
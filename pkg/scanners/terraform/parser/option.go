@@ -1,6 +1,7 @@
 package parser
 
 import (
+	"github.com/aquasecurity/defsec/pkg/scanners/limits"
 	"github.com/aquasecurity/defsec/pkg/scanners/options"
 )
 
@@ -10,6 +11,8 @@ type ConfigurableTerraformParser interface {
 	SetStopOnHCLError(bool)
 	SetWorkspaceName(string)
 	SetAllowDownloads(bool)
+	SetPathFilter(filter options.PathFilter, scanDir string)
+	SetFileLimitTracker(tracker *limits.Tracker)
 }
 
 type Option func(p ConfigurableTerraformParser)
@@ -45,3 +48,24 @@ func OptionWithDownloads(allowed bool) options.ParserOption {
 		}
 	}
 }
+
+// OptionWithPathFilter skips files in the root module which are not allowed by the filter, matching patterns against
+// paths relative to scanDir. Files in modules called by the root module are always parsed.
+func OptionWithPathFilter(filter options.PathFilter, scanDir string) options.ParserOption {
+	return func(p options.ConfigurableParser) {
+		if tf, ok := p.(ConfigurableTerraformParser); ok {
+			tf.SetPathFilter(filter, scanDir)
+		}
+	}
+}
+
+// OptionWithFileLimitTracker applies the limits of the tracker to every file parsed, including the files of modules.
+// A single tracker is shared by the parsers of every root module in a scan, so the limits apply to the scan as a
+// whole.
+func OptionWithFileLimitTracker(tracker *limits.Tracker) options.ParserOption {
+	return func(p options.ConfigurableParser) {
+		if tf, ok := p.(ConfigurableTerraformParser); ok {
+			tf.SetFileLimitTracker(tracker)
+		}
+	}
+}
@@ -2,6 +2,7 @@ package parser
 
 import (
 	"context"
+	"errors"
 	"io"
 	"io/fs"
 	"os"
@@ -12,6 +13,8 @@ import (
 
 	"github.com/aquasecurity/defsec/pkg/debug"
 
+	"github.com/aquasecurity/defsec/pkg/scanners/charset"
+	"github.com/aquasecurity/defsec/pkg/scanners/limits"
 	"github.com/aquasecurity/defsec/pkg/scanners/options"
 
 	tfcontext "github.com/aquasecurity/defsec/pkg/scanners/terraform/context"
@@ -22,7 +25,8 @@ import (
 	"github.com/zclconf/go-cty/cty"
 
 	"github.com/hashicorp/hcl/v2"
-	"github.com/hashicorp/hcl/v2/hclparse"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	hcljson "github.com/hashicorp/hcl/v2/json"
 )
 
 type sourceFile struct {
@@ -57,7 +61,6 @@ type Parser struct {
 	tfvarsPaths    []string
 	stopOnHCLError bool
 	workspaceName  string
-	underlying     *hclparse.Parser
 	children       []*Parser
 	metrics        Metrics
 	options        []options.ParserOption
@@ -65,12 +68,20 @@ type Parser struct {
 	allowDownloads bool
 	fsMap          map[string]fs.FS
 	skipRequired   bool
+	pathFilter     options.PathFilter
+	scanDir        string
+	tracker        *limits.Tracker
 }
 
 func (p *Parser) SetDebugWriter(writer io.Writer) {
 	p.debug = debug.New(writer, "terraform", "parser", "<"+p.moduleName+">")
 }
 
+func (p *Parser) SetLogHandler(handler debug.Handler) {
+	logger := debug.NewWithHandler(handler, "terraform", "parser")
+	p.debug = logger.With("module", p.moduleName)
+}
+
 func (p *Parser) SetTFVarsPaths(s ...string) {
 	p.tfvarsPaths = s
 }
@@ -91,11 +102,19 @@ func (p *Parser) SetSkipRequiredCheck(b bool) {
 	p.skipRequired = b
 }
 
+func (p *Parser) SetPathFilter(filter options.PathFilter, scanDir string) {
+	p.pathFilter = filter
+	p.scanDir = scanDir
+}
+
+func (p *Parser) SetFileLimitTracker(tracker *limits.Tracker) {
+	p.tracker = tracker
+}
+
 // New creates a new Parser
 func New(moduleFS fs.FS, moduleSource string, opts ...options.ParserOption) *Parser {
 	p := &Parser{
 		workspaceName:  "default",
-		underlying:     hclparse.NewParser(),
 		options:        opts,
 		moduleName:     "root",
 		allowDownloads: true,
@@ -136,7 +155,7 @@ func (p *Parser) Metrics() Metrics {
 	return total
 }
 
-func (p *Parser) ParseFile(_ context.Context, fullPath string) error {
+func (p *Parser) ParseFile(ctx context.Context, fullPath string) error {
 	diskStart := time.Now()
 
 	isJSON := strings.HasSuffix(fullPath, ".tf.json")
@@ -156,6 +175,7 @@ func (p *Parser) ParseFile(_ context.Context, fullPath string) error {
 	if err != nil {
 		return err
 	}
+	data = charset.Decode(data)
 	p.metrics.Timings.DiskIODuration += time.Since(diskStart)
 	if dir := filepath.Dir(fullPath); p.projectRoot == "" {
 		p.debug.Log("Setting project/module root to '%s'", dir)
@@ -164,16 +184,11 @@ func (p *Parser) ParseFile(_ context.Context, fullPath string) error {
 	}
 
 	start := time.Now()
-	var file *hcl.File
-	var diag hcl.Diagnostics
-
-	if isHCL {
-		file, diag = p.underlying.ParseHCL(data, fullPath)
-	} else {
-		file, diag = p.underlying.ParseJSON(data, fullPath)
-	}
-	if diag != nil && diag.HasErrors() {
-		return diag
+	file, err := limits.Parse(ctx, p.tracker, fullPath, func(context.Context) (*hcl.File, error) {
+		return parseSource(data, fullPath, isHCL)
+	})
+	if err != nil {
+		return err
 	}
 	p.files = append(p.files, sourceFile{
 		file: file,
@@ -185,6 +200,22 @@ func (p *Parser) ParseFile(_ context.Context, fullPath string) error {
 	return nil
 }
 
+// parseSource parses the content of a file. The file is parsed on its own rather than by a shared hclparse.Parser,
+// as a parse abandoned after the parse timeout may still be running when the next file is parsed.
+func parseSource(data []byte, path string, isHCL bool) (*hcl.File, error) {
+	var file *hcl.File
+	var diag hcl.Diagnostics
+	if isHCL {
+		file, diag = hclsyntax.ParseConfig(data, path, hcl.Pos{Line: 1, Column: 1})
+	} else {
+		file, diag = hcljson.Parse(data, path)
+	}
+	if diag.HasErrors() {
+		return nil, diag
+	}
+	return file, nil
+}
+
 // ParseFS parses a root module, where it exists at the root of the provided filesystem
 func (p *Parser) ParseFS(ctx context.Context, dir string) error {
 
@@ -210,17 +241,17 @@ func (p *Parser) ParseFS(ctx context.Context, dir string) error {
 			extra, ok := p.moduleFS.(extrafs.FS)
 			if !ok {
 				// we can't handle symlinks in this fs type for now
-				p.debug.Log("Cannot resolve symlink '%s' in '%s' for this fs type", info.Name(), dir)
+				p.debug.Warn("Cannot resolve symlink for this fs type", "name", info.Name(), "dir", dir)
 				continue
 			}
 			realPath, err = extra.ResolveSymlink(info.Name(), dir)
 			if err != nil {
-				p.debug.Log("Failed to resolve symlink '%s' in '%s': %s", info.Name(), dir, err)
+				p.debug.Warn("Failed to resolve symlink", "name", info.Name(), "dir", dir, "error", err)
 				continue
 			}
 			info, err := extra.Stat(realPath)
 			if err != nil {
-				p.debug.Log("Failed to stat resolved symlink '%s': %s", realPath, err)
+				p.debug.Warn("Failed to stat resolved symlink", "path", realPath, "error", err)
 				continue
 			}
 			if info.IsDir() {
@@ -230,15 +261,27 @@ func (p *Parser) ParseFS(ctx context.Context, dir string) error {
 		} else if info.IsDir() {
 			continue
 		}
+		if p.moduleBlock == nil && !p.pathFilter.AllowsFile(p.scanDir, filepath.Join(dir, info.Name())) {
+			p.debug.Log("Skipping '%s' as it is excluded by the path filter", info.Name())
+			continue
+		}
 		paths = append(paths, realPath)
 	}
 	sort.Strings(paths)
 	for _, path := range paths {
+		// files which exceed the limits are recorded in the summary of the tracker and skipped
+		if !p.tracker.AllowSize(p.moduleFS, path) || !p.tracker.AllowFile(path) {
+			continue
+		}
 		if err := p.ParseFile(ctx, path); err != nil {
+			if ctx.Err() == nil && errors.Is(err, context.DeadlineExceeded) {
+				p.debug.Warn("Parsing timed out", "path", path)
+				continue
+			}
 			if p.stopOnHCLError {
 				return err
 			}
-			p.debug.Log("error parsing '%s': %s", path, err)
+			p.debug.Warn("Failed to parse file", "path", path, "error", err)
 			continue
 		}
 	}
@@ -6,6 +6,7 @@ import (
 	"io/fs"
 	"os"
 	"path/filepath"
+	"sync"
 	"sync/atomic"
 
 	"github.com/hashicorp/go-getter"
@@ -13,6 +14,14 @@ import (
 
 type remoteResolver struct {
 	count int32
+	// downloads records each module downloaded, so root modules scanned concurrently which use the same module do
+	// not download it over each other
+	downloads sync.Map
+}
+
+type download struct {
+	sync.Mutex
+	done bool
 }
 
 var Remote = &remoteResolver{
@@ -21,8 +30,8 @@ var Remote = &remoteResolver{
 
 func (r *remoteResolver) incrementCount(o Options) {
 	o.Debug("Incrementing the download counter")
-	atomic.CompareAndSwapInt32(&r.count, r.count, r.count+1)
-	o.Debug("Download counter is now %d", r.count)
+	count := atomic.AddInt32(&r.count, 1)
+	o.Debug("Download counter is now %d", count)
 }
 
 func (r *remoteResolver) GetDownloadCount() int {
@@ -46,26 +55,46 @@ func (r *remoteResolver) Resolve(ctx context.Context, _ fs.FS, opt Options) (fil
 		return nil, "", "", true, fmt.Errorf("failed to locate cache directory: %w", err)
 	}
 	cacheDir := filepath.Join(baseCacheDir, key)
-	if err := r.download(ctx, opt, cacheDir); err != nil {
-		return nil, "", "", true, err
-	}
 
-	r.incrementCount(opt)
-	opt.Debug("Successfully downloaded %s from %s", opt.Name, opt.Source)
+	existing, _ := r.downloads.LoadOrStore(cacheDir, &download{})
+	d := existing.(*download)
+	d.Lock()
+	defer d.Unlock()
+
+	// the module may already have been downloaded for another root module, which may still be reading it
+	if !d.done {
+		if err := r.download(ctx, opt, cacheDir); err != nil {
+			return nil, "", "", true, err
+		}
+		d.done = true
+		r.incrementCount(opt)
+		opt.Debug("Successfully downloaded %s from %s", opt.Name, opt.Source)
+	}
 	opt.Debug("Module '%s' resolved via remote download.", opt.Name)
 	return os.DirFS(cacheDir), opt.Source, filepath.Join(".", opt.RelativePath), true, nil
 }
 
+// download fetches a module into dst. It is downloaded to a temporary directory first, so a partially downloaded
+// module is never found in the cache by the cache resolver.
 func (r *remoteResolver) download(ctx context.Context, opt Options, dst string) error {
-	_ = os.RemoveAll(dst)
 	if err := os.MkdirAll(filepath.Dir(dst), 0o755); err != nil {
 		return err
 	}
+	tmp, err := os.MkdirTemp(filepath.Dir(dst), filepath.Base(dst)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer func() { _ = os.RemoveAll(tmp) }()
 
 	var opts []getter.ClientOption
 
-	// Overwrite the file getter so that a file will be copied
-	getter.Getters["file"] = &getter.FileGetter{Copy: true}
+	// Overwrite the file getter so that a file will be copied. The shared getters are copied rather than modified,
+	// as modules can be downloaded concurrently.
+	getters := make(map[string]getter.Getter, len(getter.Getters))
+	for scheme, g := range getter.Getters {
+		getters[scheme] = g
+	}
+	getters["file"] = &getter.FileGetter{Copy: true}
 
 	opt.Debug("Downloading %s...", opt.Source)
 
@@ -73,9 +102,9 @@ func (r *remoteResolver) download(ctx context.Context, opt Options, dst string)
 	client := &getter.Client{
 		Ctx:     ctx,
 		Src:     opt.Source,
-		Dst:     dst,
+		Dst:     filepath.Join(tmp, "module"),
 		Pwd:     opt.WorkingDir,
-		Getters: getter.Getters,
+		Getters: getters,
 		Mode:    getter.ClientModeAny,
 		Options: opts,
 	}
@@ -84,7 +113,8 @@ func (r *remoteResolver) download(ctx context.Context, opt Options, dst string)
 		return fmt.Errorf("failed to download: %w", err)
 	}
 
-	return nil
+	_ = os.RemoveAll(dst)
+	return os.Rename(filepath.Join(tmp, "module"), dst)
 }
 
 func (r *remoteResolver) GetSourcePrefix(source string) string {
@@ -11,6 +11,8 @@ import (
 	"sync"
 	"time"
 
+	"github.com/aquasecurity/defsec/pkg/concurrency"
+	"github.com/aquasecurity/defsec/pkg/terraform"
 	"github.com/aquasecurity/defsec/pkg/types"
 
 	"github.com/aquasecurity/defsec/pkg/framework"
@@ -26,13 +28,26 @@ import (
 	"github.com/aquasecurity/defsec/pkg/scanners/terraform/parser/resolvers"
 
 	"github.com/aquasecurity/defsec/pkg/scan"
+	"github.com/aquasecurity/defsec/pkg/severity"
 
 	"github.com/aquasecurity/defsec/pkg/extrafs"
 )
 
 var _ scanners.FSScanner = (*Scanner)(nil)
+var _ scanners.ReaderScanner = (*Scanner)(nil)
 var _ options.ConfigurableScanner = (*Scanner)(nil)
 var _ ConfigurableTerraformScanner = (*Scanner)(nil)
+var _ options.ResultProcessingScanner = (*Scanner)(nil)
+var _ options.StreamingScanner = (*Scanner)(nil)
+var _ options.ParallelScanner = (*Scanner)(nil)
+var _ options.InstrumentedScanner = (*Scanner)(nil)
+var _ options.MetricsScanner = (*Scanner)(nil)
+var _ options.ProgressReportingScanner = (*Scanner)(nil)
+var _ options.PathFilteredScanner = (*Scanner)(nil)
+var _ options.SymlinkScanner = (*Scanner)(nil)
+var _ options.SeverityFilteredScanner = (*Scanner)(nil)
+var _ options.RuleSelectingScanner = (*Scanner)(nil)
+var _ options.LoggingScanner = (*Scanner)(nil)
 
 type Scanner struct {
 	options                 []options.ScannerOption
@@ -50,6 +65,11 @@ type Scanner struct {
 	loadEmbedded bool
 	frameworks   []framework.Framework
 	spec         string
+	parallelism  int
+	options.FileLimitHandler
+	options.SymlinkHandler
+	options.Instrumentation
+	options.ResultHandler
 }
 
 func (s *Scanner) SetSpec(spec string) {
@@ -64,6 +84,14 @@ func (s *Scanner) SetFrameworks(frameworks []framework.Framework) {
 	s.frameworks = frameworks
 }
 
+func (s *Scanner) SetMinimumSeverity(threshold severity.Severity) {
+	s.executorOpt = append(s.executorOpt, executor.OptionWithMinimumSeverity(threshold))
+}
+
+func (s *Scanner) SetRuleSelection(selection scan.RuleSelection) {
+	s.executorOpt = append(s.executorOpt, executor.OptionWithRuleSelection(selection))
+}
+
 func (s *Scanner) SetUseEmbeddedPolicies(b bool) {
 	s.loadEmbedded = b
 }
@@ -96,12 +124,22 @@ func (s *Scanner) SetSkipRequiredCheck(skip bool) {
 	s.parserOpt = append(s.parserOpt, options.ParserWithSkipRequiredCheck(skip))
 }
 
+func (s *Scanner) SetParallelism(parallelism int) {
+	s.parallelism = parallelism
+}
+
 func (s *Scanner) SetDebugWriter(writer io.Writer) {
 	s.parserOpt = append(s.parserOpt, options.ParserWithDebug(writer))
 	s.executorOpt = append(s.executorOpt, executor.OptionWithDebugWriter(writer))
 	s.debug = debug.New(writer, "terraform", "scanner")
 }
 
+func (s *Scanner) SetLogHandler(handler debug.Handler) {
+	s.parserOpt = append(s.parserOpt, options.ParserWithLogHandler(handler))
+	s.executorOpt = append(s.executorOpt, executor.OptionWithLogHandler(handler))
+	s.debug = debug.NewWithHandler(handler, "terraform", "scanner")
+}
+
 func (s *Scanner) SetTraceWriter(_ io.Writer) {
 }
 
@@ -133,8 +171,9 @@ type Metrics struct {
 
 func New(options ...options.ScannerOption) *Scanner {
 	s := &Scanner{
-		dirs:    make(map[string]struct{}),
-		options: options,
+		dirs:        make(map[string]struct{}),
+		options:     options,
+		parallelism: 1,
 	}
 	for _, opt := range options {
 		opt(s)
@@ -142,6 +181,11 @@ func New(options ...options.ScannerOption) *Scanner {
 	return s
 }
 
+// ScanReaders scans the given documents together, keyed by their paths, see scanners.ReaderScanner
+func (s *Scanner) ScanReaders(ctx context.Context, readers map[string]io.Reader) (scan.Results, error) {
+	return scanners.ScanReaders(ctx, s, readers)
+}
+
 func (s *Scanner) ScanFS(ctx context.Context, target fs.FS, dir string) (scan.Results, error) {
 	results, _, err := s.ScanFSWithMetrics(ctx, target, dir)
 	return results, err
@@ -171,10 +215,12 @@ func (s *Scanner) ScanFSWithMetrics(ctx context.Context, target fs.FS, dir strin
 
 	var metrics Metrics
 
+	target = s.SymlinkFS(target, s.debug, s.ScanSummary())
 	s.debug.Log("Scanning [%s] at '%s'...", target, dir)
 
 	// find directories which directly contain tf files (and have no parent containing tf files)
-	rootDirs := s.findRootModules(target, dir, dir)
+	scanDir := dir
+	rootDirs := s.findRootModules(target, scanDir, dir)
 	sort.Strings(rootDirs)
 
 	if len(rootDirs) == 0 {
@@ -191,78 +237,130 @@ func (s *Scanner) ScanFSWithMetrics(ctx context.Context, target fs.FS, dir strin
 	s.executorOpt = append(s.executorOpt, executor.OptionWithRegoScanner(regoScanner), executor.OptionWithFrameworks(s.frameworks...))
 	s.execLock.Unlock()
 
+	// each root module has its own parser and executor, so root modules are parsed and evaluated concurrently, and
+	// are released as soon as their results have been handled rather than held until every module is parsed
+	parserOpts := make([]options.ParserOption, 0, len(s.parserOpt)+2)
+	parserOpts = append(parserOpts, s.parserOpt...)
+	parserOpts = append(parserOpts,
+		parser.OptionWithPathFilter(s.PathFilter(), scanDir),
+		// with parallelism, which files exceed the maximum number of files can vary as root modules are parsed
+		// concurrently
+		parser.OptionWithFileLimitTracker(s.FileLimitTracker()),
+	)
+	progress := rootModuleProgress{
+		parse:    s.TrackProgress(s.Name(), options.PhaseParse),
+		evaluate: s.TrackProgress(s.Name(), options.PhaseEvaluate),
+	}
+	progress.parse.Discovered(len(rootDirs))
+	progress.evaluate.Discovered(len(rootDirs))
+	roots, err := concurrency.Process(ctx, rootDirs, concurrency.Workers(s.parallelism), func(ctx context.Context, dir string) (scannedRootModule, error) {
+		return s.scanRootModule(ctx, target, dir, parserOpts, progress)
+	})
+	if err != nil {
+		return nil, metrics, err
+	}
+
 	var allResults scan.Results
+	for _, root := range roots {
+		metrics.Parser.Counts.Blocks += root.parserMetrics.Counts.Blocks
+		metrics.Parser.Counts.Modules += root.parserMetrics.Counts.Modules
+		metrics.Parser.Counts.Files += root.parserMetrics.Counts.Files
+		metrics.Parser.Timings.DiskIODuration += root.parserMetrics.Timings.DiskIODuration
+		metrics.Parser.Timings.ParseDuration += root.parserMetrics.Timings.ParseDuration
+
+		metrics.Executor.Counts.Passed += root.executorMetrics.Counts.Passed
+		metrics.Executor.Counts.Failed += root.executorMetrics.Counts.Failed
+		metrics.Executor.Counts.Ignored += root.executorMetrics.Counts.Ignored
+		metrics.Executor.Counts.Critical += root.executorMetrics.Counts.Critical
+		metrics.Executor.Counts.High += root.executorMetrics.Counts.High
+		metrics.Executor.Counts.Medium += root.executorMetrics.Counts.Medium
+		metrics.Executor.Counts.Low += root.executorMetrics.Counts.Low
+		metrics.Executor.Timings.Adaptation += root.executorMetrics.Timings.Adaptation
+		metrics.Executor.Timings.RunningChecks += root.executorMetrics.Timings.RunningChecks
+
+		allResults = append(allResults, root.results...)
+	}
 
-	// parse all root module directories
-	for _, dir := range rootDirs {
+	s.RecordFiles(s.Name(), metrics.Parser.Counts.Files)
+	s.RecordResults(s.Name(), allResults)
 
-		s.debug.Log("Scanning root module '%s'...", dir)
+	metrics.Parser.Counts.ModuleDownloads = resolvers.Remote.GetDownloadCount()
 
-		p := parser.New(target, "", s.parserOpt...)
-		s.execLock.RLock()
-		e := executor.New(s.executorOpt...)
-		s.execLock.RUnlock()
+	metrics.Timings.Total += metrics.Parser.Timings.DiskIODuration
+	metrics.Timings.Total += metrics.Parser.Timings.ParseDuration
+	metrics.Timings.Total += metrics.Executor.Timings.Adaptation
+	metrics.Timings.Total += metrics.Executor.Timings.RunningChecks
 
-		if err := p.ParseFS(ctx, dir); err != nil {
-			return nil, metrics, err
-		}
+	return allResults, metrics, nil
+}
 
-		modules, _, err := p.EvaluateAll(ctx)
-		if err != nil {
-			return nil, metrics, err
-		}
+type scannedRootModule struct {
+	results         scan.Results
+	parserMetrics   parser.Metrics
+	executorMetrics executor.Metrics
+}
 
-		parserMetrics := p.Metrics()
-		metrics.Parser.Counts.Blocks += parserMetrics.Counts.Blocks
-		metrics.Parser.Counts.Modules += parserMetrics.Counts.Modules
-		metrics.Parser.Counts.Files += parserMetrics.Counts.Files
-		metrics.Parser.Timings.DiskIODuration += parserMetrics.Timings.DiskIODuration
-		metrics.Parser.Timings.ParseDuration += parserMetrics.Timings.ParseDuration
+type rootModuleProgress struct {
+	parse    *options.ProgressTracker
+	evaluate *options.ProgressTracker
+}
 
-		results, execMetrics, err := e.Execute(modules)
-		if err != nil {
-			return nil, metrics, err
-		}
+// scanRootModule parses and evaluates a single root module, handling its results as soon as they are available
+func (s *Scanner) scanRootModule(ctx context.Context, target fs.FS, dir string, parserOpts []options.ParserOption, progress rootModuleProgress) (scannedRootModule, error) {
+	s.debug.Log("Parsing root module '%s'...", dir)
+	progress.parse.Started(dir)
+	done := s.StartPhase(ctx, s.Name(), options.PhaseParse, dir)
+	p := parser.New(target, "", parserOpts...)
+	err := p.ParseFS(ctx, dir)
+	var modules terraform.Modules
+	if err == nil {
+		modules, _, err = p.EvaluateAll(ctx)
+	}
+	done()
+	progress.parse.Completed(dir)
+	if err != nil {
+		return scannedRootModule{}, err
+	}
 
-		fsMap := p.GetFilesystemMap()
-		for i, result := range results {
-			if result.Metadata().Range().GetFS() != nil {
-				continue
-			}
-			key := result.Metadata().Range().GetFSKey()
-			if key == "" {
-				continue
-			}
-			if filesystem, ok := fsMap[key]; ok {
-				override := scan.Results{
-					result,
-				}
-				override.SetSourceAndFilesystem(result.Range().GetSourcePrefix(), filesystem, false)
-				results[i] = override[0]
-			}
-		}
+	s.debug.Log("Scanning root module '%s'...", dir)
+	progress.evaluate.Started(dir)
+	s.execLock.RLock()
+	e := executor.New(s.executorOpt...)
+	s.execLock.RUnlock()
 
-		metrics.Executor.Counts.Passed += execMetrics.Counts.Passed
-		metrics.Executor.Counts.Failed += execMetrics.Counts.Failed
-		metrics.Executor.Counts.Ignored += execMetrics.Counts.Ignored
-		metrics.Executor.Counts.Critical += execMetrics.Counts.Critical
-		metrics.Executor.Counts.High += execMetrics.Counts.High
-		metrics.Executor.Counts.Medium += execMetrics.Counts.Medium
-		metrics.Executor.Counts.Low += execMetrics.Counts.Low
-		metrics.Executor.Timings.Adaptation += execMetrics.Timings.Adaptation
-		metrics.Executor.Timings.RunningChecks += execMetrics.Timings.RunningChecks
-
-		allResults = append(allResults, results...)
+	results, execMetrics, err := e.ExecuteContext(ctx, modules)
+	if err != nil {
+		return scannedRootModule{}, err
 	}
+	s.Record(s.Name(), options.PhaseAdapt, dir, execMetrics.Timings.Adaptation)
+	s.Record(s.Name(), options.PhaseEvaluate, dir, execMetrics.Timings.RunningChecks)
 
-	metrics.Parser.Counts.ModuleDownloads = resolvers.Remote.GetDownloadCount()
+	fsMap := p.GetFilesystemMap()
+	for i, result := range results {
+		if result.Metadata().Range().GetFS() != nil {
+			continue
+		}
+		key := result.Metadata().Range().GetFSKey()
+		if key == "" {
+			continue
+		}
+		if filesystem, ok := fsMap[key]; ok {
+			override := scan.Results{
+				result,
+			}
+			override.SetSourceAndFilesystem(result.Range().GetSourcePrefix(), filesystem, false)
+			results[i] = override[0]
+		}
+	}
 
-	metrics.Timings.Total += metrics.Parser.Timings.DiskIODuration
-	metrics.Timings.Total += metrics.Parser.Timings.ParseDuration
-	metrics.Timings.Total += metrics.Executor.Timings.Adaptation
-	metrics.Timings.Total += metrics.Executor.Timings.RunningChecks
+	results = s.HandleResults(results)
+	progress.evaluate.Completed(dir)
 
-	return allResults, metrics, nil
+	return scannedRootModule{
+		results:         results,
+		parserMetrics:   p.Metrics(),
+		executorMetrics: execMetrics,
+	}, nil
 }
 
 func (s *Scanner) removeNestedDirs(dirs []string) []string {
@@ -294,7 +392,10 @@ func (s *Scanner) findRootModules(target fs.FS, scanDir string, dirs ...string)
 	var others []string
 
 	for _, dir := range dirs {
-		if s.isRootModule(target, dir) {
+		if s.PathFilter().SkipsDir(scanDir, dir) {
+			continue
+		}
+		if s.isRootModule(target, scanDir, dir) {
 			roots = append(roots, dir)
 			if !s.forceAllDirs {
 				continue
@@ -336,14 +437,17 @@ func (s *Scanner) findRootModules(target fs.FS, scanDir string, dirs ...string)
 	return s.removeNestedDirs(roots)
 }
 
-func (s *Scanner) isRootModule(target fs.FS, dir string) bool {
+func (s *Scanner) isRootModule(target fs.FS, scanDir string, dir string) bool {
 	files, err := fs.ReadDir(target, filepath.ToSlash(dir))
 	if err != nil {
 		s.debug.Log("failed to read dir '%s' from filesystem [%s]: %s", dir, target, err)
 		return false
 	}
 	for _, file := range files {
-		if strings.HasSuffix(file.Name(), ".tf") || strings.HasSuffix(file.Name(), ".tf.json") {
+		if !strings.HasSuffix(file.Name(), ".tf") && !strings.HasSuffix(file.Name(), ".tf.json") {
+			continue
+		}
+		if s.PathFilter().AllowsFile(scanDir, filepath.Join(dir, file.Name())) {
 			return true
 		}
 	}
@@ -4,12 +4,15 @@ import (
 	"bytes"
 	"context"
 	"fmt"
+	"io"
 	"strconv"
+	"strings"
 	"testing"
 
 	"github.com/aquasecurity/defsec/internal/rules"
 	"github.com/aquasecurity/defsec/pkg/providers"
 	"github.com/aquasecurity/defsec/pkg/scan"
+	"github.com/aquasecurity/defsec/pkg/scanners/limits"
 	"github.com/aquasecurity/defsec/pkg/scanners/options"
 	"github.com/aquasecurity/defsec/pkg/severity"
 	"github.com/aquasecurity/defsec/pkg/state"
@@ -953,3 +956,116 @@ deny[res] {
 	}
 
 }
+
+func Test_ScanReadersResolvesReferencesAcrossDocuments(t *testing.T) {
+	reg := rules.Register(scan.Rule{
+		Provider:  providers.AWSProvider,
+		Service:   "service",
+		ShortCode: "enabled",
+		Severity:  severity.High,
+		CustomChecks: scan.CustomChecks{
+			Terraform: &scan.TerraformCustomCheck{
+				RequiredTypes:  []string{"resource"},
+				RequiredLabels: []string{"something"},
+				Check: func(resourceBlock *terraform.Block, _ *terraform.Module) (results scan.Results) {
+					if attr := resourceBlock.GetAttribute("enabled"); attr.IsTrue() {
+						results.Add("enabled", attr)
+					}
+					return
+				},
+			},
+		},
+	}, nil)
+	defer rules.Deregister(reg)
+
+	scanner := New(ScannerWithAllDirectories(true))
+	results, err := scanner.ScanReaders(context.TODO(), map[string]io.Reader{
+		"project/main.tf": strings.NewReader(`
+resource "something" "else" {
+  enabled = var.enabled
+}
+`),
+		"project/variables.tf": strings.NewReader(`
+variable "enabled" {
+  default = true
+}
+`),
+	})
+	require.NoError(t, err)
+
+	failed := results.GetFailed()
+	require.Len(t, failed, 1)
+	assert.Equal(t, "project/main.tf", failed[0].Range().GetFilename())
+}
+
+func Test_OptionWithParallelism(t *testing.T) {
+	reg := rules.Register(alwaysFailRule, nil)
+	defer rules.Deregister(reg)
+
+	files := make(map[string]string)
+	for i := 0; i < 6; i++ {
+		files[fmt.Sprintf("project/root%d/main.tf", i)] = `
+resource "something" "else" {}
+`
+	}
+	fs := testutil.CreateFS(t, files)
+
+	var streamed int
+	scanner := New(
+		options.ScannerWithParallelism(4),
+		options.ScannerWithResultCallback(func(result scan.Result) {
+			streamed++
+		}),
+	)
+	results, _, err := scanner.ScanFSWithMetrics(context.TODO(), fs, "project")
+	require.NoError(t, err)
+
+	var failed int
+	for _, result := range results.GetFailed() {
+		if result.Rule().LongID() == alwaysFailRule.LongID() {
+			failed++
+		}
+	}
+	assert.Equal(t, 6, failed)
+	assert.Equal(t, len(results), streamed)
+}
+
+func Test_OptionWithFileLimits(t *testing.T) {
+	reg := rules.Register(alwaysFailRule, nil)
+	defer rules.Deregister(reg)
+
+	fs := testutil.CreateFS(t, map[string]string{
+		"project/a.tf": `
+resource "something" "a" {}
+`,
+		"project/b.tf": `
+resource "something" "b" {}
+`,
+		"project/c.tf": `
+resource "something" "c" {
+	# padded to exceed the maximum file size ` + strings.Repeat("-", 100) + `
+}
+`,
+	})
+
+	summary := &limits.Summary{}
+	scanner := New(
+		options.ScannerWithMaxFileSize(64),
+		options.ScannerWithMaxFiles(1),
+		options.ScannerWithScanSummary(summary),
+	)
+	results, err := scanner.ScanFS(context.TODO(), fs, "project")
+	require.NoError(t, err)
+
+	var failed int
+	for _, result := range results.GetFailed() {
+		if result.Rule().LongID() == alwaysFailRule.LongID() {
+			failed++
+		}
+	}
+	assert.Equal(t, 1, failed)
+	assert.Equal(t, []limits.SkippedFile{
+		{Path: "project/b.tf", Reason: limits.ReasonTooManyFiles},
+		{Path: "project/c.tf", Reason: limits.ReasonFileTooLarge},
+	}, summary.Skipped())
+}
@@ -1,6 +1,7 @@
 package terraformplan
 
 import (
+	"github.com/aquasecurity/defsec/pkg/debug"
 	"github.com/aquasecurity/defsec/pkg/scanners/terraformplan/parser"
 )
 
@@ -11,3 +12,19 @@ func OptionStopOnHCLError(stop bool) Option {
 		s.parserOpt = append(s.parserOpt, parser.OptionStopOnHCLError(stop))
 	}
 }
+
+// OptionWithMaxDocumentSize sets the maximum size in bytes of a plan file - larger plans fail to parse
+// rather than being read into memory. Values below 1 mean no limit.
+func OptionWithMaxDocumentSize(size int64) Option {
+	return func(s *Scanner) {
+		s.parserOpt = append(s.parserOpt, parser.OptionWithMaxDocumentSize(size))
+	}
+}
+
+// OptionWithLogHandler passes structured, levelled logs from the scanner, and the terraform scanner used to scan
+// the plan, to the given handler
+func OptionWithLogHandler(handler debug.Handler) Option {
+	return func(s *Scanner) {
+		s.SetLogHandler(handler)
+	}
+}
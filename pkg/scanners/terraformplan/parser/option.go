@@ -15,3 +15,10 @@ func OptionStopOnHCLError(stop bool) Option {
 		p.stopOnHCLError = stop
 	}
 }
+
+// OptionWithMaxDocumentSize sets the maximum size in bytes of a plan file - values below 1 mean no limit
+func OptionWithMaxDocumentSize(size int64) Option {
+	return func(p *Parser) {
+		p.maxDocumentSize = size
+	}
+}
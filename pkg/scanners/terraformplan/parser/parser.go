@@ -8,13 +8,16 @@ import (
 	"os"
 	"strings"
 
+	"github.com/aquasecurity/defsec/pkg/scanners/charset"
+	"github.com/aquasecurity/defsec/pkg/scanners/limits"
 	"github.com/aquasecurity/defsec/pkg/terraform"
 	"github.com/liamg/memoryfs"
 )
 
 type Parser struct {
-	debugWriter    io.Writer
-	stopOnHCLError bool
+	debugWriter     io.Writer
+	stopOnHCLError  bool
+	maxDocumentSize int64
 }
 
 func New(options ...Option) *Parser {
@@ -52,7 +55,7 @@ func (p *Parser) Parse(reader io.Reader) (*PlanFile, error) {
 
 	var planFile PlanFile
 
-	if err := json.NewDecoder(reader).Decode(&planFile); err != nil {
+	if err := json.NewDecoder(limits.NewDocumentReader(charset.NewReader(reader), p.maxDocumentSize)).Decode(&planFile); err != nil {
 		return nil, err
 	}
 
@@ -8,6 +8,7 @@ import (
 	"github.com/aquasecurity/defsec/pkg/debug"
 
 	"github.com/aquasecurity/defsec/pkg/scan"
+	"github.com/aquasecurity/defsec/pkg/scanners/options"
 	terraformScanner "github.com/aquasecurity/defsec/pkg/scanners/terraform"
 	"github.com/aquasecurity/defsec/pkg/scanners/terraformplan/parser"
 )
@@ -19,12 +20,11 @@ type Scanner struct {
 }
 
 func New(options ...Option) *Scanner {
-	scanner := &Scanner{
-		parser: *parser.New(),
-	}
+	scanner := &Scanner{}
 	for _, o := range options {
 		o(scanner)
 	}
+	scanner.parser = *parser.New(scanner.parserOpt...)
 	return scanner
 }
 
@@ -32,6 +32,10 @@ func (s *Scanner) SetDebugWriter(writer io.Writer) {
 	s.debug = debug.New(writer, "tfplan", "scanner")
 }
 
+func (s *Scanner) SetLogHandler(handler debug.Handler) {
+	s.debug = debug.NewWithHandler(handler, "tfplan", "scanner")
+}
+
 func (s *Scanner) ScanFile(filepath string) (scan.Results, error) {
 
 	s.debug.Log("Scanning file %s", filepath)
@@ -55,6 +59,9 @@ func (s *Scanner) Scan(reader io.Reader) (scan.Results, error) {
 		return nil, err
 	}
 
-	scanner := terraformScanner.New(terraformScanner.ScannerWithStopOnHCLError(true))
+	scanner := terraformScanner.New(
+		terraformScanner.ScannerWithStopOnHCLError(true),
+		options.ScannerWithLogHandler(s.debug.Handler()),
+	)
 	return scanner.ScanFS(context.TODO(), planFS, ".")
 }
@@ -8,6 +8,8 @@ import (
 
 	"github.com/aquasecurity/defsec/pkg/debug"
 
+	"github.com/aquasecurity/defsec/pkg/scanners/charset"
+	"github.com/aquasecurity/defsec/pkg/scanners/limits"
 	"github.com/aquasecurity/defsec/pkg/scanners/options"
 
 	"github.com/BurntSushi/toml"
@@ -17,18 +19,46 @@ import (
 var _ options.ConfigurableParser = (*Parser)(nil)
 
 type Parser struct {
-	debug        debug.Logger
-	skipRequired bool
+	debug           debug.Logger
+	skipRequired    bool
+	fileLimits      limits.FileLimits
+	summary         *limits.Summary
+	pathFilter      options.PathFilter
+	customParsers   options.CustomParsers
+	instrumentation *options.Instrumentation
+	scannerName     string
 }
 
 func (p *Parser) SetDebugWriter(writer io.Writer) {
 	p.debug = debug.New(writer, "toml", "parser")
 }
 
+func (p *Parser) SetLogHandler(handler debug.Handler) {
+	p.debug = debug.NewWithHandler(handler, "toml", "parser")
+}
+
 func (p *Parser) SetSkipRequiredCheck(b bool) {
 	p.skipRequired = b
 }
 
+func (p *Parser) SetFileLimits(fileLimits limits.FileLimits, summary *limits.Summary) {
+	p.fileLimits = fileLimits
+	p.summary = summary
+}
+
+func (p *Parser) SetPathFilter(filter options.PathFilter) {
+	p.pathFilter = filter
+}
+
+func (p *Parser) SetCustomParsers(parsers options.CustomParsers) {
+	p.customParsers = parsers
+}
+
+func (p *Parser) SetInstrumentation(instrumentation *options.Instrumentation, scanner string) {
+	p.instrumentation = instrumentation
+	p.scannerName = scanner
+}
+
 // New creates a new parser
 func New(opts ...options.ParserOption) *Parser {
 	p := &Parser{}
@@ -40,7 +70,11 @@ func New(opts ...options.ParserOption) *Parser {
 
 func (p *Parser) ParseFS(ctx context.Context, target fs.FS, path string) (map[string]interface{}, error) {
 
+	tracker := limits.NewTracker(p.fileLimits, p.summary)
+	progress := p.instrumentation.TrackProgress(p.scannerName, options.PhaseParse)
+	var paths []string
 	files := make(map[string]interface{})
+	root := path
 	if err := fs.WalkDir(target, filepath.ToSlash(path), func(path string, entry fs.DirEntry, err error) error {
 		select {
 		case <-ctx.Done():
@@ -51,21 +85,47 @@ func (p *Parser) ParseFS(ctx context.Context, target fs.FS, path string) (map[st
 			return err
 		}
 		if entry.IsDir() {
+			if p.pathFilter.SkipsDir(root, path) {
+				return fs.SkipDir
+			}
+			return nil
+		}
+		if !p.pathFilter.AllowsFile(root, path) {
 			return nil
 		}
 		if !p.Required(path) {
 			return nil
 		}
-		df, err := p.ParseFile(ctx, target, path)
-		if err != nil {
-			p.debug.Log("Parse error in '%s': %s", path, err)
+		if !tracker.AllowSize(target, path) || !tracker.AllowFile(path) {
 			return nil
 		}
-		files[path] = df
+		paths = append(paths, path)
 		return nil
 	}); err != nil {
 		return nil, err
 	}
+
+	// every file is discovered before any is parsed, so the total is known from the start
+	progress.Discovered(len(paths))
+	for _, path := range paths {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		default:
+		}
+		progress.Started(path)
+		done := p.instrumentation.StartPhase(ctx, p.scannerName, options.PhaseParse, path)
+		df, err := limits.Parse(ctx, tracker, path, func(ctx context.Context) (interface{}, error) {
+			return p.ParseFile(ctx, target, path)
+		})
+		done()
+		progress.Completed(path)
+		if err != nil {
+			p.debug.Warn("Failed to parse file", "path", path, "error", err)
+			continue
+		}
+		files[path] = df
+	}
 	return files, nil
 }
 
@@ -77,7 +137,7 @@ func (p *Parser) ParseFile(_ context.Context, fs fs.FS, path string) (interface{
 	}
 	defer func() { _ = f.Close() }()
 	var target interface{}
-	if _, err := toml.NewDecoder(f).Decode(&target); err != nil {
+	if _, err := toml.NewDecoder(charset.NewReader(f)).Decode(&target); err != nil {
 		return nil, err
 	}
 	return target, nil
@@ -87,5 +147,5 @@ func (p *Parser) Required(path string) bool {
 	if p.skipRequired {
 		return true
 	}
-	return detection.IsType(path, nil, detection.FileTypeTOML)
+	return detection.IsType(p.customParsers.ParsedAs(path), nil, detection.FileTypeTOML)
 }
@@ -2,8 +2,10 @@ package universal
 
 import (
 	"context"
+	"io"
 	"io/fs"
 
+	"github.com/aquasecurity/defsec/pkg/config"
 	"github.com/aquasecurity/defsec/pkg/scanners/azure/arm"
 	"github.com/aquasecurity/defsec/pkg/scanners/cloud/aws"
 	"github.com/aquasecurity/defsec/pkg/scanners/helm"
@@ -34,6 +36,7 @@ type nestableAPIScanners interface {
 }
 
 var _ scanners.FSScanner = (*Scanner)(nil)
+var _ scanners.ReaderScanner = (*Scanner)(nil)
 
 type Scanner struct {
 	fsScanners  []nestableFSScanners
@@ -41,21 +44,44 @@ type Scanner struct {
 }
 
 func New(opts ...options.ScannerOption) *Scanner {
-	s := &Scanner{
-		fsScanners: []nestableFSScanners{
-			terraform.New(opts...),
-			cloudformation.New(opts...),
-			dockerfile.NewScanner(opts...),
-			kubernetes.NewScanner(opts...),
-			json.NewScanner(opts...),
-			yaml.NewScanner(opts...),
-			toml.NewScanner(opts...),
-			helm.New(opts...),
-			arm.New(opts...),
-		},
-		apiScanners: []nestableAPIScanners{
-			aws.New(opts...),
-		},
+	return newScanner(nil, opts...)
+}
+
+// NewFromConfig creates a scanner from a configuration file, leaving out any scanners it disables. Options given
+// here are applied before those from the configuration, so act as defaults which it can override.
+func NewFromConfig(cfg *config.Config, defaults ...options.ScannerOption) (*Scanner, error) {
+	configured, err := cfg.Options()
+	if err != nil {
+		return nil, err
+	}
+	opts := make([]options.ScannerOption, 0, len(defaults)+len(configured))
+	opts = append(opts, defaults...)
+	opts = append(opts, configured...)
+	return newScanner(cfg, opts...), nil
+}
+
+func newScanner(cfg *config.Config, opts ...options.ScannerOption) *Scanner {
+	s := &Scanner{}
+	for _, inner := range []struct {
+		name string
+		new  func() nestableFSScanners
+	}{
+		{name: "terraform", new: func() nestableFSScanners { return terraform.New(opts...) }},
+		{name: "cloudformation", new: func() nestableFSScanners { return cloudformation.New(opts...) }},
+		{name: "dockerfile", new: func() nestableFSScanners { return dockerfile.NewScanner(opts...) }},
+		{name: "kubernetes", new: func() nestableFSScanners { return kubernetes.NewScanner(opts...) }},
+		{name: "json", new: func() nestableFSScanners { return json.NewScanner(opts...) }},
+		{name: "yaml", new: func() nestableFSScanners { return yaml.NewScanner(opts...) }},
+		{name: "toml", new: func() nestableFSScanners { return toml.NewScanner(opts...) }},
+		{name: "helm", new: func() nestableFSScanners { return helm.New(opts...) }},
+		{name: "arm", new: func() nestableFSScanners { return arm.New(opts...) }},
+	} {
+		if cfg.ScannerEnabled(inner.name) {
+			s.fsScanners = append(s.fsScanners, inner.new())
+		}
+	}
+	if cfg.ScannerEnabled("aws") {
+		s.apiScanners = append(s.apiScanners, aws.New(opts...))
 	}
 	return s
 }
@@ -64,6 +90,11 @@ func (s *Scanner) Name() string {
 	return "Universal"
 }
 
+// ScanReaders scans the given documents together, keyed by their paths, see scanners.ReaderScanner
+func (s *Scanner) ScanReaders(ctx context.Context, readers map[string]io.Reader) (scan.Results, error) {
+	return scanners.ScanReaders(ctx, s, readers)
+}
+
 func (s *Scanner) ScanFS(ctx context.Context, fs fs.FS, dir string) (scan.Results, error) {
 	var results scan.Results
 	for _, inner := range s.fsScanners {
@@ -0,0 +1,138 @@
+package anchors
+
+import (
+	"gopkg.in/yaml.v3"
+)
+
+// maxExpandedNodes stops expansion of documents which alias their anchors exponentially many times (i.e. a billion
+// laughs attack). Aliases which are not expanded are left for the yaml decoder, which rejects excessive aliasing.
+const maxExpandedNodes = 1_000_000
+
+// Anchor is where the value of an expanded alias, or of a merged key, was defined
+type Anchor struct {
+	Name      string
+	StartLine int
+	EndLine   int
+}
+
+// Expand replaces each alias below node with a copy of the value of its anchor, and moves the keys of each `<<`
+// merge key into the mapping containing it. Keys given explicitly take precedence over merged keys, and keys of
+// earlier merged mappings over those of later ones. Copied nodes are positioned at the alias, so that anything
+// found in them is reported where the alias is used. The definition of each copied node is returned, so that it
+// can be referenced too.
+func Expand(node *yaml.Node) map[*yaml.Node]Anchor {
+	e := &expander{
+		anchors:   make(map[*yaml.Node]Anchor),
+		expanding: make(map[*yaml.Node]bool),
+	}
+	e.expand(node)
+	return e.anchors
+}
+
+type expander struct {
+	anchors   map[*yaml.Node]Anchor
+	expanding map[*yaml.Node]bool
+	copied    int
+}
+
+func (e *expander) expand(node *yaml.Node) {
+	switch node.Kind {
+	case yaml.DocumentNode, yaml.SequenceNode:
+		for i, child := range node.Content {
+			node.Content[i] = e.resolve(child)
+			e.expand(node.Content[i])
+		}
+	case yaml.MappingNode:
+		e.expandMapping(node)
+	}
+}
+
+func (e *expander) expandMapping(node *yaml.Node) {
+	content := make([]*yaml.Node, 0, len(node.Content))
+	explicit := make(map[string]bool)
+	var merged []*yaml.Node
+
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		key, value := node.Content[i], node.Content[i+1]
+		if !isMerge(key) {
+			value = e.resolve(value)
+			e.expand(value)
+			content = append(content, key, value)
+			explicit[key.Value] = true
+			continue
+		}
+
+		sources := []*yaml.Node{value}
+		if value.Kind == yaml.SequenceNode {
+			sources = value.Content
+		}
+		for _, source := range sources {
+			source = e.resolve(source)
+			e.expand(source)
+			if source.Kind != yaml.MappingNode {
+				continue
+			}
+			merged = append(merged, source.Content...)
+		}
+	}
+
+	for i := 0; i+1 < len(merged); i += 2 {
+		if explicit[merged[i].Value] {
+			continue
+		}
+		explicit[merged[i].Value] = true
+		content = append(content, merged[i], merged[i+1])
+	}
+	node.Content = content
+}
+
+// resolve returns a copy of the value an alias refers to, positioned at the alias, or the node itself if it is not
+// an alias
+func (e *expander) resolve(node *yaml.Node) *yaml.Node {
+	if node.Kind != yaml.AliasNode || node.Alias == nil || e.expanding[node.Alias] || e.copied > maxExpandedNodes {
+		return node
+	}
+	// aliases within the copy are expanded after it is made, so the anchor is marked to stop it including itself
+	e.expanding[node.Alias] = true
+	defer delete(e.expanding, node.Alias)
+
+	copied := e.copy(node.Alias, node.Line, node.Value)
+	e.expand(copied)
+	return copied
+}
+
+func (e *expander) copy(node *yaml.Node, line int, anchor string) *yaml.Node {
+	e.copied++
+	copied := *node
+	copied.Anchor = ""
+	copied.Line = line
+	copied.Content = nil
+	for _, child := range node.Content {
+		copied.Content = append(copied.Content, e.copy(child, line, anchor))
+	}
+	definition := Anchor{
+		Name:      anchor,
+		StartLine: node.Line,
+		EndLine:   endLine(node),
+	}
+	if previous, ok := e.anchors[node]; ok {
+		// the node was itself copied from an alias, so refer to its original definition
+		definition = previous
+	}
+	e.anchors[&copied] = definition
+	return &copied
+}
+
+func isMerge(key *yaml.Node) bool {
+	return key.Kind == yaml.ScalarNode && key.ShortTag() == "!!merge"
+}
+
+func endLine(node *yaml.Node) int {
+	end := node.Line
+	for _, child := range node.Content {
+		if line := endLine(child); line > end {
+			end = line
+		}
+	}
+	return end
+}
@@ -0,0 +1,123 @@
+package anchors
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+func parse(t *testing.T, source string) (*yaml.Node, map[*yaml.Node]Anchor) {
+	var root yaml.Node
+	require.NoError(t, yaml.Unmarshal([]byte(source), &root))
+	definitions := Expand(&root)
+	return root.Content[0], definitions
+}
+
+func lookup(node *yaml.Node, key string) *yaml.Node {
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i+1]
+		}
+	}
+	return nil
+}
+
+func Test_AliasIsPositionedWhereItIsUsed(t *testing.T) {
+	root, definitions := parse(t, `
+base: &base
+  image: nginx
+  privileged: true
+copy: *base
+`)
+	copied := lookup(root, "copy")
+	require.NotNil(t, copied)
+	assert.Equal(t, yaml.MappingNode, copied.Kind)
+	assert.Equal(t, 5, copied.Line)
+	assert.Equal(t, 5, lookup(copied, "privileged").Line)
+	assert.Equal(t, "true", lookup(copied, "privileged").Value)
+
+	assert.Equal(t, Anchor{Name: "base", StartLine: 2, EndLine: 4}, definitions[copied])
+	assert.Equal(t, Anchor{Name: "base", StartLine: 4, EndLine: 4}, definitions[lookup(copied, "privileged")])
+
+	original := lookup(root, "base")
+	assert.Equal(t, 3, lookup(original, "image").Line)
+	_, ok := definitions[original]
+	assert.False(t, ok)
+}
+
+func Test_MergeKeysAreExpanded(t *testing.T) {
+	root, definitions := parse(t, `
+defaults: &defaults
+  privileged: true
+  user: root
+extra: &extra
+  user: nobody
+  readOnly: false
+container:
+  name: app
+  <<: [*defaults, *extra]
+  readOnly: true
+`)
+	container := lookup(root, "container")
+	require.NotNil(t, container)
+	assert.Nil(t, lookup(container, "<<"))
+	assert.Len(t, container.Content, 8)
+
+	assert.Equal(t, "app", lookup(container, "name").Value)
+	assert.Equal(t, "true", lookup(container, "readOnly").Value, "explicit keys take precedence")
+	assert.Equal(t, 11, lookup(container, "readOnly").Line)
+	assert.Equal(t, "root", lookup(container, "user").Value, "earlier merged mappings take precedence")
+
+	privileged := lookup(container, "privileged")
+	require.NotNil(t, privileged)
+	assert.Equal(t, 10, privileged.Line)
+	assert.Equal(t, Anchor{Name: "defaults", StartLine: 3, EndLine: 3}, definitions[privileged])
+}
+
+func Test_InlineMergeKeepsPositions(t *testing.T) {
+	root, definitions := parse(t, `
+container:
+  <<:
+    privileged: true
+  name: app
+`)
+	container := lookup(root, "container")
+	privileged := lookup(container, "privileged")
+	require.NotNil(t, privileged)
+	assert.Equal(t, 4, privileged.Line)
+	_, ok := definitions[privileged]
+	assert.False(t, ok)
+}
+
+func Test_NestedAliasesAreExpanded(t *testing.T) {
+	root, definitions := parse(t, `
+port: &port 8080
+service: &service
+  port: *port
+copy: *service
+`)
+	copied := lookup(root, "copy")
+	port := lookup(copied, "port")
+	require.NotNil(t, port)
+	assert.Equal(t, yaml.ScalarNode, port.Kind)
+	assert.Equal(t, "8080", port.Value)
+	assert.Equal(t, 5, port.Line)
+	assert.Equal(t, "port", definitions[port].Name)
+	assert.Equal(t, 2, definitions[port].StartLine)
+}
+
+func Test_AliasesInSequences(t *testing.T) {
+	root, _ := parse(t, `
+first: &first
+  name: a
+items:
+- *first
+- name: b
+`)
+	items := lookup(root, "items")
+	require.Len(t, items.Content, 2)
+	assert.Equal(t, yaml.MappingNode, items.Content[0].Kind)
+	assert.Equal(t, 5, items.Content[0].Line)
+}
@@ -3,14 +3,17 @@ package parser
 import (
 	"bytes"
 	"context"
+	"errors"
 	"io"
 	"io/fs"
 	"path/filepath"
-	"strings"
 
 	"github.com/aquasecurity/defsec/pkg/debug"
 
 	"github.com/aquasecurity/defsec/pkg/detection"
+	"github.com/aquasecurity/defsec/pkg/scanners/charset"
+	"github.com/aquasecurity/defsec/pkg/scanners/lenient"
+	"github.com/aquasecurity/defsec/pkg/scanners/limits"
 	"github.com/aquasecurity/defsec/pkg/scanners/options"
 	"gopkg.in/yaml.v3"
 )
@@ -18,18 +21,56 @@ import (
 var _ options.ConfigurableParser = (*Parser)(nil)
 
 type Parser struct {
-	debug        debug.Logger
-	skipRequired bool
+	debug           debug.Logger
+	skipRequired    bool
+	maxDocumentSize int64
+	lenient         bool
+	fileLimits      limits.FileLimits
+	summary         *limits.Summary
+	pathFilter      options.PathFilter
+	customParsers   options.CustomParsers
+	instrumentation *options.Instrumentation
+	scannerName     string
 }
 
 func (p *Parser) SetDebugWriter(writer io.Writer) {
 	p.debug = debug.New(writer, "yaml", "parser")
 }
 
+func (p *Parser) SetLogHandler(handler debug.Handler) {
+	p.debug = debug.NewWithHandler(handler, "yaml", "parser")
+}
+
 func (p *Parser) SetSkipRequiredCheck(b bool) {
 	p.skipRequired = b
 }
 
+func (p *Parser) SetFileLimits(fileLimits limits.FileLimits, summary *limits.Summary) {
+	p.fileLimits = fileLimits
+	p.summary = summary
+}
+
+func (p *Parser) SetPathFilter(filter options.PathFilter) {
+	p.pathFilter = filter
+}
+
+func (p *Parser) SetCustomParsers(parsers options.CustomParsers) {
+	p.customParsers = parsers
+}
+
+func (p *Parser) SetInstrumentation(instrumentation *options.Instrumentation, scanner string) {
+	p.instrumentation = instrumentation
+	p.scannerName = scanner
+}
+
+func (p *Parser) SetMaxDocumentSize(size int64) {
+	p.maxDocumentSize = size
+}
+
+func (p *Parser) SetLenientParsing(lenient bool) {
+	p.lenient = lenient
+}
+
 // New creates a new parser
 func New(opts ...options.ParserOption) *Parser {
 	p := &Parser{}
@@ -41,7 +82,11 @@ func New(opts ...options.ParserOption) *Parser {
 
 func (p *Parser) ParseFS(ctx context.Context, target fs.FS, path string) (map[string][]interface{}, error) {
 
+	tracker := limits.NewTracker(p.fileLimits, p.summary)
+	progress := p.instrumentation.TrackProgress(p.scannerName, options.PhaseParse)
+	var paths []string
 	files := make(map[string][]interface{})
+	root := path
 	if err := fs.WalkDir(target, filepath.ToSlash(path), func(path string, entry fs.DirEntry, err error) error {
 		select {
 		case <-ctx.Done():
@@ -52,21 +97,47 @@ func (p *Parser) ParseFS(ctx context.Context, target fs.FS, path string) (map[st
 			return err
 		}
 		if entry.IsDir() {
+			if p.pathFilter.SkipsDir(root, path) {
+				return fs.SkipDir
+			}
+			return nil
+		}
+		if !p.pathFilter.AllowsFile(root, path) {
 			return nil
 		}
 		if !p.Required(path) {
 			return nil
 		}
-		df, err := p.ParseFile(ctx, target, path)
-		if err != nil {
-			p.debug.Log("Parse error in '%s': %s", path, err)
+		if !tracker.AllowSize(target, path) || !tracker.AllowFile(path) {
 			return nil
 		}
-		files[path] = df
+		paths = append(paths, path)
 		return nil
 	}); err != nil {
 		return nil, err
 	}
+
+	// every file is discovered before any is parsed, so the total is known from the start
+	progress.Discovered(len(paths))
+	for _, path := range paths {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		default:
+		}
+		progress.Started(path)
+		done := p.instrumentation.StartPhase(ctx, p.scannerName, options.PhaseParse, path)
+		df, err := limits.Parse(ctx, tracker, path, func(ctx context.Context) ([]interface{}, error) {
+			return p.ParseFile(ctx, target, path)
+		})
+		done()
+		progress.Completed(path)
+		if err != nil {
+			p.debug.Warn("Failed to parse file", "path", path, "error", err)
+			continue
+		}
+		files[path] = df
+	}
 	return files, nil
 }
 
@@ -78,33 +149,59 @@ func (p *Parser) ParseFile(_ context.Context, fs fs.FS, path string) ([]interfac
 	}
 	defer func() { _ = f.Close() }()
 
-	contents, err := io.ReadAll(f)
-	if err != nil {
-		return nil, err
-	}
+	// split the file one document at a time, so only the parsed documents are held in memory rather than the whole
+	// file, and an oversized document can be skipped without skipping the rest of the file
+	documents := limits.NewYAMLDocuments(charset.NewReader(f), p.maxDocumentSize)
 
 	var results []interface{}
-
-	marker := "\n---\n"
-	altMarker := "\r\n---\r\n"
-	if bytes.Contains(contents, []byte(altMarker)) {
-		marker = altMarker
-	}
-
-	for _, partial := range strings.Split(string(contents), marker) {
-		var target interface{}
-		if err := yaml.Unmarshal([]byte(partial), &target); err != nil {
+	for {
+		document, err := documents.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if errors.Is(err, limits.ErrDocumentTooLarge) {
+			p.debug.Log("Skipping oversized document in %s", path)
+			p.summary.Record(path, limits.ReasonDocumentTooLarge)
+			continue
+		}
+		if err != nil {
 			return nil, err
 		}
-		results = append(results, target)
+		decoder := yaml.NewDecoder(bytes.NewReader(document))
+		for {
+			target, err := p.decode(decoder)
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			if err != nil {
+				return nil, err
+			}
+			results = append(results, target)
+		}
 	}
 
 	return results, nil
 }
 
+// decode reads the next document, normalising its values first if parsing is lenient
+func (p *Parser) decode(decoder *yaml.Decoder) (interface{}, error) {
+	var target interface{}
+	if !p.lenient {
+		err := decoder.Decode(&target)
+		return target, err
+	}
+	var node yaml.Node
+	if err := decoder.Decode(&node); err != nil {
+		return nil, err
+	}
+	lenient.NormaliseYAML(&node)
+	err := node.Decode(&target)
+	return target, err
+}
+
 func (p *Parser) Required(path string) bool {
 	if p.skipRequired {
 		return true
 	}
-	return detection.IsType(path, nil, detection.FileTypeYAML)
+	return detection.IsType(p.customParsers.ParsedAs(path), nil, detection.FileTypeYAML)
 }
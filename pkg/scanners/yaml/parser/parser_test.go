@@ -2,10 +2,14 @@ package parser
 
 import (
 	"context"
+	"strings"
 	"testing"
 
 	"github.com/liamg/memoryfs"
 
+	"github.com/aquasecurity/defsec/pkg/scanners/limits"
+	"github.com/aquasecurity/defsec/pkg/scanners/options"
+
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -149,3 +153,66 @@ x:
 	}
 
 }
+
+func Test_Parser_WithWindowsLineEndings(t *testing.T) {
+	input := "x: 123\r\n---\r\nx: 456\r\n"
+
+	memfs := memoryfs.New()
+	err := memfs.WriteFile("something.yaml", []byte(input), 0644)
+	require.NoError(t, err)
+
+	data, err := New().ParseFile(context.TODO(), memfs, "something.yaml")
+	require.NoError(t, err)
+
+	require.Len(t, data, 2)
+	assert.Equal(t, map[string]interface{}{"x": 123}, data[0])
+	assert.Equal(t, map[string]interface{}{"x": 456}, data[1])
+}
+
+func Test_Parser_WithMaxDocumentSize(t *testing.T) {
+	input := `x: 123
+---
+x: ` + strings.Repeat("a", 4096) + `
+`
+
+	memfs := memoryfs.New()
+	err := memfs.WriteFile("something.yaml", []byte(input), 0644)
+	require.NoError(t, err)
+
+	summary := &limits.Summary{}
+	data, err := New(
+		options.ParserWithMaxDocumentSize(1024),
+		options.ParserWithFileLimits(limits.FileLimits{}, summary),
+	).ParseFile(context.TODO(), memfs, "something.yaml")
+	require.NoError(t, err)
+	assert.Equal(t, []interface{}{map[string]interface{}{"x": 123}}, data)
+	assert.Equal(t, []limits.SkippedFile{
+		{Path: "something.yaml", Reason: limits.ReasonDocumentTooLarge},
+	}, summary.Skipped())
+
+	data, err = New(options.ParserWithMaxDocumentSize(8192)).ParseFile(context.TODO(), memfs, "something.yaml")
+	require.NoError(t, err)
+	assert.Len(t, data, 2)
+}
+
+func Test_Parser_WithLenientParsing(t *testing.T) {
+	input := `enabled: yes
+mode: 0o644
+---
+enabled: "yes"
+`
+
+	memfs := memoryfs.New()
+	require.NoError(t, memfs.WriteFile("something.yaml", []byte(input), 0644))
+
+	data, err := New().ParseFile(context.TODO(), memfs, "something.yaml")
+	require.NoError(t, err)
+	require.Len(t, data, 2)
+	assert.Equal(t, map[string]interface{}{"enabled": "yes", "mode": 420}, data[0])
+
+	data, err = New(options.ParserWithLenientParsing(true)).ParseFile(context.TODO(), memfs, "something.yaml")
+	require.NoError(t, err)
+	require.Len(t, data, 2)
+	assert.Equal(t, map[string]interface{}{"enabled": true, "mode": 420}, data[0])
+	assert.Equal(t, map[string]interface{}{"enabled": "yes"}, data[1])
+}
@@ -12,14 +12,29 @@ import (
 
 	"github.com/aquasecurity/defsec/pkg/debug"
 
+	"github.com/aquasecurity/defsec/pkg/scanners/cache"
 	"github.com/aquasecurity/defsec/pkg/scanners/options"
 
 	"github.com/aquasecurity/defsec/pkg/rego"
 	"github.com/aquasecurity/defsec/pkg/scan"
+	"github.com/aquasecurity/defsec/pkg/scanners"
 	"github.com/aquasecurity/defsec/pkg/scanners/yaml/parser"
 )
 
 var _ options.ConfigurableScanner = (*Scanner)(nil)
+var _ scanners.ReaderScanner = (*Scanner)(nil)
+var _ options.ResultProcessingScanner = (*Scanner)(nil)
+var _ options.StreamingScanner = (*Scanner)(nil)
+var _ options.InstrumentedScanner = (*Scanner)(nil)
+var _ options.MetricsScanner = (*Scanner)(nil)
+var _ options.ProgressReportingScanner = (*Scanner)(nil)
+var _ options.PathFilteredScanner = (*Scanner)(nil)
+var _ options.SymlinkScanner = (*Scanner)(nil)
+var _ options.CustomParsingScanner = (*Scanner)(nil)
+var _ cache.CachingScanner = (*Scanner)(nil)
+var _ options.DocumentSizeLimitedScanner = (*Scanner)(nil)
+var _ options.LenientScanner = (*Scanner)(nil)
+var _ options.LoggingScanner = (*Scanner)(nil)
 
 type Scanner struct {
 	options       []options.ScannerOption
@@ -30,9 +45,17 @@ type Scanner struct {
 	regoScanner   *rego.Scanner
 	skipRequired  bool
 	sync.Mutex
-	loadEmbedded bool
-	frameworks   []framework.Framework
-	spec         string
+	loadEmbedded    bool
+	frameworks      []framework.Framework
+	spec            string
+	maxDocumentSize int64
+	lenient         bool
+	cache           *cache.Cache
+	options.FileLimitHandler
+	options.SymlinkHandler
+	options.CustomParserHandler
+	options.Instrumentation
+	options.ResultHandler
 }
 
 func (s *Scanner) SetRegoOnly(bool) {}
@@ -49,6 +72,18 @@ func (s *Scanner) SetUseEmbeddedPolicies(b bool) {
 	s.loadEmbedded = b
 }
 
+func (s *Scanner) SetMaxDocumentSize(size int64) {
+	s.maxDocumentSize = size
+}
+
+func (s *Scanner) SetLenientParsing(lenient bool) {
+	s.lenient = lenient
+}
+
+func (s *Scanner) SetScanCache(c *cache.Cache) {
+	s.cache = c
+}
+
 func (s *Scanner) Name() string {
 	return "YAML"
 }
@@ -65,6 +100,10 @@ func (s *Scanner) SetDebugWriter(writer io.Writer) {
 	s.debug = debug.New(writer, "yaml", "scanner")
 }
 
+func (s *Scanner) SetLogHandler(handler debug.Handler) {
+	s.debug = debug.NewWithHandler(handler, "yaml", "scanner")
+}
+
 func (s *Scanner) SetTraceWriter(_ io.Writer)        {}
 func (s *Scanner) SetPerResultTracingEnabled(_ bool) {}
 
@@ -89,17 +128,35 @@ func NewScanner(opts ...options.ScannerOption) *Scanner {
 	for _, opt := range opts {
 		opt(s)
 	}
-	s.parser = parser.New(options.ParserWithSkipRequiredCheck(s.skipRequired))
+	s.parser = parser.New(
+		options.ParserWithSkipRequiredCheck(s.skipRequired),
+		options.ParserWithMaxDocumentSize(s.maxDocumentSize),
+		options.ParserWithLenientParsing(s.lenient),
+		s.FileLimitParserOption(),
+		s.PathFilterParserOption(),
+		s.CustomParserOption(),
+		s.InstrumentationParserOption(s.Name()),
+		options.ParserWithLogHandler(s.debug.Handler()),
+	)
 	return s
 }
 
+// ScanReaders scans the given documents together, keyed by their paths, see scanners.ReaderScanner
+func (s *Scanner) ScanReaders(ctx context.Context, readers map[string]io.Reader) (scan.Results, error) {
+	return scanners.ScanReaders(ctx, s, readers)
+}
+
 func (s *Scanner) ScanFS(ctx context.Context, fs fs.FS, path string) (scan.Results, error) {
+	fs = s.PreprocessFS(ctx, s.SymlinkFS(fs, s.debug, s.ScanSummary()))
 
+	done := s.StartPhase(ctx, s.Name(), options.PhaseParse, "")
 	fileset, err := s.parser.ParseFS(ctx, fs, path)
+	done()
 	if err != nil {
 		return nil, err
 	}
 
+	s.RecordFiles(s.Name(), len(fileset))
 	if len(fileset) == 0 {
 		return nil, nil
 	}
@@ -123,10 +180,13 @@ func (s *Scanner) ScanFS(ctx context.Context, fs fs.FS, path string) (scan.Resul
 }
 
 func (s *Scanner) ScanFile(ctx context.Context, fs fs.FS, path string) (scan.Results, error) {
+	fs = s.PreprocessFS(ctx, s.SymlinkFS(fs, s.debug, s.ScanSummary()))
+
 	parsed, err := s.parser.ParseFile(ctx, fs, path)
 	if err != nil {
 		return nil, err
 	}
+	s.RecordFiles(s.Name(), 1)
 	s.debug.Log("Scanning %s...", path)
 	return s.scanRego(ctx, fs, rego.Input{
 		Path:     path,
@@ -154,10 +214,17 @@ func (s *Scanner) scanRego(ctx context.Context, srcFS fs.FS, inputs ...rego.Inpu
 	if err != nil {
 		return nil, err
 	}
-	results, err := regoScanner.ScanInput(ctx, inputs...)
+	progress := s.TrackProgress(s.Name(), options.PhaseEvaluate)
+	done := s.StartPhase(ctx, s.Name(), options.PhaseEvaluate, "")
+	// results are handled a file at a time, so they are streamed to any callbacks as each file is scanned
+	results, err := s.cache.ScanFiles(ctx, cache.ParsingKey(s.Name(), s.lenient, s.CustomParsers()), regoScanner, srcFS, inputs, progress, func(_ string, results scan.Results) scan.Results {
+		results.SetSourceAndFilesystem("", srcFS, false)
+		return s.HandleResults(results)
+	})
+	done()
 	if err != nil {
 		return nil, err
 	}
-	results.SetSourceAndFilesystem("", srcFS, false)
+	s.RecordResults(s.Name(), results)
 	return results, nil
 }
@@ -1,6 +1,7 @@
 package yaml
 
 import (
+	"bytes"
 	"context"
 	"testing"
 
@@ -88,3 +89,53 @@ deny[res] {
 		results.GetFailed()[0].Rule(),
 	)
 }
+
+func Test_ScanWithCustomParser(t *testing.T) {
+
+	fs := testutil.CreateFS(t, map[string]string{
+		"/code/data.yml.tpl": `---
+x:
+  y: {{ .Value }}
+`,
+		"/rules/rule.rego": `package builtin.yaml.lol
+
+__rego_metadata__ := {
+	"id": "ABC123",
+	"avd_id": "AVD-AB-0123",
+	"title": "title",
+	"short_code": "short",
+	"severity": "CRITICAL",
+	"type": "YAML Check",
+}
+
+__rego_input__ := {
+	"combine": false,
+	"selector": [{"type": "yaml"}],
+}
+
+deny[res] {
+	input.x.y == 123
+	res := {
+		"msg": "oh no",
+		"startline": 2,
+		"endline": 3,
+	}
+}
+`,
+	})
+
+	render := func(_ context.Context, _ string, content []byte) ([]byte, error) {
+		return bytes.ReplaceAll(content, []byte("{{ .Value }}"), []byte("123")), nil
+	}
+
+	scanner := NewScanner(
+		options.ScannerWithPolicyDirs("rules"),
+		options.ScannerWithCustomParser(".yml.tpl", ".yaml", render),
+	)
+
+	results, err := scanner.ScanFS(context.TODO(), fs, "code")
+	require.NoError(t, err)
+
+	require.Len(t, results.GetFailed(), 1)
+	assert.Equal(t, "code/data.yml.tpl", results.GetFailed()[0].Range().GetFilename())
+}
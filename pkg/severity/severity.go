@@ -46,3 +46,15 @@ func StringToSeverity(sev string) Severity {
 		return None
 	}
 }
+
+var levels = map[Severity]int{
+	Low:      1,
+	Medium:   2,
+	High:     3,
+	Critical: 4,
+}
+
+// AtLeast reports whether s is equal to or more severe than threshold. Every severity is at least None.
+func (s Severity) AtLeast(threshold Severity) bool {
+	return levels[s] >= levels[threshold]
+}
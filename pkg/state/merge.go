@@ -41,3 +41,50 @@ func (a *State) Merge(b *State) (*State, error) {
 	normalised := outputVal.Interface().(State)
 	return &normalised, nil
 }
+
+// Combine combines the states of the same services read from different places, such as several regions, into a
+// single state. Lists of resources are concatenated, and other service fields prefer the value from b where it is set.
+func (a *State) Combine(b *State) *State {
+	var output State
+
+	aVal := reflect.ValueOf(a).Elem()
+	bVal := reflect.ValueOf(b).Elem()
+	outputVal := reflect.ValueOf(&output).Elem()
+
+	stateType := aVal.Type()
+	for i := 0; i < stateType.NumField(); i++ {
+		field := stateType.Field(i)
+		if !field.IsExported() || field.Type.Kind() != reflect.Struct {
+			continue
+		}
+		for j := 0; j < field.Type.NumField(); j++ {
+			serviceField := field.Type.Field(j)
+			if !serviceField.IsExported() || serviceField.Type.Kind() != reflect.Struct {
+				continue
+			}
+			aService := aVal.Field(i).Field(j)
+			bService := bVal.Field(i).Field(j)
+			outputService := outputVal.Field(i).Field(j)
+			for k := 0; k < serviceField.Type.NumField(); k++ {
+				if !serviceField.Type.Field(k).IsExported() {
+					continue
+				}
+				aItem, bItem := aService.Field(k), bService.Field(k)
+				switch {
+				case aItem.Kind() == reflect.Slice:
+					if aItem.Len()+bItem.Len() == 0 {
+						continue
+					}
+					combined := reflect.MakeSlice(aItem.Type(), 0, aItem.Len()+bItem.Len())
+					outputService.Field(k).Set(reflect.AppendSlice(reflect.AppendSlice(combined, aItem), bItem))
+				case !bItem.IsZero():
+					outputService.Field(k).Set(bItem)
+				default:
+					outputService.Field(k).Set(aItem)
+				}
+			}
+		}
+	}
+
+	return &output
+}
@@ -339,3 +339,37 @@ func Test_Merging(t *testing.T) {
 	}
 
 }
+
+func Test_Combining(t *testing.T) {
+	a := State{
+		AWS: aws.AWS{
+			EC2: ec2.EC2{
+				Instances: []ec2.Instance{
+					{Metadata: defsecTypes.NewRemoteMetadata("arn:aws:ec2:eu-west-1:123456789012:instance/i-a")},
+				},
+			},
+		},
+	}
+	b := State{
+		AWS: aws.AWS{
+			EC2: ec2.EC2{
+				Instances: []ec2.Instance{
+					{Metadata: defsecTypes.NewRemoteMetadata("arn:aws:ec2:us-east-1:123456789012:instance/i-b")},
+				},
+			},
+			RDS: rds.RDS{
+				Instances: []rds.Instance{
+					{PublicAccess: defsecTypes.Bool(true, defsecTypes.Metadata{})},
+				},
+			},
+		},
+	}
+
+	output := a.Combine(&b)
+	assert.Len(t, output.AWS.EC2.Instances, 2)
+	assert.Equal(t, "arn:aws:ec2:eu-west-1:123456789012:instance/i-a", output.AWS.EC2.Instances[0].Metadata.Range().GetFilename())
+	assert.Equal(t, "arn:aws:ec2:us-east-1:123456789012:instance/i-b", output.AWS.EC2.Instances[1].Metadata.Range().GetFilename())
+	assert.Len(t, output.AWS.RDS.Instances, 1)
+	assert.Nil(t, output.AWS.S3.Buckets)
+	assert.Len(t, a.AWS.EC2.Instances, 1)
+}
@@ -0,0 +1,70 @@
+package state
+
+import (
+	"strings"
+
+	"github.com/aquasecurity/defsec/pkg/providers"
+)
+
+// Selection is the set of services which should be adapted into a State. Building only the sections of the
+// state which will be read by the rules being evaluated avoids the cost of adapting every service when only a
+// few rules are selected. The selection is made before adapting, from the services each rule and policy declares
+// it reads, and the selected services are then adapted up front rather than as rules first read them - rules read
+// the state's fields directly, so cannot trigger adaptation themselves. The zero value selects every service.
+type Selection struct {
+	restricted bool
+	providers  map[providers.Provider]map[string]struct{}
+}
+
+// NewSelection creates a selection containing no services
+func NewSelection() Selection {
+	return Selection{
+		restricted: true,
+		providers:  make(map[providers.Provider]map[string]struct{}),
+	}
+}
+
+// Add adds a service to the selection. Service names are matched against the fields of the provider type,
+// ignoring case, dashes and underscores - "api-gateway" selects AWS.APIGateway. An empty service name
+// selects every service of the provider.
+func (s *Selection) Add(provider providers.Provider, service string) {
+	if !s.restricted {
+		return
+	}
+	provider = providers.Provider(strings.ToLower(string(provider)))
+	services, ok := s.providers[provider]
+	if !ok {
+		services = make(map[string]struct{})
+		s.providers[provider] = services
+	}
+	services[normaliseService(service)] = struct{}{}
+}
+
+// Includes reports whether the given service of the provider should be adapted
+func (s Selection) Includes(provider providers.Provider, service string) bool {
+	if !s.restricted {
+		return true
+	}
+	services, ok := s.providers[providers.Provider(strings.ToLower(string(provider)))]
+	if !ok {
+		return false
+	}
+	if _, ok := services[""]; ok {
+		return true
+	}
+	_, ok = services[normaliseService(service)]
+	return ok
+}
+
+// IncludesProvider reports whether any service of the provider should be adapted
+func (s Selection) IncludesProvider(provider providers.Provider) bool {
+	if !s.restricted {
+		return true
+	}
+	_, ok := s.providers[providers.Provider(strings.ToLower(string(provider)))]
+	return ok
+}
+
+func normaliseService(service string) string {
+	return strings.NewReplacer("-", "", "_", "").Replace(strings.ToLower(service))
+}
@@ -0,0 +1,30 @@
+package state
+
+import (
+	"testing"
+
+	"github.com/aquasecurity/defsec/pkg/providers"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_SelectionZeroValueIncludesEverything(t *testing.T) {
+	var selection Selection
+	assert.True(t, selection.IncludesProvider(providers.AWSProvider))
+	assert.True(t, selection.Includes(providers.AWSProvider, "s3"))
+}
+
+func Test_SelectionIncludesAddedServices(t *testing.T) {
+	selection := NewSelection()
+	selection.Add(providers.AWSProvider, "api-gateway")
+	selection.Add(providers.GitHubProvider, "")
+
+	assert.True(t, selection.IncludesProvider(providers.AWSProvider))
+	assert.True(t, selection.Includes(providers.AWSProvider, "apigateway"))
+	assert.False(t, selection.Includes(providers.AWSProvider, "s3"))
+
+	assert.True(t, selection.Includes(providers.GitHubProvider, "repositories"))
+
+	assert.False(t, selection.IncludesProvider(providers.GoogleProvider))
+	assert.False(t, selection.Includes(providers.GoogleProvider, "storage"))
+}
@@ -16,6 +16,7 @@ type Ignore struct {
 	Workspace string
 	Block     bool
 	Params    map[string]string
+	Reason    string
 }
 
 type Ignores []Ignore
@@ -15,6 +15,7 @@ type Metadata struct {
 	isUnresolvable bool
 	parent         *Metadata
 	internal       interface{}
+	definition     *Range
 }
 
 func (m Metadata) MarshalJSON() ([]byte, error) {
@@ -26,6 +27,7 @@ func (m Metadata) MarshalJSON() ([]byte, error) {
 		"explicit":     m.isExplicit,
 		"unresolvable": m.isUnresolvable,
 		"parent":       m.parent,
+		"definition":   m.definition,
 	})
 }
 
@@ -73,11 +75,22 @@ func (m *Metadata) UnmarshalJSON(data []byte) error {
 			m.parent = &parent
 		}
 	}
+	if keys["definition"] != nil {
+		raw, err := json.Marshal(keys["definition"])
+		if err != nil {
+			return err
+		}
+		var r Range
+		if err := json.Unmarshal(raw, &r); err != nil {
+			return err
+		}
+		m.definition = &r
+	}
 	return nil
 }
 
 func (m *Metadata) ToRego() interface{} {
-	return map[string]interface{}{
+	output := map[string]interface{}{
 		"filepath":  m.Range().GetFilename(),
 		"startline": m.Range().GetStartLine(),
 		"endline":   m.Range().GetEndLine(),
@@ -86,6 +99,14 @@ func (m *Metadata) ToRego() interface{} {
 		"fskey":     CreateFSKey(m.Range().GetFS()),
 		"resource":  m.Reference(),
 	}
+	if m.definition != nil {
+		// results of rego checks read where the value was defined from the same key as for parsed yaml
+		output["anchor"] = map[string]interface{}{
+			"startline": m.definition.GetStartLine(),
+			"endline":   m.definition.GetEndLine(),
+		}
+	}
+	return output
 }
 
 func NewMetadata(r Range, ref string) Metadata {
@@ -138,6 +159,17 @@ func (m Metadata) Internal() interface{} {
 	return m.internal
 }
 
+// WithDefinition records where the value was defined, when it is used elsewhere, e.g. a yaml alias of an anchor
+func (m Metadata) WithDefinition(r Range) Metadata {
+	m.definition = &r
+	return m
+}
+
+// Definition returns where the value was defined, or nil if it was defined where it is used
+func (m Metadata) Definition() *Range {
+	return m.definition
+}
+
 func (m Metadata) IsMultiLine() bool {
 	return m.rnge.GetStartLine() < m.rnge.GetEndLine()
 }
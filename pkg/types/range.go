@@ -4,12 +4,16 @@ import (
 	"encoding/json"
 	"fmt"
 	"io/fs"
-	"path/filepath"
+	"path"
+
+	"github.com/aquasecurity/defsec/pkg/extrafs"
 )
 
+// NewRange creates a range of lines of a file. The filename is converted to the slash separated form used on every
+// platform, see extrafs.SlashPath.
 func NewRange(filename string, startLine int, endLine int, sourcePrefix string, srcFS fs.FS) Range {
 	r := Range{
-		filename:     filename,
+		filename:     extrafs.SlashPath(filename),
 		startLine:    startLine,
 		endLine:      endLine,
 		fs:           srcFS,
@@ -22,7 +26,7 @@ func NewRange(filename string, startLine int, endLine int, sourcePrefix string,
 func NewRangeWithLogicalSource(filename string, startLine int, endLine int, sourcePrefix string,
 	srcFS fs.FS) Range {
 	r := Range{
-		filename:        filename,
+		filename:        extrafs.SlashPath(filename),
 		startLine:       startLine,
 		endLine:         endLine,
 		fs:              srcFS,
@@ -35,7 +39,7 @@ func NewRangeWithLogicalSource(filename string, startLine int, endLine int, sour
 
 func NewRangeWithFSKey(filename string, startLine int, endLine int, sourcePrefix string, fsKey string, fs fs.FS) Range {
 	r := Range{
-		filename:     filename,
+		filename:     extrafs.SlashPath(filename),
 		startLine:    startLine,
 		endLine:      endLine,
 		fs:           fs,
@@ -110,7 +114,7 @@ func (r Range) GetFilename() string {
 	if r.isLogicalSource {
 		return fmt.Sprintf("%s:%s", r.sourcePrefix, r.filename)
 	}
-	return filepath.Join(r.sourcePrefix, r.filename)
+	return path.Join(extrafs.SlashPath(r.sourcePrefix), r.filename)
 }
 
 func (r Range) GetLocalFilename() string {
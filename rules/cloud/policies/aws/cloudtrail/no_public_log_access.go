@@ -11,10 +11,11 @@ import (
 
 var checkNoPublicLogAccess = rules.Register(
 	scan.Rule{
-		AVDID:     "AVD-AWS-0161",
-		Provider:  providers.AWSProvider,
-		Service:   "cloudtrail",
-		ShortCode: "no-public-log-access",
+		AVDID:            "AVD-AWS-0161",
+		Provider:         providers.AWSProvider,
+		Service:          "cloudtrail",
+		RequiredServices: []string{"s3"},
+		ShortCode:        "no-public-log-access",
 		Frameworks: map[framework.Framework][]string{
 			framework.Default:     nil,
 			framework.CIS_AWS_1_2: {"2.3"},
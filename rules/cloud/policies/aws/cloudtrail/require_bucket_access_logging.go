@@ -11,10 +11,11 @@ import (
 
 var checkBucketAccessLoggingRequired = rules.Register(
 	scan.Rule{
-		AVDID:     "AVD-AWS-0163",
-		Provider:  providers.AWSProvider,
-		Service:   "cloudtrail",
-		ShortCode: "require-bucket-access-logging",
+		AVDID:            "AVD-AWS-0163",
+		Provider:         providers.AWSProvider,
+		Service:          "cloudtrail",
+		RequiredServices: []string{"s3"},
+		ShortCode:        "require-bucket-access-logging",
 		Frameworks: map[framework.Framework][]string{
 			framework.Default:     nil,
 			framework.CIS_AWS_1_2: {"2.6"},
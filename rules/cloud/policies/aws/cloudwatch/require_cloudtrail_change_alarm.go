@@ -13,13 +13,14 @@ import (
 
 var requireCloudTrailChangeAlarm = rules.Register(
 	scan.Rule{
-		AVDID:      "AVD-AWS-0151",
-		Provider:   providers.AWSProvider,
-		Service:    "cloudwatch",
-		ShortCode:  "require-cloud-trail-change-alarm",
-		Summary:    "Ensure a log metric filter and alarm exist for CloudTrail configuration changes",
-		Impact:     "CloudTrail tracks all changes through the API, attempts to change the configuration may indicate malicious activity. Without alerting on changes, visibility of this activity is reduced.",
-		Resolution: "Create an alarm to alert on CloudTrail configuration changes",
+		AVDID:            "AVD-AWS-0151",
+		Provider:         providers.AWSProvider,
+		Service:          "cloudwatch",
+		RequiredServices: []string{"cloudtrail"},
+		ShortCode:        "require-cloud-trail-change-alarm",
+		Summary:          "Ensure a log metric filter and alarm exist for CloudTrail configuration changes",
+		Impact:           "CloudTrail tracks all changes through the API, attempts to change the configuration may indicate malicious activity. Without alerting on changes, visibility of this activity is reduced.",
+		Resolution:       "Create an alarm to alert on CloudTrail configuration changes",
 		Frameworks: map[framework.Framework][]string{
 			framework.CIS_AWS_1_2: {
 				"3.5",
@@ -13,13 +13,14 @@ import (
 
 var requireCMKDisabledAlarm = rules.Register(
 	scan.Rule{
-		AVDID:      "AVD-AWS-0153",
-		Provider:   providers.AWSProvider,
-		Service:    "cloudwatch",
-		ShortCode:  "require-cmk-disabled-alarm",
-		Summary:    "Ensure a log metric filter and alarm exist for disabling or scheduled deletion of customer managed keys",
-		Impact:     "CloudTrail tracks all changes through the API, attempts to change the configuration may indicate malicious activity. Without alerting on changes, visibility of this activity is reduced.",
-		Resolution: "Create an alarm to alert on CMKs being disabled or scheduled for deletion",
+		AVDID:            "AVD-AWS-0153",
+		Provider:         providers.AWSProvider,
+		Service:          "cloudwatch",
+		RequiredServices: []string{"cloudtrail"},
+		ShortCode:        "require-cmk-disabled-alarm",
+		Summary:          "Ensure a log metric filter and alarm exist for disabling or scheduled deletion of customer managed keys",
+		Impact:           "CloudTrail tracks all changes through the API, attempts to change the configuration may indicate malicious activity. Without alerting on changes, visibility of this activity is reduced.",
+		Resolution:       "Create an alarm to alert on CMKs being disabled or scheduled for deletion",
 		Frameworks: map[framework.Framework][]string{
 			framework.CIS_AWS_1_2: {
 				"3.7",
@@ -13,13 +13,14 @@ import (
 
 var requireConfigConfigurationChangeAlarm = rules.Register(
 	scan.Rule{
-		AVDID:      "AVD-AWS-0155",
-		Provider:   providers.AWSProvider,
-		Service:    "cloudwatch",
-		ShortCode:  "require-config-configuration-changes-alarm",
-		Summary:    "Ensure a log metric filter and alarm exist for AWS Config configuration changes",
-		Impact:     "Changes to the configuration of AWS Config may indicate malicious activity. Without alerting on changes, visibility of this activity is reduced.",
-		Resolution: "Create an alarm to alert on AWS Config configuration changes",
+		AVDID:            "AVD-AWS-0155",
+		Provider:         providers.AWSProvider,
+		Service:          "cloudwatch",
+		RequiredServices: []string{"cloudtrail"},
+		ShortCode:        "require-config-configuration-changes-alarm",
+		Summary:          "Ensure a log metric filter and alarm exist for AWS Config configuration changes",
+		Impact:           "Changes to the configuration of AWS Config may indicate malicious activity. Without alerting on changes, visibility of this activity is reduced.",
+		Resolution:       "Create an alarm to alert on AWS Config configuration changes",
 		Frameworks: map[framework.Framework][]string{
 			framework.CIS_AWS_1_2: {
 				"3.9",
@@ -13,13 +13,14 @@ import (
 
 var requireConsoleLoginFailureAlarm = rules.Register(
 	scan.Rule{
-		AVDID:      "AVD-AWS-0152",
-		Provider:   providers.AWSProvider,
-		Service:    "cloudwatch",
-		ShortCode:  "require-console-login-failures-alarm",
-		Summary:    "Ensure a log metric filter and alarm exist for AWS Management Console authentication failures",
-		Impact:     "Failed attempts to log into the Management console may indicate an attempt to maliciously access an account. Failure to alert reduces visibility of this activity.",
-		Resolution: "Create an alarm to alert on console login failures",
+		AVDID:            "AVD-AWS-0152",
+		Provider:         providers.AWSProvider,
+		Service:          "cloudwatch",
+		RequiredServices: []string{"cloudtrail"},
+		ShortCode:        "require-console-login-failures-alarm",
+		Summary:          "Ensure a log metric filter and alarm exist for AWS Management Console authentication failures",
+		Impact:           "Failed attempts to log into the Management console may indicate an attempt to maliciously access an account. Failure to alert reduces visibility of this activity.",
+		Resolution:       "Create an alarm to alert on console login failures",
 		Frameworks: map[framework.Framework][]string{
 			framework.CIS_AWS_1_2: {
 				"3.6",
@@ -13,13 +13,14 @@ import (
 
 var requireIAMPolicyChangeAlarm = rules.Register(
 	scan.Rule{
-		AVDID:      "AVD-AWS-0150",
-		Provider:   providers.AWSProvider,
-		Service:    "cloudwatch",
-		ShortCode:  "require-iam-policy-change-alarm",
-		Summary:    "Ensure a log metric filter and alarm exist for IAM policy changes",
-		Impact:     "IAM Policy changes could lead to excessive permissions and may have been performed maliciously.",
-		Resolution: "Create an alarm to alert on IAM Policy changes",
+		AVDID:            "AVD-AWS-0150",
+		Provider:         providers.AWSProvider,
+		Service:          "cloudwatch",
+		RequiredServices: []string{"cloudtrail"},
+		ShortCode:        "require-iam-policy-change-alarm",
+		Summary:          "Ensure a log metric filter and alarm exist for IAM policy changes",
+		Impact:           "IAM Policy changes could lead to excessive permissions and may have been performed maliciously.",
+		Resolution:       "Create an alarm to alert on IAM Policy changes",
 		Frameworks: map[framework.Framework][]string{
 			framework.CIS_AWS_1_2: {
 				"3.4",
@@ -13,13 +13,14 @@ import (
 
 var requireNACLChangeAlarm = rules.Register(
 	scan.Rule{
-		AVDID:      "AVD-AWS-0157",
-		Provider:   providers.AWSProvider,
-		Service:    "cloudwatch",
-		ShortCode:  "require-nacl-changes-alarm",
-		Summary:    "Ensure a log metric filter and alarm exist for changes to Network Access Control Lists (NACL)",
-		Impact:     "Network ACLs control the ingress and egress, changes could be made to maliciously allow egress of data or external ingress. Without alerting, this could go unnoticed.",
-		Resolution: "Create an alarm to alert on network acl changes",
+		AVDID:            "AVD-AWS-0157",
+		Provider:         providers.AWSProvider,
+		Service:          "cloudwatch",
+		RequiredServices: []string{"cloudtrail"},
+		ShortCode:        "require-nacl-changes-alarm",
+		Summary:          "Ensure a log metric filter and alarm exist for changes to Network Access Control Lists (NACL)",
+		Impact:           "Network ACLs control the ingress and egress, changes could be made to maliciously allow egress of data or external ingress. Without alerting, this could go unnoticed.",
+		Resolution:       "Create an alarm to alert on network acl changes",
 		Frameworks: map[framework.Framework][]string{
 			framework.CIS_AWS_1_2: {
 				"3.11",
@@ -13,13 +13,14 @@ import (
 
 var requireNetworkGatewayChangeAlarm = rules.Register(
 	scan.Rule{
-		AVDID:      "AVD-AWS-0158",
-		Provider:   providers.AWSProvider,
-		Service:    "cloudwatch",
-		ShortCode:  "require-network-gateway-changes-alarm",
-		Summary:    "Ensure a log metric filter and alarm exist for changes to network gateways",
-		Impact:     "Network gateways control the ingress and egress, changes could be made to maliciously allow egress of data or external ingress. Without alerting, this could go unnoticed.",
-		Resolution: "Create an alarm to alert on network gateway changes",
+		AVDID:            "AVD-AWS-0158",
+		Provider:         providers.AWSProvider,
+		Service:          "cloudwatch",
+		RequiredServices: []string{"cloudtrail"},
+		ShortCode:        "require-network-gateway-changes-alarm",
+		Summary:          "Ensure a log metric filter and alarm exist for changes to network gateways",
+		Impact:           "Network gateways control the ingress and egress, changes could be made to maliciously allow egress of data or external ingress. Without alerting, this could go unnoticed.",
+		Resolution:       "Create an alarm to alert on network gateway changes",
 		Frameworks: map[framework.Framework][]string{
 			framework.CIS_AWS_1_2: {
 				"3.12",
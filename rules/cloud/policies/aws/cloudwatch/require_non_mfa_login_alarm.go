@@ -13,13 +13,14 @@ import (
 
 var requireNonMFALoginAlarm = rules.Register(
 	scan.Rule{
-		AVDID:      "AVD-AWS-0148",
-		Provider:   providers.AWSProvider,
-		Service:    "cloudwatch",
-		ShortCode:  "require-non-mfa-login-alarm",
-		Summary:    "Ensure a log metric filter and alarm exist for AWS Management Console sign-in without MFA",
-		Impact:     "Not alerting on logins with no MFA allows the risk to go un-notified.",
-		Resolution: "Create an alarm to alert on non MFA logins",
+		AVDID:            "AVD-AWS-0148",
+		Provider:         providers.AWSProvider,
+		Service:          "cloudwatch",
+		RequiredServices: []string{"cloudtrail"},
+		ShortCode:        "require-non-mfa-login-alarm",
+		Summary:          "Ensure a log metric filter and alarm exist for AWS Management Console sign-in without MFA",
+		Impact:           "Not alerting on logins with no MFA allows the risk to go un-notified.",
+		Resolution:       "Create an alarm to alert on non MFA logins",
 		Frameworks: map[framework.Framework][]string{
 			framework.CIS_AWS_1_2: {
 				"3.2",
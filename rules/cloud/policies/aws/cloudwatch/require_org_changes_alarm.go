@@ -13,13 +13,14 @@ import (
 
 var CheckRequireOrgChangesAlarm = rules.Register(
 	scan.Rule{
-		AVDID:      "AVD-AWS-0174",
-		Provider:   providers.AWSProvider,
-		Service:    "cloudwatch",
-		ShortCode:  "require-org-changes-alarm",
-		Summary:    "Ensure a log metric filter and alarm exist for organisation changes",
-		Impact:     "Lack of observability into critical organisation changes",
-		Resolution: "Create an alarm to alert on organisation changes",
+		AVDID:            "AVD-AWS-0174",
+		Provider:         providers.AWSProvider,
+		Service:          "cloudwatch",
+		RequiredServices: []string{"cloudtrail"},
+		ShortCode:        "require-org-changes-alarm",
+		Summary:          "Ensure a log metric filter and alarm exist for organisation changes",
+		Impact:           "Lack of observability into critical organisation changes",
+		Resolution:       "Create an alarm to alert on organisation changes",
 		Frameworks: map[framework.Framework][]string{
 			framework.CIS_AWS_1_4: {
 				"4.15",
@@ -13,13 +13,14 @@ import (
 
 var requireRootUserUsageAlarm = rules.Register(
 	scan.Rule{
-		AVDID:      "AVD-AWS-0149",
-		Provider:   providers.AWSProvider,
-		Service:    "cloudwatch",
-		ShortCode:  "require-root-user-usage-alarm",
-		Summary:    "Ensure a log metric filter and alarm exist for usage of root user",
-		Impact:     "The root user has significant permissions and should not be used for day to day tasks.",
-		Resolution: "Create an alarm to alert on root user login",
+		AVDID:            "AVD-AWS-0149",
+		Provider:         providers.AWSProvider,
+		Service:          "cloudwatch",
+		RequiredServices: []string{"cloudtrail"},
+		ShortCode:        "require-root-user-usage-alarm",
+		Summary:          "Ensure a log metric filter and alarm exist for usage of root user",
+		Impact:           "The root user has significant permissions and should not be used for day to day tasks.",
+		Resolution:       "Create an alarm to alert on root user login",
 		Frameworks: map[framework.Framework][]string{
 			framework.CIS_AWS_1_2: {
 				"3.3",
@@ -13,13 +13,14 @@ import (
 
 var requireS3BucketPolicyChangeAlarm = rules.Register(
 	scan.Rule{
-		AVDID:      "AVD-AWS-0154",
-		Provider:   providers.AWSProvider,
-		Service:    "cloudwatch",
-		ShortCode:  "require-s3-bucket-policy-change-alarm",
-		Summary:    "Ensure a log metric filter and alarm exist for S3 bucket policy changes",
-		Impact:     "Misconfigured policies on S3 buckets could lead to data leakage, without alerting visibility of this is reduced.",
-		Resolution: "Create an alarm to alert on S3 Bucket policy changes",
+		AVDID:            "AVD-AWS-0154",
+		Provider:         providers.AWSProvider,
+		Service:          "cloudwatch",
+		RequiredServices: []string{"cloudtrail"},
+		ShortCode:        "require-s3-bucket-policy-change-alarm",
+		Summary:          "Ensure a log metric filter and alarm exist for S3 bucket policy changes",
+		Impact:           "Misconfigured policies on S3 buckets could lead to data leakage, without alerting visibility of this is reduced.",
+		Resolution:       "Create an alarm to alert on S3 Bucket policy changes",
 		Frameworks: map[framework.Framework][]string{
 			framework.CIS_AWS_1_2: {
 				"3.8",
@@ -13,13 +13,14 @@ import (
 
 var requireSecurityGroupChangeAlarm = rules.Register(
 	scan.Rule{
-		AVDID:      "AVD-AWS-0156",
-		Provider:   providers.AWSProvider,
-		Service:    "cloudwatch",
-		ShortCode:  "require-sg-change-alarms",
-		Summary:    "Ensure a log metric filter and alarm exist for security group changes",
-		Impact:     "Security groups control the ingress and egress, changes could be made to maliciously allow egress of data or external ingress. Without alerting, this could go unnoticed.",
-		Resolution: "Create an alarm to alert on security group changes",
+		AVDID:            "AVD-AWS-0156",
+		Provider:         providers.AWSProvider,
+		Service:          "cloudwatch",
+		RequiredServices: []string{"cloudtrail"},
+		ShortCode:        "require-sg-change-alarms",
+		Summary:          "Ensure a log metric filter and alarm exist for security group changes",
+		Impact:           "Security groups control the ingress and egress, changes could be made to maliciously allow egress of data or external ingress. Without alerting, this could go unnoticed.",
+		Resolution:       "Create an alarm to alert on security group changes",
 		Frameworks: map[framework.Framework][]string{
 			framework.CIS_AWS_1_2: {
 				"3.10",
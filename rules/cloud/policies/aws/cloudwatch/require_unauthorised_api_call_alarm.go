@@ -13,13 +13,14 @@ import (
 
 var requireUnauthorizedApiCallAlarm = rules.Register(
 	scan.Rule{
-		AVDID:      "AVD-AWS-0147",
-		Provider:   providers.AWSProvider,
-		Service:    "cloudwatch",
-		ShortCode:  "require-unauthorised-api-call-alarm",
-		Summary:    "Ensure a log metric filter and alarm exist for unauthorized API calls",
-		Impact:     "Unauthorized API Calls may be attempted without being notified. CloudTrail logs these actions but without the alarm you aren't actively notified.",
-		Resolution: "Create an alarm to alert on unauthorized API calls",
+		AVDID:            "AVD-AWS-0147",
+		Provider:         providers.AWSProvider,
+		Service:          "cloudwatch",
+		RequiredServices: []string{"cloudtrail"},
+		ShortCode:        "require-unauthorised-api-call-alarm",
+		Summary:          "Ensure a log metric filter and alarm exist for unauthorized API calls",
+		Impact:           "Unauthorized API Calls may be attempted without being notified. CloudTrail logs these actions but without the alarm you aren't actively notified.",
+		Resolution:       "Create an alarm to alert on unauthorized API calls",
 		Frameworks: map[framework.Framework][]string{
 			framework.CIS_AWS_1_2: {
 				"3.1",
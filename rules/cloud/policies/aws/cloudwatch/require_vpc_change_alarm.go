@@ -13,13 +13,14 @@ import (
 
 var requireVPCChangeAlarm = rules.Register(
 	scan.Rule{
-		AVDID:      "AVD-AWS-0160",
-		Provider:   providers.AWSProvider,
-		Service:    "cloudwatch",
-		ShortCode:  "require-vpc-changes-alarm",
-		Summary:    "Ensure a log metric filter and alarm exist for VPC changes",
-		Impact:     "Route tables control the flow of network traffic, changes could be made to maliciously allow egress of data or external ingress. Without alerting, this could go unnoticed.",
-		Resolution: "Create an alarm to alert on route table changes",
+		AVDID:            "AVD-AWS-0160",
+		Provider:         providers.AWSProvider,
+		Service:          "cloudwatch",
+		RequiredServices: []string{"cloudtrail"},
+		ShortCode:        "require-vpc-changes-alarm",
+		Summary:          "Ensure a log metric filter and alarm exist for VPC changes",
+		Impact:           "Route tables control the flow of network traffic, changes could be made to maliciously allow egress of data or external ingress. Without alerting, this could go unnoticed.",
+		Resolution:       "Create an alarm to alert on route table changes",
 		Frameworks: map[framework.Framework][]string{
 			framework.CIS_AWS_1_2: {
 				"3.14",
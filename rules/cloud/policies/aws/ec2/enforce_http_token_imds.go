@@ -33,6 +33,24 @@ To fully protect IMDS you need to enable session tokens by using <code>metadata_
 			Links:               terraformEnforceHttpTokenImdsLinks,
 			RemediationMarkdown: terraformEnforceHttpTokenImdsRemediationMarkdown,
 		},
+		Remediation: &scan.Remediation{
+			Snippets: []scan.FixSnippet{
+				{
+					Format: scan.RemediationFormatTerraform,
+					Code: `metadata_options {
+  http_tokens = "required"
+}`,
+				},
+			},
+			Patches: []scan.Patch{
+				{
+					Format:    scan.RemediationFormatTerraform,
+					Attribute: "metadata_options.http_tokens",
+					Operation: scan.PatchOperationSet,
+					Value:     "required",
+				},
+			},
+		},
 		Severity: severity.High,
 	},
 	func(s *state.State) (results scan.Results) {
@@ -35,6 +35,38 @@ S3 buckets should block public ACLs on buckets and any objects they contain. By
 			Links:               cloudFormationBlockPublicAclsLinks,
 			RemediationMarkdown: cloudFormationBlockPublicAclsRemediationMarkdown,
 		},
+		Remediation: &scan.Remediation{
+			Snippets: []scan.FixSnippet{
+				{
+					Format: scan.RemediationFormatTerraform,
+					Code: `resource "aws_s3_bucket_public_access_block" "example" {
+  bucket            = aws_s3_bucket.example.id
+  block_public_acls = true
+}`,
+				},
+				{
+					Format: scan.RemediationFormatCloudFormation,
+					Code: `PublicAccessBlockConfiguration:
+  BlockPublicAcls: true`,
+				},
+			},
+			Patches: []scan.Patch{
+				{
+					Format:    scan.RemediationFormatTerraform,
+					Attribute: "block_public_acls",
+					Operation: scan.PatchOperationSet,
+					Value:     true,
+					// a bucket without a public access block needs a new resource, which the snippet describes
+					ResourceType: "aws_s3_bucket_public_access_block",
+				},
+				{
+					Format:    scan.RemediationFormatCloudFormation,
+					Attribute: "PublicAccessBlockConfiguration.BlockPublicAcls",
+					Operation: scan.PatchOperationSet,
+					Value:     true,
+				},
+			},
+		},
 		Severity: severity.High,
 	},
 	func(s *state.State) (results scan.Results) {
@@ -34,6 +34,29 @@ var CheckEncryptionIsEnabled = rules.Register(
 			Links:               cloudFormationEnableBucketEncryptionLinks,
 			RemediationMarkdown: cloudFormationEnableBucketEncryptionRemediationMarkdown,
 		},
+		Remediation: &scan.Remediation{
+			Snippets: []scan.FixSnippet{
+				{
+					Format: scan.RemediationFormatTerraform,
+					Code: `resource "aws_s3_bucket_server_side_encryption_configuration" "example" {
+  bucket = aws_s3_bucket.example.id
+
+  rule {
+    apply_server_side_encryption_by_default {
+      sse_algorithm = "aws:kms"
+    }
+  }
+}`,
+				},
+				{
+					Format: scan.RemediationFormatCloudFormation,
+					Code: `BucketEncryption:
+  ServerSideEncryptionConfiguration:
+    - ServerSideEncryptionByDefault:
+        SSEAlgorithm: aws:kms`,
+				},
+			},
+		},
 		Severity: severity.High,
 	},
 	func(s *state.State) (results scan.Results) {
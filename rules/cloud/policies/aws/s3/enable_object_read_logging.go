@@ -13,10 +13,11 @@ import (
 
 var CheckEnableObjectReadLogging = rules.Register(
 	scan.Rule{
-		AVDID:     "AVD-AWS-0172",
-		Provider:  providers.AWSProvider,
-		Service:   "s3",
-		ShortCode: "enable-object-read-logging",
+		AVDID:            "AVD-AWS-0172",
+		Provider:         providers.AWSProvider,
+		Service:          "s3",
+		RequiredServices: []string{"cloudtrail"},
+		ShortCode:        "enable-object-read-logging",
 		Frameworks: map[framework.Framework][]string{
 			framework.CIS_AWS_1_4: {"3.11"},
 		},
@@ -13,10 +13,11 @@ import (
 
 var CheckEnableObjectWriteLogging = rules.Register(
 	scan.Rule{
-		AVDID:     "AVD-AWS-0171",
-		Provider:  providers.AWSProvider,
-		Service:   "s3",
-		ShortCode: "enable-object-write-logging",
+		AVDID:            "AVD-AWS-0171",
+		Provider:         providers.AWSProvider,
+		Service:          "s3",
+		RequiredServices: []string{"cloudtrail"},
+		ShortCode:        "enable-object-write-logging",
 		Frameworks: map[framework.Framework][]string{
 			framework.CIS_AWS_1_4: {"3.10"},
 		},
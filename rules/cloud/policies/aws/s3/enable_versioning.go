@@ -37,6 +37,33 @@ With versioning you can recover more easily from both unintended user actions an
 			Links:               cloudFormationEnableVersioningLinks,
 			RemediationMarkdown: cloudFormationEnableVersioningRemediationMarkdown,
 		},
+		Remediation: &scan.Remediation{
+			Snippets: []scan.FixSnippet{
+				{
+					Format: scan.RemediationFormatTerraform,
+					Code: `resource "aws_s3_bucket_versioning" "example" {
+  bucket = aws_s3_bucket.example.id
+
+  versioning_configuration {
+    status = "Enabled"
+  }
+}`,
+				},
+				{
+					Format: scan.RemediationFormatCloudFormation,
+					Code: `VersioningConfiguration:
+  Status: Enabled`,
+				},
+			},
+			Patches: []scan.Patch{
+				{
+					Format:    scan.RemediationFormatCloudFormation,
+					Attribute: "VersioningConfiguration.Status",
+					Operation: scan.PatchOperationSet,
+					Value:     "Enabled",
+				},
+			},
+		},
 		Severity: severity.Medium,
 	},
 	func(s *state.State) (results scan.Results) {
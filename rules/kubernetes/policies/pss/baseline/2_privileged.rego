@@ -12,6 +12,15 @@
 #   severity: HIGH
 #   short_code: no-privileged-containers
 #   recommended_action: "Change 'containers[].securityContext.privileged' to 'false'."
+#   remediation:
+#     kubernetes: |
+#       securityContext:
+#         privileged: false
+#     patches:
+#     - format: kubernetes
+#       attribute: securityContext.privileged
+#       operation: set
+#       value: false
 #   input:
 #     selector:
 #     - type: kubernetes
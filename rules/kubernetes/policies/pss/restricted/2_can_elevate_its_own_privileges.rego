@@ -12,6 +12,15 @@
 #   severity: MEDIUM
 #   short_code: no-self-privesc
 #   recommended_action: "Set 'set containers[].securityContext.allowPrivilegeEscalation' to 'false'."
+#   remediation:
+#     kubernetes: |
+#       securityContext:
+#         allowPrivilegeEscalation: false
+#     patches:
+#     - format: kubernetes
+#       attribute: securityContext.allowPrivilegeEscalation
+#       operation: set
+#       value: false
 #   input:
 #     selector:
 #     - type: kubernetes
@@ -15,6 +15,7 @@ import (
 	"github.com/aquasecurity/defsec/pkg/providers"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 var exampleRule = scan.Rule{
@@ -496,3 +497,23 @@ func Test_TrivyIgnoreInline(t *testing.T) {
 	  `, exampleRule.LongID()))
 	assert.Len(t, results.GetFailed(), 0)
 }
+
+func Test_DefsecIgnoreWithExpiryAndReason(t *testing.T) {
+	reg := rules.Register(exampleRule, nil)
+	defer rules.Deregister(reg)
+
+	results := scanHCL(t, `
+resource "bad" "my-rule" {
+    secure = false # defsec:ignore:aws-service-abc123 exp:2221-01-02 reason:"accepted in ticket-123"
+}
+`)
+	require.Len(t, results.GetIgnored(), 1)
+	assert.Equal(t, "accepted in ticket-123", results.GetIgnored()[0].IgnoreReason())
+
+	results = scanHCL(t, `
+resource "bad" "my-rule" {
+    secure = false # defsec:ignore:aws-service-abc123 exp:2000-01-02 reason:"accepted in ticket-123"
+}
+`)
+	assert.Len(t, results.GetFailed(), 1)
+}
@@ -0,0 +1,79 @@
+package test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zclconf/go-cty/cty"
+
+	"github.com/aquasecurity/defsec/pkg/framework"
+	"github.com/aquasecurity/defsec/pkg/scan"
+	"github.com/aquasecurity/defsec/pkg/scanners/options"
+	"github.com/aquasecurity/defsec/rules/cloud/policies/aws/s3"
+	"github.com/aquasecurity/defsec/test/testutil"
+)
+
+// applyTerraformFix sets the attribute of a fix on the resource block it is bound to.
+func applyTerraformFix(t *testing.T, source string, fix scan.Fix) string {
+	require.Equal(t, scan.PatchOperationSet, fix.Operation)
+	require.NotContains(t, fix.Attribute, ".")
+
+	file, diags := hclwrite.ParseConfig([]byte(source), "main.tf", hcl.InitialPos)
+	require.False(t, diags.HasErrors(), diags.Error())
+
+	labels := strings.SplitN(fix.Resource, ".", 2)
+	require.Len(t, labels, 2)
+	block := file.Body().FirstMatchingBlock("resource", labels)
+	require.NotNil(t, block, "resource %s not found", fix.Resource)
+
+	value, ok := fix.Value.(bool)
+	require.True(t, ok)
+	block.Body().SetAttributeValue(fix.Attribute, cty.BoolVal(value))
+	return string(file.Bytes())
+}
+
+func Test_BlockPublicACLsPatchFixesBadExamples(t *testing.T) {
+	rule := s3.CheckPublicACLsAreBlocked.Rule()
+	for i, example := range rule.Terraform.BadExamples {
+		t.Run(fmt.Sprintf("example %d", i), func(t *testing.T) {
+			results := scanHCL(t, example, options.ScannerWithFrameworks(framework.ALL))
+			testutil.AssertRuleFound(t, rule.LongID(), results, "Rule %s was not detected in bad example #%d", rule.LongID(), i)
+
+			patched := example
+			for _, result := range results.GetFailed() {
+				if result.Rule().LongID() != rule.LongID() {
+					continue
+				}
+				fixes := result.Fixes(scan.RemediationFormatTerraform)
+				require.Len(t, fixes, 1)
+				assert.True(t, strings.HasPrefix(fixes[0].Resource, "aws_s3_bucket_public_access_block."))
+				patched = applyTerraformFix(t, patched, fixes[0])
+			}
+
+			results = scanHCL(t, patched, options.ScannerWithFrameworks(framework.ALL))
+			testutil.AssertRuleNotFound(t, rule.LongID(), results, "Rule %s was detected after patching:\n%s", rule.LongID(), patched)
+		})
+	}
+}
+
+func Test_BlockPublicACLsPatchSkippedWithoutPublicAccessBlock(t *testing.T) {
+	rule := s3.CheckPublicACLsAreBlocked.Rule()
+	results := scanHCL(t, `
+resource "aws_s3_bucket" "bad_example" {
+  bucket = "mybucket"
+}
+`, options.ScannerWithFrameworks(framework.ALL))
+	testutil.AssertRuleFound(t, rule.LongID(), results, "Rule %s was not detected", rule.LongID())
+
+	for _, result := range results.GetFailed() {
+		if result.Rule().LongID() == rule.LongID() {
+			// the attribute does not exist on aws_s3_bucket, so there is nothing to patch
+			assert.Empty(t, result.Fixes(scan.RemediationFormatTerraform))
+		}
+	}
+}